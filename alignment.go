@@ -0,0 +1,182 @@
+package distance
+
+// AlignedPair is one column of a pairwise alignment: indices into the two
+// sequences, with -1 marking a gap on that side.
+type AlignedPair struct {
+	A, B int
+}
+
+// NeedlemanWunschBanded computes the global alignment score of NeedlemanWunsch
+// restricted to cells with |i - j| ≤ band, i.e. alignments that never drift
+// more than band positions off the diagonal. Exact whenever the optimal
+// alignment stays inside the band. band must be at least |len(a) - len(b)|.
+// Time: O(m·band), Space: O(n)
+func NeedlemanWunschBanded[T comparable](a, b []T, match, mismatch, gap, band int) (int, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, ErrEmptyInput
+	}
+	m, n := len(a), len(b)
+	if band < 0 || band < m-n || band < n-m {
+		return 0, ErrInvalidParameter
+	}
+
+	prev, curr := make([]int, n+1), make([]int, n+1)
+	for j := range prev {
+		prev[j] = negInfScore
+		if j <= band {
+			prev[j] = j * gap
+		}
+	}
+
+	for i := 1; i <= m; i++ {
+		lo, hi := max(0, i-band), min(n, i+band)
+		// Only the cells bordering the band are read from outside it, so
+		// those are the only stale ones to reset
+		if lo == 0 {
+			curr[0] = i * gap
+			lo = 1
+		} else {
+			curr[lo-1] = negInfScore
+		}
+		for j := lo; j <= hi; j++ {
+			curr[j] = max(
+				prev[j-1]+alignScore(a[i-1], b[j-1], match, mismatch),
+				prev[j]+gap,
+				curr[j-1]+gap,
+			)
+		}
+		if hi < n {
+			curr[hi+1] = negInfScore
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[n], nil
+}
+
+// Hirschberg computes an optimal global alignment with the same score as
+// NeedlemanWunsch using Hirschberg's divide and conquer, so long sequences
+// can be aligned in linear rather than quadratic memory.
+// Returns the alignment score and its columns in order.
+// Time: O(mn), Space: O(m+n)
+func Hirschberg[T comparable](a, b []T, match, mismatch, gap int) (int, []AlignedPair, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, nil, ErrEmptyInput
+	}
+
+	h := hirschbergAligner[T]{a: a, b: b, match: match, mismatch: mismatch, gap: gap}
+	h.row = make([]int, len(b)+1)
+	h.rev = make([]int, len(b)+1)
+	h.align(0, len(a), 0, len(b))
+
+	score := 0
+	for _, p := range h.pairs {
+		if p.A < 0 || p.B < 0 {
+			score += gap
+		} else {
+			score += alignScore(a[p.A], b[p.B], match, mismatch)
+		}
+	}
+	return score, h.pairs, nil
+}
+
+// hirschbergAligner holds the sequences, scores and reusable score rows of a
+// Hirschberg alignment
+type hirschbergAligner[T comparable] struct {
+	a, b                 []T
+	match, mismatch, gap int
+	row, rev             []int
+	pairs                []AlignedPair
+}
+
+// align appends an optimal alignment of a[i0:i1] against b[j0:j1]
+func (h *hirschbergAligner[T]) align(i0, i1, j0, j1 int) {
+	switch {
+	case i0 == i1:
+		for j := j0; j < j1; j++ {
+			h.pairs = append(h.pairs, AlignedPair{A: -1, B: j})
+		}
+		return
+	case j0 == j1:
+		for i := i0; i < i1; i++ {
+			h.pairs = append(h.pairs, AlignedPair{A: i, B: -1})
+		}
+		return
+	case i1-i0 == 1:
+		h.alignSingle(i0, j0, j1)
+		return
+	}
+
+	mid := (i0 + i1) / 2
+	h.forwardScores(i0, mid, j0, j1, h.row)
+	h.reverseScores(mid, i1, j0, j1, h.rev)
+
+	split, best := j0, negInfScore
+	for j := j0; j <= j1; j++ {
+		if s := h.row[j-j0] + h.rev[j-j0]; s > best {
+			split, best = j, s
+		}
+	}
+
+	h.align(i0, mid, j0, split)
+	h.align(mid, i1, split, j1)
+}
+
+// alignSingle aligns the single element a[i] against b[j0:j1], either
+// matching it to the best-scoring position or leaving it as a gap
+func (h *hirschbergAligner[T]) alignSingle(i, j0, j1 int) {
+	bestJ, best := -1, h.gap*(j1-j0+1)
+	for j := j0; j < j1; j++ {
+		if s := h.gap*(j1-j0-1) + alignScore(h.a[i], h.b[j], h.match, h.mismatch); s > best {
+			bestJ, best = j, s
+		}
+	}
+
+	if bestJ < 0 {
+		h.pairs = append(h.pairs, AlignedPair{A: i, B: -1})
+		for j := j0; j < j1; j++ {
+			h.pairs = append(h.pairs, AlignedPair{A: -1, B: j})
+		}
+		return
+	}
+	for j := j0; j < j1; j++ {
+		if j == bestJ {
+			h.pairs = append(h.pairs, AlignedPair{A: i, B: j})
+		} else {
+			h.pairs = append(h.pairs, AlignedPair{A: -1, B: j})
+		}
+	}
+}
+
+// forwardScores fills out[k] with the best score aligning a[i0:i1] against b[j0:j0+k]
+func (h *hirschbergAligner[T]) forwardScores(i0, i1, j0, j1 int, out []int) {
+	for k := 0; k <= j1-j0; k++ {
+		out[k] = k * h.gap
+	}
+	for i := i0; i < i1; i++ {
+		diag := out[0]
+		out[0] += h.gap
+		for k := 1; k <= j1-j0; k++ {
+			up := out[k]
+			out[k] = max(diag+alignScore(h.a[i], h.b[j0+k-1], h.match, h.mismatch), up+h.gap, out[k-1]+h.gap)
+			diag = up
+		}
+	}
+}
+
+// reverseScores fills out[k] with the best score aligning a[i0:i1] against b[j0+k:j1]
+func (h *hirschbergAligner[T]) reverseScores(i0, i1, j0, j1 int, out []int) {
+	n := j1 - j0
+	for k := n; k >= 0; k-- {
+		out[k] = (n - k) * h.gap
+	}
+	for i := i1 - 1; i >= i0; i-- {
+		diag := out[n]
+		out[n] += h.gap
+		for k := n - 1; k >= 0; k-- {
+			down := out[k]
+			out[k] = max(diag+alignScore(h.a[i], h.b[j0+k], h.match, h.mismatch), down+h.gap, out[k+1]+h.gap)
+			diag = down
+		}
+	}
+}
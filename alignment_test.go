@@ -0,0 +1,164 @@
+package distance
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// randomDNA returns a reproducible random sequence over ACGT
+func randomDNA(rng *rand.Rand, n int) []byte {
+	const bases = "ACGT"
+	s := make([]byte, n)
+	for i := range s {
+		s[i] = bases[rng.IntN(len(bases))]
+	}
+	return s
+}
+
+func TestNeedlemanWunschBanded(t *testing.T) {
+	a, b := []byte("GATTACA"), []byte("GCATGCU")
+	full, _ := NeedlemanWunsch(a, b, 1, -1, -1)
+
+	score, err := NeedlemanWunschBanded(a, b, 1, -1, -1, len(a))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if score != full {
+		t.Errorf("expected %d, got %d", full, score)
+	}
+
+	// A narrow band can only do worse than the unrestricted alignment
+	narrow, err := NeedlemanWunschBanded(a, b, 1, -1, -1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if narrow > full {
+		t.Errorf("banded score %d exceeds full score %d", narrow, full)
+	}
+	if narrow != -1 { // diagonal only: 3 matches, 4 mismatches
+		t.Errorf("expected -1, got %d", narrow)
+	}
+}
+
+func TestNeedlemanWunschBandedNearDiagonal(t *testing.T) {
+	rng := testRNG(7)
+	a := randomDNA(rng, 500)
+
+	// A few point mutations and a short deletion keep the optimum near the diagonal
+	b := append([]byte{}, a...)
+	for _, i := range []int{40, 150, 320} {
+		b[i] = 'A' + (b[i]-'A'+2)%26
+	}
+	b = append(b[:200], b[203:]...)
+
+	full, _ := NeedlemanWunsch(a, b, 2, -1, -2)
+	score, err := NeedlemanWunschBanded(a, b, 2, -1, -2, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if score != full {
+		t.Errorf("expected %d, got %d", full, score)
+	}
+}
+
+func TestNeedlemanWunschBandedMatchesFullMatrix(t *testing.T) {
+	rng := testRNG(5)
+	for trial := 0; trial < 50; trial++ {
+		a, b := randomDNA(rng, 1+rng.IntN(30)), randomDNA(rng, 1+rng.IntN(30))
+		diff := max(len(a)-len(b), len(b)-len(a))
+		for band := diff; band <= diff+4; band++ {
+			// Reference: the full (m+1)×(n+1) table with out-of-band cells excluded
+			m, n := len(a), len(b)
+			table := make([][]int, m+1)
+			for i := range table {
+				table[i] = make([]int, n+1)
+				for j := range table[i] {
+					switch {
+					case i-j > band || j-i > band:
+						table[i][j] = negInfScore
+					case i == 0:
+						table[i][j] = j * -2
+					case j == 0:
+						table[i][j] = i * -2
+					default:
+						table[i][j] = max(table[i-1][j-1]+alignScore(a[i-1], b[j-1], 2, -1),
+							table[i-1][j]-2, table[i][j-1]-2)
+					}
+				}
+			}
+
+			got, err := NeedlemanWunschBanded(a, b, 2, -1, -2, band)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != table[m][n] {
+				t.Errorf("trial %d band %d: expected %d, got %d", trial, band, table[m][n], got)
+			}
+		}
+	}
+}
+
+func TestHirschberg(t *testing.T) {
+	rng := testRNG(11)
+	pairs := [][2][]byte{
+		{[]byte("GATTACA"), []byte("GCATGCU")},
+		{[]byte("A"), []byte("CCACC")},
+		{[]byte("ACGTACGT"), []byte("T")},
+		{[]byte("AAAA"), []byte("AAAA")},
+		{randomDNA(rng, 120), randomDNA(rng, 90)},
+		{randomDNA(rng, 64), randomDNA(rng, 200)},
+	}
+
+	for _, p := range pairs {
+		a, b := p[0], p[1]
+		want, _ := NeedlemanWunsch(a, b, 1, -1, -2)
+		score, alignment, err := Hirschberg(a, b, 1, -1, -2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if score != want {
+			t.Errorf("%s/%s: expected %d, got %d", a, b, want, score)
+		}
+
+		// Every element appears exactly once, in order
+		nextA, nextB := 0, 0
+		for _, col := range alignment {
+			if col.A < 0 && col.B < 0 {
+				t.Fatalf("column aligns a gap with a gap")
+			}
+			if col.A >= 0 {
+				if col.A != nextA {
+					t.Fatalf("expected a index %d, got %d", nextA, col.A)
+				}
+				nextA++
+			}
+			if col.B >= 0 {
+				if col.B != nextB {
+					t.Fatalf("expected b index %d, got %d", nextB, col.B)
+				}
+				nextB++
+			}
+		}
+		if nextA != len(a) || nextB != len(b) {
+			t.Errorf("alignment covers %d/%d and %d/%d elements", nextA, len(a), nextB, len(b))
+		}
+	}
+}
+
+func TestAlignmentErrors(t *testing.T) {
+	if _, _, err := Hirschberg([]byte{}, []byte("A"), 1, -1, -1); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := NeedlemanWunschBanded([]byte("AAAA"), []byte("A"), 1, -1, -1, 2); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func BenchmarkHirschberg(b *testing.B) {
+	rng := testRNG(1)
+	x, y := randomDNA(rng, 2000), randomDNA(rng, 2000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = Hirschberg(x, y, 1, -1, -1)
+	}
+}
@@ -0,0 +1,180 @@
+package distance
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"runtime"
+)
+
+// Thresholds used by the automatic algorithm selectors. They are rough
+// crossover points measured on commodity hardware, not hard limits.
+const (
+	// autoParallelWork is the n²d work below which goroutine overhead dominates
+	autoParallelWork = 1 << 22
+	// autoFullDTWCells is the n·m cell count up to which unconstrained DTW is used
+	autoFullDTWCells = 1 << 20
+	// autoDTWWindowFraction is the Sakoe-Chiba band width, relative to the
+	// longer sequence, used when DTW must be constrained
+	autoDTWWindowFraction = 0.1
+)
+
+// AlgorithmChoice describes the implementation picked by an auto selector.
+type AlgorithmChoice struct {
+	Algorithm string  `json:"algorithm"`
+	Reason    string  `json:"reason"`
+	Cost      float64 `json:"cost"` // estimated elementary operations
+}
+
+// ExplainBatch reports which strategy BatchComputeAuto uses for n vectors of
+// dimension d.
+func ExplainBatch(n, d int) AlgorithmChoice {
+	work := float64(n) * float64(n) * float64(d) / 2
+	workers := runtime.NumCPU()
+	if work < autoParallelWork || workers < 2 {
+		return AlgorithmChoice{
+			Algorithm: "serial",
+			Reason:    fmt.Sprintf("%.0f pair-dimension operations is below the parallel threshold or only one CPU is available", work),
+			Cost:      work,
+		}
+	}
+	return AlgorithmChoice{
+		Algorithm: "parallel",
+		Reason:    fmt.Sprintf("%.0f pair-dimension operations split across %d workers", work, workers),
+		Cost:      work / float64(workers),
+	}
+}
+
+// BatchComputeAuto computes the distance matrix, choosing between the tiled
+// serial and the parallel implementation via ExplainBatch.
+// Time: O(n²d), Space: O(n²)
+func BatchComputeAuto[T Number](vectors [][]T, distFn DistanceFunc[T]) ([][]float64, error) {
+	d := 0
+	if len(vectors) > 0 {
+		d = len(vectors[0])
+	}
+	if ExplainBatch(len(vectors), d).Algorithm == "parallel" {
+		return BatchComputeParallel(vectors, distFn, runtime.NumCPU())
+	}
+	return BatchCompute(vectors, distFn)
+}
+
+// ExplainDTW reports which strategy DTWAuto uses for sequences of lengths n and m.
+func ExplainDTW(n, m int) AlgorithmChoice {
+	cells := float64(n) * float64(m)
+	if cells <= autoFullDTWCells {
+		return AlgorithmChoice{
+			Algorithm: "dtw",
+			Reason:    fmt.Sprintf("%.0f cells is small enough for exact unconstrained DTW", cells),
+			Cost:      cells,
+		}
+	}
+
+	window := dtwAutoWindow(n, m)
+	return AlgorithmChoice{
+		Algorithm: "dtw-window",
+		Reason:    fmt.Sprintf("%.0f cells exceeds %d; using a Sakoe-Chiba band of %d", cells, autoFullDTWCells, window),
+		Cost:      math.Min(cells, float64(max(n, m))*float64(2*window+1)),
+	}
+}
+
+// DTWAuto computes dynamic time warping, falling back to a Sakoe-Chiba band
+// of 10% of the longer sequence (at least the length difference) when exact
+// DTW would be too expensive. The banded result is an upper bound on exact DTW.
+// Time: O(mn) or O(max(m,n)·w), Space: O(min(m,n))
+func DTWAuto[T Number](a, b []T) (float64, error) {
+	if ExplainDTW(len(a), len(b)).Algorithm == "dtw" {
+		return DTW(a, b)
+	}
+	return DTWWithWindow(a, b, dtwAutoWindow(len(a), len(b)))
+}
+
+func dtwAutoWindow(n, m int) int {
+	w := int(math.Ceil(autoDTWWindowFraction * float64(max(n, m))))
+	return max(w, n-m, m-n)
+}
+
+// ExplainAllPairs reports which strategy AllPairsShortestPaths uses.
+// Repeated Dijkstra wins on sparse graphs but requires non-negative weights;
+// otherwise Floyd-Warshall is used.
+func (g *Graph) ExplainAllPairs() AlgorithmChoice {
+	v, e := float64(len(g.nodes)), 0.0
+	negative := false
+	for _, edges := range g.adjacency {
+		for _, w := range edges {
+			e++
+			negative = negative || w < 0
+		}
+	}
+
+	floyd := v * v * v
+	if negative {
+		return AlgorithmChoice{
+			Algorithm: "floyd-warshall",
+			Reason:    "graph has negative edge weights, which Dijkstra cannot handle",
+			Cost:      floyd,
+		}
+	}
+
+	dijkstra := v * (v + e) * math.Log2(v+1)
+	if dijkstra < floyd {
+		return AlgorithmChoice{
+			Algorithm: "dijkstra",
+			Reason:    fmt.Sprintf("sparse graph (%.0f nodes, %.0f edges): V·(V+E)·log V < V³", v, e),
+			Cost:      dijkstra,
+		}
+	}
+	return AlgorithmChoice{
+		Algorithm: "floyd-warshall",
+		Reason:    fmt.Sprintf("dense graph (%.0f nodes, %.0f edges): V³ ≤ V·(V+E)·log V", v, e),
+		Cost:      floyd,
+	}
+}
+
+// AllPairsShortestPaths computes all-pairs shortest path distances, choosing
+// between repeated Dijkstra and Floyd-Warshall via ExplainAllPairs.
+// Unreachable pairs are +Inf, matching FloydWarshall. Each node is at
+// distance 0 from itself whichever algorithm runs, even with a positive
+// self-loop; only a negative cycle makes it negative.
+// Time: O(min(V³, V(V+E)logV)), Space: O(V²)
+func (g *Graph) AllPairsShortestPaths() map[int]map[int]float64 {
+	if g.ExplainAllPairs().Algorithm == "floyd-warshall" {
+		dist := g.FloydWarshall()
+		for node, row := range dist {
+			// FloydWarshall seeds the diagonal with self-loop weights
+			row[node] = math.Min(row[node], 0)
+		}
+		return dist
+	}
+
+	dist := make(map[int]map[int]float64, len(g.nodes))
+	for source := range g.nodes {
+		dist[source] = g.shortestPathsFrom(source)
+	}
+	return dist
+}
+
+// shortestPathsFrom runs Dijkstra from source to every node
+func (g *Graph) shortestPathsFrom(source int) map[int]float64 {
+	dist := make(map[int]float64, len(g.nodes))
+	for node := range g.nodes {
+		dist[node] = math.Inf(1)
+	}
+	dist[source] = 0
+
+	pq := &priorityQueue{}
+	heap.Push(pq, &item{node: source, priority: 0})
+	for pq.Len() > 0 {
+		cur := heap.Pop(pq).(*item)
+		if cur.priority > dist[cur.node] {
+			continue // stale entry
+		}
+		for next, w := range g.adjacency[cur.node] {
+			if d := cur.priority + w; d < dist[next] {
+				dist[next] = d
+				heap.Push(pq, &item{node: next, priority: d})
+			}
+		}
+	}
+	return dist
+}
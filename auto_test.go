@@ -0,0 +1,113 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExplainBatch(t *testing.T) {
+	small := ExplainBatch(10, 3)
+	if small.Algorithm != "serial" || small.Reason == "" {
+		t.Errorf("expected serial for tiny input, got %+v", small)
+	}
+
+	vectors := [][]float64{{0, 0}, {3, 4}, {1, 1}}
+	got, err := BatchComputeAuto(vectors, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(got[0][1], 5) {
+		t.Errorf("expected 5, got %v", got[0][1])
+	}
+}
+
+func TestDTWAuto(t *testing.T) {
+	if c := ExplainDTW(100, 100); c.Algorithm != "dtw" {
+		t.Errorf("expected exact dtw, got %+v", c)
+	}
+	if c := ExplainDTW(5000, 4800); c.Algorithm != "dtw-window" || c.Cost >= 5000*4800 {
+		t.Errorf("expected cheaper windowed dtw, got %+v", c)
+	}
+
+	a := []float64{1, 2, 3, 4, 3, 2}
+	b := []float64{1, 1, 2, 3, 4, 2}
+	want, _ := DTW(a, b)
+	got, err := DTWAuto(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestAllPairsShortestPaths(t *testing.T) {
+	sparse := NewGraph()
+	for i := 0; i < 20; i++ {
+		sparse.AddUndirectedEdge(i, i+1, 1)
+	}
+	sparse.AddEdge(21, 22, 1)
+
+	if c := sparse.ExplainAllPairs(); c.Algorithm != "dijkstra" {
+		t.Errorf("expected dijkstra for a path graph, got %+v", c)
+	}
+	got := sparse.AllPairsShortestPaths()
+	want := sparse.FloydWarshall()
+	for from := range want {
+		for to, d := range want[from] {
+			if got[from][to] != d {
+				t.Errorf("%d→%d: expected %v, got %v", from, to, d, got[from][to])
+			}
+		}
+	}
+	if !math.IsInf(got[0][22], 1) {
+		t.Errorf("expected +Inf for unreachable node, got %v", got[0][22])
+	}
+
+	negative := NewGraph()
+	negative.AddEdge(0, 1, 2)
+	negative.AddEdge(1, 2, -1)
+	if c := negative.ExplainAllPairs(); c.Algorithm != "floyd-warshall" {
+		t.Errorf("expected floyd-warshall for negative weights, got %+v", c)
+	}
+	if d := negative.AllPairsShortestPaths()[0][2]; d != 1 {
+		t.Errorf("expected 1, got %v", d)
+	}
+}
+
+func TestAllPairsShortestPathsSelfLoop(t *testing.T) {
+	// The same self-looped triangle, once sparse enough for Dijkstra and
+	// once padded into a dense graph for Floyd-Warshall
+	sparse := NewGraph()
+	dense := NewGraph()
+	for _, g := range []*Graph{sparse, dense} {
+		g.AddUndirectedEdge(0, 1, 1)
+		g.AddUndirectedEdge(1, 2, 2)
+		g.AddEdge(1, 1, 5)
+	}
+	for i := 3; i < 23; i++ {
+		sparse.AddUndirectedEdge(i, i+1, 1)
+	}
+	for i := 3; i < 8; i++ {
+		for j := 3; j < 8; j++ {
+			dense.AddEdge(i, j, 1)
+		}
+	}
+	if sparse.ExplainAllPairs().Algorithm != "dijkstra" || dense.ExplainAllPairs().Algorithm != "floyd-warshall" {
+		t.Fatalf("expected different algorithms, got %v and %v",
+			sparse.ExplainAllPairs().Algorithm, dense.ExplainAllPairs().Algorithm)
+	}
+
+	viaDijkstra := sparse.AllPairsShortestPaths()
+	viaFloyd := dense.AllPairsShortestPaths()
+	for from := 0; from < 3; from++ {
+		for to := 0; to < 3; to++ {
+			if viaDijkstra[from][to] != viaFloyd[from][to] {
+				t.Errorf("%d→%d: dijkstra %v, floyd-warshall %v", from, to, viaDijkstra[from][to], viaFloyd[from][to])
+			}
+		}
+	}
+	if viaFloyd[1][1] != 0 || viaFloyd[5][5] != 0 {
+		t.Errorf("expected zero self-distance, got %v and %v", viaFloyd[1][1], viaFloyd[5][5])
+	}
+}
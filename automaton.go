@@ -0,0 +1,95 @@
+package distance
+
+// LevenshteinAutomaton recognizes the strings within maxDist edits
+// (Levenshtein, over runes) of a query. Feeding a candidate one rune at a
+// time with Step lets a caller walk its own trie, FST or sorted dictionary
+// and abandon a branch as soon as CanMatch reports that no extension can be
+// accepted. States are immutable values, so one state can be stepped along
+// many branches. An automaton is safe for concurrent use.
+//
+// The automaton is simulated rather than compiled to a DFA: a state is one
+// row of the edit-distance table, capped at maxDist+1, and each step costs
+// O(len(query)).
+type LevenshteinAutomaton struct {
+	query   []rune
+	maxDist int
+}
+
+// LevenshteinState is the position of a LevenshteinAutomaton after
+// consuming a prefix of a candidate.
+type LevenshteinState struct {
+	row []int // row[j] = capped distance between the consumed input and query[:j]
+}
+
+// NewLevenshteinAutomaton creates an automaton accepting strings within
+// maxDist edits of query.
+func NewLevenshteinAutomaton(query string, maxDist int) (*LevenshteinAutomaton, error) {
+	if maxDist < 0 {
+		return nil, ErrInvalidParameter
+	}
+	return &LevenshteinAutomaton{query: []rune(query), maxDist: maxDist}, nil
+}
+
+// Start returns the state before any input.
+func (a *LevenshteinAutomaton) Start() LevenshteinState {
+	row := make([]int, len(a.query)+1)
+	for j := range row {
+		row[j] = min(j, a.maxDist+1)
+	}
+	return LevenshteinState{row: row}
+}
+
+// Step returns the state after consuming r from s; s is unchanged.
+// Time: O(len(query)), Space: O(len(query))
+func (a *LevenshteinAutomaton) Step(s LevenshteinState, r rune) LevenshteinState {
+	row := make([]int, len(s.row))
+	row[0] = min(s.row[0]+1, a.maxDist+1)
+	for j := 1; j < len(row); j++ {
+		cost := 1
+		if a.query[j-1] == r {
+			cost = 0
+		}
+		row[j] = min(min3(row[j-1]+1, s.row[j]+1, s.row[j-1]+cost), a.maxDist+1)
+	}
+	return LevenshteinState{row: row}
+}
+
+// IsMatch reports whether the input consumed so far is within maxDist of
+// the query.
+func (a *LevenshteinAutomaton) IsMatch(s LevenshteinState) bool {
+	return s.row[len(s.row)-1] <= a.maxDist
+}
+
+// Distance returns the edit distance between the input consumed so far and
+// the query, or maxDist+1 if it exceeds maxDist.
+func (a *LevenshteinAutomaton) Distance(s LevenshteinState) int {
+	return s.row[len(s.row)-1]
+}
+
+// CanMatch reports whether some continuation of the input consumed so far
+// can still be accepted. For autocomplete with typos, walk a trie of
+// completions: once IsMatch holds at a node, the typed query is within
+// maxDist of that prefix and every completion below it qualifies; once
+// CanMatch fails, the whole subtree can be skipped.
+func (a *LevenshteinAutomaton) CanMatch(s LevenshteinState) bool {
+	for _, d := range s.row {
+		if d <= a.maxDist {
+			return true
+		}
+	}
+	return false
+}
+
+// Accepts reports whether candidate is within maxDist edits of the query,
+// stopping early once no continuation can match.
+// Time: O(len(candidate)·len(query)), Space: O(len(query))
+func (a *LevenshteinAutomaton) Accepts(candidate string) bool {
+	s := a.Start()
+	for _, r := range candidate {
+		s = a.Step(s, r)
+		if !a.CanMatch(s) {
+			return false
+		}
+	}
+	return a.IsMatch(s)
+}
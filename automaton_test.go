@@ -0,0 +1,82 @@
+package distance
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestLevenshteinAutomatonAccepts(t *testing.T) {
+	words := append(randomWords(21, 300), "", "a", "café", "cafe", "caffè")
+	for _, query := range []string{"abcd", "cafe", "", "hij"} {
+		for maxDist := 0; maxDist <= 2; maxDist++ {
+			a, err := NewLevenshteinAutomaton(query, maxDist)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, w := range words {
+				d, _ := LevenshteinRunes(query, w)
+				if got := a.Accepts(w); got != (d <= maxDist) {
+					t.Errorf("%q vs %q (distance %d, max %d): Accepts = %v", query, w, d, maxDist, got)
+				}
+			}
+		}
+	}
+}
+
+func TestLevenshteinAutomatonStep(t *testing.T) {
+	a, _ := NewLevenshteinAutomaton("kitten", 3)
+	s := a.Start()
+	for _, r := range "sitting" {
+		s = a.Step(s, r)
+	}
+	if !a.IsMatch(s) || a.Distance(s) != 3 {
+		t.Errorf("expected a match at distance 3, got %d", a.Distance(s))
+	}
+
+	// States are values: stepping a shared state along two branches
+	base := a.Step(a.Step(a.Start(), 'k'), 'i')
+	left, right := a.Step(base, 't'), a.Step(base, 'x')
+	if a.Distance(a.Step(base, 't')) != a.Distance(left) || a.Distance(right) != 4 {
+		t.Errorf("expected independent branches, got %d and %d", a.Distance(left), a.Distance(right))
+	}
+
+	// Far inputs are abandoned early
+	dead := a.Start()
+	for _, r := range "zzzz" {
+		dead = a.Step(dead, r)
+	}
+	if a.CanMatch(dead) {
+		t.Errorf("expected no possible match after four foreign runes")
+	}
+}
+
+func TestLevenshteinAutomatonAutocomplete(t *testing.T) {
+	// Prefix-filter a sorted dictionary the way a trie walk would: once the
+	// typed query matches a prefix, every word with that prefix qualifies
+	dictionary := []string{"apple", "application", "apply", "banana", "band", "bandana", "applause"}
+	sort.Strings(dictionary)
+	a, _ := NewLevenshteinAutomaton("appli", 1)
+
+	var got []string
+	for _, w := range dictionary {
+		s := a.Start()
+		for _, r := range w {
+			s = a.Step(s, r)
+			if a.IsMatch(s) {
+				got = append(got, w)
+				break
+			}
+			if !a.CanMatch(s) {
+				break
+			}
+		}
+	}
+	if strings.Join(got, ",") != "applause,apple,application,apply" {
+		t.Errorf("unexpected completions %v", got)
+	}
+
+	if _, err := NewLevenshteinAutomaton("x", -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
@@ -1,11 +1,19 @@
 package distance
 
 import (
+	"container/heap"
 	"context"
+	"math"
 	"sync"
 )
 
+// batchBlockSize is the tile edge used by blocked batch computations.
+// 64 rows of typical embeddings fit comfortably in L2 cache.
+const batchBlockSize = 64
+
 // BatchCompute computes distances between all pairs of vectors (distance matrix).
+// Pairs are visited in square tiles so each block of vectors stays cache-resident
+// while it is compared against another block.
 // Time: O(n²d), Space: O(n²) where n=vectors, d=dimensions
 func BatchCompute[T Number](vectors [][]T, distFn DistanceFunc[T]) ([][]float64, error) {
 	n := len(vectors)
@@ -18,20 +26,121 @@ func BatchCompute[T Number](vectors [][]T, distFn DistanceFunc[T]) ([][]float64,
 		result[i] = make([]float64, n)
 	}
 
-	for i := 0; i < n; i++ {
-		for j := i; j < n; j++ {
-			dist, err := distFn(vectors[i], vectors[j])
-			if err != nil {
-				return nil, err
+	for bi := 0; bi < n; bi += batchBlockSize {
+		iEnd := min(bi+batchBlockSize, n)
+		for bj := bi; bj < n; bj += batchBlockSize {
+			jEnd := min(bj+batchBlockSize, n)
+			for i := bi; i < iEnd; i++ {
+				for j := max(i, bj); j < jEnd; j++ {
+					dist, err := distFn(vectors[i], vectors[j])
+					if err != nil {
+						return nil, err
+					}
+					result[i][j] = dist
+					result[j][i] = dist // Symmetric
+				}
 			}
-			result[i][j] = dist
-			result[j][i] = dist // Symmetric
 		}
 	}
 
 	return result, nil
 }
 
+// BatchComputeEuclidean computes the Euclidean distance matrix using the
+// identity ||a-b||² = ||a||² + ||b||² - 2a·b with precomputed squared norms,
+// processing vectors in cache-friendly tiles. Much faster than BatchCompute
+// with Euclidean for wide matrices; results may differ in the last few ulps.
+// Time: O(n²d), Space: O(n²)
+func BatchComputeEuclidean[T Number](vectors [][]T) ([][]float64, error) {
+	data, norms, err := gramInputs(vectors)
+	if err != nil {
+		return nil, err
+	}
+
+	return blockedGram(data, func(i, j int, dot float64) float64 {
+		if i == j {
+			return 0
+		}
+		sq := norms[i] + norms[j] - 2*dot
+		if sq < 0 {
+			sq = 0 // Cancellation error for nearly identical vectors
+		}
+		return math.Sqrt(sq)
+	}), nil
+}
+
+// BatchComputeCosine computes the cosine distance matrix using precomputed
+// norms and tiled dot products. Returns ErrZeroVector if any vector is zero.
+// Time: O(n²d), Space: O(n²)
+func BatchComputeCosine[T Number](vectors [][]T) ([][]float64, error) {
+	data, norms, err := gramInputs(vectors)
+	if err != nil {
+		return nil, err
+	}
+	for i := range norms {
+		if norms[i] == 0 {
+			return nil, ErrZeroVector
+		}
+		norms[i] = math.Sqrt(norms[i])
+	}
+
+	return blockedGram(data, func(i, j int, dot float64) float64 {
+		if i == j {
+			return 0
+		}
+		return 1 - clampUnit(dot/(norms[i]*norms[j]))
+	}), nil
+}
+
+// gramInputs converts vectors to float64 and computes their squared norms
+func gramInputs[T Number](vectors [][]T) ([][]float64, []float64, error) {
+	if len(vectors) == 0 {
+		return [][]float64{}, []float64{}, nil
+	}
+
+	d := len(vectors[0])
+	data := make([][]float64, len(vectors))
+	norms := make([]float64, len(vectors))
+	for i, v := range vectors {
+		if len(v) == 0 {
+			return nil, nil, ErrEmptyInput
+		}
+		if len(v) != d {
+			return nil, nil, ErrDimensionMismatch
+		}
+		data[i] = make([]float64, d)
+		for k, x := range v {
+			data[i][k] = float64(x)
+		}
+		norms[i] = dotF64(data[i], data[i])
+	}
+	return data, norms, nil
+}
+
+// blockedGram fills a symmetric matrix with finish(i, j, a_i·a_j) in tiles
+func blockedGram(data [][]float64, finish func(i, j int, dot float64) float64) [][]float64 {
+	n := len(data)
+	result := make([][]float64, n)
+	for i := range result {
+		result[i] = make([]float64, n)
+	}
+
+	for bi := 0; bi < n; bi += batchBlockSize {
+		iEnd := min(bi+batchBlockSize, n)
+		for bj := bi; bj < n; bj += batchBlockSize {
+			jEnd := min(bj+batchBlockSize, n)
+			for i := bi; i < iEnd; i++ {
+				for j := max(i, bj); j < jEnd; j++ {
+					dist := finish(i, j, dotF64(data[i], data[j]))
+					result[i][j] = dist
+					result[j][i] = dist
+				}
+			}
+		}
+	}
+	return result
+}
+
 // BatchComputeParallel computes distance matrix in parallel.
 // Time: O(n²d/workers), Space: O(n²)
 func BatchComputeParallel[T Number](vectors [][]T, distFn DistanceFunc[T], workers int) ([][]float64, error) {
@@ -96,27 +205,170 @@ func BatchComputeParallel[T Number](vectors [][]T, distFn DistanceFunc[T], worke
 	return result, nil
 }
 
+// CrossCompute computes distances between every vector in x and every vector in y.
+// Returns a len(x)×len(y) matrix where result[i][j] = distFn(x[i], y[j]).
+// Useful for query-vs-corpus scenarios (similar to scipy's cdist).
+// Time: O(nmd), Space: O(nm) where n=len(x), m=len(y)
+func CrossCompute[T Number](x, y [][]T, distFn DistanceFunc[T]) ([][]float64, error) {
+	result := make([][]float64, len(x))
+	for i := range x {
+		result[i] = make([]float64, len(y))
+		for j := range y {
+			dist, err := distFn(x[i], y[j])
+			if err != nil {
+				return nil, err
+			}
+			result[i][j] = dist
+		}
+	}
+
+	return result, nil
+}
+
+// Neighbor is a vector index paired with its distance to a query.
+type Neighbor struct {
+	Index    int
+	Distance float64
+}
+
 // KNearestNeighbors finds k nearest neighbors for each vector.
 // Returns indices of k nearest neighbors for each vector.
-// Time: O(n²d), Space: O(nk)
+// Time: O(n²d + n² log k), Space: O(nk)
 func KNearestNeighbors[T Number](vectors [][]T, k int, distFn DistanceFunc[T]) ([][]int, error) {
+	neighbors, err := KNearestNeighborsWithDistances(vectors, k, distFn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]int, len(neighbors))
+	for i, row := range neighbors {
+		result[i] = make([]int, len(row))
+		for p, nb := range row {
+			result[i][p] = nb.Index
+		}
+	}
+
+	return result, nil
+}
+
+// KNearestNeighborsWithDistances finds k nearest neighbors for each vector,
+// returning each neighbor's index and distance sorted by ascending distance.
+// Uses a bounded max-heap per vector, so only k candidates are retained.
+// Time: O(n²d + n² log k), Space: O(nk)
+func KNearestNeighborsWithDistances[T Number](vectors [][]T, k int, distFn DistanceFunc[T]) ([][]Neighbor, error) {
 	n := len(vectors)
 	if n == 0 || k <= 0 {
-		return [][]int{}, nil
+		return [][]Neighbor{}, nil
 	}
 	if k > n-1 {
 		k = n - 1
 	}
 
-	result := make([][]int, n)
+	result := make([][]Neighbor, n)
 
 	for i := 0; i < n; i++ {
-		// Compute distances to all other vectors
-		distances := make([]struct {
-			index int
-			dist  float64
-		}, 0, n-1)
+		row, err := kNearest(vectors, vectors[i], i, k, distFn)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = row
+	}
+
+	return result, nil
+}
+
+// kNearest selects the k vectors closest to query, skipping index skip (-1 for none)
+func kNearest[T Number](vectors [][]T, query []T, skip, k int, distFn DistanceFunc[T]) ([]Neighbor, error) {
+	return nearestIndices(len(vectors), skip, k, func(j int) (float64, error) {
+		return distFn(query, vectors[j])
+	})
+}
+
+// nearestIndices selects the k indices in [0, n) with the smallest dist,
+// skipping index skip (-1 for none); ties go to the lower index
+func nearestIndices(n, skip, k int, dist func(j int) (float64, error)) ([]Neighbor, error) {
+	h := make(neighborHeap, 0, min(k, n))
+	for j := 0; j < n; j++ {
+		if j == skip {
+			continue
+		}
+		d, err := dist(j)
+		if err != nil {
+			return nil, err
+		}
+		h.offer(Neighbor{j, d}, k)
+	}
+	return h.sorted(), nil
+}
+
+// neighborHeap is a max-heap of neighbors by distance (ties broken by larger index)
+type neighborHeap []Neighbor
+
+func (h neighborHeap) Len() int { return len(h) }
+
+func (h neighborHeap) Less(i, j int) bool {
+	if h[i].Distance != h[j].Distance {
+		return h[i].Distance > h[j].Distance
+	}
+	return h[i].Index > h[j].Index
+}
 
+func (h neighborHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *neighborHeap) Push(x any) { *h = append(*h, x.(Neighbor)) }
+
+func (h *neighborHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// offer adds nb to h, which holds at most k neighbors, evicting the
+// farthest when nb is closer (ties go to the lower index)
+func (h *neighborHeap) offer(nb Neighbor, k int) {
+	if len(*h) < k {
+		heap.Push(h, nb)
+		return
+	}
+	far := (*h)[0]
+	if k > 0 && (nb.Distance < far.Distance || (nb.Distance == far.Distance && nb.Index < far.Index)) {
+		(*h)[0] = nb
+		heap.Fix(h, 0)
+	}
+}
+
+// bound returns the distance a neighbor must not exceed to enter h, which
+// holds at most k neighbors: +Inf until h is full
+func (h neighborHeap) bound(k int) float64 {
+	if len(h) < k {
+		return math.Inf(1)
+	}
+	return h[0].Distance
+}
+
+// sorted empties h, returning its neighbors by ascending distance
+func (h *neighborHeap) sorted() []Neighbor {
+	row := make([]Neighbor, len(*h))
+	for p := len(row) - 1; p >= 0; p-- {
+		row[p] = heap.Pop(h).(Neighbor)
+	}
+	return row
+}
+
+// RadiusNeighbors finds all neighbors within radius for each vector.
+// Time: O(n²d), Space: O(n*m) where m=avg neighbors
+func RadiusNeighbors[T Number](vectors [][]T, radius float64, distFn DistanceFunc[T]) ([][]int, error) {
+	n := len(vectors)
+	if n == 0 || radius < 0 {
+		return [][]int{}, nil
+	}
+
+	result := make([][]int, n)
+
+	for i := 0; i < n; i++ {
+		neighbors := make([]int, 0)
 		for j := 0; j < n; j++ {
 			if i == j {
 				continue
@@ -125,44 +377,60 @@ func KNearestNeighbors[T Number](vectors [][]T, k int, distFn DistanceFunc[T]) (
 			if err != nil {
 				return nil, err
 			}
-			distances = append(distances, struct {
-				index int
-				dist  float64
-			}{j, dist})
-		}
-
-		// Sort by distance (simple bubble sort for k elements)
-		for p := 0; p < k; p++ {
-			minIdx := p
-			for q := p + 1; q < len(distances); q++ {
-				if distances[q].dist < distances[minIdx].dist {
-					minIdx = q
-				}
+			if dist <= radius {
+				neighbors = append(neighbors, j)
 			}
-			distances[p], distances[minIdx] = distances[minIdx], distances[p]
 		}
+		result[i] = neighbors
+	}
+
+	return result, nil
+}
 
-		// Extract k nearest
-		result[i] = make([]int, k)
-		for p := 0; p < k; p++ {
-			result[i][p] = distances[p].index
+// KNearestNeighborsParallel finds k nearest neighbors for each vector in parallel.
+// Rows are distributed across workers; cancelling ctx stops work early and
+// returns ctx.Err().
+// Time: O(n²d/workers), Space: O(nk)
+func KNearestNeighborsParallel[T Number](ctx context.Context, vectors [][]T, k int, distFn DistanceFunc[T], workers int) ([][]int, error) {
+	n := len(vectors)
+	if n == 0 || k <= 0 {
+		return [][]int{}, nil
+	}
+	if k > n-1 {
+		k = n - 1
+	}
+
+	result := make([][]int, n)
+	err := parallelRows(ctx, n, workers, func(i int) error {
+		row, err := kNearest(vectors, vectors[i], i, k, distFn)
+		if err != nil {
+			return err
+		}
+		result[i] = make([]int, len(row))
+		for p, nb := range row {
+			result[i][p] = nb.Index
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return result, nil
 }
 
-// RadiusNeighbors finds all neighbors within radius for each vector.
-// Time: O(n²d), Space: O(n*m) where m=avg neighbors
-func RadiusNeighbors[T Number](vectors [][]T, radius float64, distFn DistanceFunc[T]) ([][]int, error) {
+// RadiusNeighborsParallel finds all neighbors within radius for each vector in parallel.
+// Rows are distributed across workers; cancelling ctx stops work early and
+// returns ctx.Err().
+// Time: O(n²d/workers), Space: O(n*m) where m=avg neighbors
+func RadiusNeighborsParallel[T Number](ctx context.Context, vectors [][]T, radius float64, distFn DistanceFunc[T], workers int) ([][]int, error) {
 	n := len(vectors)
 	if n == 0 || radius < 0 {
 		return [][]int{}, nil
 	}
 
 	result := make([][]int, n)
-
-	for i := 0; i < n; i++ {
+	err := parallelRows(ctx, n, workers, func(i int) error {
 		neighbors := make([]int, 0)
 		for j := 0; j < n; j++ {
 			if i == j {
@@ -170,18 +438,83 @@ func RadiusNeighbors[T Number](vectors [][]T, radius float64, distFn DistanceFun
 			}
 			dist, err := distFn(vectors[i], vectors[j])
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if dist <= radius {
 				neighbors = append(neighbors, j)
 			}
 		}
 		result[i] = neighbors
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return result, nil
 }
 
+// parallelRows runs fn for each row index in [0, n) across workers,
+// stopping at the first error or when ctx is cancelled
+func parallelRows(ctx context.Context, n, workers int, fn func(i int) error) error {
+	if workers <= 0 {
+		workers = 4
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rows := make(chan int, workers)
+	errors := make(chan error, 1)
+	report := func(err error) {
+		select {
+		case errors <- err:
+		default:
+		}
+		cancel()
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	// Start workers
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range rows {
+				if ctx.Err() != nil {
+					return
+				}
+				if err := fn(i); err != nil {
+					report(err)
+					return
+				}
+			}
+		}()
+	}
+
+	// Send rows with cancellation check
+	func() {
+		defer close(rows)
+		for i := 0; i < n; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case rows <- i:
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(errors)
+
+	// Check for errors, preferring the caller's cancellation
+	if err := <-errors; err != nil {
+		return err
+	}
+	return context.Cause(ctx)
+}
+
 // ComputeToPoint computes distances from all vectors to a single point.
 // Time: O(nd), Space: O(n)
 func ComputeToPoint[T Number](vectors [][]T, point []T, distFn DistanceFunc[T]) ([]float64, error) {
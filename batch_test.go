@@ -2,6 +2,7 @@ package distance
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 )
@@ -40,6 +41,72 @@ func TestBatchCompute(t *testing.T) {
 	}
 }
 
+func TestBatchComputeBlocked(t *testing.T) {
+	// More vectors than one tile so cross-block pairs are exercised
+	n := batchBlockSize*2 + 7
+	vectors := make([][]float64, n)
+	for i := range vectors {
+		vectors[i] = []float64{float64(i % 13), float64(i%7) + 1, float64(i % 5)}
+	}
+
+	reference := make([][]float64, n)
+	for i := range reference {
+		reference[i] = make([]float64, n)
+		for j := range reference[i] {
+			reference[i][j], _ = Euclidean(vectors[i], vectors[j])
+		}
+	}
+
+	blocked, err := BatchCompute(vectors, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gram, err := BatchComputeEuclidean(vectors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if blocked[i][j] != reference[i][j] {
+				t.Fatalf("BatchCompute[%d][%d]: expected %v, got %v", i, j, reference[i][j], blocked[i][j])
+			}
+			if math.Abs(gram[i][j]-reference[i][j]) > 1e-6 {
+				t.Fatalf("BatchComputeEuclidean[%d][%d]: expected %v, got %v", i, j, reference[i][j], gram[i][j])
+			}
+		}
+	}
+}
+
+func TestBatchComputeCosine(t *testing.T) {
+	vectors := [][]float64{
+		{1, 0},
+		{0, 1},
+		{-1, 0},
+		{1, 1},
+	}
+
+	result, err := BatchComputeCosine(vectors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range vectors {
+		for j := range vectors {
+			want, _ := Cosine(vectors[i], vectors[j])
+			if math.Abs(result[i][j]-want) > 1e-12 {
+				t.Errorf("result[%d][%d]: expected %v, got %v", i, j, want, result[i][j])
+			}
+		}
+	}
+
+	if _, err := BatchComputeCosine([][]float64{{1, 0}, {0, 0}}); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+	if _, err := BatchComputeEuclidean([][]float64{{1, 0}, {0}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
 func TestBatchComputeParallel(t *testing.T) {
 	vectors := [][]float64{
 		{1, 2},
@@ -58,6 +125,51 @@ func TestBatchComputeParallel(t *testing.T) {
 	}
 }
 
+func TestCrossCompute(t *testing.T) {
+	queries := [][]float64{
+		{0, 0},
+		{1, 1},
+	}
+	corpus := [][]float64{
+		{3, 4},
+		{0, 0},
+		{1, 1},
+	}
+
+	result, err := CrossCompute(queries, corpus, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result) != 2 || len(result[0]) != 3 {
+		t.Fatalf("expected 2x3 matrix, got %dx%d", len(result), len(result[0]))
+	}
+
+	expected := [][]float64{
+		{5, 0, math.Sqrt(2)},
+		{math.Sqrt(13), math.Sqrt(2), 0},
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if !almostEqual(result[i][j], expected[i][j]) {
+				t.Errorf("result[%d][%d]: expected %v, got %v", i, j, expected[i][j], result[i][j])
+			}
+		}
+	}
+
+	// Dimension mismatch propagates
+	_, err = CrossCompute([][]float64{{1}}, corpus, Euclidean[float64])
+	if err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+
+	// Empty inputs yield empty matrices
+	result, _ = CrossCompute([][]float64{}, corpus, Euclidean[float64])
+	if len(result) != 0 {
+		t.Errorf("expected empty result, got %v", result)
+	}
+}
+
 func TestKNearestNeighbors(t *testing.T) {
 	vectors := [][]float64{
 		{0, 0},
@@ -75,6 +187,116 @@ func TestKNearestNeighbors(t *testing.T) {
 	if len(result[0]) != 2 {
 		t.Errorf("expected 2 neighbors, got %d", len(result[0]))
 	}
+	if result[0][0] != 1 || result[0][1] != 2 {
+		t.Errorf("expected neighbors [1 2], got %v", result[0])
+	}
+	if result[3][0] != 2 || result[3][1] != 1 {
+		t.Errorf("expected neighbors [2 1], got %v", result[3])
+	}
+}
+
+func TestKNearestNeighborsWithDistances(t *testing.T) {
+	vectors := [][]float64{
+		{0},
+		{1},
+		{3},
+		{6},
+		{10},
+	}
+
+	result, err := KNearestNeighborsWithDistances(vectors, 3, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Neighbor{{1, 2}, {0, 3}, {3, 3}}
+	for p, nb := range result[2] {
+		if nb.Index != expected[p].Index || !almostEqual(nb.Distance, expected[p].Distance) {
+			t.Errorf("neighbor %d: expected %+v, got %+v", p, expected[p], nb)
+		}
+	}
+
+	// k larger than n-1 is clamped
+	result, _ = KNearestNeighborsWithDistances(vectors, 10, Euclidean[float64])
+	if len(result[0]) != 4 {
+		t.Errorf("expected 4 neighbors, got %d", len(result[0]))
+	}
+	for p := 1; p < len(result[0]); p++ {
+		if result[0][p].Distance < result[0][p-1].Distance {
+			t.Errorf("neighbors not sorted: %v", result[0])
+		}
+	}
+
+	if _, err := KNearestNeighborsWithDistances([][]float64{{1}, {1, 2}}, 1, Euclidean[float64]); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestKNearestNeighborsParallel(t *testing.T) {
+	vectors := make([][]float64, 50)
+	for i := range vectors {
+		vectors[i] = []float64{float64(i * i % 37), float64(i % 11)}
+	}
+
+	expected, _ := KNearestNeighbors(vectors, 3, Euclidean[float64])
+	result, err := KNearestNeighborsParallel(context.Background(), vectors, 3, Euclidean[float64], 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range expected {
+		for p := range expected[i] {
+			if result[i][p] != expected[i][p] {
+				t.Errorf("row %d: expected %v, got %v", i, expected[i], result[i])
+				break
+			}
+		}
+	}
+}
+
+func TestRadiusNeighborsParallel(t *testing.T) {
+	vectors := [][]float64{
+		{0, 0},
+		{1, 0},
+		{0, 1},
+		{10, 10},
+	}
+
+	result, err := RadiusNeighborsParallel(context.Background(), vectors, 2.0, Euclidean[float64], 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result[0]) != 2 || len(result[3]) != 0 {
+		t.Errorf("unexpected neighbors %v", result)
+	}
+}
+
+func TestParallelNeighborsCancellation(t *testing.T) {
+	vectors := make([][]float64, 200)
+	for i := range vectors {
+		vectors[i] = []float64{float64(i)}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := KNearestNeighborsParallel(ctx, vectors, 3, Euclidean[float64], 4); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if _, err := RadiusNeighborsParallel(ctx, vectors, 1, Euclidean[float64], 4); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestParallelNeighborsError(t *testing.T) {
+	vectors := [][]float64{{1}, {1, 2}, {3}}
+
+	if _, err := KNearestNeighborsParallel(context.Background(), vectors, 1, Euclidean[float64], 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := RadiusNeighborsParallel(context.Background(), vectors, 1, Euclidean[float64], 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
 }
 
 func TestRadiusNeighbors(t *testing.T) {
@@ -204,6 +426,21 @@ func BenchmarkBatchCompute(b *testing.B) {
 	}
 }
 
+func BenchmarkBatchComputeEuclidean(b *testing.B) {
+	vectors := make([][]float64, 100)
+	for i := range vectors {
+		vectors[i] = make([]float64, 50)
+		for j := range vectors[i] {
+			vectors[i][j] = float64(i*j) / 100.0
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = BatchComputeEuclidean(vectors)
+	}
+}
+
 func BenchmarkBatchComputeParallel(b *testing.B) {
 	vectors := make([][]float64, 50)
 	for i := range vectors {
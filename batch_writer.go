@@ -0,0 +1,70 @@
+package distance
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+	"strconv"
+)
+
+// MatrixFormat selects the encoding used by BatchComputeToWriter.
+type MatrixFormat int
+
+const (
+	// MatrixCSV writes one comma-separated line per row using the shortest
+	// float representation that round-trips.
+	MatrixCSV MatrixFormat = iota
+	// MatrixBinary writes rows back to back as little-endian float64 values,
+	// n*n*8 bytes in total, suitable for memory-mapping.
+	MatrixBinary
+)
+
+// BatchComputeToWriter streams the distance matrix of vectors to w one row at a
+// time, so memory use is O(n) rather than O(n²). Each pair is computed twice
+// (once per row); use BatchCompute when the matrix fits in memory.
+// Time: O(n²d), Space: O(n)
+func BatchComputeToWriter[T Number](vectors [][]T, distFn DistanceFunc[T], w io.Writer, format MatrixFormat) error {
+	if format != MatrixCSV && format != MatrixBinary {
+		return ErrInvalidParameter
+	}
+
+	bw := bufio.NewWriter(w)
+	row := make([]float64, len(vectors))
+	var buf []byte
+
+	for i := range vectors {
+		for j := range vectors {
+			dist, err := distFn(vectors[i], vectors[j])
+			if err != nil {
+				return err
+			}
+			row[j] = dist
+		}
+
+		buf = appendMatrixRow(buf[:0], row, format)
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// appendMatrixRow encodes one row in the requested format
+func appendMatrixRow(buf []byte, row []float64, format MatrixFormat) []byte {
+	if format == MatrixBinary {
+		for _, v := range row {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+		}
+		return buf
+	}
+
+	for j, v := range row {
+		if j > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendFloat(buf, v, 'g', -1, 64)
+	}
+	return append(buf, '\n')
+}
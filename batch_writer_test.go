@@ -0,0 +1,81 @@
+package distance
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBatchComputeToWriterCSV(t *testing.T) {
+	vectors := [][]float64{{0, 0}, {3, 4}, {0, 1}}
+
+	var buf bytes.Buffer
+	if err := BatchComputeToWriter(vectors, Euclidean[float64], &buf, MatrixCSV); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "0,5,1\n5,0,4.242640687119285\n1,4.242640687119285,0\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestBatchComputeToWriterBinary(t *testing.T) {
+	vectors := [][]float64{{0, 0}, {3, 4}, {1, 1}}
+
+	var buf bytes.Buffer
+	if err := BatchComputeToWriter(vectors, Manhattan[float64], &buf, MatrixBinary); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want, _ := BatchCompute(vectors, Manhattan[float64])
+	data := buf.Bytes()
+	if len(data) != 3*3*8 {
+		t.Fatalf("expected %d bytes, got %d", 3*3*8, len(data))
+	}
+	for i := range want {
+		for j := range want[i] {
+			got := math.Float64frombits(binary.LittleEndian.Uint64(data[(i*3+j)*8:]))
+			if !almostEqual(got, want[i][j]) {
+				t.Errorf("[%d][%d]: expected %v, got %v", i, j, want[i][j], got)
+			}
+		}
+	}
+}
+
+func TestBatchComputeToWriterComputesDiagonal(t *testing.T) {
+	// A smoothed divergence is nonzero between a vector and itself
+	smoothed := func(a, b []float64) (float64, error) {
+		d, err := Euclidean(a, b)
+		return d + 0.5, err
+	}
+
+	var buf bytes.Buffer
+	if err := BatchComputeToWriter([][]float64{{0}, {2}}, smoothed, &buf, MatrixCSV); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "0.5,2.5\n2.5,0.5\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestBatchComputeToWriterErrors(t *testing.T) {
+	vectors := [][]float64{{1, 2}, {3}}
+	if err := BatchComputeToWriter(vectors, Euclidean[float64], &bytes.Buffer{}, MatrixCSV); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if err := BatchComputeToWriter(vectors, Euclidean[float64], &bytes.Buffer{}, MatrixFormat(9)); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+
+	ok := [][]float64{{1}, {2}}
+	if err := BatchComputeToWriter(ok, Euclidean[float64], failingWriter{}, MatrixCSV); err == nil {
+		t.Error("expected write error, got nil")
+	}
+}
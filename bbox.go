@@ -0,0 +1,82 @@
+package distance
+
+import "math"
+
+// BBox is a latitude/longitude rectangle in degrees. Longitudes lie in
+// [-180, 180]; a box with MinLon > MaxLon crosses the antimeridian, covering
+// MinLon to 180 and -180 to MaxLon.
+type BBox struct {
+	MinLat, MinLon float64
+	MaxLat, MaxLon float64
+}
+
+// CrossesAntimeridian reports whether the box wraps across longitude ±180.
+func (b BBox) CrossesAntimeridian() bool {
+	return b.MinLon > b.MaxLon
+}
+
+// Split returns the box as one or two boxes with MinLon <= MaxLon, splitting
+// at the antimeridian, for range queries such as
+// "lat BETWEEN ? AND ? AND lon BETWEEN ? AND ?" that cannot wrap.
+// Time: O(1), Space: O(1)
+func (b BBox) Split() []BBox {
+	if !b.CrossesAntimeridian() {
+		return []BBox{b}
+	}
+	return []BBox{
+		{MinLat: b.MinLat, MinLon: b.MinLon, MaxLat: b.MaxLat, MaxLon: 180},
+		{MinLat: b.MinLat, MinLon: -180, MaxLat: b.MaxLat, MaxLon: b.MaxLon},
+	}
+}
+
+// BoundingBox computes the smallest latitude/longitude box containing every
+// point within radiusKm of center by Haversine distance (Matuschek's
+// method), to pre-filter a database query before the exact distance check.
+// The box crosses the antimeridian when the circle does (see Split), and
+// spans all longitudes when the circle contains a pole. Ellipsoidal
+// distances (Vincenty, Karney) differ from Haversine by up to 0.5%, so
+// enlarge radiusKm accordingly when filtering for those.
+// Returns ErrInvalidParameter for a negative or NaN radius.
+// Time: O(1), Space: O(1)
+func BoundingBox(center Coord, radiusKm float64) (BBox, error) {
+	if !(radiusKm >= 0) {
+		return BBox{}, ErrInvalidParameter
+	}
+
+	r := radiusKm / earthRadiusKm
+	minLat, maxLat := center.Lat-r/degToRad, center.Lat+r/degToRad
+	if minLat <= -90 || maxLat >= 90 || r >= math.Pi/2 {
+		// The circle reaches a pole, so it spans every meridian
+		return BBox{MinLat: math.Max(minLat, -90), MinLon: -180, MaxLat: math.Min(maxLat, 90), MaxLon: 180}, nil
+	}
+
+	// Meridians tangent to the circle
+	deltaLon := math.Asin(math.Sin(r)/math.Cos(center.Lat*degToRad)) / degToRad
+	lon := normalizeLon(center.Lon)
+	minLon, maxLon := lon-deltaLon, lon+deltaLon
+	if minLon < -180 {
+		minLon += 360
+	}
+	if maxLon > 180 {
+		maxLon -= 360
+	}
+	return BBox{MinLat: minLat, MinLon: minLon, MaxLat: maxLat, MaxLon: maxLon}, nil
+}
+
+// BBoxContains reports whether p lies in box, edges included, honoring
+// antimeridian-crossing boxes. p's longitude may be given in any range.
+// Time: O(1), Space: O(1)
+func BBoxContains(box BBox, p Coord) bool {
+	if p.Lat < box.MinLat || p.Lat > box.MaxLat {
+		return false
+	}
+	lon := normalizeLon(p.Lon)
+	inLon := func(lon float64) bool {
+		if box.CrossesAntimeridian() {
+			return lon >= box.MinLon || lon <= box.MaxLon
+		}
+		return lon >= box.MinLon && lon <= box.MaxLon
+	}
+	// normalizeLon maps 180 to -180; a box may have either as its edge
+	return inLon(lon) || (lon == -180 && inLon(180))
+}
@@ -0,0 +1,156 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		name     string
+		center   Coord
+		radiusKm float64
+		expected BBox
+		crosses  bool
+	}{
+		{
+			name:     "zero radius",
+			center:   Coord{Lat: 10, Lon: 20},
+			expected: BBox{MinLat: 10, MinLon: 20, MaxLat: 10, MaxLon: 20},
+		},
+		{
+			// On the equator the box is a square of 2r/R radians
+			name:     "equator",
+			center:   Coord{Lat: 0, Lon: 0},
+			radiusKm: 111.19492664455873,
+			expected: BBox{MinLat: -1, MinLon: -1, MaxLat: 1, MaxLon: 1},
+		},
+		{
+			name:     "antimeridian",
+			center:   Coord{Lat: 0, Lon: 179.5},
+			radiusKm: 111.19492664455873,
+			expected: BBox{MinLat: -1, MinLon: 178.5, MaxLat: 1, MaxLon: -179.5},
+			crosses:  true,
+		},
+		{
+			name:     "near north pole",
+			center:   Coord{Lat: 89.5, Lon: 40},
+			radiusKm: 111.19492664455873,
+			expected: BBox{MinLat: 88.5, MinLon: -180, MaxLat: 90, MaxLon: 180},
+		},
+		{
+			name:     "whole globe",
+			center:   Coord{Lat: 0, Lon: 0},
+			radiusKm: 30000,
+			expected: BBox{MinLat: -90, MinLon: -180, MaxLat: 90, MaxLon: 180},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box, err := BoundingBox(tt.center, tt.radiusKm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(box.MinLat, tt.expected.MinLat) || !almostEqual(box.MaxLat, tt.expected.MaxLat) ||
+				!almostEqual(box.MinLon, tt.expected.MinLon) || !almostEqual(box.MaxLon, tt.expected.MaxLon) {
+				t.Errorf("expected %+v, got %+v", tt.expected, box)
+			}
+			if box.CrossesAntimeridian() != tt.crosses {
+				t.Errorf("expected CrossesAntimeridian %v", tt.crosses)
+			}
+		})
+	}
+}
+
+func TestBoundingBoxWidensWithLatitude(t *testing.T) {
+	// At 60° a degree of longitude is half as long as at the equator
+	box, err := BoundingBox(Coord{Lat: 60, Lon: 0}, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lonSpan, latSpan := box.MaxLon-box.MinLon, box.MaxLat-box.MinLat
+	if !almostEqualTolerance(lonSpan/latSpan, 2, 0.01) {
+		t.Errorf("expected longitude span twice the latitude span, got %v / %v", lonSpan, latSpan)
+	}
+}
+
+func TestBoundingBoxContainsCircle(t *testing.T) {
+	rng := testRNG(13)
+	for i := 0; i < 200; i++ {
+		center := Coord{Lat: rng.Float64()*180 - 90, Lon: rng.Float64()*360 - 180}
+		radius := rng.Float64() * 3000
+		box, err := BoundingBox(center, radius)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for j := 0; j < 50; j++ {
+			// Points on and just inside the circle must be in the box
+			p := Destination(center, rng.Float64()*360, radius*(1-1e-9))
+			if !BBoxContains(box, p) {
+				t.Fatalf("box %+v around %v (r=%v km) misses %v at %v km",
+					box, center, radius, p, Haversine(center, p))
+			}
+		}
+	}
+}
+
+func TestBBoxContains(t *testing.T) {
+	box := BBox{MinLat: -10, MinLon: 170, MaxLat: 10, MaxLon: -170}
+	tests := []struct {
+		p        Coord
+		expected bool
+	}{
+		{Coord{Lat: 0, Lon: 175}, true},
+		{Coord{Lat: 0, Lon: -175}, true},
+		{Coord{Lat: 0, Lon: 180}, true},
+		{Coord{Lat: 0, Lon: -180}, true},
+		{Coord{Lat: 0, Lon: 185}, true}, // Unnormalized -175
+		{Coord{Lat: 0, Lon: 0}, false},
+		{Coord{Lat: 0, Lon: 160}, false},
+		{Coord{Lat: 11, Lon: 175}, false},
+		{Coord{Lat: 10, Lon: 170}, true}, // Corner
+	}
+	for _, tt := range tests {
+		if got := BBoxContains(box, tt.p); got != tt.expected {
+			t.Errorf("BBoxContains(%+v, %v) = %v, expected %v", box, tt.p, got, tt.expected)
+		}
+	}
+
+	east := BBox{MinLat: 0, MinLon: 170, MaxLat: 1, MaxLon: 180}
+	if !BBoxContains(east, Coord{Lat: 0.5, Lon: 180}) || !BBoxContains(east, Coord{Lat: 0.5, Lon: -180}) {
+		t.Error("expected the ±180 edge to be contained")
+	}
+}
+
+func TestBBoxSplit(t *testing.T) {
+	box := BBox{MinLat: -1, MinLon: 178.5, MaxLat: 1, MaxLon: -179.5}
+	parts := box.Split()
+	if len(parts) != 2 || parts[0].MaxLon != 180 || parts[1].MinLon != -180 ||
+		parts[0].MinLon != 178.5 || parts[1].MaxLon != -179.5 {
+		t.Fatalf("unexpected split %+v", parts)
+	}
+	for _, lon := range []float64{179, -180, 180, -179.9} {
+		p := Coord{Lat: 0, Lon: lon}
+		in := false
+		for _, part := range parts {
+			in = in || (p.Lon >= part.MinLon && p.Lon <= part.MaxLon)
+		}
+		if !in {
+			t.Errorf("split boxes miss longitude %v", lon)
+		}
+	}
+
+	plain := BBox{MinLat: 0, MinLon: 0, MaxLat: 1, MaxLon: 1}
+	if parts := plain.Split(); len(parts) != 1 || parts[0] != plain {
+		t.Errorf("expected the box itself, got %+v", parts)
+	}
+}
+
+func TestBoundingBoxErrors(t *testing.T) {
+	for _, r := range []float64{-1, math.NaN()} {
+		if _, err := BoundingBox(Coord{}, r); err != ErrInvalidParameter {
+			t.Errorf("radius %v: expected ErrInvalidParameter, got %v", r, err)
+		}
+	}
+}
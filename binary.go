@@ -0,0 +1,148 @@
+package distance
+
+import (
+	"math"
+	"math/bits"
+)
+
+// BinaryVector is a sign-binarized float vector packed 64 dimensions per word.
+// Bit i is set when the source value at i is positive. Scale holds the mean
+// absolute source value so asymmetric distances can reconstruct magnitudes.
+type BinaryVector struct {
+	Bits  []uint64
+	Dim   int
+	Scale float64
+}
+
+// Binarize packs the signs of v into a BinaryVector.
+// Time: O(n), Space: O(n/64)
+func Binarize[T Number](v []T) (BinaryVector, error) {
+	if len(v) == 0 {
+		return BinaryVector{}, ErrEmptyInput
+	}
+
+	b := BinaryVector{Bits: make([]uint64, (len(v)+63)/64), Dim: len(v)}
+	var absSum float64
+	for i, x := range v {
+		if x > 0 {
+			b.Bits[i/64] |= 1 << (uint(i) % 64)
+		}
+		absSum += math.Abs(float64(x))
+	}
+	b.Scale = absSum / float64(len(v))
+	return b, nil
+}
+
+// sign returns +1 when bit i is set and -1 otherwise
+func (b BinaryVector) sign(i int) float64 {
+	if b.Bits[i/64]&(1<<(uint(i)%64)) != 0 {
+		return 1
+	}
+	return -1
+}
+
+// HammingBinary counts differing bits between two binary vectors.
+// Time: O(n/64), Space: O(1)
+func HammingBinary(a, b BinaryVector) (int, error) {
+	if a.Dim == 0 || b.Dim == 0 {
+		return 0, ErrEmptyInput
+	}
+	if a.Dim != b.Dim {
+		return 0, ErrDimensionMismatch
+	}
+
+	count := 0
+	for i, w := range a.Bits {
+		count += bits.OnesCount64(w ^ b.Bits[i])
+	}
+	return count, nil
+}
+
+// HammingSearch returns the k codes closest to query in Hamming distance,
+// sorted ascending (ties broken by lower index).
+// Time: O(n·d/64·log k), Space: O(k)
+func HammingSearch(query BinaryVector, codes []BinaryVector, k int) ([]Neighbor, error) {
+	return binaryTopK(len(codes), k, func(i int) (float64, error) {
+		d, err := HammingBinary(query, codes[i])
+		return float64(d), err
+	})
+}
+
+// AsymmetricBinaryDot estimates the dot product between an unquantized query
+// and a binary code reconstructed as Scale·sign(x).
+// Time: O(n), Space: O(1)
+func AsymmetricBinaryDot[T Number](query []T, code BinaryVector) (float64, error) {
+	if len(query) == 0 || code.Dim == 0 {
+		return 0, ErrEmptyInput
+	}
+	if len(query) != code.Dim {
+		return 0, ErrDimensionMismatch
+	}
+
+	var sum float64
+	for i, q := range query {
+		sum += float64(q) * code.sign(i)
+	}
+	return code.Scale * sum, nil
+}
+
+// AsymmetricBinaryEuclidean estimates the Euclidean distance between an
+// unquantized query and a binary code: ||q||² - 2·q·x̂ + Scale²·d.
+// Keeping the query in full precision gives much better ranking than
+// binarizing both sides.
+// Time: O(n), Space: O(1)
+func AsymmetricBinaryEuclidean[T Number](query []T, code BinaryVector) (float64, error) {
+	dot, err := AsymmetricBinaryDot(query, code)
+	if err != nil {
+		return 0, err
+	}
+
+	var qq float64
+	for _, q := range query {
+		qq += float64(q) * float64(q)
+	}
+	sq := qq - 2*dot + code.Scale*code.Scale*float64(code.Dim)
+	if sq < 0 {
+		sq = 0
+	}
+	return math.Sqrt(sq), nil
+}
+
+// AsymmetricBinaryCosine estimates the cosine distance between an unquantized
+// query and a binary code. Scale cancels, so only signs matter.
+// Time: O(n), Space: O(1)
+func AsymmetricBinaryCosine[T Number](query []T, code BinaryVector) (float64, error) {
+	if len(query) == 0 || code.Dim == 0 {
+		return 0, ErrEmptyInput
+	}
+	if len(query) != code.Dim {
+		return 0, ErrDimensionMismatch
+	}
+
+	var dot, qq float64
+	for i, q := range query {
+		dot += float64(q) * code.sign(i)
+		qq += float64(q) * float64(q)
+	}
+	if qq == 0 {
+		return 0, ErrZeroVector
+	}
+	return 1 - clampUnit(dot/(math.Sqrt(qq)*math.Sqrt(float64(code.Dim)))), nil
+}
+
+// AsymmetricSearch returns the k codes closest to an unquantized query
+// using AsymmetricBinaryEuclidean, sorted ascending.
+// Time: O(n·d·log k), Space: O(k)
+func AsymmetricSearch[T Number](query []T, codes []BinaryVector, k int) ([]Neighbor, error) {
+	return binaryTopK(len(codes), k, func(i int) (float64, error) {
+		return AsymmetricBinaryEuclidean(query, codes[i])
+	})
+}
+
+// binaryTopK selects the k smallest distances among n candidates
+func binaryTopK(n, k int, dist func(i int) (float64, error)) ([]Neighbor, error) {
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	return nearestIndices(n, -1, k, dist)
+}
@@ -0,0 +1,135 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBinarize(t *testing.T) {
+	v := make([]float64, 70)
+	for i := range v {
+		v[i] = -1
+	}
+	v[0], v[63], v[64], v[69] = 2, 2, 2, 2
+
+	b, err := Binarize(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Dim != 70 || len(b.Bits) != 2 {
+		t.Fatalf("expected 70 dims in 2 words, got %d dims in %d words", b.Dim, len(b.Bits))
+	}
+	if b.Bits[0] != 1|1<<63 || b.Bits[1] != 1|1<<5 {
+		t.Errorf("unexpected bits: %b %b", b.Bits[0], b.Bits[1])
+	}
+	if !almostEqual(b.Scale, 74.0/70) {
+		t.Errorf("expected scale %v, got %v", 74.0/70, b.Scale)
+	}
+
+	if _, err := Binarize([]float64{}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestHammingBinary(t *testing.T) {
+	a, _ := Binarize([]float64{1, -1, 1, -1})
+	b, _ := Binarize([]float64{1, 1, -1, -1})
+	c, _ := Binarize([]float64{1, 1, 1})
+
+	d, err := HammingBinary(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d != 2 {
+		t.Errorf("expected 2, got %d", d)
+	}
+	if _, err := HammingBinary(a, c); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestHammingSearch(t *testing.T) {
+	vectors := [][]float64{
+		{1, 1, 1, 1},
+		{-1, -1, -1, -1},
+		{1, 1, 1, -1},
+		{1, -1, -1, -1},
+	}
+	codes := make([]BinaryVector, len(vectors))
+	for i, v := range vectors {
+		codes[i], _ = Binarize(v)
+	}
+
+	got, err := HammingSearch(codes[0], codes, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Neighbor{{0, 0}, {2, 1}, {3, 3}}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+			break
+		}
+	}
+
+	if _, err := HammingSearch(codes[0], codes, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestAsymmetricBinaryDistances(t *testing.T) {
+	x := []float64{0.5, -0.5, 0.5, -0.5}
+	code, _ := Binarize(x)
+
+	// Uniform-magnitude vectors are reconstructed exactly
+	query := []float64{1, 2, -3, 0.5}
+	dot, err := AsymmetricBinaryDot(query, code)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantDot, _ := DotProduct(query, x)
+	if !almostEqual(dot, wantDot) {
+		t.Errorf("expected %v, got %v", wantDot, dot)
+	}
+
+	euc, _ := AsymmetricBinaryEuclidean(query, code)
+	wantEuc, _ := Euclidean(query, x)
+	if !almostEqual(euc, wantEuc) {
+		t.Errorf("expected %v, got %v", wantEuc, euc)
+	}
+
+	cos, _ := AsymmetricBinaryCosine(query, code)
+	wantCos, _ := Cosine(query, x)
+	if !almostEqual(cos, wantCos) {
+		t.Errorf("expected %v, got %v", wantCos, cos)
+	}
+
+	if _, err := AsymmetricBinaryCosine([]float64{0, 0, 0, 0}, code); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+	if _, err := AsymmetricBinaryEuclidean([]float64{1}, code); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestAsymmetricSearch(t *testing.T) {
+	vectors := [][]float64{{3, 3}, {-1, 1}, {1, -1}, {-2, -2}}
+	codes := make([]BinaryVector, len(vectors))
+	for i, v := range vectors {
+		codes[i], _ = Binarize(v)
+	}
+
+	got, err := AsymmetricSearch([]float64{2.5, 2}, codes, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[0].Index != 0 {
+		t.Errorf("expected index 0 first, got %v", got)
+	}
+	if math.IsNaN(got[1].Distance) || got[1].Distance < got[0].Distance {
+		t.Errorf("expected ascending distances, got %v", got)
+	}
+}
@@ -0,0 +1,144 @@
+package distance
+
+import "math"
+
+// BKTree is a Burkhard-Keller tree over strings, a Searcher[string] for
+// dictionary lookups within a small edit distance. Each child edge is
+// labelled with its distance to the parent, so the triangle inequality lets
+// a search skip every subtree whose label differs from the query's distance
+// by more than the radius, visiting a small fraction of the words.
+//
+// distFn must be a metric: Levenshtein, LevenshteinRunes or (for equal-length
+// words) HammingString. DamerauLevenshtein computes optimal string alignment,
+// which can violate the triangle inequality and occasionally miss a match.
+// A BKTree is not safe for concurrent Add; concurrent searches are safe.
+type BKTree struct {
+	distFn StringDistanceFunc
+	root   *bkNode
+	words  []string
+}
+
+// bkNode holds the word with the given insertion index and its children by edge distance
+type bkNode struct {
+	index    int
+	children map[int]*bkNode
+}
+
+// NewBKTree creates an empty BK-tree using distFn.
+func NewBKTree(distFn StringDistanceFunc) *BKTree {
+	return &BKTree{distFn: distFn}
+}
+
+// Add inserts word; its Index in search results is the insertion order.
+// Returns ErrInvalidParameter if word is already present.
+// Time: O(depth) distance evaluations, Space: O(1)
+func (t *BKTree) Add(word string) error {
+	if t.root == nil {
+		t.root = &bkNode{index: 0}
+		t.words = append(t.words, word)
+		return nil
+	}
+
+	node := t.root
+	for {
+		d, err := t.distFn(word, t.words[node.index])
+		if err != nil {
+			return err
+		}
+		if d == 0 {
+			return ErrInvalidParameter
+		}
+		child, ok := node.children[d]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[int]*bkNode)
+			}
+			node.children[d] = &bkNode{index: len(t.words)}
+			t.words = append(t.words, word)
+			return nil
+		}
+		node = child
+	}
+}
+
+// Len returns the number of stored words.
+func (t *BKTree) Len() int {
+	return len(t.words)
+}
+
+// Search returns the k stored words closest to query.
+// Time: sublinear for small k on typical dictionaries, Space: O(k + depth)
+func (t *BKTree) Search(query string, k int) ([]SearchResult, error) {
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	h := make(neighborHeap, 0, k)
+	err := t.walk(query, func() float64 { return h.bound(k) }, func(index int, d float64) {
+		h.offer(Neighbor{index, d}, k)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nearest := h.sorted()
+	results := make([]SearchResult, len(nearest))
+	for p, nb := range nearest {
+		results[p] = t.result(nb.Index, nb.Distance)
+	}
+	return results, nil
+}
+
+// SearchRadius returns every stored word within edit distance radius of
+// query, the classic BK-tree lookup.
+// Time: sublinear for small radii on typical dictionaries, Space: O(m + depth)
+func (t *BKTree) SearchRadius(query string, radius float64) ([]SearchResult, error) {
+	if radius < 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	var results []SearchResult
+	err := t.walk(query, func() float64 { return radius }, func(index int, d float64) {
+		if d <= radius {
+			results = append(results, t.result(index, d))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortSearchResults(results)
+	return results, nil
+}
+
+// walk visits every node that may lie within radius() of query, reporting
+// each visited word's distance to visit; radius may shrink during the walk
+func (t *BKTree) walk(query string, radius func() float64, visit func(index int, d float64)) error {
+	if t.root == nil {
+		return nil
+	}
+	stack := []*bkNode{t.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		di, err := t.distFn(query, t.words[node.index])
+		if err != nil {
+			return err
+		}
+		d := float64(di)
+		visit(node.index, d)
+
+		r := radius()
+		for edge, child := range node.children {
+			if math.Abs(float64(edge)-d) <= r {
+				stack = append(stack, child)
+			}
+		}
+	}
+	return nil
+}
+
+// result wraps the stored word at index as a SearchResult
+func (t *BKTree) result(index int, dist float64) SearchResult {
+	return SearchResult{ID: t.words[index], Index: index, Distance: dist}
+}
@@ -0,0 +1,129 @@
+package distance
+
+import (
+	"reflect"
+	"testing"
+)
+
+// randomWords returns n reproducible distinct lowercase words of length 3-8
+func randomWords(seed uint64, n int) []string {
+	rng := testRNG(seed)
+	seen := make(map[string]bool)
+	words := make([]string, 0, n)
+	for len(words) < n {
+		b := make([]byte, 3+rng.IntN(6))
+		for i := range b {
+			b[i] = "abcdefghij"[rng.IntN(10)]
+		}
+		if w := string(b); !seen[w] {
+			seen[w] = true
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+func TestBKTreeSearchRadius(t *testing.T) {
+	tree := NewBKTree(Levenshtein)
+	for _, w := range []string{"book", "books", "cake", "boo", "boon", "cook", "cape", "cart"} {
+		if err := tree.Add(w); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := tree.Add("cake"); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter for duplicate, got %v", err)
+	}
+	if tree.Len() != 8 {
+		t.Errorf("expected 8 words, got %d", tree.Len())
+	}
+
+	results, err := tree.SearchRadius("book", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, r := range results {
+		got = append(got, r.ID)
+	}
+	if !reflect.DeepEqual(got, []string{"book", "books", "boo", "boon", "cook"}) {
+		t.Errorf("unexpected results %v", got)
+	}
+
+	if results, _ := tree.SearchRadius("zzzz", 1); len(results) != 0 {
+		t.Errorf("expected no results, got %v", results)
+	}
+	if _, err := tree.SearchRadius("book", -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestBKTreeMatchesBruteForce(t *testing.T) {
+	words := randomWords(11, 2000)
+	calls := 0
+	tree := NewBKTree(func(a, b string) (int, error) {
+		calls++
+		return Levenshtein(a, b)
+	})
+	for _, w := range words {
+		if err := tree.Add(w); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for _, query := range randomWords(12, 20) {
+		calls = 0
+		got, err := tree.SearchRadius(query, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls >= len(words)/2 {
+			t.Errorf("expected fewer than %d evaluations, got %d", len(words)/2, calls)
+		}
+
+		var want []SearchResult
+		for i, w := range words {
+			if d, _ := Levenshtein(query, w); d <= 1 {
+				want = append(want, SearchResult{ID: w, Index: i, Distance: float64(d)})
+			}
+		}
+		sortSearchResults(want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %v, got %v", query, want, got)
+		}
+
+		nearest, err := tree.Search(query, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var all []SearchResult
+		for i, w := range words {
+			d, _ := Levenshtein(query, w)
+			all = append(all, SearchResult{ID: w, Index: i, Distance: float64(d)})
+		}
+		sortSearchResults(all)
+		if !reflect.DeepEqual(nearest, all[:5]) {
+			t.Errorf("%s: expected %v, got %v", query, all[:5], nearest)
+		}
+	}
+}
+
+func TestBKTreeEmpty(t *testing.T) {
+	var s Searcher[string] = NewBKTree(Levenshtein)
+	if results, err := s.Search("x", 3); err != nil || len(results) != 0 {
+		t.Errorf("expected no results, got %v (%v)", results, err)
+	}
+	if _, err := s.Search("x", 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func BenchmarkBKTreeSearchRadius(b *testing.B) {
+	tree := NewBKTree(Levenshtein)
+	for _, w := range randomWords(11, 10000) {
+		_ = tree.Add(w)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = tree.SearchRadius("abcdef", 1)
+	}
+}
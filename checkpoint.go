@@ -0,0 +1,135 @@
+package distance
+
+import (
+	"context"
+	"encoding/gob"
+	"io"
+)
+
+// chunkedCheckpoint is the serialized form of a ChunkedBatch
+type chunkedCheckpoint struct {
+	N, Dim int
+	I, J   int
+	Done   int
+	Result [][]float64
+}
+
+// kmeansCheckpoint is the serialized form of a StreamingKMeans
+type kmeansCheckpoint struct {
+	K, Dim    int
+	Centroids [][]float64
+	Counts    []float64
+}
+
+// Checkpoint writes the progress of c to w so it can be resumed with
+// RestoreChunkedBatch after a restart. The input vectors are not written.
+// Time: O(n²), Space: O(1)
+func (c *ChunkedBatch[T]) Checkpoint(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(chunkedCheckpoint{
+		N: len(c.vectors), Dim: vectorDim(c.vectors),
+		I: c.i, J: c.j, Done: c.done, Result: c.result,
+	})
+}
+
+// RestoreChunkedBatch resumes a computation saved by Checkpoint. vectors must
+// be the same data the checkpoint was taken from; a differing count or
+// dimension returns ErrDimensionMismatch. A corrupt checkpoint whose position
+// or progress does not fit the matrix returns ErrInvalidParameter.
+// Time: O(n²), Space: O(n²)
+func RestoreChunkedBatch[T Number](r io.Reader, vectors [][]T, distFn DistanceFunc[T]) (*ChunkedBatch[T], error) {
+	var cp chunkedCheckpoint
+	if err := gob.NewDecoder(r).Decode(&cp); err != nil {
+		return nil, err
+	}
+	if cp.N != len(vectors) || cp.Dim != vectorDim(vectors) || len(cp.Result) != cp.N {
+		return nil, ErrDimensionMismatch
+	}
+	if !validChunkPosition(cp) {
+		return nil, ErrInvalidParameter
+	}
+
+	c := NewChunkedBatch(vectors, distFn)
+	c.i, c.j, c.done = cp.I, cp.J, cp.Done
+	for i, row := range cp.Result {
+		if len(row) != cp.N {
+			return nil, ErrDimensionMismatch
+		}
+		copy(c.result[i], row)
+	}
+	return c, nil
+}
+
+// Run steps c to completion in chunks of chunkPairs pairs, calling onChunk
+// after each chunk (e.g. to Checkpoint to disk). Stops early when ctx is
+// cancelled or onChunk fails; the computation can then be checkpointed and resumed.
+// Time: O(n²d), Space: O(1)
+func (c *ChunkedBatch[T]) Run(ctx context.Context, chunkPairs int, onChunk func() error) error {
+	for !c.Done() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := c.Step(chunkPairs); err != nil {
+			return err
+		}
+		if onChunk != nil {
+			if err := onChunk(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Checkpoint writes the model state to w so it can be resumed with
+// RestoreStreamingKMeans.
+// Time: O(kd), Space: O(1)
+func (m *StreamingKMeans[T]) Checkpoint(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(kmeansCheckpoint{
+		K: m.k, Dim: m.dim, Centroids: m.centroids, Counts: m.counts,
+	})
+}
+
+// RestoreStreamingKMeans recreates a model saved by Checkpoint.
+// Time: O(kd), Space: O(kd)
+func RestoreStreamingKMeans[T Number](r io.Reader) (*StreamingKMeans[T], error) {
+	var cp kmeansCheckpoint
+	if err := gob.NewDecoder(r).Decode(&cp); err != nil {
+		return nil, err
+	}
+
+	m, err := NewStreamingKMeans[T](cp.K, cp.Dim)
+	if err != nil {
+		return nil, err
+	}
+	if len(cp.Centroids) > cp.K || len(cp.Centroids) != len(cp.Counts) {
+		return nil, ErrInvalidParameter
+	}
+	for _, c := range cp.Centroids {
+		if len(c) != cp.Dim {
+			return nil, ErrDimensionMismatch
+		}
+	}
+	m.centroids = append(m.centroids, cp.Centroids...)
+	m.counts = append(m.counts, cp.Counts...)
+	return m, nil
+}
+
+// validChunkPosition reports whether the next pair (I, J) lies in the upper
+// triangle with diagonal, or at (N, N) once complete, and Done counts the
+// pairs before it
+func validChunkPosition(cp chunkedCheckpoint) bool {
+	if cp.I < 0 || cp.J < cp.I || cp.J > cp.N || (cp.J == cp.N && cp.I != cp.N) {
+		return false
+	}
+	// Rows before I hold N + (N-1) + ... + (N-I+1) pairs
+	before := cp.I*cp.N - cp.I*(cp.I-1)/2 + cp.J - cp.I
+	return cp.Done == before && cp.Done <= cp.N*(cp.N+1)/2
+}
+
+// vectorDim returns the dimension of the first vector, or 0 if there are none
+func vectorDim[T Number](vectors [][]T) int {
+	if len(vectors) == 0 {
+		return 0
+	}
+	return len(vectors[0])
+}
@@ -0,0 +1,146 @@
+package distance
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestChunkedBatchCheckpointResume(t *testing.T) {
+	vectors := [][]float64{{0, 0}, {1, 0}, {0, 2}, {3, 4}, {1, 1}}
+	want, _ := BatchCompute(vectors, Euclidean[float64])
+
+	// Simulate a worker that dies after a few chunks
+	c := NewChunkedBatch(vectors, Euclidean[float64])
+	var saved bytes.Buffer
+	chunks := 0
+	errCrash := errors.New("crash")
+	err := c.Run(context.Background(), 2, func() error {
+		saved.Reset()
+		if err := c.Checkpoint(&saved); err != nil {
+			return err
+		}
+		chunks++
+		if chunks == 3 {
+			return errCrash
+		}
+		return nil
+	})
+	if err != errCrash {
+		t.Fatalf("expected simulated crash, got %v", err)
+	}
+
+	resumed, err := RestoreChunkedBatch(&saved, vectors, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if done, _ := resumed.Progress(); done != 6 {
+		t.Errorf("expected to resume at 6 pairs, got %d", done)
+	}
+	if err := resumed.Run(context.Background(), 2, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, _ := resumed.Result()
+	for i := range want {
+		for j := range want[i] {
+			if !almostEqual(got[i][j], want[i][j]) {
+				t.Errorf("[%d][%d]: expected %v, got %v", i, j, want[i][j], got[i][j])
+			}
+		}
+	}
+}
+
+func TestChunkedBatchCheckpointErrors(t *testing.T) {
+	vectors := [][]float64{{0}, {1}, {2}}
+	c := NewChunkedBatch(vectors, Euclidean[float64])
+	var buf bytes.Buffer
+	_ = c.Checkpoint(&buf)
+
+	if _, err := RestoreChunkedBatch(&buf, vectors[:2], Euclidean[float64]); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := RestoreChunkedBatch(bytes.NewReader([]byte("junk")), vectors, Euclidean[float64]); err == nil {
+		t.Error("expected decode error, got nil")
+	}
+
+	// Positions outside the matrix or inconsistent progress are rejected
+	// rather than panicking in Step
+	result := [][]float64{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}
+	corrupt := []chunkedCheckpoint{
+		{N: 3, Dim: 1, I: 5, J: 6, Done: 0, Result: result},
+		{N: 3, Dim: 1, I: -1, J: 1, Done: 0, Result: result},
+		{N: 3, Dim: 1, I: 2, J: 1, Done: 5, Result: result},
+		{N: 3, Dim: 1, I: 0, J: 4, Done: 2, Result: result},
+		{N: 3, Dim: 1, I: 0, J: 2, Done: 7, Result: result},
+	}
+	for _, cp := range corrupt {
+		var encoded bytes.Buffer
+		if err := gob.NewEncoder(&encoded).Encode(cp); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := RestoreChunkedBatch(&encoded, vectors, Euclidean[float64]); err != ErrInvalidParameter {
+			t.Errorf("I=%d J=%d Done=%d: expected ErrInvalidParameter, got %v", cp.I, cp.J, cp.Done, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Run(ctx, 1, nil); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestCheckpointPreservesInf(t *testing.T) {
+	pruned := func(a, b []float64) (float64, error) { return math.Inf(1), nil }
+	c := NewChunkedBatch([][]float64{{0}, {1}}, pruned)
+	_, _ = c.Step(3)
+
+	var buf bytes.Buffer
+	if err := c.Checkpoint(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err := RestoreChunkedBatch(&buf, [][]float64{{0}, {1}}, pruned)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m, _ := r.Result()
+	if !math.IsInf(m[0][1], 1) {
+		t.Errorf("expected +Inf, got %v", m[0][1])
+	}
+}
+
+func TestStreamingKMeansCheckpoint(t *testing.T) {
+	m, _ := NewStreamingKMeans[float64](2, 2)
+	for _, x := range [][]float64{{0, 0}, {10, 10}, {1, 1}, {9, 9}} {
+		_, _ = m.Add(x)
+	}
+
+	var buf bytes.Buffer
+	if err := m.Checkpoint(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err := RestoreStreamingKMeans[float64](&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want, got := m.Centroids(), r.Centroids()
+	for i := range want {
+		for j := range want[i] {
+			if want[i][j] != got[i][j] {
+				t.Errorf("centroid %d: expected %v, got %v", i, want[i], got[i])
+			}
+		}
+	}
+
+	// Both models evolve identically after restore
+	a, _ := m.Add([]float64{2, 2})
+	b, _ := r.Add([]float64{2, 2})
+	if a != b || m.Counts()[a] != r.Counts()[b] {
+		t.Errorf("restored model diverged: %v vs %v", m.Counts(), r.Counts())
+	}
+}
@@ -0,0 +1,78 @@
+package distance
+
+// ChunkedBatch computes a distance matrix incrementally so callers in
+// cooperative environments (WebAssembly, UI event loops) can yield between
+// chunks. Each Step evaluates at most a fixed number of pairs and returns.
+// Like BatchCompute it evaluates the upper triangle including the diagonal,
+// so distFns with nonzero self-distance give the same matrix.
+// Not safe for concurrent use.
+type ChunkedBatch[T Number] struct {
+	vectors [][]T
+	distFn  DistanceFunc[T]
+	result  [][]float64
+	i, j    int // next pair to compute, upper triangle with diagonal
+	done    int
+	total   int
+}
+
+// NewChunkedBatch prepares an incremental distance matrix computation.
+// Time: O(n²) to allocate the result, Space: O(n²)
+func NewChunkedBatch[T Number](vectors [][]T, distFn DistanceFunc[T]) *ChunkedBatch[T] {
+	n := len(vectors)
+	result := make([][]float64, n)
+	for i := range result {
+		result[i] = make([]float64, n)
+	}
+	return &ChunkedBatch[T]{
+		vectors: vectors,
+		distFn:  distFn,
+		result:  result,
+		total:   n * (n + 1) / 2,
+	}
+}
+
+// Step computes up to maxPairs more pairs and reports whether the matrix is
+// complete. On error the computation stops at the failing pair and may be retried.
+// Time: O(maxPairs·d), Space: O(1)
+func (c *ChunkedBatch[T]) Step(maxPairs int) (bool, error) {
+	if maxPairs <= 0 {
+		return c.Done(), ErrInvalidParameter
+	}
+
+	n := len(c.vectors)
+	for k := 0; k < maxPairs && c.i < n; k++ {
+		dist, err := c.distFn(c.vectors[c.i], c.vectors[c.j])
+		if err != nil {
+			return false, err
+		}
+		c.result[c.i][c.j] = dist
+		c.result[c.j][c.i] = dist
+		c.done++
+
+		c.j++
+		if c.j == n {
+			c.i++
+			c.j = c.i
+		}
+	}
+	return c.Done(), nil
+}
+
+// Done reports whether every pair has been computed.
+func (c *ChunkedBatch[T]) Done() bool {
+	return c.done == c.total
+}
+
+// Progress returns the number of computed pairs and the total.
+func (c *ChunkedBatch[T]) Progress() (int, int) {
+	return c.done, c.total
+}
+
+// Result returns the distance matrix once Done, or ErrInvalidParameter
+// if computation is still in progress.
+func (c *ChunkedBatch[T]) Result() ([][]float64, error) {
+	if !c.Done() {
+		return nil, ErrInvalidParameter
+	}
+	return c.result, nil
+}
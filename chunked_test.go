@@ -0,0 +1,98 @@
+package distance
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestChunkedBatchMatchesBatchCompute(t *testing.T) {
+	vectors := [][]float64{{0, 0}, {1, 0}, {0, 2}, {3, 4}, {1, 1}}
+	want, _ := BatchCompute(vectors, Euclidean[float64])
+
+	c := NewChunkedBatch(vectors, Euclidean[float64])
+	if _, err := c.Result(); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter before completion, got %v", err)
+	}
+
+	steps := 0
+	for !c.Done() {
+		if _, err := c.Step(3); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		steps++
+	}
+	if steps != 5 {
+		t.Errorf("expected 5 steps for 15 pairs, got %d", steps)
+	}
+	if done, total := c.Progress(); done != 15 || total != 15 {
+		t.Errorf("expected 15/15, got %d/%d", done, total)
+	}
+
+	got, err := c.Result()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if !almostEqual(got[i][j], want[i][j]) {
+				t.Errorf("[%d][%d]: expected %v, got %v", i, j, want[i][j], got[i][j])
+			}
+		}
+	}
+}
+
+func TestChunkedBatchEdgeCases(t *testing.T) {
+	empty := NewChunkedBatch([][]float64{}, Euclidean[float64])
+	if !empty.Done() {
+		t.Error("expected no vectors to be done immediately")
+	}
+	single := NewChunkedBatch([][]float64{{1}}, Euclidean[float64])
+	if done, err := single.Step(1); err != nil || !done {
+		t.Errorf("expected a single vector to finish in one step, got done=%v err=%v", done, err)
+	}
+
+	c := NewChunkedBatch([][]float64{{1}, {2}}, Euclidean[float64])
+	if _, err := c.Step(0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+
+	failures := 1
+	flaky := func(a, b []float64) (float64, error) {
+		if failures > 0 {
+			failures--
+			return 0, errors.New("transient")
+		}
+		return Euclidean(a, b)
+	}
+	r := NewChunkedBatch([][]float64{{1}, {4}}, flaky)
+	if _, err := r.Step(1); err == nil {
+		t.Fatal("expected error")
+	}
+	if done, err := r.Step(3); err != nil || !done {
+		t.Errorf("expected retry to complete, got done=%v err=%v", done, err)
+	}
+}
+
+func TestChunkedBatchComputesDiagonal(t *testing.T) {
+	// A smoothed divergence is nonzero between a vector and itself
+	smoothed := func(a, b []float64) (float64, error) {
+		d, err := Euclidean(a, b)
+		return d + 0.5, err
+	}
+	vectors := [][]float64{{0}, {1}, {3}}
+	want, _ := BatchCompute(vectors, smoothed)
+
+	c := NewChunkedBatch(vectors, smoothed)
+	if err := c.Run(context.Background(), 2, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := c.Result()
+	for i := range want {
+		for j := range want[i] {
+			if got[i][j] != want[i][j] {
+				t.Errorf("[%d][%d]: expected %v, got %v", i, j, want[i][j], got[i][j])
+			}
+		}
+	}
+}
@@ -0,0 +1,469 @@
+package distance
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// KNNClassifier predicts labels by majority vote among the k nearest
+// training vectors under distFn.
+type KNNClassifier[T Number] struct {
+	k       int
+	distFn  DistanceFunc[T]
+	vectors [][]T
+	labels  []string
+}
+
+// NewKNNClassifier creates an untrained k-nearest-neighbor classifier.
+func NewKNNClassifier[T Number](k int, distFn DistanceFunc[T]) (*KNNClassifier[T], error) {
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	return &KNNClassifier[T]{k: k, distFn: distFn}, nil
+}
+
+// Fit stores the training data. vectors and labels must have the same length.
+// Time: O(1), Space: O(1)
+func (c *KNNClassifier[T]) Fit(vectors [][]T, labels []string) error {
+	if len(vectors) == 0 {
+		return ErrEmptyInput
+	}
+	if len(vectors) != len(labels) {
+		return ErrDimensionMismatch
+	}
+	c.vectors, c.labels = vectors, labels
+	return nil
+}
+
+// Predict returns the majority label among the k nearest training vectors.
+// Ties go to the label with the smaller total distance, then lexicographic order.
+// Time: O(nd + n log k), Space: O(k)
+func (c *KNNClassifier[T]) Predict(x []T) (string, error) {
+	if len(c.vectors) == 0 {
+		return "", ErrEmptyInput
+	}
+
+	neighbors, err := kNearest(c.vectors, x, -1, c.k, c.distFn)
+	if err != nil {
+		return "", err
+	}
+
+	votes := make(map[string]int)
+	spread := make(map[string]float64)
+	for _, nb := range neighbors {
+		votes[c.labels[nb.Index]]++
+		spread[c.labels[nb.Index]] += nb.Distance
+	}
+
+	best, found := "", false
+	for label, n := range votes {
+		if !found || n > votes[best] ||
+			(n == votes[best] && (spread[label] < spread[best] ||
+				(spread[label] == spread[best] && label < best))) {
+			best, found = label, true
+		}
+	}
+	return best, nil
+}
+
+// KNNRegressor predicts a numeric target as the mean over the k nearest
+// training vectors.
+type KNNRegressor[T Number] struct {
+	k       int
+	distFn  DistanceFunc[T]
+	vectors [][]T
+	targets []float64
+}
+
+// NewKNNRegressor creates an untrained k-nearest-neighbor regressor.
+func NewKNNRegressor[T Number](k int, distFn DistanceFunc[T]) (*KNNRegressor[T], error) {
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	return &KNNRegressor[T]{k: k, distFn: distFn}, nil
+}
+
+// Fit stores the training data. vectors and targets must have the same length.
+// Time: O(1), Space: O(1)
+func (r *KNNRegressor[T]) Fit(vectors [][]T, targets []float64) error {
+	if len(vectors) == 0 {
+		return ErrEmptyInput
+	}
+	if len(vectors) != len(targets) {
+		return ErrDimensionMismatch
+	}
+	r.vectors, r.targets = vectors, targets
+	return nil
+}
+
+// Predict returns the mean target of the k nearest training vectors.
+// Time: O(nd + n log k), Space: O(k)
+func (r *KNNRegressor[T]) Predict(x []T) (float64, error) {
+	if len(r.vectors) == 0 {
+		return 0, ErrEmptyInput
+	}
+
+	neighbors, err := kNearest(r.vectors, x, -1, r.k, r.distFn)
+	if err != nil {
+		return 0, err
+	}
+
+	var sum float64
+	for _, nb := range neighbors {
+		sum += r.targets[nb.Index]
+	}
+	return sum / float64(len(neighbors)), nil
+}
+
+// CentroidMethod selects how NearestCentroid summarizes each class.
+type CentroidMethod int
+
+const (
+	// CentroidMean uses the per-dimension mean, the classic Rocchio classifier.
+	CentroidMean CentroidMethod = iota
+	// CentroidMedian uses the per-dimension median, robust to outliers and
+	// natural for Manhattan distance.
+	CentroidMedian
+	// CentroidMedoid uses the class member with the smallest total distance
+	// to the rest under the classifier's metric, so prototypes are real
+	// examples; best for non-Euclidean metrics.
+	CentroidMedoid
+)
+
+// NearestCentroidOptions configures a NearestCentroid classifier.
+type NearestCentroidOptions struct {
+	Method CentroidMethod // How class prototypes are computed (default CentroidMean)
+	// Shrinkage, when positive, applies nearest shrunken centroids
+	// (Tibshirani et al., PAM): each class mean's standardized deviation
+	// from the overall mean is soft-thresholded by Shrinkage, so dimensions
+	// that do not separate the classes fall back to the overall mean and
+	// stop influencing predictions. Requires CentroidMean.
+	Shrinkage float64
+}
+
+// NearestCentroid predicts the label whose class prototype is nearest under
+// distFn. A fast, interpretable baseline: prediction costs one distance per
+// class regardless of the training set size.
+type NearestCentroid[T Float] struct {
+	distFn    DistanceFunc[T]
+	opts      NearestCentroidOptions
+	classes   []string
+	centroids [][]T
+}
+
+// NewNearestCentroid creates an untrained nearest-centroid classifier.
+// Returns ErrInvalidParameter for a negative Shrinkage, shrinkage with a
+// method other than CentroidMean, or an unknown method.
+func NewNearestCentroid[T Float](distFn DistanceFunc[T], opts NearestCentroidOptions) (*NearestCentroid[T], error) {
+	if opts.Method < CentroidMean || opts.Method > CentroidMedoid || opts.Shrinkage < 0 ||
+		(opts.Shrinkage > 0 && opts.Method != CentroidMean) {
+		return nil, ErrInvalidParameter
+	}
+	return &NearestCentroid[T]{distFn: distFn, opts: opts}, nil
+}
+
+// Fit computes one prototype per distinct label. vectors and labels must
+// have the same length.
+// Time: O(nd) for mean, O(nd log n) for median, O(Σ nₖ² d) for medoid, Space: O(nd)
+func (c *NearestCentroid[T]) Fit(vectors [][]T, labels []string) error {
+	if len(vectors) == 0 {
+		return ErrEmptyInput
+	}
+	if len(vectors) != len(labels) {
+		return ErrDimensionMismatch
+	}
+	d := len(vectors[0])
+	members := make(map[string][]int)
+	var classes []string
+	for i, v := range vectors {
+		if len(v) != d {
+			return ErrDimensionMismatch
+		}
+		if _, ok := members[labels[i]]; !ok {
+			classes = append(classes, labels[i])
+		}
+		members[labels[i]] = append(members[labels[i]], i)
+	}
+	sort.Strings(classes)
+
+	centroids := make([][]float64, len(classes))
+	for k, label := range classes {
+		idx := members[label]
+		centroid := make([]float64, d)
+		switch c.opts.Method {
+		case CentroidMean:
+			for _, i := range idx {
+				for j, x := range vectors[i] {
+					centroid[j] += float64(x) / float64(len(idx))
+				}
+			}
+		case CentroidMedian:
+			column := make([]float64, len(idx))
+			for j := range centroid {
+				for m, i := range idx {
+					column[m] = float64(vectors[i][j])
+				}
+				sort.Float64s(column)
+				centroid[j] = percentile(column, 0.5)
+			}
+		case CentroidMedoid:
+			best, bestCost := idx[0], math.Inf(1)
+			for _, a := range idx {
+				var cost float64
+				for _, b := range idx {
+					dist, err := c.distFn(vectors[a], vectors[b])
+					if err != nil {
+						return err
+					}
+					cost += dist
+				}
+				if cost < bestCost {
+					best, bestCost = a, cost
+				}
+			}
+			for j, x := range vectors[best] {
+				centroid[j] = float64(x)
+			}
+		}
+		centroids[k] = centroid
+	}
+	if c.opts.Shrinkage > 0 {
+		shrinkCentroids(centroids, vectors, labels, classes, members, c.opts.Shrinkage)
+	}
+
+	c.classes = classes
+	c.centroids = make([][]T, len(classes))
+	for k, centroid := range centroids {
+		c.centroids[k] = make([]T, d)
+		for j, x := range centroid {
+			c.centroids[k][j] = T(x)
+		}
+	}
+	return nil
+}
+
+// Predict returns the label of the nearest class prototype; ties go to the
+// lexicographically smaller label.
+// Time: O(cd) for c classes, Space: O(1)
+func (c *NearestCentroid[T]) Predict(x []T) (string, error) {
+	if len(c.centroids) == 0 {
+		return "", ErrEmptyInput
+	}
+	i, _, err := NearestNeighbor(c.centroids, x, c.distFn)
+	if err != nil {
+		return "", err
+	}
+	return c.classes[i], nil
+}
+
+// Centroids returns the sorted class labels and their prototypes, which are
+// shared with the classifier and must not be modified.
+func (c *NearestCentroid[T]) Centroids() ([]string, [][]T) {
+	return c.classes, c.centroids
+}
+
+// KFold shuffles indices 0..n-1 with seed and splits them into folds
+// near-equal test folds.
+// Time: O(n), Space: O(n)
+func KFold(n, folds int, seed uint64) ([][]int, error) {
+	if folds < 2 || folds > n {
+		return nil, ErrInvalidParameter
+	}
+
+	//nolint:gosec // G404: fold assignment does not require cryptographic randomness
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	perm := rng.Perm(n)
+
+	out := make([][]int, folds)
+	for f := range out {
+		lo, hi := f*n/folds, (f+1)*n/folds
+		out[f] = perm[lo:hi]
+		sort.Ints(out[f])
+	}
+	return out, nil
+}
+
+// CrossValidationResult summarizes k-fold evaluation of a classifier.
+type CrossValidationResult struct {
+	FoldAccuracy []float64 `json:"fold_accuracy"`
+	Accuracy     float64   `json:"accuracy"` // over all out-of-fold predictions
+	MacroF1      float64   `json:"macro_f1"` // over all out-of-fold predictions
+}
+
+// CrossValidateKNN evaluates a k-nearest-neighbor classifier using
+// distFn with folds-fold cross-validation.
+// Time: O(n²d), Space: O(n)
+func CrossValidateKNN[T Number](vectors [][]T, labels []string, k, folds int, distFn DistanceFunc[T], seed uint64) (*CrossValidationResult, error) {
+	if len(vectors) != len(labels) {
+		return nil, ErrDimensionMismatch
+	}
+	splits, err := KFold(len(vectors), folds, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	predicted := make([]string, len(labels))
+	result := &CrossValidationResult{FoldAccuracy: make([]float64, folds)}
+	for f, test := range splits {
+		clf, err := NewKNNClassifier(k, distFn)
+		if err != nil {
+			return nil, err
+		}
+		trainX, trainY := trainingSplit(vectors, labels, test)
+		if err := clf.Fit(trainX, trainY); err != nil {
+			return nil, err
+		}
+
+		correct := 0
+		for _, i := range test {
+			if predicted[i], err = clf.Predict(vectors[i]); err != nil {
+				return nil, err
+			}
+			if predicted[i] == labels[i] {
+				correct++
+			}
+		}
+		result.FoldAccuracy[f] = float64(correct) / float64(len(test))
+	}
+
+	result.Accuracy, _ = Accuracy(labels, predicted)
+	result.MacroF1, _ = MacroF1(labels, predicted)
+	return result, nil
+}
+
+// trainingSplit returns the vectors and labels not listed in test (sorted)
+func trainingSplit[T Number](vectors [][]T, labels []string, test []int) ([][]T, []string) {
+	x := make([][]T, 0, len(vectors)-len(test))
+	y := make([]string, 0, len(vectors)-len(test))
+	t := 0
+	for i := range vectors {
+		if t < len(test) && test[t] == i {
+			t++
+			continue
+		}
+		x = append(x, vectors[i])
+		y = append(y, labels[i])
+	}
+	return x, y
+}
+
+// Accuracy returns the fraction of predictions equal to the true labels.
+// Time: O(n), Space: O(1)
+func Accuracy(yTrue, yPred []string) (float64, error) {
+	if err := validateLabels(yTrue, yPred); err != nil {
+		return 0, err
+	}
+
+	correct := 0
+	for i := range yTrue {
+		if yTrue[i] == yPred[i] {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(yTrue)), nil
+}
+
+// F1Score returns the F1 score treating positive as the positive class.
+// Returns 0 when there are no true or predicted positives.
+// Time: O(n), Space: O(1)
+func F1Score(yTrue, yPred []string, positive string) (float64, error) {
+	if err := validateLabels(yTrue, yPred); err != nil {
+		return 0, err
+	}
+
+	var tp, fp, fn int
+	for i := range yTrue {
+		switch {
+		case yPred[i] == positive && yTrue[i] == positive:
+			tp++
+		case yPred[i] == positive:
+			fp++
+		case yTrue[i] == positive:
+			fn++
+		}
+	}
+	if tp == 0 {
+		return 0, nil
+	}
+	return 2 * float64(tp) / float64(2*tp+fp+fn), nil
+}
+
+// MacroF1 returns the unweighted mean F1 score over all labels present in yTrue.
+// Time: O(nc) where c = number of classes, Space: O(c)
+func MacroF1(yTrue, yPred []string) (float64, error) {
+	if err := validateLabels(yTrue, yPred); err != nil {
+		return 0, err
+	}
+
+	seen := make(map[string]bool)
+	var sum float64
+	for _, label := range yTrue {
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		f1, _ := F1Score(yTrue, yPred, label)
+		sum += f1
+	}
+	return sum / float64(len(seen)), nil
+}
+
+func validateLabels(yTrue, yPred []string) error {
+	if len(yTrue) == 0 {
+		return ErrEmptyInput
+	}
+	if len(yTrue) != len(yPred) {
+		return ErrDimensionMismatch
+	}
+	return nil
+}
+
+// shrinkCentroids soft-thresholds the standardized class-mean deviations
+// dₖⱼ = (x̄ₖⱼ - x̄ⱼ) / (mₖ(sⱼ + s₀)) by delta, with sⱼ the pooled within-class
+// standard deviation, s₀ its median and mₖ = √(1/nₖ - 1/n)
+func shrinkCentroids[T Float](centroids [][]float64, vectors [][]T, labels, classes []string, members map[string][]int, delta float64) {
+	n, d := len(vectors), len(centroids[0])
+	overall := make([]float64, d)
+	for k, label := range classes {
+		for j := range overall {
+			overall[j] += centroids[k][j] * float64(len(members[label])) / float64(n)
+		}
+	}
+
+	class := make(map[string]int, len(classes))
+	for k, label := range classes {
+		class[label] = k
+	}
+	s := make([]float64, d)
+	for i, v := range vectors {
+		for j, x := range v {
+			diff := float64(x) - centroids[class[labels[i]]][j]
+			s[j] += diff * diff
+		}
+	}
+	for j := range s {
+		if dof := n - len(classes); dof > 0 {
+			s[j] = math.Sqrt(s[j] / float64(dof))
+		} else {
+			s[j] = 0
+		}
+	}
+	sorted := append([]float64(nil), s...)
+	sort.Float64s(sorted)
+	s0 := percentile(sorted, 0.5)
+
+	for k, label := range classes {
+		m := math.Sqrt(math.Max(1/float64(len(members[label]))-1/float64(n), 0))
+		for j := range overall {
+			scale := m * (s[j] + s0)
+			if scale == 0 {
+				centroids[k][j] = overall[j]
+				continue
+			}
+			dev := (centroids[k][j] - overall[j]) / scale
+			dev = math.Copysign(math.Max(math.Abs(dev)-delta, 0), dev)
+			centroids[k][j] = overall[j] + scale*dev
+		}
+	}
+}
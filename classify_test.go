@@ -0,0 +1,282 @@
+package distance
+
+import "testing"
+
+func clusteredData() ([][]float64, []string) {
+	var x [][]float64
+	var y []string
+	for i := 0; i < 10; i++ {
+		off := float64(i) * 0.1
+		x = append(x, []float64{off, off}, []float64{10 + off, 10 - off})
+		y = append(y, "low", "high")
+	}
+	return x, y
+}
+
+func TestKNNClassifier(t *testing.T) {
+	x, y := clusteredData()
+	clf, err := NewKNNClassifier(3, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := clf.Predict([]float64{0, 0}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if err := clf.Fit(x, y); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		x    []float64
+		want string
+	}{
+		{[]float64{0.5, 0.5}, "low"},
+		{[]float64{9, 9}, "high"},
+	}
+	for _, tt := range tests {
+		got, err := clf.Predict(tt.x)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("%v: expected %s, got %s", tt.x, tt.want, got)
+		}
+	}
+
+	if err := clf.Fit(x, y[:1]); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := NewKNNClassifier(0, Euclidean[float64]); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestKNNClassifierTieBreak(t *testing.T) {
+	clf, _ := NewKNNClassifier(2, Euclidean[float64])
+	_ = clf.Fit([][]float64{{0}, {3}}, []string{"b", "a"})
+
+	got, _ := clf.Predict([]float64{1})
+	if got != "b" {
+		t.Errorf("expected closer label b, got %s", got)
+	}
+
+	// The empty string is a label like any other
+	majority, _ := NewKNNClassifier(3, Euclidean[float64])
+	_ = majority.Fit([][]float64{{0}, {1}, {5}}, []string{"", "", "x"})
+	for _, q := range []float64{0.2, 5.5} {
+		if got, _ = majority.Predict([]float64{q}); got != "" {
+			t.Errorf("%v: expected majority label \"\", got %q", q, got)
+		}
+	}
+	_ = clf.Fit([][]float64{{0}, {4}}, []string{"", "x"})
+	if got, _ = clf.Predict([]float64{1}); got != "" {
+		t.Errorf("expected closer label \"\", got %q", got)
+	}
+}
+
+func TestKNNRegressor(t *testing.T) {
+	reg, _ := NewKNNRegressor(2, Euclidean[float64])
+	if err := reg.Fit([][]float64{{0}, {1}, {10}}, []float64{1, 3, 100}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := reg.Predict([]float64{0.4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(got, 2) {
+		t.Errorf("expected 2, got %v", got)
+	}
+}
+
+func TestNearestCentroid(t *testing.T) {
+	x, y := clusteredData()
+	for _, method := range []CentroidMethod{CentroidMean, CentroidMedian, CentroidMedoid} {
+		clf, err := NewNearestCentroid(Euclidean[float64], NearestCentroidOptions{Method: method})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := clf.Predict([]float64{0, 0}); err != ErrEmptyInput {
+			t.Errorf("expected ErrEmptyInput, got %v", err)
+		}
+		if err := clf.Fit(x, y); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, tt := range []struct {
+			x    []float64
+			want string
+		}{
+			{[]float64{0.5, 0.5}, "low"},
+			{[]float64{9, 9}, "high"},
+			{[]float64{4, 4}, "low"},
+		} {
+			got, err := clf.Predict(tt.x)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("method %d: Predict(%v) = %q, want %q", method, tt.x, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestNearestCentroidPrototypes(t *testing.T) {
+	x := [][]float64{{0, 0}, {1, 0}, {2, 0}, {30, 0}, {10, 10}}
+	y := []string{"a", "a", "a", "a", "b"}
+	tests := []struct {
+		method CentroidMethod
+		want   []float64
+	}{
+		{CentroidMean, []float64{8.25, 0}},
+		{CentroidMedian, []float64{1.5, 0}},
+		{CentroidMedoid, []float64{1, 0}}, // A real member, unlike the median
+	}
+
+	for _, tt := range tests {
+		clf, err := NewNearestCentroid(Manhattan[float64], NearestCentroidOptions{Method: tt.method})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := clf.Fit(x, y); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		classes, centroids := clf.Centroids()
+		if len(classes) != 2 || classes[0] != "a" || classes[1] != "b" {
+			t.Fatalf("unexpected classes %v", classes)
+		}
+		if !almostEqual(centroids[0][0], tt.want[0]) || !almostEqual(centroids[0][1], tt.want[1]) {
+			t.Errorf("method %d: expected %v, got %v", tt.method, tt.want, centroids[0])
+		}
+	}
+}
+
+func TestNearestCentroidShrinkage(t *testing.T) {
+	// Dimension 0 separates the classes; dimension 1 is noise
+	x := [][]float64{{0, 0.3}, {0.2, -0.1}, {-0.2, 0.1}, {5, -0.3}, {5.2, 0.1}, {4.8, -0.1}}
+	y := []string{"a", "a", "a", "b", "b", "b"}
+
+	clf, err := NewNearestCentroid(Euclidean[float64], NearestCentroidOptions{Shrinkage: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := clf.Fit(x, y); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, centroids := clf.Centroids()
+
+	// The noise dimension collapses to the overall mean, 0
+	if !almostEqual(centroids[0][1], 0) || !almostEqual(centroids[1][1], 0) {
+		t.Errorf("expected noise dimension shrunk to 0, got %v", centroids)
+	}
+	// The informative dimension moves towards the overall mean but keeps its order
+	if centroids[0][0] <= 0 || centroids[0][0] >= 2.5 || centroids[1][0] >= 5 || centroids[1][0] <= 2.5 {
+		t.Errorf("expected informative dimension shrunk but separated, got %v", centroids)
+	}
+	if got, _ := clf.Predict([]float64{4, 2}); got != "b" {
+		t.Errorf("expected b, got %q", got)
+	}
+
+	// A huge threshold shrinks every class to the overall mean
+	clf, _ = NewNearestCentroid(Euclidean[float64], NearestCentroidOptions{Shrinkage: 100})
+	if err := clf.Fit(x, y); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, centroids = clf.Centroids()
+	if !almostEqual(centroids[0][0], 2.5) || !almostEqual(centroids[1][0], 2.5) {
+		t.Errorf("expected both centroids at the overall mean, got %v", centroids)
+	}
+}
+
+func TestNearestCentroidErrors(t *testing.T) {
+	invalid := []NearestCentroidOptions{
+		{Shrinkage: -1},
+		{Method: CentroidMedian, Shrinkage: 0.5},
+		{Method: CentroidMethod(7)},
+	}
+	for _, opts := range invalid {
+		if _, err := NewNearestCentroid(Euclidean[float64], opts); err != ErrInvalidParameter {
+			t.Errorf("%+v: expected ErrInvalidParameter, got %v", opts, err)
+		}
+	}
+
+	clf, _ := NewNearestCentroid(Euclidean[float32], NearestCentroidOptions{})
+	if err := clf.Fit(nil, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if err := clf.Fit([][]float32{{1}}, []string{"a", "b"}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if err := clf.Fit([][]float32{{1}, {1, 2}}, []string{"a", "b"}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestKFold(t *testing.T) {
+	folds, err := KFold(10, 3, 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	seen := make(map[int]bool)
+	for _, f := range folds {
+		if len(f) < 3 || len(f) > 4 {
+			t.Errorf("unbalanced fold size %d", len(f))
+		}
+		for _, i := range f {
+			if seen[i] {
+				t.Errorf("index %d in multiple folds", i)
+			}
+			seen[i] = true
+		}
+	}
+	if len(seen) != 10 {
+		t.Errorf("expected 10 indices, got %d", len(seen))
+	}
+
+	if _, err := KFold(3, 4, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestCrossValidateKNN(t *testing.T) {
+	x, y := clusteredData()
+
+	res, err := CrossValidateKNN(x, y, 3, 5, Euclidean[float64], 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Accuracy != 1 || res.MacroF1 != 1 || len(res.FoldAccuracy) != 5 {
+		t.Errorf("expected perfect separation, got %+v", res)
+	}
+}
+
+func TestClassificationMetrics(t *testing.T) {
+	yTrue := []string{"a", "a", "b", "b"}
+	yPred := []string{"a", "b", "b", "b"}
+
+	acc, err := Accuracy(yTrue, yPred)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(acc, 0.75) {
+		t.Errorf("expected 0.75, got %v", acc)
+	}
+
+	f1a, _ := F1Score(yTrue, yPred, "a")
+	if !almostEqual(f1a, 2.0/3) {
+		t.Errorf("expected %v, got %v", 2.0/3, f1a)
+	}
+	macro, _ := MacroF1(yTrue, yPred)
+	if !almostEqual(macro, (2.0/3+0.8)/2) {
+		t.Errorf("expected %v, got %v", (2.0/3+0.8)/2, macro)
+	}
+
+	if _, err := Accuracy(nil, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := MacroF1(yTrue, yPred[:1]); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package distance
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SessionOptions controls how TokenizeSession turns raw clickstream events
+// into page tokens. The zero value normalizes aggressively, which suits most
+// session comparisons.
+type SessionOptions struct {
+	// KeepQuery keeps query strings and fragments ("?q=shoes", "#top").
+	KeepQuery bool
+	// KeepIDs keeps path segments that look like identifiers (numbers,
+	// UUIDs, long hex strings) instead of replacing them with ":id", so
+	// "/product/123" and "/product/456" stay different pages.
+	KeepIDs bool
+	// KeepRepeats keeps consecutive duplicate tokens such as page reloads.
+	KeepRepeats bool
+}
+
+// TokenizeSession normalizes raw clickstream events (URLs, paths or action
+// names) into page tokens: the scheme and host are dropped, letters are
+// lowercased and trailing slashes trimmed, then query strings, identifier
+// segments and consecutive repeats are removed unless opts keeps them.
+// Empty events are skipped.
+// Time: O(total length), Space: O(total length)
+func TokenizeSession(events []string, opts SessionOptions) []string {
+	tokens := make([]string, 0, len(events))
+	for _, e := range events {
+		token := normalizeSessionEvent(strings.TrimSpace(e), opts)
+		if token == "" {
+			continue
+		}
+		if !opts.KeepRepeats && len(tokens) > 0 && tokens[len(tokens)-1] == token {
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// SessionMetric selects how SessionSimilarity compares two sessions.
+type SessionMetric int
+
+const (
+	// SessionLCS is the length of the longest common subsequence of pages
+	// divided by the longer session: shared navigation in the same order,
+	// tolerating detours.
+	SessionLCS SessionMetric = iota
+	// SessionEdit is 1 - Levenshtein distance over pages / longer session.
+	SessionEdit
+	// SessionJaccard is the Jaccard similarity of the sets of pages
+	// visited, ignoring order and repeats.
+	SessionJaccard
+)
+
+// SessionSimilarity compares two tokenized sessions with metric.
+// Two empty sessions are identical.
+// Range [0, 1] where 1=identical
+// Time: O(mn) for SessionLCS and SessionEdit, O(m+n) for SessionJaccard,
+// Space: O(mn)
+func SessionSimilarity(a, b []string, metric SessionMetric) (float64, error) {
+	longest := max(len(a), len(b))
+	switch metric {
+	case SessionLCS:
+		if longest == 0 {
+			return 1.0, nil
+		}
+		return float64(len(LCSSlices(a, b))) / float64(longest), nil
+	case SessionEdit:
+		if longest == 0 {
+			return 1.0, nil
+		}
+		return 1.0 - float64(levenshteinSeq(a, b))/float64(longest), nil
+	case SessionJaccard:
+		return JaccardSimilarity(a, b)
+	default:
+		return 0, ErrInvalidParameter
+	}
+}
+
+// ClusterSessions groups tokenized sessions by average-linkage hierarchical
+// clustering on 1 - SessionSimilarity, merging clusters while their average
+// similarity is at least threshold. Returns one label per session, numbered
+// in order of first appearance.
+// Time: O(n²·L² + n³), Space: O(n²)
+func ClusterSessions(sessions [][]string, metric SessionMetric, threshold float64) ([]int, error) {
+	if len(sessions) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, ErrInvalidParameter
+	}
+
+	n := len(sessions)
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			s, err := SessionSimilarity(sessions[i], sessions[j], metric)
+			if err != nil {
+				return nil, err
+			}
+			matrix[i][j], matrix[j][i] = 1-s, 1-s
+		}
+	}
+
+	merges, err := AgglomerativeCluster(matrix, AverageLinkage)
+	if err != nil {
+		return nil, err
+	}
+	return CutTree(merges, 1-threshold), nil
+}
+
+// normalizeSessionEvent maps one raw event to its page token
+func normalizeSessionEvent(e string, opts SessionOptions) string {
+	if i := strings.Index(e, "://"); i >= 0 {
+		e = e[i+3:]
+		if j := strings.IndexByte(e, '/'); j >= 0 {
+			e = e[j:]
+		} else {
+			e = "/"
+		}
+	}
+	if !opts.KeepQuery {
+		if i := strings.IndexAny(e, "?#"); i >= 0 {
+			e = e[:i]
+		}
+	}
+	e = strings.ToLower(e)
+	if len(e) > 1 {
+		e = strings.TrimRight(e, "/")
+		if e == "" {
+			e = "/"
+		}
+	}
+	if opts.KeepIDs || !strings.Contains(e, "/") {
+		return e
+	}
+
+	segments := strings.Split(e, "/")
+	for i, seg := range segments {
+		if isIdentifierSegment(seg) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isIdentifierSegment reports whether a path segment looks like a record
+// identifier: all digits, or at least 8 hex digits and dashes containing a digit
+func isIdentifierSegment(seg string) bool {
+	if seg == "" {
+		return false
+	}
+	digits, hex := 0, 0
+	for _, r := range seg {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+			hex++
+		case strings.ContainsRune("abcdef", r):
+			hex++
+		case r == '-':
+		default:
+			return false
+		}
+	}
+	return digits == len(seg) || (hex >= 8 && digits > 0)
+}
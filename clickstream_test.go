@@ -0,0 +1,95 @@
+package distance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenizeSession(t *testing.T) {
+	events := []string{
+		"https://shop.example.com/",
+		"https://shop.example.com/Search?q=shoes",
+		"/product/12345/",
+		"/product/12345",
+		"/order/3f2b9c1e-8d4a-4b7e-9f00-1a2b3c4d5e6f#summary",
+		"  ",
+		"add_to_cart",
+		"https://shop.example.com",
+	}
+
+	got := TokenizeSession(events, SessionOptions{})
+	expected := []string{"/", "/search", "/product/:id", "/order/:id", "add_to_cart", "/"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	kept := TokenizeSession(events[1:4], SessionOptions{KeepQuery: true, KeepIDs: true, KeepRepeats: true})
+	expected = []string{"/search?q=shoes", "/product/12345", "/product/12345"}
+	if !reflect.DeepEqual(kept, expected) {
+		t.Errorf("expected %q, got %q", expected, kept)
+	}
+
+	// Words that merely contain hex letters are not identifiers
+	if got := TokenizeSession([]string{"/faces/decade/beef"}, SessionOptions{}); got[0] != "/faces/decade/beef" {
+		t.Errorf("unexpected token %q", got[0])
+	}
+}
+
+func TestSessionSimilarity(t *testing.T) {
+	a := []string{"/", "/search", "/product/:id", "/cart", "/checkout"}
+	b := []string{"/", "/product/:id", "/search", "/product/:id", "/cart"}
+
+	tests := []struct {
+		metric   SessionMetric
+		expected float64
+	}{
+		{SessionLCS, 4.0 / 5},     // /, /search, /product/:id, /cart
+		{SessionEdit, 1 - 2.0/5},  // insert /product/:id, delete /checkout
+		{SessionJaccard, 4.0 / 5}, // 4 shared of 5 distinct pages
+	}
+	for _, tt := range tests {
+		result, err := SessionSimilarity(a, b, tt.metric)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !almostEqual(result, tt.expected) {
+			t.Errorf("metric %d: expected %v, got %v", tt.metric, tt.expected, result)
+		}
+	}
+
+	if s, _ := SessionSimilarity(nil, nil, SessionEdit); s != 1 {
+		t.Errorf("expected empty sessions to be identical, got %v", s)
+	}
+	if _, err := SessionSimilarity(a, b, SessionMetric(9)); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestClusterSessions(t *testing.T) {
+	raw := [][]string{
+		{"/", "/search?q=a", "/product/1", "/cart", "/checkout"},
+		{"/blog", "/blog/post/9", "/blog/post/12"},
+		{"/", "/search?q=b", "/product/7", "/product/7", "/cart", "/checkout"},
+		{"/blog", "/blog/post/3", "/about"},
+		{"/", "/search", "/product/2", "/cart"},
+	}
+	sessions := make([][]string, len(raw))
+	for i, r := range raw {
+		sessions[i] = TokenizeSession(r, SessionOptions{})
+	}
+
+	labels, err := ClusterSessions(sessions, SessionLCS, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(labels, []int{0, 1, 0, 1, 0}) {
+		t.Errorf("expected shoppers and readers, got %v", labels)
+	}
+
+	if _, err := ClusterSessions(nil, SessionLCS, 0.5); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := ClusterSessions(sessions, SessionLCS, 2); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package distance
+
+import "math"
+
+// StreamingKMeans maintains k cluster centroids over an unbounded stream of
+// vectors in O(k·d) memory. Single points use MacQueen's online update and
+// AddBatch applies Sculley's mini-batch k-means with per-centroid learning rates.
+type StreamingKMeans[T Number] struct {
+	k         int
+	dim       int
+	centroids [][]float64
+	counts    []float64
+}
+
+// NewStreamingKMeans creates an empty streaming k-means model for dim-dimensional vectors.
+// The first k points seen become the initial centroids.
+func NewStreamingKMeans[T Number](k, dim int) (*StreamingKMeans[T], error) {
+	if k <= 0 || dim <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	return &StreamingKMeans[T]{
+		k:         k,
+		dim:       dim,
+		centroids: make([][]float64, 0, k),
+		counts:    make([]float64, 0, k),
+	}, nil
+}
+
+// Add assigns x to its nearest centroid and moves that centroid towards x.
+// Returns the index of the assigned cluster.
+// Time: O(kd), Space: O(1)
+func (m *StreamingKMeans[T]) Add(x []T) (int, error) {
+	if len(x) != m.dim {
+		return -1, ErrDimensionMismatch
+	}
+	if idx, seeded := m.seed(x); seeded {
+		return idx, nil
+	}
+
+	idx, _ := m.nearest(x)
+	m.counts[idx]++
+	eta := 1 / m.counts[idx]
+	for j, v := range x {
+		m.centroids[idx][j] += eta * (float64(v) - m.centroids[idx][j])
+	}
+	return idx, nil
+}
+
+// AddBatch performs one mini-batch k-means step: all points are assigned
+// against the current centroids, then each centroid is updated per point with
+// learning rate 1/count. Returns the cluster assignment of each point.
+// Time: O(bkd) where b=batch size, Space: O(b)
+func (m *StreamingKMeans[T]) AddBatch(batch [][]T) ([]int, error) {
+	for _, x := range batch {
+		if len(x) != m.dim {
+			return nil, ErrDimensionMismatch
+		}
+	}
+
+	assignments := make([]int, len(batch))
+	seeded := make([]bool, len(batch))
+	for i, x := range batch {
+		assignments[i], seeded[i] = m.seed(x)
+		if !seeded[i] {
+			assignments[i], _ = m.nearest(x)
+		}
+	}
+
+	for i, x := range batch {
+		if seeded[i] {
+			continue
+		}
+		idx := assignments[i]
+		m.counts[idx]++
+		eta := 1 / m.counts[idx]
+		for j, v := range x {
+			m.centroids[idx][j] += eta * (float64(v) - m.centroids[idx][j])
+		}
+	}
+	return assignments, nil
+}
+
+// Predict returns the nearest centroid index and its Euclidean distance to x.
+// Time: O(kd), Space: O(1)
+func (m *StreamingKMeans[T]) Predict(x []T) (int, float64, error) {
+	if len(m.centroids) == 0 {
+		return -1, 0, ErrEmptyInput
+	}
+	if len(x) != m.dim {
+		return -1, 0, ErrDimensionMismatch
+	}
+	idx, sq := m.nearest(x)
+	return idx, math.Sqrt(sq), nil
+}
+
+// Centroids returns a copy of the current centroids.
+func (m *StreamingKMeans[T]) Centroids() [][]float64 {
+	out := make([][]float64, len(m.centroids))
+	for i, c := range m.centroids {
+		out[i] = append([]float64{}, c...)
+	}
+	return out
+}
+
+// Counts returns the number of points absorbed by each centroid.
+func (m *StreamingKMeans[T]) Counts() []float64 {
+	return append([]float64{}, m.counts...)
+}
+
+// seed adds x as a new centroid while fewer than k exist
+func (m *StreamingKMeans[T]) seed(x []T) (int, bool) {
+	if len(m.centroids) >= m.k {
+		return -1, false
+	}
+	c := make([]float64, m.dim)
+	for j, v := range x {
+		c[j] = float64(v)
+	}
+	m.centroids = append(m.centroids, c)
+	m.counts = append(m.counts, 1)
+	return len(m.centroids) - 1, true
+}
+
+// nearest returns the closest centroid index and its squared distance
+func (m *StreamingKMeans[T]) nearest(x []T) (int, float64) {
+	best, bestDist := 0, -1.0
+	for i, c := range m.centroids {
+		var sum float64
+		for j, v := range x {
+			diff := float64(v) - c[j]
+			sum += diff * diff
+		}
+		if bestDist < 0 || sum < bestDist {
+			best, bestDist = i, sum
+		}
+	}
+	return best, bestDist
+}
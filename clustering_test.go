@@ -0,0 +1,84 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStreamingKMeans(t *testing.T) {
+	m, err := NewStreamingKMeans[float64](2, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Seed one point per cluster, then stream alternating points
+	if _, err := m.Add([]float64{0, 0}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := m.Add([]float64{10, 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		offset := float64(i%3) - 1
+		_, _ = m.Add([]float64{1 + offset, 1 - offset})
+		_, _ = m.Add([]float64{11 + offset, 11 - offset})
+	}
+
+	centroids := m.Centroids()
+	if len(centroids) != 2 {
+		t.Fatalf("expected 2 centroids, got %d", len(centroids))
+	}
+	if d, _ := Euclidean(centroids[0], []float64{1, 1}); d > 0.1 {
+		t.Errorf("expected centroid near (1,1), got %v", centroids[0])
+	}
+	if d, _ := Euclidean(centroids[1], []float64{11, 11}); d > 0.1 {
+		t.Errorf("expected centroid near (11,11), got %v", centroids[1])
+	}
+
+	counts := m.Counts()
+	if counts[0]+counts[1] != 202 {
+		t.Errorf("expected 202 points absorbed, got %v", counts)
+	}
+
+	idx, dist, err := m.Predict([]float64{12, 12})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if idx != 1 || math.Abs(dist-math.Sqrt(2)) > 0.2 {
+		t.Errorf("expected cluster 1 at ~%v, got %d at %v", math.Sqrt(2), idx, dist)
+	}
+}
+
+func TestStreamingKMeansAddBatch(t *testing.T) {
+	m, _ := NewStreamingKMeans[int](2, 1)
+
+	assignments, err := m.AddBatch([][]int{{0}, {100}, {2}, {98}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if assignments[0] != 0 || assignments[1] != 1 || assignments[2] != 0 || assignments[3] != 1 {
+		t.Errorf("unexpected assignments %v", assignments)
+	}
+
+	centroids := m.Centroids()
+	if !almostEqual(centroids[0][0], 1) || !almostEqual(centroids[1][0], 99) {
+		t.Errorf("expected centroids [1] and [99], got %v", centroids)
+	}
+}
+
+func TestStreamingKMeansErrors(t *testing.T) {
+	if _, err := NewStreamingKMeans[float64](0, 2); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+
+	m, _ := NewStreamingKMeans[float64](2, 2)
+	if _, _, err := m.Predict([]float64{1, 1}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := m.Add([]float64{1}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := m.AddBatch([][]float64{{1, 2}, {1}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
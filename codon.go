@@ -0,0 +1,216 @@
+package distance
+
+import (
+	"math"
+	"strings"
+)
+
+// Codon-level distances over aligned protein-coding DNA. Sequences must have
+// equal length, a multiple of three, and start in the reading frame (e.g.
+// codon-aware alignments with gaps in whole codons). Letters are
+// case-insensitive and U is read as T; codons containing a gap or an
+// ambiguous base, and stop codons, are skipped. The standard genetic code is
+// used throughout.
+
+// CodonSubstitutions holds the Nei-Gojobori (1986) counts between two
+// aligned coding sequences: the numbers of synonymous and nonsynonymous
+// sites, averaged over both sequences, and the synonymous and nonsynonymous
+// differences between them. Codons differing at several positions have their
+// differences averaged over all mutational pathways that avoid a stop codon.
+type CodonSubstitutions struct {
+	SynonymousSites    float64 `json:"synonymous_sites"`
+	NonsynonymousSites float64 `json:"nonsynonymous_sites"`
+	Synonymous         float64 `json:"synonymous"`
+	Nonsynonymous      float64 `json:"nonsynonymous"`
+	Codons             int     `json:"codons"` // codon pairs compared
+}
+
+// PS returns the proportion of synonymous differences per synonymous site,
+// NaN if there are no synonymous sites.
+func (c CodonSubstitutions) PS() float64 {
+	return c.Synonymous / c.SynonymousSites
+}
+
+// PN returns the proportion of nonsynonymous differences per nonsynonymous
+// site.
+func (c CodonSubstitutions) PN() float64 {
+	return c.Nonsynonymous / c.NonsynonymousSites
+}
+
+// CountCodonSubstitutions counts synonymous and nonsynonymous sites and
+// differences between two aligned coding sequences.
+// Returns ErrInvalidParameter if the length is not a multiple of three and
+// ErrEmptyInput if no codon pair can be compared.
+// Time: O(n), Space: O(1)
+func CountCodonSubstitutions(a, b string) (CodonSubstitutions, error) {
+	if len(a) != len(b) {
+		return CodonSubstitutions{}, ErrDimensionMismatch
+	}
+	if len(a)%3 != 0 {
+		return CodonSubstitutions{}, ErrInvalidParameter
+	}
+
+	var c CodonSubstitutions
+	for i := 0; i < len(a); i += 3 {
+		x, y := codonIndex(a[i:i+3]), codonIndex(b[i:i+3])
+		if x < 0 || y < 0 || geneticCode[x] == '*' || geneticCode[y] == '*' {
+			continue
+		}
+		sx, sy := synonymousSites(x), synonymousSites(y)
+		c.SynonymousSites += (sx + sy) / 2
+		c.NonsynonymousSites += 3 - (sx+sy)/2
+		syn, non := codonDifferences(x, y)
+		c.Synonymous += syn
+		c.Nonsynonymous += non
+		c.Codons++
+	}
+	if c.Codons == 0 {
+		return CodonSubstitutions{}, ErrEmptyInput
+	}
+	return c, nil
+}
+
+// DNDS estimates the nonsynonymous (dN) and synonymous (dS) substitutions
+// per site between two aligned coding sequences with the Nei-Gojobori
+// method, applying the Jukes-Cantor correction to PN and PS, and their ratio
+// omega = dN/dS. Omega above 1 suggests positive selection, below 1
+// purifying selection. Omega is +Inf when dS is 0 but dN is not, and 0 when
+// both are 0. Returns ErrSaturated if either proportion is at least 3/4, and
+// ErrEmptyInput if the compared codons have no synonymous sites (e.g. only
+// Met and Trp).
+// Time: O(n), Space: O(1)
+func DNDS(a, b string) (dn, ds, omega float64, err error) {
+	c, err := CountCodonSubstitutions(a, b)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if c.SynonymousSites == 0 {
+		return 0, 0, 0, ErrEmptyInput
+	}
+	dn, okN := jukesCantorCorrect(c.PN())
+	ds, okS := jukesCantorCorrect(c.PS())
+	if !okN || !okS {
+		return 0, 0, 0, ErrSaturated
+	}
+	switch {
+	case ds > 0:
+		omega = dn / ds
+	case dn > 0:
+		omega = math.Inf(1)
+	}
+	return dn, ds, omega, nil
+}
+
+// Translate translates a DNA sequence into one-letter amino acids using the
+// standard genetic code, starting at offset frame (0, 1 or 2). Stop codons
+// become '*' and codons with gaps or ambiguous bases 'X'; a trailing partial
+// codon is dropped.
+// Time: O(n), Space: O(n)
+func Translate(seq string, frame int) (string, error) {
+	if frame < 0 || frame > 2 {
+		return "", ErrInvalidParameter
+	}
+	var sb strings.Builder
+	for i := frame; i+3 <= len(seq); i += 3 {
+		if c := codonIndex(seq[i : i+3]); c >= 0 {
+			sb.WriteByte(geneticCode[c])
+		} else {
+			sb.WriteByte('X')
+		}
+	}
+	return sb.String(), nil
+}
+
+// geneticCode is the standard code indexed by codon with bases ordered
+// T, C, A, G, i.e. TTT, TTC, TTA, TTG, TCT, ...
+const geneticCode = "FFLLSSSSYY**CC*WLLLLPPPPHHQQRRRRIIIMTTTTNNKKSSRRVVVVAAAADDEEGGGG"
+
+// tcagOrder maps nucleotideIndex values (A, C, G, T) to their T, C, A, G rank
+var tcagOrder = [4]int{2, 1, 3, 0}
+
+// codonIndex returns the geneticCode index of a three-base codon, or -1 if
+// it contains a gap or an ambiguous base
+func codonIndex(codon string) int {
+	index := 0
+	for i := 0; i < 3; i++ {
+		n := nucleotideIndex(codon[i])
+		if n < 0 {
+			return -1
+		}
+		index = index*4 + tcagOrder[n]
+	}
+	return index
+}
+
+// withBase returns codon with the base at position pos replaced by base
+func withBase(codon, pos, base int) int {
+	shift := 2 * (2 - pos)
+	return codon&^(3<<shift) | base<<shift
+}
+
+// baseAt returns the base at position pos of codon
+func baseAt(codon, pos int) int {
+	return codon >> (2 * (2 - pos)) & 3
+}
+
+// synonymousSites returns the number of synonymous sites of a codon: the
+// fraction of single-base changes at each position that keep the amino acid
+func synonymousSites(codon int) float64 {
+	s := 0.0
+	for pos := 0; pos < 3; pos++ {
+		for base := 0; base < 4; base++ {
+			if base != baseAt(codon, pos) && geneticCode[withBase(codon, pos, base)] == geneticCode[codon] {
+				s += 1.0 / 3
+			}
+		}
+	}
+	return s
+}
+
+// codonDifferences returns the synonymous and nonsynonymous differences
+// between two sense codons, averaged over the pathways that avoid stop codons
+func codonDifferences(x, y int) (syn, non float64) {
+	var positions []int
+	for pos := 0; pos < 3; pos++ {
+		if baseAt(x, pos) != baseAt(y, pos) {
+			positions = append(positions, pos)
+		}
+	}
+
+	paths := 0
+	permute(positions, 0, func(order []int) {
+		s, n, cur := 0, 0, x
+		for _, pos := range order {
+			next := withBase(cur, pos, baseAt(y, pos))
+			if geneticCode[next] == '*' {
+				return
+			}
+			if geneticCode[next] == geneticCode[cur] {
+				s++
+			} else {
+				n++
+			}
+			cur = next
+		}
+		syn += float64(s)
+		non += float64(n)
+		paths++
+	})
+	if paths == 0 {
+		return 0, float64(len(positions))
+	}
+	return syn / float64(paths), non / float64(paths)
+}
+
+// permute calls visit with every ordering of values[k:], permuting in place
+func permute(values []int, k int, visit func([]int)) {
+	if k >= len(values) {
+		visit(values)
+		return
+	}
+	for i := k; i < len(values); i++ {
+		values[k], values[i] = values[i], values[k]
+		permute(values, k+1, visit)
+		values[k], values[i] = values[i], values[k]
+	}
+}
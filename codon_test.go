@@ -0,0 +1,113 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCountCodonSubstitutions(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		syn, non float64
+		sSites   float64
+	}{
+		// TTT and TTC (Phe): only the TTC change at the third position keeps Phe
+		{"synonymous", "TTT", "TTC", 1, 0, 1.0 / 3},
+		// TGG (Trp) to CAG (Gln): the path through TAG (stop) is excluded
+		{"stop pathway", "TGG", "CAG", 0, 2, 1.0 / 6},
+		// TTT (Phe) to TGC (Cys): one synonymous and one nonsynonymous step either way
+		{"two pathways", "TTT", "TGC", 1, 1, 1.0 / 3},
+		{"identical", "ATGAAA", "ATGAAA", 0, 0, 1.0 / 3},
+		{"gap and stop codons skipped", "TTT---TAA", "TTCAAATAG", 1, 0, 1.0 / 3},
+		{"rna and case", "uuu", "TTC", 1, 0, 1.0 / 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := CountCodonSubstitutions(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(c.Synonymous, tt.syn) || !almostEqual(c.Nonsynonymous, tt.non) {
+				t.Errorf("expected %v synonymous and %v nonsynonymous, got %+v", tt.syn, tt.non, c)
+			}
+			if !almostEqual(c.SynonymousSites, tt.sSites) || !almostEqual(c.SynonymousSites+c.NonsynonymousSites, 3*float64(c.Codons)) {
+				t.Errorf("expected %v synonymous sites, got %+v", tt.sSites, c)
+			}
+		})
+	}
+}
+
+func TestDNDS(t *testing.T) {
+	// Leu CTG, Ala GCT, Lys AAA, Glu GAA, Ser TCT, Gly GGC
+	a := "CTGGCTAAAGAATCTGGC"
+	b := "CTAGCCAAAGATTCTGGA" // three synonymous changes, one Glu→Asp
+
+	c, err := CountCodonSubstitutions(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dn, ds, omega, err := DNDS(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedN := -0.75 * math.Log(1-4*c.PN()/3)
+	expectedS := -0.75 * math.Log(1-4*c.PS()/3)
+	if !almostEqual(dn, expectedN) || !almostEqual(ds, expectedS) || !almostEqual(omega, dn/ds) {
+		t.Errorf("expected dN %v dS %v, got %v %v (omega %v)", expectedN, expectedS, dn, ds, omega)
+	}
+	if !almostEqual(c.Synonymous, 3) || !almostEqual(c.Nonsynonymous, 1) || omega >= 1 {
+		t.Errorf("expected 3 synonymous, 1 nonsynonymous and omega < 1, got %+v omega %v", c, omega)
+	}
+
+	if _, _, omega, err := DNDS(a, a); err != nil || omega != 0 {
+		t.Errorf("expected omega 0 for identical sequences, got %v (%v)", omega, err)
+	}
+	if _, _, omega, err := DNDS("CTGGAA", "CTGGAC"); err != nil || !math.IsInf(omega, 1) {
+		t.Errorf("expected +Inf omega without synonymous changes, got %v (%v)", omega, err)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		seq      string
+		frame    int
+		expected string
+	}{
+		{"ATGGCCTAA", 0, "MA*"},
+		{"GATGGCCTAAG", 1, "MA*"},
+		{"ATGN--TGG", 0, "MXW"},
+		{"augUUUgg", 0, "MF"},
+	}
+	for _, tt := range tests {
+		result, err := Translate(tt.seq, tt.frame)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != tt.expected {
+			t.Errorf("%s frame %d: expected %q, got %q", tt.seq, tt.frame, tt.expected, result)
+		}
+	}
+}
+
+func TestCodonErrors(t *testing.T) {
+	if _, err := CountCodonSubstitutions("ATG", "ATGA"); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := CountCodonSubstitutions("ATGA", "ATGC"); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := CountCodonSubstitutions("TAA---", "TGAATG"); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, _, _, err := DNDS("ATGTGG", "ATGTGG"); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, _, _, err := DNDS("TTTTTT", "TTCTTC"); err != ErrSaturated {
+		t.Errorf("expected ErrSaturated, got %v", err)
+	}
+	if _, err := Translate("ATG", 3); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package distance
+
+// Arrow and Parquet readers/writers are intentionally not provided: the
+// package has no external dependencies, and both formats need the upstream
+// libraries to be read and written correctly. Instead, FlattenVectors and
+// VectorsFromFlat convert between [][]T and the contiguous row-major buffer
+// Arrow uses as the child array of a FixedSizeList<dim> column (and Parquet
+// writers accept for repeated fixed-width columns), so data can be handed to
+// or taken from those libraries without per-element conversion.
+
+// FlattenVectors packs equal-length vectors into one contiguous row-major
+// buffer, returning the buffer and the vector dimension.
+// Time: O(nd), Space: O(nd)
+func FlattenVectors[T Number](vectors [][]T) ([]T, int, error) {
+	if len(vectors) == 0 {
+		return nil, 0, ErrEmptyInput
+	}
+
+	dim := len(vectors[0])
+	if dim == 0 {
+		return nil, 0, ErrEmptyInput
+	}
+	flat := make([]T, 0, len(vectors)*dim)
+	for _, v := range vectors {
+		if len(v) != dim {
+			return nil, 0, ErrDimensionMismatch
+		}
+		flat = append(flat, v...)
+	}
+	return flat, dim, nil
+}
+
+// VectorsFromFlat views a contiguous row-major buffer as vectors of length dim.
+// No data is copied: each vector aliases flat, so the buffer (e.g. an Arrow
+// FixedSizeList child array) must outlive and not be mutated under the result.
+// Time: O(n), Space: O(n)
+func VectorsFromFlat[T Number](flat []T, dim int) ([][]T, error) {
+	if dim <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	if len(flat) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if len(flat)%dim != 0 {
+		return nil, ErrDimensionMismatch
+	}
+
+	vectors := make([][]T, len(flat)/dim)
+	for i := range vectors {
+		vectors[i] = flat[i*dim : (i+1)*dim : (i+1)*dim]
+	}
+	return vectors, nil
+}
+
+// FlattenMatrix packs a distance matrix into a row-major buffer suitable for a
+// FixedSizeList<n> column with one row per matrix row.
+// Time: O(n²), Space: O(n²)
+func FlattenMatrix(matrix [][]float64) ([]float64, error) {
+	if err := validateSquare(matrix); err != nil {
+		return nil, err
+	}
+	flat, _, err := FlattenVectors(matrix)
+	return flat, err
+}
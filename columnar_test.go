@@ -0,0 +1,51 @@
+package distance
+
+import "testing"
+
+func TestFlattenVectorsRoundTrip(t *testing.T) {
+	vectors := [][]float32{{1, 2, 3}, {4, 5, 6}}
+
+	flat, dim, err := FlattenVectors(vectors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dim != 3 || len(flat) != 6 || flat[3] != 4 {
+		t.Fatalf("unexpected layout %v (dim %d)", flat, dim)
+	}
+
+	back, err := VectorsFromFlat(flat, dim)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range vectors {
+		for j := range vectors[i] {
+			if back[i][j] != vectors[i][j] {
+				t.Errorf("[%d][%d]: expected %v, got %v", i, j, vectors[i][j], back[i][j])
+			}
+		}
+	}
+
+	// Views alias the buffer and cannot grow into the next row
+	flat[0] = 9
+	if back[0][0] != 9 {
+		t.Error("expected zero-copy view of the buffer")
+	}
+	if cap(back[0]) != 3 {
+		t.Errorf("expected capacity 3, got %d", cap(back[0]))
+	}
+}
+
+func TestFlattenErrors(t *testing.T) {
+	if _, _, err := FlattenVectors([][]float64{{1, 2}, {3}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := VectorsFromFlat([]float64{1, 2, 3}, 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := VectorsFromFlat([]float64{1, 2}, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := FlattenMatrix([][]float64{{0, 1}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package distance
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Thresholds used by DiagnoseConcentration to flag a dataset. They follow the
+// rules of thumb from the hubness literature rather than formal tests.
+const (
+	// concentrationContrastWarn is the mean relative contrast below which the
+	// nearest and farthest neighbors are hard to tell apart
+	concentrationContrastWarn = 0.5
+	// concentrationSkewWarn is the k-occurrence skewness above which a few
+	// hub points dominate neighbor lists
+	concentrationSkewWarn = 1.0
+)
+
+// ConcentrationReport describes how well a metric separates points in a
+// dataset. In high dimensions distances concentrate around their mean, so the
+// relative contrast shrinks towards 0 and a few "hub" points appear in most
+// k-nearest-neighbor lists while many "anti-hubs" appear in none.
+type ConcentrationReport struct {
+	Points           int      `json:"points"`
+	K                int      `json:"k"`
+	RelativeContrast float64  `json:"relative_contrast"` // mean of (Dmax - Dmin) / Dmin per point
+	MinContrast      float64  `json:"min_contrast"`      // smallest per-point relative contrast
+	HubnessSkewness  float64  `json:"hubness_skewness"`  // skewness of the k-occurrence distribution
+	MaxOccurrence    int      `json:"max_occurrence"`    // k-occurrence of the strongest hub
+	AntiHubs         int      `json:"anti_hubs"`         // points in no other point's k-NN list
+	Warnings         []string `json:"warnings,omitempty"`
+}
+
+// Concentrated reports whether any warning was raised.
+func (r *ConcentrationReport) Concentrated() bool {
+	return len(r.Warnings) > 0
+}
+
+// DiagnoseConcentration measures distance concentration of vectors under
+// distFn: the relative contrast between each point's farthest and nearest
+// neighbor, and the skewness of the k-occurrence counts (how often each point
+// appears in others' k-nearest-neighbor lists). Warnings are added when the
+// contrast is low or hubness is high, signalling that nearest-neighbor results
+// under this metric are unreliable.
+// Time: O(n²d + n² log n), Space: O(n²)
+func DiagnoseConcentration[T Number](vectors [][]T, k int, distFn DistanceFunc[T]) (*ConcentrationReport, error) {
+	if len(vectors) == 0 {
+		return nil, ErrEmptyInput
+	}
+	matrix, err := BatchCompute(vectors, distFn)
+	if err != nil {
+		return nil, err
+	}
+	return DiagnoseConcentrationMatrix(matrix, k)
+}
+
+// DiagnoseConcentrationMatrix is DiagnoseConcentration for a precomputed
+// distance matrix. Points whose nearest neighbor is at distance 0 (duplicates)
+// are left out of the contrast averages.
+// Time: O(n² log n), Space: O(n²)
+func DiagnoseConcentrationMatrix(matrix [][]float64, k int) (*ConcentrationReport, error) {
+	if err := validateSquare(matrix); err != nil {
+		return nil, err
+	}
+	n := len(matrix)
+	if n < 3 || k <= 0 || k >= n {
+		return nil, ErrInvalidParameter
+	}
+
+	report := &ConcentrationReport{Points: n, K: k, MinContrast: math.Inf(1)}
+
+	var contrastSum float64
+	var contrastCount int
+	for i, row := range matrix {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for j, d := range row {
+			if i == j {
+				continue
+			}
+			lo = math.Min(lo, d)
+			hi = math.Max(hi, d)
+		}
+		if lo <= 0 {
+			continue
+		}
+		c := (hi - lo) / lo
+		contrastSum += c
+		contrastCount++
+		report.MinContrast = math.Min(report.MinContrast, c)
+	}
+	if contrastCount == 0 {
+		return nil, ErrZeroVector
+	}
+	report.RelativeContrast = contrastSum / float64(contrastCount)
+
+	occurrences := KOccurrence(matrixNeighbors(matrix, k), n)
+	counts := make([]float64, n)
+	for i, c := range occurrences {
+		counts[i] = float64(c)
+		report.MaxOccurrence = max(report.MaxOccurrence, c)
+		if c == 0 {
+			report.AntiHubs++
+		}
+	}
+	report.HubnessSkewness = skewness(counts)
+
+	if report.RelativeContrast < concentrationContrastWarn {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"relative contrast %.3f is below %.2f: nearest and farthest neighbors are nearly equidistant",
+			report.RelativeContrast, concentrationContrastWarn))
+	}
+	if report.HubnessSkewness > concentrationSkewWarn {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"k-occurrence skewness %.3f exceeds %.2f: %d hub(s) dominate neighbor lists and %d point(s) are never retrieved",
+			report.HubnessSkewness, concentrationSkewWarn, hubCount(occurrences, k), report.AntiHubs))
+	}
+	return report, nil
+}
+
+// KOccurrence counts how often each of n points appears in the given
+// neighbor lists (as returned by KNearestNeighbors). Points with counts far
+// above k are hubs; points with count 0 are anti-hubs.
+// Time: O(nk), Space: O(n)
+func KOccurrence(neighbors [][]int, n int) []int {
+	counts := make([]int, n)
+	for _, row := range neighbors {
+		for _, j := range row {
+			if j >= 0 && j < n {
+				counts[j]++
+			}
+		}
+	}
+	return counts
+}
+
+// matrixNeighbors returns each row's k nearest other indices (ties by index)
+func matrixNeighbors(matrix [][]float64, k int) [][]int {
+	result := make([][]int, len(matrix))
+	for i, row := range matrix {
+		order := make([]int, 0, len(row)-1)
+		for j := range row {
+			if j != i {
+				order = append(order, j)
+			}
+		}
+		sort.SliceStable(order, func(x, y int) bool { return row[order[x]] < row[order[y]] })
+		result[i] = order[:min(k, len(order))]
+	}
+	return result
+}
+
+// skewness returns the sample skewness E[(x-μ)³]/σ³ (0 for constant input)
+func skewness(values []float64) float64 {
+	mu := mean(values)
+	var m2, m3 float64
+	for _, v := range values {
+		d := v - mu
+		m2 += d * d
+		m3 += d * d * d
+	}
+	m2 /= float64(len(values))
+	m3 /= float64(len(values))
+	if m2 == 0 {
+		return 0
+	}
+	return m3 / math.Pow(m2, 1.5)
+}
+
+// hubCount counts points retrieved more than twice as often as expected
+func hubCount(occurrences []int, k int) int {
+	hubs := 0
+	for _, c := range occurrences {
+		if c > 2*k {
+			hubs++
+		}
+	}
+	return hubs
+}
@@ -0,0 +1,103 @@
+package distance
+
+import (
+	"reflect"
+	"testing"
+)
+
+// randomCube returns n reproducible points drawn uniformly from [0,1)^d
+func randomCube(seed uint64, n, d int) [][]float64 {
+	rng := testRNG(seed)
+	points := make([][]float64, n)
+	for i := range points {
+		points[i] = make([]float64, d)
+		for j := range points[i] {
+			points[i][j] = rng.Float64()
+		}
+	}
+	return points
+}
+
+func TestDiagnoseConcentration(t *testing.T) {
+	low, err := DiagnoseConcentration(randomCube(1, 300, 2), 10, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	high, err := DiagnoseConcentration(randomCube(1, 300, 500), 10, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if low.Concentrated() {
+		t.Errorf("expected no warnings in 2D, got %v", low.Warnings)
+	}
+	if !high.Concentrated() {
+		t.Errorf("expected warnings in 500D, got report %+v", high)
+	}
+	if high.RelativeContrast >= low.RelativeContrast {
+		t.Errorf("expected contrast to shrink with dimension: %v >= %v", high.RelativeContrast, low.RelativeContrast)
+	}
+	if high.HubnessSkewness <= low.HubnessSkewness {
+		t.Errorf("expected hubness to grow with dimension: %v <= %v", high.HubnessSkewness, low.HubnessSkewness)
+	}
+	if high.Points != 300 || high.K != 10 || high.MaxOccurrence <= 10 {
+		t.Errorf("unexpected report %+v", high)
+	}
+}
+
+func TestDiagnoseConcentrationMatrix(t *testing.T) {
+	// Points on a line: 0, 1, 2, 10
+	matrix := [][]float64{
+		{0, 1, 2, 10},
+		{1, 0, 1, 9},
+		{2, 1, 0, 8},
+		{10, 9, 8, 0},
+	}
+	report, err := DiagnoseConcentrationMatrix(matrix, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Contrasts: (10-1)/1, (9-1)/1, (8-1)/1, (10-8)/8
+	expected := (9.0 + 8 + 7 + 0.25) / 4
+	if !almostEqual(report.RelativeContrast, expected) {
+		t.Errorf("expected contrast %v, got %v", expected, report.RelativeContrast)
+	}
+	if !almostEqual(report.MinContrast, 0.25) {
+		t.Errorf("expected min contrast 0.25, got %v", report.MinContrast)
+	}
+	// 1-NN lists: 0→1, 1→0, 2→1, 3→2
+	if report.MaxOccurrence != 2 || report.AntiHubs != 1 {
+		t.Errorf("expected max occurrence 2 and 1 anti-hub, got %d and %d", report.MaxOccurrence, report.AntiHubs)
+	}
+}
+
+func TestKOccurrence(t *testing.T) {
+	neighbors := [][]int{{1, 2}, {0, 2}, {1, 0}, {2, 1}}
+	if got := KOccurrence(neighbors, 4); !reflect.DeepEqual(got, []int{2, 3, 3, 0}) {
+		t.Errorf("expected [2 3 3 0], got %v", got)
+	}
+}
+
+func TestDiagnoseConcentrationErrors(t *testing.T) {
+	valid := [][]float64{{0, 1, 2}, {1, 0, 1}, {2, 1, 0}}
+
+	if _, err := DiagnoseConcentration[float64](nil, 1, Euclidean[float64]); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := DiagnoseConcentrationMatrix(valid, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := DiagnoseConcentrationMatrix(valid, 3); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := DiagnoseConcentrationMatrix([][]float64{{0, 1}, {1, 0}}, 1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := DiagnoseConcentrationMatrix([][]float64{{0, 1}, {1}}, 1); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	duplicates := [][]float64{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}
+	if _, err := DiagnoseConcentrationMatrix(duplicates, 1); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package distance
+
+import "testing"
+
+func TestConfusableSkeleton(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"paypal", "paypal"},
+		{"pаypаl", "paypal"}, // Cyrillic а
+		{"ΡΑΥΡΑL", "PAYPAL"}, // Greek capitals
+		{"G00GLE", "GOOGLE"},
+		{"paypa1", "paypal"},
+		{"ａｐｐｌｅ", "apple"}, // Fullwidth
+		{"modern", "rnoclern"},
+		{"ԁ", "cl"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := ConfusableSkeleton(tt.input); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestConfusable(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"apple.com", "аррӏе.com", true},
+		{"rnicrosoft", "microsoft", true},
+		{"vvikipedia", "wikipedia", true},
+		{"apple", "apple", false},
+		{"apple", "appel", false},
+		{"Paypal", "paypal", false}, // Case-sensitive like TR39
+	}
+
+	for _, tt := range tests {
+		if got := Confusable(tt.a, tt.b); got != tt.expected {
+			t.Errorf("%s/%s: expected %v, got %v", tt.a, tt.b, tt.expected, got)
+		}
+	}
+}
+
+func TestConfusableDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"pаypal", "paypal", 0},
+		{"rnicrosoft", "microsoft", 0},
+		{"paypa1-secure", "paypal", 7},
+		{"g00gle", "google", 2}, // 0 looks like O, not o
+		{"amazon", "amaz0n", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			result, err := ConfusableDistance(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+
+	// Plain Levenshtein counts every homoglyph as an edit
+	if d, _ := LevenshteinRunes("pаypаl", "paypal"); d != 2 {
+		t.Errorf("expected 2, got %d", d)
+	}
+}
@@ -0,0 +1,228 @@
+package distance
+
+import "math"
+
+// ConsensusMethod selects how each view's distances are put on a common
+// scale before ConsensusMatrix averages them.
+type ConsensusMethod int
+
+const (
+	// ConsensusZScore divides each view's off-diagonal distances by their
+	// standard deviation, shifted so the closest pair is 0: z-scores moved to
+	// be non-negative, so the result is still a distance matrix.
+	ConsensusZScore ConsensusMethod = iota
+	// ConsensusRank replaces each view's distances by their ranks scaled to
+	// [0, 1] (ties share their mean rank), so only the ordering matters.
+	ConsensusRank
+	// ConsensusMinMax rescales each view's distances to [0, 1].
+	ConsensusMinMax
+)
+
+// ConsensusMatrix combines symmetric distance matrices computed over the same
+// items by different metrics or feature views into one consensus matrix: each
+// matrix is normalized with method, then the results are averaged with the
+// given weights (nil for equal weights). Weights must be non-negative with a
+// positive sum. The diagonal of the result is 0.
+// Time: O(v·n² log n) for ranks, O(v·n²) otherwise, Space: O(n²)
+func ConsensusMatrix(matrices [][][]float64, method ConsensusMethod, weights []float64) ([][]float64, error) {
+	if err := validateViews(matrices); err != nil {
+		return nil, err
+	}
+	if weights == nil {
+		weights = make([]float64, len(matrices))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	if len(weights) != len(matrices) {
+		return nil, ErrDimensionMismatch
+	}
+	var total float64
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) {
+			return nil, ErrInvalidParameter
+		}
+		total += w
+	}
+	if total == 0 || math.IsInf(total, 0) {
+		return nil, ErrInvalidParameter
+	}
+
+	n := len(matrices[0])
+	result := make([][]float64, n)
+	for i := range result {
+		result[i] = make([]float64, n)
+	}
+	for v, m := range matrices {
+		if weights[v] == 0 {
+			continue
+		}
+		normalized, err := normalizeView(m, method)
+		if err != nil {
+			return nil, err
+		}
+		w := weights[v] / total
+		for i := range result {
+			for j := range result[i] {
+				if i != j {
+					result[i][j] += w * normalized[i][j]
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// LearnConsensusWeights derives ConsensusMatrix weights from item labels.
+// Each view is z-scored and scored by how well it separates classes: the gap
+// between its mean between-class and mean within-class distance, in units of
+// the pooled standard deviation. Views that do not separate the classes get
+// weight 0; the rest are weighted in proportion to their score and sum to 1.
+// Views that separate the classes perfectly (no spread at all) share the
+// whole weight.
+// Returns ErrInvalidParameter if labels contain a single class or only
+// singletons, or if no view separates the classes.
+// Time: O(v·n²), Space: O(n²)
+func LearnConsensusWeights(matrices [][][]float64, labels []string) ([]float64, error) {
+	if err := validateViews(matrices); err != nil {
+		return nil, err
+	}
+	if len(labels) != len(matrices[0]) {
+		return nil, ErrDimensionMismatch
+	}
+
+	weights := make([]float64, len(matrices))
+	perfect := make([]bool, len(matrices))
+	var total float64
+	var perfectCount int
+	for v, m := range matrices {
+		z, err := normalizeView(m, ConsensusZScore)
+		if err != nil {
+			return nil, err
+		}
+		var within, between []float64
+		for i := range z {
+			for j := i + 1; j < len(z); j++ {
+				if labels[i] == labels[j] {
+					within = append(within, z[i][j])
+				} else {
+					between = append(between, z[i][j])
+				}
+			}
+		}
+		if len(within) == 0 || len(between) == 0 {
+			return nil, ErrInvalidParameter
+		}
+
+		muW, muB := mean(within), mean(between)
+		var ss float64
+		for _, d := range within {
+			ss += (d - muW) * (d - muW)
+		}
+		for _, d := range between {
+			ss += (d - muB) * (d - muB)
+		}
+		pooled := math.Sqrt(ss / float64(len(within)+len(between)))
+		switch {
+		case pooled > 0:
+			weights[v] = math.Max(0, (muB-muW)/pooled)
+			total += weights[v]
+		case muB > muW:
+			perfect[v] = true // Classes separated without any spread
+			perfectCount++
+		}
+	}
+
+	// Perfectly separating views have unbounded score and share all the weight
+	for v := range weights {
+		switch {
+		case perfectCount > 0 && perfect[v]:
+			weights[v] = 1 / float64(perfectCount)
+		case perfectCount > 0:
+			weights[v] = 0
+		case total > 0:
+			weights[v] /= total
+		default:
+			return nil, ErrInvalidParameter
+		}
+	}
+	return weights, nil
+}
+
+// validateViews checks for at least one matrix, all square distance
+// matrices of the same size
+func validateViews(matrices [][][]float64) error {
+	if len(matrices) == 0 {
+		return ErrEmptyInput
+	}
+	for _, m := range matrices {
+		if err := validateSquare(m); err != nil {
+			return err
+		}
+		if len(m) != len(matrices[0]) {
+			return ErrDimensionMismatch
+		}
+	}
+	return nil
+}
+
+// normalizeView returns a copy of m with off-diagonal entries rescaled by method
+func normalizeView(m [][]float64, method ConsensusMethod) ([][]float64, error) {
+	n := len(m)
+	values := make([]float64, 0, n*(n-1)/2)
+	for i := range m {
+		for j := i + 1; j < n; j++ {
+			values = append(values, m[i][j])
+		}
+	}
+
+	var scale func(d float64) float64
+	switch method {
+	case ConsensusZScore:
+		mu, lo := mean(values), math.Inf(1)
+		var ss float64
+		for _, d := range values {
+			ss += (d - mu) * (d - mu)
+			lo = math.Min(lo, d)
+		}
+		sd := math.Sqrt(ss / float64(max(len(values), 1)))
+		scale = func(d float64) float64 {
+			if sd == 0 {
+				return 0
+			}
+			return (d - lo) / sd
+		}
+	case ConsensusRank:
+		ranks := computeRanks(values)
+		span := float64(max(len(values)-1, 1))
+		byValue := make(map[float64]float64, len(values))
+		for k, d := range values {
+			byValue[d] = (ranks[k] - 1) / span
+		}
+		scale = func(d float64) float64 { return byValue[d] }
+	case ConsensusMinMax:
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, d := range values {
+			lo, hi = math.Min(lo, d), math.Max(hi, d)
+		}
+		scale = func(d float64) float64 {
+			if hi <= lo {
+				return 0
+			}
+			return (d - lo) / (hi - lo)
+		}
+	default:
+		return nil, ErrInvalidParameter
+	}
+
+	out := make([][]float64, n)
+	for i := range out {
+		out[i] = make([]float64, n)
+		for j := range out[i] {
+			if i != j {
+				out[i][j] = scale(m[i][j])
+			}
+		}
+	}
+	return out, nil
+}
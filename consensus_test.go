@@ -0,0 +1,139 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConsensusMatrix(t *testing.T) {
+	// Same ordering of pairs, wildly different scales
+	a := [][]float64{
+		{0, 1, 2},
+		{1, 0, 3},
+		{2, 3, 0},
+	}
+	b := [][]float64{
+		{0, 100, 400},
+		{100, 0, 900},
+		{400, 900, 0},
+	}
+
+	tests := []struct {
+		name     string
+		method   ConsensusMethod
+		expected [3]float64 // (0,1), (0,2), (1,2)
+	}{
+		{"rank", ConsensusRank, [3]float64{0, 0.5, 1}},
+		{"minmax", ConsensusMinMax, [3]float64{0, 0.4375, 1}},      // a: 0, .5, 1; b: 0, .375, 1
+		{"zscore", ConsensusZScore, [3]float64{0, 1.0669, 2.4369}}, // a: 0, 1.2247, 2.4495; b: 0, 0.9091, 2.4244
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ConsensusMatrix([][][]float64{a, b}, tt.method, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := [3]float64{result[0][1], result[0][2], result[1][2]}
+			for k := range got {
+				if math.Abs(got[k]-tt.expected[k]) > 1e-4 {
+					t.Errorf("expected %v, got %v", tt.expected, got)
+					break
+				}
+			}
+			if result[1][0] != result[0][1] || result[2][2] != 0 {
+				t.Errorf("expected symmetric matrix with zero diagonal, got %v", result)
+			}
+			// Every method yields a matrix the embedding and tree builders accept
+			if err := validateDistanceMatrix(result); err != nil {
+				t.Errorf("expected a valid distance matrix, got %v", err)
+			}
+		})
+	}
+
+	// Weights select a single view
+	result, err := ConsensusMatrix([][][]float64{a, b}, ConsensusMinMax, []float64{0, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(result[0][2], 0.375) {
+		t.Errorf("expected 0.375, got %v", result[0][2])
+	}
+}
+
+func TestLearnConsensusWeights(t *testing.T) {
+	labels := []string{"x", "x", "x", "y", "y", "y"}
+	// Informative view: classes on two separate lines
+	good := layoutMatrix([][]float64{{0, 0}, {0, 1}, {1, 0}, {10, 10}, {10, 11}, {11, 10}})
+	// Noise view: points interleaved across classes
+	noise := layoutMatrix([][]float64{{0}, {3}, {1}, {2}, {5}, {4}})
+
+	weights, err := LearnConsensusWeights([][][]float64{noise, good}, labels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(weights[0]+weights[1], 1) || weights[1] <= weights[0] {
+		t.Errorf("expected the informative view to dominate, got %v", weights)
+	}
+
+	// The learned consensus keeps every item's nearest neighbor in its class
+	consensus, err := ConsensusMatrix([][][]float64{noise, good}, ConsensusZScore, weights)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, nb := range matrixNeighbors(consensus, 1) {
+		if labels[nb[0]] != labels[i] {
+			t.Errorf("item %d: nearest neighbor %d has a different label", i, nb[0])
+		}
+	}
+
+	// A view with zero spread inside and between classes takes all the weight
+	perfect := [][]float64{
+		{0, 1, 5, 5},
+		{1, 0, 5, 5},
+		{5, 5, 0, 1},
+		{5, 5, 1, 0},
+	}
+	weights, err = LearnConsensusWeights([][][]float64{perfect, layoutMatrix([][]float64{{0}, {5}, {1}, {4}})}, []string{"a", "a", "b", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if weights[0] != 1 || weights[1] != 0 {
+		t.Errorf("expected [1 0], got %v", weights)
+	}
+}
+
+func TestConsensusErrors(t *testing.T) {
+	m := [][]float64{{0, 1}, {1, 0}}
+	big := [][]float64{{0, 1, 1}, {1, 0, 1}, {1, 1, 0}}
+
+	if _, err := ConsensusMatrix(nil, ConsensusRank, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := ConsensusMatrix([][][]float64{m, big}, ConsensusRank, nil); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := ConsensusMatrix([][][]float64{m}, ConsensusRank, []float64{1, 1}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := ConsensusMatrix([][][]float64{m}, ConsensusRank, []float64{-1}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := ConsensusMatrix([][][]float64{m}, ConsensusRank, []float64{0}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := ConsensusMatrix([][][]float64{m}, ConsensusMethod(99), nil); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := LearnConsensusWeights([][][]float64{big}, []string{"a", "b"}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := LearnConsensusWeights([][][]float64{big}, []string{"a", "a", "a"}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	// Between-class pairs are no farther than within-class pairs
+	inverted := [][]float64{{0, 5, 1}, {5, 0, 1}, {1, 1, 0}}
+	if _, err := LearnConsensusWeights([][][]float64{inverted}, []string{"a", "a", "b"}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package distance
+
+import "math"
+
+// ConstraintKind distinguishes the two kinds of pairwise clustering constraint.
+type ConstraintKind int
+
+const (
+	// MustLink requires two items to end up in the same cluster.
+	MustLink ConstraintKind = iota
+	// CannotLink requires two items to end up in different clusters.
+	CannotLink
+)
+
+// String returns the lowercase name of the kind.
+func (k ConstraintKind) String() string {
+	switch k {
+	case MustLink:
+		return "must-link"
+	case CannotLink:
+		return "cannot-link"
+	}
+	return "unknown"
+}
+
+// PairConstraint is a must-link or cannot-link constraint between the items
+// at indices I and J, as used by semi-supervised clustering (COP-k-means,
+// constrained agglomerative clustering).
+type PairConstraint struct {
+	I, J int
+	Kind ConstraintKind
+}
+
+// ConstrainMatrix adjusts a distance matrix to a set of constraints, after
+// Klein, Kamvar and Manning (2002): must-linked pairs get distance 0 and the
+// change is propagated by shortest paths, so points near one member of a
+// must-linked pair are also pulled towards the other; then cannot-linked
+// pairs, extended to everything must-linked to either side, are pushed to
+// the largest distance in the matrix. Feeding the result to
+// AgglomerativeCluster with complete linkage keeps cannot-linked items apart
+// until the final merges. Before cannot-links are applied the result is a
+// metric; afterwards the triangle inequality may no longer hold.
+// Returns ErrInvalidParameter for an out-of-range index, an unknown kind, a
+// cannot-link of an item with itself, or a cannot-link between items that
+// are transitively must-linked.
+// Time: O(n³), Space: O(n²)
+func ConstrainMatrix(matrix [][]float64, constraints []PairConstraint) ([][]float64, error) {
+	if err := validateSquare(matrix); err != nil {
+		return nil, err
+	}
+	n := len(matrix)
+	component, err := mustLinkComponents(n, constraints)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]float64, n)
+	far := 0.0
+	for i, row := range matrix {
+		result[i] = append([]float64(nil), row...)
+		for _, d := range row {
+			far = math.Max(far, d)
+		}
+	}
+	mustLinked := false
+	for _, c := range constraints {
+		if c.Kind == MustLink && c.I != c.J {
+			result[c.I][c.J], result[c.J][c.I] = 0, 0
+			mustLinked = true
+		}
+	}
+
+	// Floyd-Warshall restores the shortest-path metric around the shortcuts
+	if mustLinked {
+		for k := 0; k < n; k++ {
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if d := result[i][k] + result[k][j]; d < result[i][j] {
+						result[i][j] = d
+					}
+				}
+			}
+		}
+	}
+
+	// Cannot-links apply to whole must-link components
+	members := make(map[int][]int)
+	for i, c := range component {
+		members[c] = append(members[c], i)
+	}
+	for _, c := range constraints {
+		if c.Kind != CannotLink {
+			continue
+		}
+		for _, i := range members[component[c.I]] {
+			for _, j := range members[component[c.J]] {
+				result[i][j], result[j][i] = far, far
+			}
+		}
+	}
+	return result, nil
+}
+
+// LearnConstraintWeights learns per-dimension weights for WeightedEuclidean
+// from constraints, a diagonal form of constraint-driven metric learning:
+// each dimension is weighted by the ratio of its mean squared difference
+// over cannot-linked pairs to that over must-linked pairs, so dimensions
+// that keep must-linked items together and cannot-linked items apart count
+// more. Weights are normalized to mean 1; a dimension on which must-linked
+// pairs never differ gets the largest finite weight, or 1 if all do.
+// Returns ErrEmptyInput for no vectors, ErrDimensionMismatch for ragged
+// vectors and ErrInvalidParameter for invalid constraints or unless there is
+// at least one must-link and one cannot-link between distinct items.
+// Time: O(cd + nd) for c constraints, Space: O(d)
+func LearnConstraintWeights[T Number](vectors [][]T, constraints []PairConstraint) ([]float64, error) {
+	if len(vectors) == 0 {
+		return nil, ErrEmptyInput
+	}
+	d := len(vectors[0])
+	for _, v := range vectors {
+		if len(v) != d {
+			return nil, ErrDimensionMismatch
+		}
+	}
+	if _, err := mustLinkComponents(len(vectors), constraints); err != nil {
+		return nil, err
+	}
+
+	must := make([]float64, d)
+	cannot := make([]float64, d)
+	var nMust, nCannot int
+	for _, c := range constraints {
+		if c.I == c.J {
+			continue
+		}
+		acc := must
+		if c.Kind == CannotLink {
+			acc = cannot
+			nCannot++
+		} else {
+			nMust++
+		}
+		for k := range acc {
+			diff := float64(vectors[c.I][k]) - float64(vectors[c.J][k])
+			acc[k] += diff * diff
+		}
+	}
+	if nMust == 0 || nCannot == 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	weights := make([]float64, d)
+	largest := 0.0
+	for k := range weights {
+		if must[k] > 0 {
+			weights[k] = (cannot[k] / float64(nCannot)) / (must[k] / float64(nMust))
+			largest = math.Max(largest, weights[k])
+		}
+	}
+	if largest == 0 {
+		largest = 1
+	}
+	var sum float64
+	for k := range weights {
+		if must[k] == 0 {
+			weights[k] = largest
+		}
+		sum += weights[k]
+	}
+	if sum == 0 {
+		for k := range weights {
+			weights[k] = 1
+		}
+		return weights, nil
+	}
+	for k := range weights {
+		weights[k] *= float64(d) / sum
+	}
+	return weights, nil
+}
+
+// ConstraintViolations counts the constraints a clustering breaks: must-linked
+// items with different labels and cannot-linked items with the same label.
+// Returns ErrInvalidParameter for an out-of-range index.
+// Time: O(c), Space: O(1)
+func ConstraintViolations(labels []int, constraints []PairConstraint) (int, error) {
+	violations := 0
+	for _, c := range constraints {
+		if c.I < 0 || c.I >= len(labels) || c.J < 0 || c.J >= len(labels) {
+			return 0, ErrInvalidParameter
+		}
+		same := labels[c.I] == labels[c.J]
+		if (c.Kind == MustLink && !same) || (c.Kind == CannotLink && same) {
+			violations++
+		}
+	}
+	return violations, nil
+}
+
+// mustLinkComponents validates constraints over n items and returns the
+// must-link component of each item
+func mustLinkComponents(n int, constraints []PairConstraint) ([]int, error) {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for _, c := range constraints {
+		if c.I < 0 || c.I >= n || c.J < 0 || c.J >= n ||
+			c.Kind < MustLink || c.Kind > CannotLink {
+			return nil, ErrInvalidParameter
+		}
+		if c.Kind == MustLink {
+			parent[find(c.I)] = find(c.J)
+		}
+	}
+	for _, c := range constraints {
+		if c.Kind == CannotLink && find(c.I) == find(c.J) {
+			return nil, ErrInvalidParameter
+		}
+	}
+
+	component := make([]int, n)
+	for i := range component {
+		component[i] = find(i)
+	}
+	return component, nil
+}
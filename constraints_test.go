@@ -0,0 +1,173 @@
+package distance
+
+import "testing"
+
+func TestConstrainMatrix(t *testing.T) {
+	// Four points on a line at 0, 1, 5, 6
+	points := [][]float64{{0}, {1}, {5}, {6}}
+	matrix, err := BatchCompute(points, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := ConstrainMatrix(matrix, []PairConstraint{
+		{I: 1, J: 2, Kind: MustLink},
+		{I: 0, J: 1, Kind: CannotLink},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := [][]float64{
+		{0, 6, 6, 2},
+		{6, 0, 0, 1},
+		{6, 0, 0, 1},
+		{2, 1, 1, 0},
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if !almostEqual(result[i][j], expected[i][j]) {
+				t.Errorf("[%d][%d]: expected %v, got %v", i, j, expected[i][j], result[i][j])
+			}
+		}
+	}
+	if matrix[1][2] != 4 {
+		t.Error("input matrix was modified")
+	}
+}
+
+func TestConstrainMatrixClustering(t *testing.T) {
+	// Without constraints {0,1} and {2,3} form the clusters; constraints
+	// regroup them as {0,2} and {1,3}
+	points := [][]float64{{0}, {1}, {10}, {11}}
+	matrix, _ := BatchCompute(points, Euclidean[float64])
+	constraints := []PairConstraint{
+		{I: 0, J: 2, Kind: MustLink},
+		{I: 1, J: 3, Kind: MustLink},
+		{I: 0, J: 1, Kind: CannotLink},
+	}
+	constrained, err := ConstrainMatrix(matrix, constraints)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merges, err := AgglomerativeCluster(constrained, CompleteLinkage)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	labels := CutTree(merges, 0.5)
+	if v, _ := ConstraintViolations(labels, constraints); v != 0 {
+		t.Errorf("expected no violations, got %d with labels %v", v, labels)
+	}
+}
+
+func TestConstrainMatrixErrors(t *testing.T) {
+	matrix := [][]float64{{0, 1, 2}, {1, 0, 1}, {2, 1, 0}}
+	tests := []struct {
+		name        string
+		constraints []PairConstraint
+	}{
+		{"out of range", []PairConstraint{{I: 0, J: 3, Kind: MustLink}}},
+		{"negative", []PairConstraint{{I: -1, J: 0, Kind: CannotLink}}},
+		{"unknown kind", []PairConstraint{{I: 0, J: 1, Kind: ConstraintKind(5)}}},
+		{"self cannot-link", []PairConstraint{{I: 1, J: 1, Kind: CannotLink}}},
+		{"contradiction", []PairConstraint{
+			{I: 0, J: 1, Kind: MustLink},
+			{I: 1, J: 2, Kind: MustLink},
+			{I: 2, J: 0, Kind: CannotLink},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ConstrainMatrix(matrix, tt.constraints); err != ErrInvalidParameter {
+				t.Errorf("expected ErrInvalidParameter, got %v", err)
+			}
+		})
+	}
+	if _, err := ConstrainMatrix(nil, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestLearnConstraintWeights(t *testing.T) {
+	// Dimension 0 carries the class, dimension 1 is noise
+	vectors := [][]float64{{0, 0}, {0.1, 5}, {3, 0.2}, {3.1, 4.8}}
+	constraints := []PairConstraint{
+		{I: 0, J: 1, Kind: MustLink},
+		{I: 2, J: 3, Kind: MustLink},
+		{I: 0, J: 2, Kind: CannotLink},
+		{I: 1, J: 3, Kind: CannotLink},
+	}
+	weights, err := LearnConstraintWeights(vectors, constraints)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(weights) != 2 || weights[0] <= 10*weights[1] || !almostEqual(weights[0]+weights[1], 2) {
+		t.Fatalf("expected dimension 0 to dominate with mean weight 1, got %v", weights)
+	}
+
+	// Under the learned metric must-linked pairs are closer than cannot-linked
+	ml, _ := WeightedEuclidean(vectors[0], vectors[1], weights)
+	cl, _ := WeightedEuclidean(vectors[0], vectors[2], weights)
+	if ml >= cl {
+		t.Errorf("expected must-link distance %v < cannot-link distance %v", ml, cl)
+	}
+
+	// A dimension where must-linked pairs agree exactly gets the top weight
+	weights, err = LearnConstraintWeights([][]int{{0, 0}, {0, 1}, {5, 9}}, []PairConstraint{
+		{I: 0, J: 1, Kind: MustLink},
+		{I: 0, J: 2, Kind: CannotLink},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if weights[0] < weights[1] {
+		t.Errorf("expected dimension 0 to weigh at least as much, got %v", weights)
+	}
+}
+
+func TestLearnConstraintWeightsErrors(t *testing.T) {
+	vectors := [][]float64{{0}, {1}, {2}}
+	if _, err := LearnConstraintWeights([][]float64{}, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := LearnConstraintWeights([][]float64{{0}, {1, 2}}, nil); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := LearnConstraintWeights(vectors, []PairConstraint{{I: 0, J: 1, Kind: MustLink}}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter without cannot-links, got %v", err)
+	}
+	if _, err := LearnConstraintWeights(vectors, []PairConstraint{{I: 0, J: 9, Kind: CannotLink}}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestConstraintViolations(t *testing.T) {
+	constraints := []PairConstraint{
+		{I: 0, J: 1, Kind: MustLink},
+		{I: 0, J: 2, Kind: CannotLink},
+		{I: 1, J: 2, Kind: CannotLink},
+	}
+	tests := []struct {
+		labels   []int
+		expected int
+	}{
+		{[]int{0, 0, 1}, 0},
+		{[]int{0, 1, 1}, 2},
+		{[]int{0, 0, 0}, 2},
+	}
+	for _, tt := range tests {
+		got, err := ConstraintViolations(tt.labels, constraints)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tt.expected {
+			t.Errorf("labels %v: expected %d, got %d", tt.labels, tt.expected, got)
+		}
+	}
+	if _, err := ConstraintViolations([]int{0}, constraints); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if MustLink.String() != "must-link" || ConstraintKind(9).String() != "unknown" {
+		t.Error("unexpected ConstraintKind names")
+	}
+}
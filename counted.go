@@ -0,0 +1,74 @@
+package distance
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// EvalCounter records how many times a wrapped function was called and the
+// cumulative time spent inside it. It is safe for concurrent use, so counted
+// functions can be passed to BatchComputeParallel and other parallel helpers.
+type EvalCounter struct {
+	calls atomic.Int64
+	nanos atomic.Int64
+}
+
+// Calls returns the number of completed calls.
+func (c *EvalCounter) Calls() int64 {
+	return c.calls.Load()
+}
+
+// Elapsed returns the cumulative wall-clock time spent in the wrapped function.
+func (c *EvalCounter) Elapsed() time.Duration {
+	return time.Duration(c.nanos.Load())
+}
+
+// Mean returns the average duration of a call, or 0 before the first call.
+func (c *EvalCounter) Mean() time.Duration {
+	calls := c.calls.Load()
+	if calls == 0 {
+		return 0
+	}
+	return time.Duration(c.nanos.Load() / calls)
+}
+
+// Reset zeroes the counter, e.g. between runs of different algorithms.
+func (c *EvalCounter) Reset() {
+	c.calls.Store(0)
+	c.nanos.Store(0)
+}
+
+// record adds one call that started at start
+func (c *EvalCounter) record(start time.Time) {
+	c.nanos.Add(int64(time.Since(start)))
+	c.calls.Add(1)
+}
+
+// WrapCounted wraps an objective so every evaluation is counted and timed,
+// for comparing how many function evaluations optimizers need.
+func WrapCounted(f OptimizationFunc) (OptimizationFunc, *EvalCounter) {
+	c := &EvalCounter{}
+	return func(x []float64) float64 {
+		defer c.record(time.Now())
+		return f(x)
+	}, c
+}
+
+// WrapCountedGradient wraps a gradient so every evaluation is counted and timed.
+func WrapCountedGradient(grad GradientFunc) (GradientFunc, *EvalCounter) {
+	c := &EvalCounter{}
+	return func(x []float64) []float64 {
+		defer c.record(time.Now())
+		return grad(x)
+	}, c
+}
+
+// WrapCountedDistance wraps a distance function so every evaluation is
+// counted and timed, including calls that return an error.
+func WrapCountedDistance[T Number](fn DistanceFunc[T]) (DistanceFunc[T], *EvalCounter) {
+	c := &EvalCounter{}
+	return func(a, b []T) (float64, error) {
+		defer c.record(time.Now())
+		return fn(a, b)
+	}, c
+}
@@ -0,0 +1,77 @@
+package distance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWrapCounted(t *testing.T) {
+	f, counter := WrapCounted(quadratic)
+	grad, gradCounter := WrapCountedGradient(quadraticGrad)
+
+	if _, err := GradientDescent(f, grad, []float64{1, 1}, 0.1, 25); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// GradientDescent only evaluates the gradient
+	if counter.Calls() != 0 {
+		t.Errorf("expected 0 objective calls, got %d", counter.Calls())
+	}
+	if gradCounter.Calls() != 25 {
+		t.Errorf("expected 25 gradient calls, got %d", gradCounter.Calls())
+	}
+
+	if _, err := NelderMead(f, []float64{1, 1}, 10, 1, 2, 0.5, 0.5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counter.Calls() < 13 { // 3 initial vertices plus at least one evaluation per iteration
+		t.Errorf("expected at least 13 objective calls, got %d", counter.Calls())
+	}
+
+	counter.Reset()
+	if counter.Calls() != 0 || counter.Elapsed() != 0 || counter.Mean() != 0 {
+		t.Errorf("expected zeroed counter, got %d calls, %v", counter.Calls(), counter.Elapsed())
+	}
+}
+
+func TestWrapCountedDistanceTiming(t *testing.T) {
+	slow := func(a, b []float64) (float64, error) {
+		time.Sleep(time.Millisecond)
+		return Euclidean(a, b)
+	}
+	fn, counter := WrapCountedDistance(slow)
+
+	for range 3 {
+		if _, err := fn([]float64{0, 0}, []float64{3, 4}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := fn([]float64{0}, []float64{3, 4}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+
+	if counter.Calls() != 4 {
+		t.Errorf("expected 4 calls, got %d", counter.Calls())
+	}
+	if counter.Elapsed() < 4*time.Millisecond {
+		t.Errorf("expected at least 4ms elapsed, got %v", counter.Elapsed())
+	}
+	if counter.Mean() < time.Millisecond {
+		t.Errorf("expected mean of at least 1ms, got %v", counter.Mean())
+	}
+}
+
+func TestWrapCountedDistanceConcurrent(t *testing.T) {
+	vectors := make([][]float64, 20)
+	for i := range vectors {
+		vectors[i] = []float64{float64(i), float64(i * i)}
+	}
+	fn, counter := WrapCountedDistance(Euclidean[float64])
+
+	if _, err := BatchComputeParallel(vectors, fn, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// BatchComputeParallel evaluates the upper triangle including the diagonal
+	if want := int64(20 * 21 / 2); counter.Calls() != want {
+		t.Errorf("expected %d calls, got %d", want, counter.Calls())
+	}
+}
@@ -0,0 +1,160 @@
+package distance
+
+import "math"
+
+// CoverageReport quantifies how well a selected subset of points (test
+// cases, a curated training subset, landmarks) represents the full set under
+// a metric, and how diverse the subset is. Coverage asks whether every point
+// has a selected point nearby; diversity asks whether the selected points
+// avoid redundancy.
+type CoverageReport struct {
+	Points   int `json:"points"`
+	Selected int `json:"selected"`
+	// CoverageRadius is the largest distance from any point to its nearest
+	// selected point (the k-center objective); every point lies within this
+	// radius of the subset.
+	CoverageRadius float64 `json:"coverage_radius"`
+	// MeanCoverage is the mean distance from each point to its nearest
+	// selected point (the k-median objective divided by n).
+	MeanCoverage float64 `json:"mean_coverage"`
+	// Dispersion is the smallest distance between two selected points (the
+	// max-min diversity objective); 0 with fewer than two selected.
+	Dispersion float64 `json:"dispersion"`
+	// MeanPairwise is the mean distance between selected points (the max-sum
+	// diversity objective); 0 with fewer than two selected.
+	MeanPairwise float64 `json:"mean_pairwise"`
+	// SubsetDiameter and Diameter are the largest pairwise distances within
+	// the subset and within the full set; their ratio shows how much of the
+	// spread the subset spans.
+	SubsetDiameter float64 `json:"subset_diameter"`
+	Diameter       float64 `json:"diameter"`
+	// RelativeRadius is CoverageRadius / Diameter, in [0, 1]: 0 when the
+	// subset covers every point exactly, 1 for a single far-off point. 0 if
+	// all points coincide.
+	RelativeRadius float64 `json:"relative_radius"`
+}
+
+// Coverage measures how well the points at indices selected cover and
+// diversify points under distFn.
+// Returns ErrEmptyInput for no points or an empty selection and
+// ErrInvalidParameter for an out-of-range or repeated index.
+// Time: O(n²d), Space: O(n)
+func Coverage[T Number](points [][]T, selected []int, distFn DistanceFunc[T]) (*CoverageReport, error) {
+	if len(points) == 0 {
+		return nil, ErrEmptyInput
+	}
+	return coverageReport(len(points), selected, func(i, j int) (float64, error) {
+		return distFn(points[i], points[j])
+	})
+}
+
+// CoverageMatrix is Coverage for a precomputed distance matrix.
+// Time: O(n²), Space: O(n)
+func CoverageMatrix(matrix [][]float64, selected []int) (*CoverageReport, error) {
+	if err := validateSquare(matrix); err != nil {
+		return nil, err
+	}
+	return coverageReport(len(matrix), selected, func(i, j int) (float64, error) {
+		return matrix[i][j], nil
+	})
+}
+
+// FarthestPointSelection greedily selects k diverse points (Gonzalez's
+// farthest-first traversal): starting from points[0], it repeatedly adds the
+// point farthest from those already chosen. The result's coverage radius is
+// within a factor of 2 of the best possible for k points, and so is its
+// dispersion, so it is a strong default for picking a representative test
+// subset. Indices are returned in selection order, so any prefix is itself a
+// farthest-first selection.
+// Returns ErrEmptyInput for no points and ErrInvalidParameter unless
+// 0 < k <= len(points).
+// Time: O(nkd), Space: O(n)
+func FarthestPointSelection[T Number](points [][]T, k int, distFn DistanceFunc[T]) ([]int, error) {
+	if len(points) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if k <= 0 || k > len(points) {
+		return nil, ErrInvalidParameter
+	}
+
+	nearest := make([]float64, len(points))
+	for i := range nearest {
+		nearest[i] = math.Inf(1)
+	}
+	chosen := make([]bool, len(points))
+	selected := make([]int, 0, k)
+	next := 0
+	for len(selected) < k {
+		current := next
+		chosen[current] = true
+		selected = append(selected, current)
+		farthest := -1.0
+		for i, p := range points {
+			d, err := distFn(p, points[current])
+			if err != nil {
+				return nil, err
+			}
+			nearest[i] = math.Min(nearest[i], d)
+			if !chosen[i] && nearest[i] > farthest {
+				farthest = nearest[i]
+				next = i
+			}
+		}
+	}
+	return selected, nil
+}
+
+// coverageReport computes a CoverageReport from a pairwise distance oracle
+func coverageReport(n int, selected []int, dist func(i, j int) (float64, error)) (*CoverageReport, error) {
+	if len(selected) == 0 {
+		return nil, ErrEmptyInput
+	}
+	chosen := make([]bool, n)
+	for _, s := range selected {
+		if s < 0 || s >= n || chosen[s] {
+			return nil, ErrInvalidParameter
+		}
+		chosen[s] = true
+	}
+
+	r := &CoverageReport{Points: n, Selected: len(selected), Dispersion: math.Inf(1)}
+	var coverageSum, pairSum float64
+	var pairs int
+	for i := 0; i < n; i++ {
+		nearest := math.Inf(1)
+		for _, s := range selected {
+			d, err := dist(i, s)
+			if err != nil {
+				return nil, err
+			}
+			nearest = math.Min(nearest, d)
+		}
+		r.CoverageRadius = math.Max(r.CoverageRadius, nearest)
+		coverageSum += nearest
+
+		for j := i + 1; j < n; j++ {
+			d, err := dist(i, j)
+			if err != nil {
+				return nil, err
+			}
+			r.Diameter = math.Max(r.Diameter, d)
+			if chosen[i] && chosen[j] {
+				r.Dispersion = math.Min(r.Dispersion, d)
+				r.SubsetDiameter = math.Max(r.SubsetDiameter, d)
+				pairSum += d
+				pairs++
+			}
+		}
+	}
+
+	r.MeanCoverage = coverageSum / float64(n)
+	if pairs == 0 {
+		r.Dispersion = 0
+	} else {
+		r.MeanPairwise = pairSum / float64(pairs)
+	}
+	if r.Diameter > 0 {
+		r.RelativeRadius = math.Min(1, r.CoverageRadius/r.Diameter)
+	}
+	return r, nil
+}
@@ -0,0 +1,168 @@
+package distance
+
+import "testing"
+
+func TestCoverage(t *testing.T) {
+	// Points on a line at 0, 1, 2, 3, 10
+	points := [][]float64{{0}, {1}, {2}, {3}, {10}}
+
+	tests := []struct {
+		name     string
+		selected []int
+		expected CoverageReport
+	}{
+		{
+			name:     "single point",
+			selected: []int{2},
+			expected: CoverageReport{Points: 5, Selected: 1, CoverageRadius: 8, MeanCoverage: 2.4, Diameter: 10, RelativeRadius: 0.8},
+		},
+		{
+			name:     "ends",
+			selected: []int{0, 4},
+			expected: CoverageReport{Points: 5, Selected: 2, CoverageRadius: 3, MeanCoverage: 1.2, Dispersion: 10,
+				MeanPairwise: 10, SubsetDiameter: 10, Diameter: 10, RelativeRadius: 0.3},
+		},
+		{
+			name:     "everything",
+			selected: []int{4, 3, 2, 1, 0},
+			expected: CoverageReport{Points: 5, Selected: 5, Dispersion: 1, MeanPairwise: 4.4, SubsetDiameter: 10, Diameter: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Coverage(points, tt.selected, Euclidean[float64])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			e := tt.expected
+			if r.Points != e.Points || r.Selected != e.Selected ||
+				!almostEqual(r.CoverageRadius, e.CoverageRadius) || !almostEqual(r.MeanCoverage, e.MeanCoverage) ||
+				!almostEqual(r.Dispersion, e.Dispersion) || !almostEqual(r.MeanPairwise, e.MeanPairwise) ||
+				!almostEqual(r.SubsetDiameter, e.SubsetDiameter) || !almostEqual(r.Diameter, e.Diameter) ||
+				!almostEqual(r.RelativeRadius, e.RelativeRadius) {
+				t.Errorf("expected %+v, got %+v", e, *r)
+			}
+		})
+	}
+}
+
+func TestCoverageMatrix(t *testing.T) {
+	points := [][]float64{{0, 0}, {3, 4}, {6, 8}, {0, 1}}
+	matrix, err := BatchCompute(points, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fromMatrix, err := CoverageMatrix(matrix, []int{0, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fromPoints, err := Coverage(points, []int{0, 2}, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *fromMatrix != *fromPoints {
+		t.Errorf("matrix %+v and points %+v disagree", *fromMatrix, *fromPoints)
+	}
+	if !almostEqual(fromMatrix.CoverageRadius, 5) {
+		t.Errorf("expected coverage radius 5, got %v", fromMatrix.CoverageRadius)
+	}
+}
+
+func TestFarthestPointSelection(t *testing.T) {
+	points := [][]float64{{0}, {1}, {2}, {3}, {10}}
+	selected, err := FarthestPointSelection(points, 3, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 0, then 10 (farthest), then 3 (3 from its nearest selected point)
+	expected := []int{0, 4, 3}
+	for i := range expected {
+		if selected[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, selected)
+		}
+	}
+
+	all, err := FarthestPointSelection(points, len(points), Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	seen := make(map[int]bool)
+	for _, i := range all {
+		seen[i] = true
+	}
+	if len(seen) != len(points) {
+		t.Errorf("expected every point once, got %v", all)
+	}
+
+	// Duplicates are still selected once each
+	dups, err := FarthestPointSelection([][]float64{{1}, {1}, {1}}, 3, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dups[0] != 0 || dups[1] != 1 || dups[2] != 2 {
+		t.Errorf("expected [0 1 2], got %v", dups)
+	}
+}
+
+func TestFarthestPointSelectionBeatsRandom(t *testing.T) {
+	rng := testRNG(17)
+	points := make([][]float64, 300)
+	for i := range points {
+		points[i] = []float64{rng.Float64(), rng.Float64()}
+	}
+
+	const k = 10
+	greedy, err := FarthestPointSelection(points, k, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gr, err := Coverage(points, greedy, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rr, err := Coverage(points, rng.Perm(len(points))[:k], Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gr.CoverageRadius >= rr.CoverageRadius || gr.Dispersion <= rr.Dispersion {
+		t.Errorf("expected greedy selection to cover and disperse better: greedy %+v, random %+v", *gr, *rr)
+	}
+	// Farthest-first guarantees dispersion >= coverage radius of the prefix
+	if gr.Dispersion < gr.CoverageRadius {
+		t.Errorf("expected dispersion %v >= coverage radius %v", gr.Dispersion, gr.CoverageRadius)
+	}
+}
+
+func TestCoverageErrors(t *testing.T) {
+	points := [][]float64{{0}, {1}}
+	tests := []struct {
+		name     string
+		points   [][]float64
+		selected []int
+		expected error
+	}{
+		{"no points", nil, []int{0}, ErrEmptyInput},
+		{"no selection", points, nil, ErrEmptyInput},
+		{"out of range", points, []int{2}, ErrInvalidParameter},
+		{"negative", points, []int{-1}, ErrInvalidParameter},
+		{"repeated", points, []int{1, 1}, ErrInvalidParameter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Coverage(tt.points, tt.selected, Euclidean[float64]); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	if _, err := CoverageMatrix([][]float64{{0, 1}}, []int{0}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := FarthestPointSelection(points, 3, Euclidean[float64]); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := FarthestPointSelection([][]float64{}, 1, Euclidean[float64]); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package distance
+
+// Dataset bundles items (vectors, strings, ...) with stable unique IDs,
+// optional class labels and per-item metadata. APIs that accept a Dataset
+// report results by ID, so they stay valid when data is filtered or reordered.
+type Dataset[T any] struct {
+	ids      []string
+	items    []T
+	labels   []string
+	metadata []map[string]any
+	index    map[string]int
+}
+
+// NewDataset creates an empty dataset.
+func NewDataset[T any]() *Dataset[T] {
+	return &Dataset[T]{index: make(map[string]int)}
+}
+
+// Add appends item under id. IDs must be unique; label and metadata are optional.
+// Time: O(1), Space: O(1)
+func (d *Dataset[T]) Add(id string, item T, label string, metadata map[string]any) error {
+	if _, ok := d.index[id]; ok {
+		return ErrInvalidParameter
+	}
+	d.index[id] = len(d.items)
+	d.ids = append(d.ids, id)
+	d.items = append(d.items, item)
+	d.labels = append(d.labels, label)
+	d.metadata = append(d.metadata, metadata)
+	return nil
+}
+
+// Len returns the number of items.
+func (d *Dataset[T]) Len() int {
+	return len(d.items)
+}
+
+// IDs returns item IDs in dataset order. The slice must not be modified.
+func (d *Dataset[T]) IDs() []string {
+	return d.ids
+}
+
+// Items returns items in dataset order. The slice must not be modified.
+func (d *Dataset[T]) Items() []T {
+	return d.items
+}
+
+// Labels returns item labels in dataset order. The slice must not be modified.
+func (d *Dataset[T]) Labels() []string {
+	return d.labels
+}
+
+// Get returns the item, label and metadata stored under id.
+// Time: O(1), Space: O(1)
+func (d *Dataset[T]) Get(id string) (T, string, map[string]any, error) {
+	i, ok := d.index[id]
+	if !ok {
+		var zero T
+		return zero, "", nil, ErrKeyNotFound
+	}
+	return d.items[i], d.labels[i], d.metadata[i], nil
+}
+
+// Filter returns a new dataset holding the items for which keep returns true,
+// preserving IDs, labels and metadata.
+// Time: O(n), Space: O(n)
+func (d *Dataset[T]) Filter(keep func(id string, item T, label string) bool) *Dataset[T] {
+	out := NewDataset[T]()
+	for i, id := range d.ids {
+		if keep(id, d.items[i], d.labels[i]) {
+			_ = out.Add(id, d.items[i], d.labels[i], d.metadata[i])
+		}
+	}
+	return out
+}
+
+// DistanceMatrix is a square distance matrix addressed by dataset IDs.
+type DistanceMatrix struct {
+	IDs    []string
+	Values [][]float64
+	index  map[string]int
+}
+
+// At returns the distance between the items with IDs a and b.
+// Time: O(1), Space: O(1)
+func (m *DistanceMatrix) At(a, b string) (float64, error) {
+	i, ok := m.index[a]
+	if !ok {
+		return 0, ErrKeyNotFound
+	}
+	j, ok := m.index[b]
+	if !ok {
+		return 0, ErrKeyNotFound
+	}
+	return m.Values[i][j], nil
+}
+
+// BatchComputeDataset computes the distance matrix of a vector dataset.
+// Time: O(n²d), Space: O(n²)
+func BatchComputeDataset[T Number](ds *Dataset[[]T], distFn DistanceFunc[T]) (*DistanceMatrix, error) {
+	values, err := BatchCompute(ds.items, distFn)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(ds.ids))
+	for id, i := range ds.index {
+		index[id] = i
+	}
+	return &DistanceMatrix{IDs: append([]string(nil), ds.ids...), Values: values, index: index}, nil
+}
+
+// KNearestNeighborsDataset finds the k nearest other items for every item,
+// keyed by ID. Results carry each neighbor's ID, index and metadata.
+// Time: O(n²d + n² log k), Space: O(nk)
+func KNearestNeighborsDataset[T Number](ds *Dataset[[]T], k int, distFn DistanceFunc[T]) (map[string][]SearchResult, error) {
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	out := make(map[string][]SearchResult, ds.Len())
+	for i, id := range ds.ids {
+		neighbors, err := kNearest(ds.items, ds.items[i], i, k, distFn)
+		if err != nil {
+			return nil, err
+		}
+		results := make([]SearchResult, len(neighbors))
+		for p, nb := range neighbors {
+			results[p] = ds.searchResult(nb.Index, nb.Distance)
+		}
+		out[id] = results
+	}
+	return out, nil
+}
+
+// NewBruteForceIndexFromDataset builds a BruteForceIndex over a vector dataset.
+// Non-empty labels are exposed in result metadata under the "label" key.
+// Time: O(n), Space: O(n)
+func NewBruteForceIndexFromDataset[T Number](ds *Dataset[[]T], distFn DistanceFunc[T]) (*BruteForceIndex[T], error) {
+	idx := NewBruteForceIndex(distFn)
+	for i, id := range ds.ids {
+		if err := idx.Add(id, ds.items[i], ds.labeledMetadata(i)); err != nil {
+			return nil, err
+		}
+	}
+	return idx, nil
+}
+
+// AddDataset feeds a vector dataset to the model as one mini-batch and
+// returns the cluster assignment of each item keyed by ID.
+// Time: O(nkd), Space: O(n)
+func (m *StreamingKMeans[T]) AddDataset(ds *Dataset[[]T]) (map[string]int, error) {
+	assignments, err := m.AddBatch(ds.items)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]int, len(assignments))
+	for i, c := range assignments {
+		out[ds.ids[i]] = c
+	}
+	return out, nil
+}
+
+// searchResult wraps item i, with its ID and labeled metadata, as a
+// SearchResult
+func (d *Dataset[T]) searchResult(i int, dist float64) SearchResult {
+	return SearchResult{ID: d.ids[i], Index: i, Distance: dist, Metadata: d.labeledMetadata(i)}
+}
+
+// labeledMetadata returns a copy of item metadata with the label added;
+// the caller's map is never modified
+func (d *Dataset[T]) labeledMetadata(i int) map[string]any {
+	if d.labels[i] == "" {
+		return d.metadata[i]
+	}
+	md := make(map[string]any, len(d.metadata[i])+1)
+	for k, v := range d.metadata[i] {
+		md[k] = v
+	}
+	md["label"] = d.labels[i]
+	return md
+}
@@ -0,0 +1,116 @@
+package distance
+
+import "testing"
+
+func newTestDataset(t *testing.T) *Dataset[[]float64] {
+	t.Helper()
+	ds := NewDataset[[]float64]()
+	items := []struct {
+		id, label string
+		v         []float64
+	}{
+		{"a", "red", []float64{0, 0}},
+		{"b", "red", []float64{0, 1}},
+		{"c", "blue", []float64{5, 5}},
+		{"d", "blue", []float64{5, 6}},
+	}
+	for _, it := range items {
+		if err := ds.Add(it.id, it.v, it.label, map[string]any{"src": "test"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return ds
+}
+
+func TestDatasetBasics(t *testing.T) {
+	ds := newTestDataset(t)
+
+	if ds.Len() != 4 {
+		t.Errorf("expected 4, got %d", ds.Len())
+	}
+	if err := ds.Add("a", nil, "", nil); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+
+	v, label, md, err := ds.Get("c")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v[0] != 5 || label != "blue" || md["src"] != "test" {
+		t.Errorf("unexpected item %v %q %v", v, label, md)
+	}
+	if _, _, _, err := ds.Get("zzz"); err != ErrKeyNotFound {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	blue := ds.Filter(func(_ string, _ []float64, label string) bool { return label == "blue" })
+	if blue.Len() != 2 || blue.IDs()[0] != "c" || blue.Labels()[1] != "blue" {
+		t.Errorf("unexpected filter result %v", blue.IDs())
+	}
+}
+
+func TestBatchComputeDataset(t *testing.T) {
+	ds := newTestDataset(t)
+	filtered := ds.Filter(func(id string, _ []float64, _ string) bool { return id != "b" })
+
+	m, err := BatchComputeDataset(filtered, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := m.At("c", "d")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 1 {
+		t.Errorf("expected 1, got %v", got)
+	}
+	if _, err := m.At("b", "c"); err != ErrKeyNotFound {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestKNearestNeighborsDataset(t *testing.T) {
+	ds := newTestDataset(t)
+
+	got, err := KNearestNeighborsDataset(ds, 1, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"a": "b", "b": "a", "c": "d", "d": "c"}
+	for id, nb := range want {
+		if got[id][0].ID != nb {
+			t.Errorf("%s: expected %s, got %s", id, nb, got[id][0].ID)
+		}
+	}
+	if got["a"][0].Metadata["label"] != "red" {
+		t.Errorf("expected label in metadata, got %v", got["a"][0].Metadata)
+	}
+
+	// Caller metadata must not be modified
+	_, _, md, _ := ds.Get("b")
+	if _, ok := md["label"]; ok {
+		t.Error("dataset metadata was modified")
+	}
+}
+
+func TestDatasetIndexAndClustering(t *testing.T) {
+	ds := newTestDataset(t)
+
+	idx, err := NewBruteForceIndexFromDataset(ds, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res, _ := idx.Search([]float64{5, 5.4}, 1)
+	if res[0].ID != "c" || res[0].Metadata["label"] != "blue" {
+		t.Errorf("unexpected result %+v", res[0])
+	}
+
+	km, _ := NewStreamingKMeans[float64](2, 2)
+	assign, err := km.AddDataset(ds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if assign["c"] != assign["d"] || assign["a"] == assign["c"] {
+		t.Errorf("unexpected assignments %v", assign)
+	}
+}
@@ -0,0 +1,149 @@
+package distance
+
+import "math"
+
+// DictionaryMatcher finds dictionary words within a small Levenshtein
+// distance of a query, a Searcher[string] for spell-checking. Words are
+// stored in a trie, so a query fills one dynamic-programming row per trie
+// node instead of one table per word: words sharing a prefix share its rows,
+// and a subtree is abandoned as soon as every cell of its row exceeds the
+// search radius. Distances count runes, matching LevenshteinRunes.
+// A DictionaryMatcher is not safe for concurrent Add; concurrent searches
+// are safe.
+type DictionaryMatcher struct {
+	root  trieNode
+	words []string
+}
+
+// trieNode holds the indices of words ending at this node and the child per next rune
+type trieNode struct {
+	children map[rune]*trieNode
+	words    []int
+}
+
+// NewDictionaryMatcher creates a matcher over words; each word's Index in
+// search results is its position in words.
+// Time: O(total runes), Space: O(total runes)
+func NewDictionaryMatcher(words []string) *DictionaryMatcher {
+	m := &DictionaryMatcher{}
+	for _, w := range words {
+		m.Add(w)
+	}
+	return m
+}
+
+// Add inserts word; its Index in search results is the insertion order.
+// Duplicates are kept and reported separately.
+// Time: O(len(word)), Space: O(len(word))
+func (m *DictionaryMatcher) Add(word string) {
+	node := &m.root
+	for _, r := range word {
+		child, ok := node.children[r]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode)
+			}
+			child = &trieNode{}
+			node.children[r] = child
+		}
+		node = child
+	}
+	node.words = append(node.words, len(m.words))
+	m.words = append(m.words, word)
+}
+
+// Len returns the number of stored words.
+func (m *DictionaryMatcher) Len() int {
+	return len(m.words)
+}
+
+// Distances returns the Levenshtein distance from query to every stored
+// word, indexed by insertion order, in a single pass over the trie.
+// Time: O(trie nodes·len(query)), Space: O(n + depth·len(query))
+func (m *DictionaryMatcher) Distances(query string) []int {
+	distances := make([]int, len(m.words))
+	m.walk([]rune(query), func() float64 { return math.Inf(1) }, func(index, d int) {
+		distances[index] = d
+	})
+	return distances
+}
+
+// Search returns the k stored words closest to query.
+// Time: O(trie nodes·len(query)) worst case, far less once k close words
+// are found, Space: O(k + depth·len(query))
+func (m *DictionaryMatcher) Search(query string, k int) ([]SearchResult, error) {
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	h := make(neighborHeap, 0, k)
+	m.walk([]rune(query), func() float64 { return h.bound(k) }, func(index, d int) {
+		h.offer(Neighbor{index, float64(d)}, k)
+	})
+
+	nearest := h.sorted()
+	results := make([]SearchResult, len(nearest))
+	for p, nb := range nearest {
+		results[p] = m.result(nb.Index, nb.Distance)
+	}
+	return results, nil
+}
+
+// SearchRadius returns every stored word within edit distance radius of query.
+// Time: O(visited nodes·len(query)), Space: O(m + depth·len(query))
+func (m *DictionaryMatcher) SearchRadius(query string, radius float64) ([]SearchResult, error) {
+	if radius < 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	var results []SearchResult
+	m.walk([]rune(query), func() float64 { return radius }, func(index, d int) {
+		if float64(d) <= radius {
+			results = append(results, m.result(index, float64(d)))
+		}
+	})
+	sortSearchResults(results)
+	return results, nil
+}
+
+// walk computes the Levenshtein row of every trie node whose prefix may
+// still lie within radius() of query, reporting each word reached to visit;
+// radius may shrink during the walk
+func (m *DictionaryMatcher) walk(query []rune, radius func() float64, visit func(index, d int)) {
+	row := make([]int, len(query)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for _, index := range m.root.words {
+		visit(index, row[len(query)])
+	}
+	m.descend(&m.root, query, row, radius, visit)
+}
+
+// descend extends the row of node's prefix by each child rune
+func (m *DictionaryMatcher) descend(node *trieNode, query []rune, prev []int, radius func() float64, visit func(index, d int)) {
+	for r, child := range node.children {
+		row := make([]int, len(prev))
+		row[0] = prev[0] + 1
+		best := row[0]
+		for j := 1; j < len(row); j++ {
+			cost := 1
+			if query[j-1] == r {
+				cost = 0
+			}
+			row[j] = min3(row[j-1]+1, prev[j]+1, prev[j-1]+cost)
+			best = min(best, row[j])
+		}
+		for _, index := range child.words {
+			visit(index, row[len(query)])
+		}
+		if float64(best) <= radius() {
+			m.descend(child, query, row, radius, visit)
+		}
+	}
+}
+
+// result wraps the stored word at index as a SearchResult
+func (m *DictionaryMatcher) result(index int, dist float64) SearchResult {
+	return SearchResult{ID: m.words[index], Index: index, Distance: dist}
+}
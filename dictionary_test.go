@@ -0,0 +1,99 @@
+package distance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDictionaryMatcherSearchRadius(t *testing.T) {
+	m := NewDictionaryMatcher([]string{"book", "books", "cake", "boo", "boon", "cook", "cape", "cart", "book"})
+	if m.Len() != 9 {
+		t.Errorf("expected 9 words, got %d", m.Len())
+	}
+
+	results, err := m.SearchRadius("book", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []int
+	for _, r := range results {
+		got = append(got, r.Index)
+	}
+	// Both copies of "book" are reported
+	if !reflect.DeepEqual(got, []int{0, 8, 1, 3, 4, 5}) {
+		t.Errorf("unexpected results %v", results)
+	}
+
+	if results, _ := m.SearchRadius("zzzz", 1); len(results) != 0 {
+		t.Errorf("expected no results, got %v", results)
+	}
+	if _, err := m.SearchRadius("book", -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestDictionaryMatcherDistances(t *testing.T) {
+	words := []string{"", "a", "café", "cafe", "coffee", "naïve"}
+	m := NewDictionaryMatcher(words)
+	for _, query := range []string{"", "cafe", "caffè", "nave"} {
+		got := m.Distances(query)
+		for i, w := range words {
+			expected, _ := LevenshteinRunes(query, w)
+			if got[i] != expected {
+				t.Errorf("%q vs %q: expected %d, got %d", query, w, expected, got[i])
+			}
+		}
+	}
+}
+
+func TestDictionaryMatcherMatchesBruteForce(t *testing.T) {
+	words := randomWords(11, 2000)
+	m := NewDictionaryMatcher(words)
+
+	for _, query := range randomWords(12, 20) {
+		var within, all []SearchResult
+		for i, w := range words {
+			d, _ := Levenshtein(query, w)
+			r := SearchResult{ID: w, Index: i, Distance: float64(d)}
+			all = append(all, r)
+			if d <= 2 {
+				within = append(within, r)
+			}
+		}
+		sortSearchResults(within)
+		sortSearchResults(all)
+
+		got, err := m.SearchRadius(query, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, within) {
+			t.Errorf("%s: expected %v, got %v", query, within, got)
+		}
+		nearest, err := m.Search(query, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(nearest, all[:5]) {
+			t.Errorf("%s: expected %v, got %v", query, all[:5], nearest)
+		}
+	}
+}
+
+func TestDictionaryMatcherEmpty(t *testing.T) {
+	var s Searcher[string] = NewDictionaryMatcher(nil)
+	if results, err := s.Search("x", 3); err != nil || len(results) != 0 {
+		t.Errorf("expected no results, got %v (%v)", results, err)
+	}
+	if _, err := s.Search("x", 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func BenchmarkDictionaryMatcherSearchRadius(b *testing.B) {
+	m := NewDictionaryMatcher(randomWords(11, 10000))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = m.SearchRadius("abcdef", 1)
+	}
+}
@@ -0,0 +1,140 @@
+package distance
+
+import "strings"
+
+// LCSSlices returns a longest common subsequence of a and b. When several
+// exist, the choice is deterministic but unspecified.
+// Time: O(mn), Space: O(mn)
+func LCSSlices[T comparable](a, b []T) []T {
+	table := lcsTable(a, b)
+	result := make([]T, 0, table[0][0])
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, a[i])
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return result
+}
+
+// LCSString returns a longest common subsequence of a and b. Unlike
+// LongestCommonSubsequence it compares Unicode code points, so the result is
+// always valid UTF-8.
+// Time: O(mn), Space: O(mn)
+func LCSString(a, b string) (string, error) {
+	return string(LCSSlices([]rune(a), []rune(b))), nil
+}
+
+// DiffOp is the kind of a DiffHunk.
+type DiffOp int
+
+const (
+	// DiffEqual marks elements present in both sequences.
+	DiffEqual DiffOp = iota
+	// DiffDelete marks elements present only in the first sequence.
+	DiffDelete
+	// DiffInsert marks elements present only in the second sequence.
+	DiffInsert
+)
+
+// DiffHunk is a run of Len elements starting at A in the first sequence
+// and/or B in the second. For DiffDelete only A is meaningful, for
+// DiffInsert only B (A and B then hold the position in the other sequence).
+type DiffHunk struct {
+	Op  DiffOp
+	A   int
+	B   int
+	Len int
+}
+
+// Diff computes the edit script turning a into b as equal, delete and insert
+// hunks, using a longest common subsequence so that the number of deleted
+// plus inserted elements is minimal (len(a)+len(b)-2·LCS, the LCSDistance).
+// Within each change deletions come before insertions.
+// Time: O(mn), Space: O(mn)
+func Diff[T comparable](a, b []T) []DiffHunk {
+	table := lcsTable(a, b)
+	var hunks []DiffHunk
+	emit := func(op DiffOp, i, j int) {
+		if last := len(hunks) - 1; last >= 0 && hunks[last].Op == op {
+			hunks[last].Len++
+			return
+		}
+		hunks = append(hunks, DiffHunk{Op: op, A: i, B: j, Len: 1})
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			emit(DiffEqual, i, j)
+			i++
+			j++
+		case j == len(b) || (i < len(a) && table[i+1][j] >= table[i][j+1]):
+			emit(DiffDelete, i, j)
+			i++
+		default:
+			emit(DiffInsert, i, j)
+			j++
+		}
+	}
+	return hunks
+}
+
+// DiffLines diffs two texts line by line and renders the result with a
+// "  ", "- " or "+ " prefix per line, in the style of diff(1).
+// Time: O(mn) where m, n = line counts, Space: O(mn)
+func DiffLines(a, b string) string {
+	linesA, linesB := splitLines(a), splitLines(b)
+	var sb strings.Builder
+	for _, h := range Diff(linesA, linesB) {
+		var prefix string
+		var lines []string
+		switch h.Op {
+		case DiffEqual:
+			prefix, lines = "  ", linesA[h.A:h.A+h.Len]
+		case DiffDelete:
+			prefix, lines = "- ", linesA[h.A:h.A+h.Len]
+		case DiffInsert:
+			prefix, lines = "+ ", linesB[h.B:h.B+h.Len]
+		}
+		for _, line := range lines {
+			sb.WriteString(prefix)
+			sb.WriteString(line)
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// splitLines splits text into lines, ignoring a trailing newline
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// lcsTable returns t where t[i][j] is the LCS length of a[i:] and b[j:]
+func lcsTable[T comparable](a, b []T) [][]int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+	return table
+}
@@ -0,0 +1,111 @@
+package distance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLCSString(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected string
+	}{
+		{"identical", "hello", "hello", "hello"},
+		{"partial match", "abcdef", "ace", "ace"},
+		{"interleaved", "AGGTAB", "GXTXAYB", "GTAB"},
+		{"no match", "abc", "xyz", ""},
+		{"empty", "", "hello", ""},
+		{"unicode", "naïve café", "naive cafe", "nave caf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := LCSString(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+			// Length agrees with LongestCommonSubsequence on ASCII input
+			if want, _ := LongestCommonSubsequence(tt.a, tt.b); tt.name != "unicode" && len(result) != want {
+				t.Errorf("expected length %d, got %d", want, len(result))
+			}
+		})
+	}
+}
+
+func TestLCSSlices(t *testing.T) {
+	a := []int{1, 2, 3, 4, 1}
+	b := []int{3, 4, 1, 2, 1, 3}
+	got := LCSSlices(a, b)
+	if len(got) != 3 {
+		t.Fatalf("expected a subsequence of length 3, got %v", got)
+	}
+	// The result must be a subsequence of both inputs
+	for _, seq := range [][]int{a, b} {
+		if common := LCSSlices(got, seq); !reflect.DeepEqual(common, got) {
+			t.Errorf("%v is not a subsequence of %v", got, seq)
+		}
+	}
+	if empty := LCSSlices([]string{"x"}, nil); len(empty) != 0 {
+		t.Errorf("expected empty result, got %v", empty)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := []byte("ABCABBA")
+	b := []byte("CBABAC")
+
+	hunks := Diff(a, b)
+
+	// Replaying the hunks must rebuild both sequences
+	var gotA, gotB []byte
+	changes := 0
+	for _, h := range hunks {
+		switch h.Op {
+		case DiffEqual:
+			if string(a[h.A:h.A+h.Len]) != string(b[h.B:h.B+h.Len]) {
+				t.Errorf("equal hunk %+v covers different elements", h)
+			}
+			gotA = append(gotA, a[h.A:h.A+h.Len]...)
+			gotB = append(gotB, b[h.B:h.B+h.Len]...)
+		case DiffDelete:
+			gotA = append(gotA, a[h.A:h.A+h.Len]...)
+			changes += h.Len
+		case DiffInsert:
+			gotB = append(gotB, b[h.B:h.B+h.Len]...)
+			changes += h.Len
+		}
+	}
+	if string(gotA) != string(a) || string(gotB) != string(b) {
+		t.Errorf("hunks rebuild %q/%q, expected %q/%q", gotA, gotB, a, b)
+	}
+	if want, _ := LCSDistance(string(a), string(b)); changes != want {
+		t.Errorf("expected %d changed elements, got %d", want, changes)
+	}
+
+	if got := Diff([]int{1, 2}, []int{1, 2}); !reflect.DeepEqual(got, []DiffHunk{{DiffEqual, 0, 0, 2}}) {
+		t.Errorf("expected a single equal hunk, got %+v", got)
+	}
+	if got := Diff([]int{1, 2}, []int{3}); !reflect.DeepEqual(got, []DiffHunk{{DiffDelete, 0, 0, 2}, {DiffInsert, 2, 0, 1}}) {
+		t.Errorf("expected delete then insert, got %+v", got)
+	}
+	if got := Diff[int](nil, nil); got != nil {
+		t.Errorf("expected no hunks, got %+v", got)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	a := "apple\nbanana\ncherry\ndate\n"
+	b := "apple\nblueberry\ncherry\ndate\nelderberry\n"
+	expected := "  apple\n- banana\n+ blueberry\n  cherry\n  date\n+ elderberry\n"
+
+	if got := DiffLines(a, b); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+	if got := DiffLines("", "x"); got != "+ x\n" {
+		t.Errorf("expected %q, got %q", "+ x\n", got)
+	}
+}
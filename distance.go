@@ -5,6 +5,7 @@ package distance
 import (
 	"context"
 	"errors"
+	"math"
 )
 
 var (
@@ -22,6 +23,32 @@ var (
 
 	// ErrNegativeValue is returned when a negative value is found in input that requires non-negative values.
 	ErrNegativeValue = errors.New("negative value in input")
+
+	// ErrUnknownMetric is returned when a metric name is not registered.
+	ErrUnknownMetric = errors.New("unknown metric")
+
+	// ErrKeyNotFound is returned when a key is not present in a cache or index.
+	ErrKeyNotFound = errors.New("key not found")
+
+	// ErrUnsupportedType is returned when a Metric receives inputs of an unsupported type.
+	ErrUnsupportedType = errors.New("unsupported input type")
+
+	// ErrNotDistribution is returned when a probability vector does not sum to 1.
+	ErrNotDistribution = errors.New("input is not a probability distribution")
+
+	// ErrInvalidBounds is returned when optimizer bounds don't match the dimensions or have min > max.
+	ErrInvalidBounds = errors.New("invalid optimization bounds")
+
+	// ErrDivergence is returned when an optimizer produces non-finite values.
+	ErrDivergence = errors.New("optimization diverged")
+
+	// ErrMaxIterations is returned alongside the last iterate when an optimizer
+	// stops before reaching its convergence tolerance.
+	ErrMaxIterations = errors.New("maximum iterations reached without convergence")
+
+	// ErrSaturated is returned when sequences are too divergent for an
+	// evolutionary distance correction to be defined.
+	ErrSaturated = errors.New("sequences too divergent for distance correction")
 )
 
 // Number constraint for generic numeric types
@@ -43,15 +70,24 @@ type Float interface {
 //nolint:revive // Name stuttering is acceptable here for API clarity and consistency
 type DistanceFunc[T Number] func(a, b []T) (float64, error)
 
+// BoundedDistanceFunc computes a distance like DistanceFunc but may stop as
+// soon as the distance is known to exceed bound, returning +Inf.
+// EuclideanBounded is one.
+type BoundedDistanceFunc[T Number] func(a, b []T, bound float64) (float64, error)
+
 // StringDistanceFunc computes distance between strings
 type StringDistanceFunc func(a, b string) (int, error)
 
-// Options for configurable distance calculations
+// Options for configurable distance calculations.
+// Consumed by Compute, BatchComputeWithOptions and ComputeToPointWithOptions.
 type Options struct {
 	Normalize   bool      // Normalize result to [0,1]
-	Weights     []float64 // Dimension weights
+	Weights     []float64 // Dimension weights, applied to squared differences
 	Parallel    bool      // Use parallel computation for batch operations
-	MaxDistance float64   // Early termination threshold (0 means no limit)
+	MaxDistance float64   // Distances above this become +Inf (0 means no limit)
+	// Bounded, when set, replaces the metric while MaxDistance > 0 so
+	// pruned pairs stop early, e.g. EuclideanBounded[float64] for Euclidean
+	Bounded BoundedDistanceFunc[float64]
 }
 
 // Metric interface for any distance metric
@@ -95,3 +131,56 @@ func ValidateWeights[T Number](v []T, weights []float64) error {
 	}
 	return nil
 }
+
+// ValidateDistribution checks that p is a probability vector: non-empty,
+// non-negative, and summing to 1 within tolerance.
+func ValidateDistribution[T Float](p []T, tolerance float64) error {
+	if len(p) == 0 {
+		return ErrEmptyInput
+	}
+	if tolerance < 0 || math.IsNaN(tolerance) {
+		return ErrInvalidParameter
+	}
+
+	var sum float64
+	for _, v := range p {
+		if v < 0 {
+			return ErrNegativeValue
+		}
+		sum += float64(v)
+	}
+	if math.Abs(sum-1) > tolerance || math.IsNaN(sum) {
+		return ErrNotDistribution
+	}
+	return nil
+}
+
+// StrictDivergence wraps a divergence such as KLDivergence so that both inputs
+// must pass ValidateDistribution before it is evaluated.
+func StrictDivergence[T Float](fn func(p, q []T) (float64, error), tolerance float64) func(p, q []T) (float64, error) {
+	return func(p, q []T) (float64, error) {
+		if err := ValidateDistribution(p, tolerance); err != nil {
+			return 0, err
+		}
+		if err := ValidateDistribution(q, tolerance); err != nil {
+			return 0, err
+		}
+		return fn(p, q)
+	}
+}
+
+// NormalizedDivergence wraps a divergence so that both inputs are scaled to
+// sum to 1 before it is evaluated, e.g. to compare raw counts.
+func NormalizedDivergence[T Float](fn func(p, q []T) (float64, error)) func(p, q []T) (float64, error) {
+	return func(p, q []T) (float64, error) {
+		pn, err := NormalizeToDistribution(p)
+		if err != nil {
+			return 0, err
+		}
+		qn, err := NormalizeToDistribution(q)
+		if err != nil {
+			return 0, err
+		}
+		return fn(pn, qn)
+	}
+}
@@ -0,0 +1,339 @@
+package distance
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// SquatPattern identifies a domain-impersonation technique detected by
+// CompareDomains.
+type SquatPattern int
+
+const (
+	// SquatHomoglyph swaps characters for lookalikes: "pаypal" (Cyrillic а),
+	// "rnicrosoft", "paypa1".
+	SquatHomoglyph SquatPattern = iota
+	// SquatBitflip differs by one flipped bit in one character, as produced by
+	// memory errors: "paypan" ('l' 0x6c → 'n' 0x6e).
+	SquatBitflip
+	// SquatKeyboard replaces a character with an adjacent QWERTY key: "paypak".
+	SquatKeyboard
+	// SquatOmission drops a character: "papal".
+	SquatOmission
+	// SquatRepetition doubles a character: "paypall".
+	SquatRepetition
+	// SquatInsertion inserts some other character: "paytpal".
+	SquatInsertion
+	// SquatTransposition swaps two adjacent characters: "papyal".
+	SquatTransposition
+	// SquatHyphenation adds hyphens: "pay-pal".
+	SquatHyphenation
+	// SquatTLD keeps the name under another public suffix: "paypal.co.uk".
+	SquatTLD
+	// SquatCombo embeds the name in a longer one: "paypal-login", "securepaypal".
+	SquatCombo
+	// SquatSubdomain uses the name as a subdomain of an unrelated domain:
+	// "paypal.com.account-verify.net".
+	SquatSubdomain
+)
+
+// String returns the lowercase name of the pattern.
+func (p SquatPattern) String() string {
+	switch p {
+	case SquatHomoglyph:
+		return "homoglyph"
+	case SquatBitflip:
+		return "bitflip"
+	case SquatKeyboard:
+		return "keyboard"
+	case SquatOmission:
+		return "omission"
+	case SquatRepetition:
+		return "repetition"
+	case SquatInsertion:
+		return "insertion"
+	case SquatTransposition:
+		return "transposition"
+	case SquatHyphenation:
+		return "hyphenation"
+	case SquatTLD:
+		return "tld"
+	case SquatCombo:
+		return "combo"
+	case SquatSubdomain:
+		return "subdomain"
+	}
+	return "unknown"
+}
+
+// DomainMatch reports how closely a candidate domain imitates a protected one.
+type DomainMatch struct {
+	// Score is a risk-oriented similarity in [0, 1]: 1 for a visually
+	// identical imitation, high for one-keystroke typos and brand
+	// embeddings, low for unrelated domains.
+	Score float64
+	// Distance is the Levenshtein distance between the confusable skeletons
+	// of the two registrable names, so homoglyphs count as no edit.
+	Distance int
+	// Patterns lists the squatting techniques detected, in declaration order.
+	Patterns []SquatPattern
+}
+
+// CompareDomains compares candidate against protected, a domain to defend
+// against lookalike registrations. Both are split into subdomains, a
+// registrable name and a public suffix (the last label, or the last two for
+// country codes under a generic second level such as "co.uk" or "com.au");
+// punycode labels ("xn--...") are decoded first, and case and a trailing dot
+// are ignored. The score is the skeleton similarity of the registrable names,
+// discounted by 10% when the suffix differs, and raised to 0.9 for subdomain
+// and 0.8 for combo squatting, whose edit distance understates the risk.
+// Identical domains, and subdomains of the protected one, score 1 with no
+// patterns, so exclude the protected domain itself before alerting.
+// Returns ErrEmptyInput for an empty domain and ErrInvalidParameter for one
+// with an empty label or an invalid punycode label.
+// Time: O(mn), Space: O(m + n)
+func CompareDomains(candidate, protected string) (*DomainMatch, error) {
+	c, err := splitDomain(candidate)
+	if err != nil {
+		return nil, err
+	}
+	p, err := splitDomain(protected)
+	if err != nil {
+		return nil, err
+	}
+
+	skelC, skelP := ConfusableSkeleton(c.name), ConfusableSkeleton(p.name)
+	dist, err := LevenshteinRunes(skelC, skelP)
+	if err != nil {
+		return nil, err
+	}
+	m := &DomainMatch{Distance: dist}
+	if c == p {
+		m.Score = 1
+		return m, nil
+	}
+
+	if c.name != p.name && skelC == skelP {
+		m.Patterns = append(m.Patterns, SquatHomoglyph)
+	}
+	m.Patterns = append(m.Patterns, typoPatterns([]rune(c.name), []rune(p.name))...)
+	if c.name == p.name && c.suffix != p.suffix {
+		m.Patterns = append(m.Patterns, SquatTLD)
+	}
+	combo := len(skelC) > len(skelP) && strings.Contains(skelC, skelP) && dist > 1 &&
+		strings.ReplaceAll(skelC, "-", "") != skelP
+	if combo {
+		m.Patterns = append(m.Patterns, SquatCombo)
+	}
+	subdomain := false
+	if c.subdomains != "" && skelC != skelP {
+		for _, label := range strings.Split(c.subdomains, ".") {
+			if ConfusableSkeleton(label) == skelP {
+				subdomain = true
+			}
+		}
+	}
+	if subdomain {
+		m.Patterns = append(m.Patterns, SquatSubdomain)
+	}
+
+	score := 1.0
+	if longest := max(len([]rune(skelC)), len([]rune(skelP))); longest > 0 {
+		score = 1 - float64(dist)/float64(longest)
+	}
+	if c.suffix != p.suffix {
+		score *= 0.9
+	}
+	if combo {
+		score = max(score, 0.8)
+	}
+	if subdomain {
+		score = max(score, 0.9)
+	}
+	m.Score = score
+	return m, nil
+}
+
+// domainParts is a domain split around its registrable name
+type domainParts struct {
+	subdomains string // labels left of the name, dot-joined
+	name       string
+	suffix     string
+}
+
+// genericSecondLevel lists second-level labels that country-code registries
+// sell names under
+var genericSecondLevel = map[string]bool{
+	"ac": true, "co": true, "com": true, "edu": true, "gov": true,
+	"net": true, "org": true, "ltd": true, "plc": true, "ne": true, "or": true,
+}
+
+// splitDomain normalizes a domain and splits off its public suffix and
+// registrable name
+func splitDomain(domain string) (domainParts, error) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if domain == "" {
+		return domainParts{}, ErrEmptyInput
+	}
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		if label == "" {
+			return domainParts{}, ErrInvalidParameter
+		}
+		if strings.HasPrefix(label, "xn--") {
+			decoded, ok := punycodeDecode(label[len("xn--"):])
+			if !ok {
+				return domainParts{}, ErrInvalidParameter
+			}
+			labels[i] = decoded
+		}
+	}
+
+	n := len(labels)
+	suffixLen := min(1, n-1)
+	if n >= 3 && len(labels[n-1]) == 2 && genericSecondLevel[labels[n-2]] {
+		suffixLen = 2
+	}
+	return domainParts{
+		subdomains: strings.Join(labels[:n-suffixLen-1], "."),
+		name:       labels[n-suffixLen-1],
+		suffix:     strings.Join(labels[n-suffixLen:], "."),
+	}, nil
+}
+
+// typoPatterns detects single-keystroke squatting patterns turning p into c
+func typoPatterns(c, p []rune) []SquatPattern {
+	var patterns []SquatPattern
+	switch {
+	case len(c) == len(p):
+		diff := []int{}
+		for i := range c {
+			if c[i] != p[i] {
+				diff = append(diff, i)
+			}
+		}
+		switch {
+		case len(diff) == 1:
+			a, b := c[diff[0]], p[diff[0]]
+			if bits.OnesCount32(uint32(a^b)) == 1 && hostnameRune(a) {
+				patterns = append(patterns, SquatBitflip)
+			}
+			if qwertyAdjacent[[2]rune{a, b}] {
+				patterns = append(patterns, SquatKeyboard)
+			}
+		case len(diff) == 2 && diff[1] == diff[0]+1 &&
+			c[diff[0]] == p[diff[1]] && c[diff[1]] == p[diff[0]]:
+			patterns = append(patterns, SquatTransposition)
+		}
+	case len(c) == len(p)-1 && dropsOne(p, c) >= 0:
+		patterns = append(patterns, SquatOmission)
+	case len(c) == len(p)+1:
+		if i := dropsOne(c, p); i >= 0 {
+			switch {
+			case c[i] == '-':
+				// Reported as hyphenation below
+			case (i > 0 && c[i-1] == c[i]) || (i+1 < len(c) && c[i+1] == c[i]):
+				patterns = append(patterns, SquatRepetition)
+			default:
+				patterns = append(patterns, SquatInsertion)
+			}
+		}
+	}
+	if len(c) > len(p) && strings.ReplaceAll(string(c), "-", "") == string(p) {
+		patterns = append(patterns, SquatHyphenation)
+	}
+	return patterns
+}
+
+// dropsOne returns the index whose removal from long yields short, or -1
+func dropsOne(long, short []rune) int {
+	i := 0
+	for i < len(short) && long[i] == short[i] {
+		i++
+	}
+	for j := i; j < len(short); j++ {
+		if long[j+1] != short[j] {
+			return -1
+		}
+	}
+	return i
+}
+
+// hostnameRune reports whether r is allowed in an ASCII hostname label
+func hostnameRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-'
+}
+
+// punycodeDecode decodes an RFC 3492 punycode label without its "xn--" prefix
+func punycodeDecode(s string) (string, bool) {
+	const (
+		base, tMin, tMax = 36, 1, 26
+		skew, damp       = 38, 700
+	)
+	adapt := func(delta, points int, first bool) int {
+		if first {
+			delta /= damp
+		} else {
+			delta /= 2
+		}
+		delta += delta / points
+		k := 0
+		for delta > (base-tMin)*tMax/2 {
+			delta /= base - tMin
+			k += base
+		}
+		return k + (base-tMin+1)*delta/(delta+skew)
+	}
+
+	var output []rune
+	if i := strings.LastIndexByte(s, '-'); i >= 0 {
+		for _, r := range s[:i] {
+			if r >= 0x80 {
+				return "", false
+			}
+			output = append(output, r)
+		}
+		s = s[i+1:]
+	}
+
+	n, bias, i := 128, 72, 0
+	for pos := 0; pos < len(s); {
+		oldi, w := i, 1
+		for k := base; ; k += base {
+			if pos >= len(s) {
+				return "", false
+			}
+			digit := punycodeDigit(s[pos])
+			pos++
+			if digit < 0 || digit > (1<<30-i)/w {
+				return "", false
+			}
+			i += digit * w
+			t := min(max(k-bias, tMin), tMax)
+			if digit < t {
+				break
+			}
+			w *= base - t
+		}
+		bias = adapt(i-oldi, len(output)+1, oldi == 0)
+		n += i / (len(output) + 1)
+		i %= len(output) + 1
+		if n > 0x10FFFF {
+			return "", false
+		}
+		output = append(output[:i], append([]rune{rune(n)}, output[i:]...)...)
+		i++
+	}
+	return string(output), true
+}
+
+// punycodeDigit maps a-z to 0-25 and 0-9 to 26-35, anything else to -1
+func punycodeDigit(c byte) int {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a')
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26
+	}
+	return -1
+}
@@ -0,0 +1,103 @@
+package distance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareDomains(t *testing.T) {
+	tests := []struct {
+		candidate string
+		patterns  []SquatPattern
+		minScore  float64
+	}{
+		{"pаypal.com", []SquatPattern{SquatHomoglyph}, 1},
+		{"xn--pypal-4ve.com", []SquatPattern{SquatHomoglyph}, 1},
+		{"paypa1.com", []SquatPattern{SquatHomoglyph}, 1},
+		{"paypan.com", []SquatPattern{SquatBitflip}, 0.8},
+		{"paypak.com", []SquatPattern{SquatKeyboard}, 0.8},
+		{"papal.com", []SquatPattern{SquatOmission}, 0.8},
+		{"paypall.com", []SquatPattern{SquatRepetition}, 0.8},
+		{"paytpal.com", []SquatPattern{SquatInsertion}, 0.8},
+		{"papyal.com", []SquatPattern{SquatTransposition}, 0.6},
+		{"pay-pal.com", []SquatPattern{SquatHyphenation}, 0.8},
+		{"paypal.co.uk", []SquatPattern{SquatTLD}, 0.9},
+		{"paypal-login.com", []SquatPattern{SquatCombo}, 0.8},
+		{"securepaypal.net", []SquatPattern{SquatCombo}, 0.8},
+		{"paypal.com.account-verify.net", []SquatPattern{SquatSubdomain}, 0.9},
+		{"PayPal.com.", nil, 1},
+		{"www.paypal.com", nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.candidate, func(t *testing.T) {
+			m, err := CompareDomains(tt.candidate, "paypal.com")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(m.Patterns, tt.patterns) {
+				t.Errorf("expected patterns %v, got %v", tt.patterns, m.Patterns)
+			}
+			if m.Score < tt.minScore-epsilon || m.Score > 1 {
+				t.Errorf("expected score at least %v, got %v", tt.minScore, m.Score)
+			}
+		})
+	}
+
+	unrelated, err := CompareDomains("weather.org", "paypal.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(unrelated.Patterns) != 0 || unrelated.Score > 0.3 {
+		t.Errorf("expected a low score and no patterns, got %+v", unrelated)
+	}
+
+	// Skeleton distance ignores homoglyphs
+	m, _ := CompareDomains("pаypаll.com", "paypal.com")
+	if m.Distance != 1 {
+		t.Errorf("expected distance 1, got %d", m.Distance)
+	}
+	if typo, _ := CompareDomains("paypall.com", "paypal.com"); typo.Score >= 1 || typo.Score <= unrelated.Score {
+		t.Errorf("expected a typo to score between unrelated and identical, got %v", typo.Score)
+	}
+}
+
+func TestCompareDomainsErrors(t *testing.T) {
+	if _, err := CompareDomains("", "paypal.com"); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := CompareDomains("paypal..com", "paypal.com"); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := CompareDomains("xn--pypal-!!.com", "paypal.com"); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestPunycodeDecode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"pypal-4ve", "pаypal"},
+		{"bcher-kva", "bücher"},
+		{"mnchen-3ya", "münchen"},
+		{"hwab-k6d3j", "ѕсhwab"},
+	}
+
+	for _, tt := range tests {
+		got, ok := punycodeDecode(tt.input)
+		if !ok || got != tt.expected {
+			t.Errorf("%s: expected %q, got %q (%v)", tt.input, tt.expected, got, ok)
+		}
+	}
+	if _, ok := punycodeDecode("abc-9"); ok {
+		t.Errorf("expected truncated input to fail")
+	}
+}
+
+func TestSquatPatternString(t *testing.T) {
+	if SquatTLD.String() != "tld" || SquatPattern(99).String() != "unknown" {
+		t.Errorf("unexpected names %q, %q", SquatTLD.String(), SquatPattern(99).String())
+	}
+}
@@ -0,0 +1,249 @@
+package distance
+
+import (
+	"math"
+	"sort"
+)
+
+// DriftMetric scores the difference between a reference sample and a current
+// sample. Samples may have different lengths.
+type DriftMetric func(reference, current []float64) (float64, error)
+
+// DriftEventKind identifies a drift detector state transition.
+type DriftEventKind int
+
+const (
+	// DriftAlarm is emitted when the score rises to or above Threshold.
+	DriftAlarm DriftEventKind = iota
+	// DriftCleared is emitted when the score falls below ClearThreshold.
+	DriftCleared
+)
+
+// DriftEvent describes a drift detector state transition.
+type DriftEvent struct {
+	Kind        DriftEventKind
+	Score       float64 // Metric value that triggered the transition
+	Observation int     // 1-based index of the observation that triggered it
+}
+
+// DriftConfig configures a DriftDetector. A zero ClearThreshold selects
+// Threshold, so a clear threshold of exactly 0 cannot be requested; with a
+// non-negative metric it could never clear the alarm anyway.
+type DriftConfig struct {
+	ReferenceSize  int         // Observations collected into the frozen reference window
+	WindowSize     int         // Size of the sliding window compared against the reference
+	EvaluateEvery  int         // Evaluate the metric every N observations (default 1)
+	Metric         DriftMetric // Distance between reference and window (default DriftWasserstein)
+	Threshold      float64     // Raise an alarm when score >= Threshold
+	ClearThreshold float64     // Clear the alarm when score < ClearThreshold (default Threshold)
+}
+
+// DriftDetector monitors a stream of observations for concept drift. The first
+// ReferenceSize observations form a frozen reference window; afterwards a
+// sliding window of the latest WindowSize observations is compared against it.
+// Alarms use hysteresis: once raised they persist until the score falls below
+// ClearThreshold, which avoids flapping around a single threshold.
+type DriftDetector struct {
+	cfg       DriftConfig
+	reference []float64
+	window    []float64 // ring buffer
+	next      int
+	filled    bool
+	seen      int
+	score     float64
+	alarmed   bool
+}
+
+// NewDriftDetector creates a drift detector from cfg.
+func NewDriftDetector(cfg DriftConfig) (*DriftDetector, error) {
+	if cfg.ReferenceSize <= 0 || cfg.WindowSize <= 0 || cfg.EvaluateEvery < 0 {
+		return nil, ErrInvalidParameter
+	}
+	if cfg.EvaluateEvery == 0 {
+		cfg.EvaluateEvery = 1
+	}
+	if cfg.Metric == nil {
+		cfg.Metric = DriftWasserstein
+	}
+	if cfg.ClearThreshold == 0 {
+		cfg.ClearThreshold = cfg.Threshold
+	}
+	if cfg.ClearThreshold > cfg.Threshold {
+		return nil, ErrInvalidParameter
+	}
+
+	return &DriftDetector{
+		cfg:       cfg,
+		reference: make([]float64, 0, cfg.ReferenceSize),
+		window:    make([]float64, cfg.WindowSize),
+		score:     math.NaN(),
+	}, nil
+}
+
+// Add records an observation. A non-nil event is returned when the detector
+// raises or clears an alarm.
+// Time: O(metric cost) on evaluation, O(1) otherwise
+func (d *DriftDetector) Add(x float64) (*DriftEvent, error) {
+	d.seen++
+	if len(d.reference) < d.cfg.ReferenceSize {
+		d.reference = append(d.reference, x)
+		return nil, nil
+	}
+
+	d.window[d.next] = x
+	d.next = (d.next + 1) % len(d.window)
+	if d.next == 0 {
+		d.filled = true
+	}
+	if !d.filled || d.seen%d.cfg.EvaluateEvery != 0 {
+		return nil, nil
+	}
+
+	score, err := d.cfg.Metric(d.reference, d.window)
+	if err != nil {
+		return nil, err
+	}
+	d.score = score
+
+	switch {
+	case !d.alarmed && score >= d.cfg.Threshold:
+		d.alarmed = true
+		return &DriftEvent{Kind: DriftAlarm, Score: score, Observation: d.seen}, nil
+	case d.alarmed && score < d.cfg.ClearThreshold:
+		d.alarmed = false
+		return &DriftEvent{Kind: DriftCleared, Score: score, Observation: d.seen}, nil
+	}
+	return nil, nil
+}
+
+// SetReference replaces the reference window and clears the sliding window and alarm state.
+func (d *DriftDetector) SetReference(reference []float64) error {
+	if len(reference) == 0 {
+		return ErrEmptyInput
+	}
+	d.reference = append(d.reference[:0], reference...)
+	d.next, d.filled, d.alarmed = 0, false, false
+	d.score = math.NaN()
+	return nil
+}
+
+// Score returns the most recent metric value (NaN before the first evaluation).
+func (d *DriftDetector) Score() float64 {
+	return d.score
+}
+
+// Alarmed reports whether the detector is currently in the alarm state.
+func (d *DriftDetector) Alarmed() bool {
+	return d.alarmed
+}
+
+// DriftWasserstein is a DriftMetric computing the 1D Wasserstein distance
+// between the empirical distributions of two samples.
+// Time: O((m+n) log(m+n)), Space: O(m+n)
+func DriftWasserstein(reference, current []float64) (float64, error) {
+	if len(reference) == 0 || len(current) == 0 {
+		return 0, ErrEmptyInput
+	}
+	return empiricalWasserstein(reference, current), nil
+}
+
+// DriftJensenShannon is a DriftMetric computing Jensen-Shannon divergence
+// between 10-bin histograms over the combined range of both samples.
+// NaNs and infinities are ignored; ErrEmptyInput is returned if either
+// sample has no finite value.
+// Time: O(m+n), Space: O(1)
+func DriftJensenShannon(reference, current []float64) (float64, error) {
+	p, q, err := sharedHistograms(reference, current, 10)
+	if err != nil {
+		return 0, err
+	}
+	return JensenShannonDivergence(p, q)
+}
+
+// DriftTotalVariation is a DriftMetric computing total variation distance
+// between 10-bin histograms over the combined range of both samples.
+// NaNs and infinities are ignored, as in DriftJensenShannon.
+// Time: O(m+n), Space: O(1)
+func DriftTotalVariation(reference, current []float64) (float64, error) {
+	p, q, err := sharedHistograms(reference, current, 10)
+	if err != nil {
+		return 0, err
+	}
+	return TotalVariation(p, q)
+}
+
+// empiricalWasserstein computes the area between two empirical CDFs.
+// Inputs need not have equal length and are not modified.
+func empiricalWasserstein(a, b []float64) float64 {
+	as := append([]float64{}, a...)
+	bs := append([]float64{}, b...)
+	sort.Float64s(as)
+	sort.Float64s(bs)
+
+	// Sweep the merged support, integrating |Fa(x) - Fb(x)| between breakpoints
+	var dist float64
+	i, j := 0, 0
+	prev := math.Min(as[0], bs[0])
+	for i < len(as) || j < len(bs) {
+		var x float64
+		if j >= len(bs) || (i < len(as) && as[i] <= bs[j]) {
+			x = as[i]
+		} else {
+			x = bs[j]
+		}
+		fa := float64(i) / float64(len(as))
+		fb := float64(j) / float64(len(bs))
+		dist += math.Abs(fa-fb) * (x - prev)
+		prev = x
+		for i < len(as) && as[i] == x {
+			i++
+		}
+		for j < len(bs) && bs[j] == x {
+			j++
+		}
+	}
+	return dist
+}
+
+// sharedHistograms bins the finite values of two samples into normalized
+// histograms over their combined range
+func sharedHistograms(a, b []float64, bins int) ([]float64, []float64, error) {
+	if bins <= 0 {
+		return nil, nil, ErrInvalidParameter
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range [][]float64{a, b} {
+		for _, x := range s {
+			if !math.IsNaN(x) && !math.IsInf(x, 0) {
+				lo = math.Min(lo, x)
+				hi = math.Max(hi, x)
+			}
+		}
+	}
+	if lo > hi {
+		return nil, nil, ErrEmptyInput
+	}
+
+	ha, err := HistogramRange(a, bins, lo, hi)
+	if err != nil {
+		return nil, nil, err
+	}
+	hb, err := HistogramRange(b, bins, lo, hi)
+	if err != nil {
+		return nil, nil, err
+	}
+	var na, nb float64
+	for k := range ha {
+		na += ha[k]
+		nb += hb[k]
+	}
+	if na == 0 || nb == 0 {
+		return nil, nil, ErrEmptyInput
+	}
+	for k := range ha {
+		ha[k] /= na
+		hb[k] /= nb
+	}
+	return ha, hb, nil
+}
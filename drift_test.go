@@ -0,0 +1,133 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDriftDetector(t *testing.T) {
+	d, err := NewDriftDetector(DriftConfig{
+		ReferenceSize:  50,
+		WindowSize:     20,
+		Threshold:      5,
+		ClearThreshold: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var events []DriftEvent
+	feed := func(base float64, n int) {
+		for i := 0; i < n; i++ {
+			ev, err := d.Add(base + float64(i%5))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ev != nil {
+				events = append(events, *ev)
+			}
+		}
+	}
+
+	feed(0, 50)  // reference
+	feed(0, 40)  // stable
+	feed(10, 40) // drift
+	feed(0, 40)  // recovery
+
+	if len(events) != 2 {
+		t.Fatalf("expected alarm and clear events, got %v", events)
+	}
+	if events[0].Kind != DriftAlarm || events[0].Score < 5 {
+		t.Errorf("expected alarm with score >= 5, got %+v", events[0])
+	}
+	if events[0].Observation <= 90 || events[0].Observation > 130 {
+		t.Errorf("expected alarm during drift phase, got observation %d", events[0].Observation)
+	}
+	if events[1].Kind != DriftCleared || events[1].Score >= 2 {
+		t.Errorf("expected clear with score < 2, got %+v", events[1])
+	}
+	if d.Alarmed() {
+		t.Error("expected detector to be cleared")
+	}
+}
+
+func TestDriftDetectorHysteresis(t *testing.T) {
+	d, _ := NewDriftDetector(DriftConfig{
+		ReferenceSize:  4,
+		WindowSize:     4,
+		Threshold:      3,
+		ClearThreshold: 1,
+	})
+	_ = d.SetReference([]float64{0, 0, 0, 0})
+
+	// Score oscillates between thresholds without clearing
+	alarms := 0
+	for _, x := range []float64{4, 4, 4, 4, 2, 2, 2, 2, 4, 4, 4, 4} {
+		ev, _ := d.Add(x)
+		if ev != nil && ev.Kind == DriftAlarm {
+			alarms++
+		}
+	}
+	if alarms != 1 {
+		t.Errorf("expected a single alarm with hysteresis, got %d", alarms)
+	}
+	if !d.Alarmed() || !almostEqual(d.Score(), 4) {
+		t.Errorf("expected alarmed with score 4, got %v %v", d.Alarmed(), d.Score())
+	}
+}
+
+func TestDriftDetectorInvalid(t *testing.T) {
+	if _, err := NewDriftDetector(DriftConfig{ReferenceSize: 0, WindowSize: 1}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := NewDriftDetector(DriftConfig{ReferenceSize: 1, WindowSize: 1, Threshold: 1, ClearThreshold: 2}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+
+	d, _ := NewDriftDetector(DriftConfig{ReferenceSize: 1, WindowSize: 1})
+	if !math.IsNaN(d.Score()) {
+		t.Errorf("expected NaN before evaluation, got %v", d.Score())
+	}
+}
+
+func TestDriftMetrics(t *testing.T) {
+	ref := []float64{0, 1, 2, 3}
+	cur := []float64{10, 11, 12, 13, 14, 15}
+
+	w, err := DriftWasserstein(ref, []float64{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(w, 1) {
+		t.Errorf("expected Wasserstein 1, got %v", w)
+	}
+
+	w, _ = DriftWasserstein([]float64{0}, []float64{0, 2})
+	if !almostEqual(w, 1) {
+		t.Errorf("expected Wasserstein 1 for unequal sizes, got %v", w)
+	}
+
+	tv, _ := DriftTotalVariation(ref, cur)
+	if !almostEqual(tv, 1) {
+		t.Errorf("expected TV 1 for disjoint samples, got %v", tv)
+	}
+	js, _ := DriftJensenShannon(ref, ref)
+	if !almostEqual(js, 0) {
+		t.Errorf("expected JS 0 for identical samples, got %v", js)
+	}
+	if _, err := DriftJensenShannon(nil, cur); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+
+	// Non-finite values are skipped instead of stretching the shared range
+	withGaps := []float64{0, math.NaN(), 1, math.Inf(1), 2, 3, math.Inf(-1)}
+	if js, err := DriftJensenShannon(withGaps, ref); err != nil || !almostEqual(js, 0) {
+		t.Errorf("expected JS 0 ignoring non-finite values, got %v (%v)", js, err)
+	}
+	if tv, err := DriftTotalVariation(ref, withGaps); err != nil || !almostEqual(tv, 0) {
+		t.Errorf("expected TV 0 ignoring non-finite values, got %v (%v)", tv, err)
+	}
+	if _, err := DriftTotalVariation([]float64{math.NaN()}, ref); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package distance
+
+import "math"
+
+// ECEF is an Earth-centered, Earth-fixed Cartesian position in meters on the
+// WGS-84 datum: the origin is the Earth's center of mass, X points to
+// latitude 0 longitude 0, Y to longitude 90°E and Z to the North Pole.
+type ECEF struct {
+	X, Y, Z float64
+}
+
+// ToECEF converts a geodetic coordinate and an altitude in meters above the
+// WGS-84 ellipsoid to ECEF.
+// Time: O(1), Space: O(1)
+func ToECEF(c Coord, altitudeM float64) ECEF {
+	e2 := geodEllipsF * (2 - geodEllipsF)
+	sinLat, cosLat := math.Sincos(c.Lat * degToRad)
+	sinLon, cosLon := math.Sincos(c.Lon * degToRad)
+
+	// Prime vertical radius of curvature
+	n := geodEllipsA / math.Sqrt(1-e2*sinLat*sinLat)
+	return ECEF{
+		X: (n + altitudeM) * cosLat * cosLon,
+		Y: (n + altitudeM) * cosLat * sinLon,
+		Z: (n*(1-e2) + altitudeM) * sinLat,
+	}
+}
+
+// FromECEF converts an ECEF position back to a geodetic coordinate and an
+// altitude in meters above the WGS-84 ellipsoid, using Bowring's method.
+// Accurate to well under a millimeter for positions from the Earth's
+// surface out to geostationary orbit.
+// Time: O(1), Space: O(1)
+func FromECEF(p ECEF) (Coord, float64) {
+	a, f := geodEllipsA, geodEllipsF
+	b := a * (1 - f)
+	e2 := f * (2 - f)
+	ep2 := e2 / ((1 - f) * (1 - f))
+
+	lon := math.Atan2(p.Y, p.X)
+	r := math.Hypot(p.X, p.Y)
+
+	// Iterate from the reduced latitude; each step gains several digits
+	beta := math.Atan2(p.Z*a, r*b)
+	var lat float64
+	for range 3 {
+		sinBeta, cosBeta := math.Sincos(beta)
+		lat = math.Atan2(p.Z+ep2*b*sinBeta*sinBeta*sinBeta, r-e2*a*cosBeta*cosBeta*cosBeta)
+		beta = math.Atan2((1-f)*math.Sin(lat), math.Cos(lat))
+	}
+
+	sinLat, cosLat := math.Sincos(lat)
+	alt := r*cosLat + p.Z*sinLat - a*math.Sqrt(1-e2*sinLat*sinLat)
+	return Coord{Lat: lat / degToRad, Lon: lon / degToRad}, alt
+}
+
+// Distance3D computes the straight-line (slant) distance in meters between
+// two positions given as coordinates and altitudes in meters above the WGS-84
+// ellipsoid, e.g. between an aircraft and a ground station. Unlike the
+// surface distances it accounts for altitude and cuts through the Earth's
+// curvature rather than following it.
+// Time: O(1), Space: O(1)
+func Distance3D(a Coord, altA float64, b Coord, altB float64) float64 {
+	pa, pb := ToECEF(a, altA), ToECEF(b, altB)
+	dx, dy, dz := pa.X-pb.X, pa.Y-pb.Y, pa.Z-pb.Z
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
@@ -0,0 +1,86 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToECEF(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        Coord
+		alt      float64
+		expected ECEF
+	}{
+		{"equator prime meridian", Coord{Lat: 0, Lon: 0}, 0, ECEF{X: 6378137}},
+		{"equator 90E", Coord{Lat: 0, Lon: 90}, 100, ECEF{Y: 6378237}},
+		{"north pole", Coord{Lat: 90, Lon: 0}, 0, ECEF{Z: 6356752.314245}},
+		{"south pole", Coord{Lat: -90, Lon: 0}, 10, ECEF{Z: -6356762.314245}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ToECEF(tt.c, tt.alt)
+			if math.Abs(p.X-tt.expected.X) > 1e-6 || math.Abs(p.Y-tt.expected.Y) > 1e-6 || math.Abs(p.Z-tt.expected.Z) > 1e-6 {
+				t.Errorf("expected %+v, got %+v", tt.expected, p)
+			}
+		})
+	}
+}
+
+func TestFromECEFRoundTrip(t *testing.T) {
+	rng := testRNG(5)
+	for i := 0; i < 1000; i++ {
+		c := Coord{Lat: rng.Float64()*180 - 90, Lon: rng.Float64()*360 - 180}
+		alt := rng.Float64()*40000000 - 5000
+		got, gotAlt := FromECEF(ToECEF(c, alt))
+		// Compare positions in meters, which stays meaningful near the poles
+		if d := Distance3D(c, alt, got, gotAlt); d > 1e-4 {
+			t.Errorf("round trip of %v at %v m moved %v m to %v at %v m", c, alt, d, got, gotAlt)
+		}
+	}
+}
+
+func TestDistance3D(t *testing.T) {
+	c := Coord{Lat: 51.47, Lon: -0.4543}
+
+	// Straight up
+	if d := Distance3D(c, 0, c, 10000); !almostEqualTolerance(d, 10000, 1e-6) {
+		t.Errorf("expected 10000 m, got %v", d)
+	}
+	// Symmetric and zero for identical positions
+	other := Coord{Lat: 51.5, Lon: -0.2}
+	if !almostEqual(Distance3D(c, 100, other, 3000), Distance3D(other, 3000, c, 100)) {
+		t.Error("Distance3D not symmetric")
+	}
+	if d := Distance3D(c, 500, c, 500); d != 0 {
+		t.Errorf("expected 0, got %v", d)
+	}
+
+	// At ground level the chord is slightly shorter than the geodesic
+	geodesic, err := Karney(c, other)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	chord := Distance3D(c, 0, other, 0)
+	if chord > geodesic || geodesic-chord > 0.01 {
+		t.Errorf("expected chord just below geodesic %v m, got %v m", geodesic, chord)
+	}
+
+	// Slant range to an aircraft 10 km up and 30 km away: the flat-Earth
+	// hypotenuse plus a little for the curvature below the aircraft
+	slant := Distance3D(c, 0, Destination(c, 90, 30), 10000)
+	if flat := math.Hypot(30000, 10000); slant < flat || slant > flat+200 {
+		t.Errorf("unexpected slant range %v m", slant)
+	}
+}
+
+func BenchmarkDistance3D(b *testing.B) {
+	nyc := Coord{Lat: 40.7128, Lon: -74.0060}
+	london := Coord{Lat: 51.5074, Lon: -0.1278}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Distance3D(nyc, 10000, london, 0)
+	}
+}
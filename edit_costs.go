@@ -0,0 +1,104 @@
+package distance
+
+import "unicode"
+
+// Cost presets for WeightedEditDistance. Insertions and deletions keep the
+// default cost of 1; only substitutions between commonly confused characters
+// are discounted.
+
+// KeyboardCosts returns edit costs for typo correction on a US QWERTY
+// keyboard: substituting a key for a physically adjacent one (including
+// diagonals, e.g. 'q'↔'w', 'q'↔'a', 'g'↔'b') costs adjacentCost, any other
+// substitution 1. Letters are compared case-insensitively, so 'Q'↔'w' is
+// also adjacent.
+func KeyboardCosts(adjacentCost float64) EditCosts {
+	return EditCosts{
+		Substitute: func(a, b rune) float64 {
+			if qwertyAdjacent[[2]rune{unicode.ToLower(a), unicode.ToLower(b)}] {
+				return adjacentCost
+			}
+			return 1
+		},
+	}
+}
+
+// OCRCosts returns edit costs for correcting optical character recognition
+// output: substituting characters that scanners often confuse (O/0, l/1,
+// I/l, S/5, B/8, c/e, n/h, ...) costs confusedCost, any other substitution 1.
+// Multi-character confusions such as "rn"/"m" are not covered, since edit
+// operations act on single runes.
+func OCRCosts(confusedCost float64) EditCosts {
+	return EditCosts{
+		Substitute: func(a, b rune) float64 {
+			if ocrConfusable[[2]rune{a, b}] {
+				return confusedCost
+			}
+			return 1
+		},
+	}
+}
+
+// ConfusableCosts returns edit costs for homoglyph-aware comparison:
+// substituting one character for a visual lookalike (Cyrillic 'а' for Latin
+// 'a', '0' for 'O', 'I' for 'l'; see ConfusableSkeleton) costs confusedCost,
+// typically 0, any other substitution 1. Multi-letter confusions such as
+// "rn"/"m" need ConfusableDistance, which compares whole skeletons.
+func ConfusableCosts(confusedCost float64) EditCosts {
+	return EditCosts{
+		Substitute: func(a, b rune) float64 {
+			if ConfusableSkeleton(string(a)) == ConfusableSkeleton(string(b)) {
+				return confusedCost
+			}
+			return 1
+		},
+	}
+}
+
+// qwertyRows lists the unshifted US QWERTY rows, each offset by roughly half
+// a key to the right of the row above
+var qwertyRows = []string{"1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}
+
+// qwertyAdjacent holds ordered pairs of neighboring keys
+var qwertyAdjacent = func() map[[2]rune]bool {
+	rows := make([][]rune, len(qwertyRows))
+	for i, row := range qwertyRows {
+		rows[i] = []rune(row)
+	}
+	adjacent := make(map[[2]rune]bool)
+	link := func(r, c, r2, c2 int) {
+		if r2 < 0 || r2 >= len(rows) || c2 < 0 || c2 >= len(rows[r2]) {
+			return
+		}
+		a, b := rows[r][c], rows[r2][c2]
+		adjacent[[2]rune{a, b}], adjacent[[2]rune{b, a}] = true, true
+	}
+	for r := range rows {
+		for c := range rows[r] {
+			link(r, c, r, c+1)   // right
+			link(r, c, r+1, c-1) // below left
+			link(r, c, r+1, c)   // below right
+		}
+	}
+	return adjacent
+}()
+
+// ocrGroups lists sets of characters that OCR engines commonly confuse
+var ocrGroups = []string{
+	"O0oDQ", "l1I|i!", "S5s$", "B8", "Z2z", "G6b", "g9q", "A4", "T7",
+	"E3", "ce", "nh", "uv", "vy", "'`,", ".,",
+}
+
+// ocrConfusable holds ordered pairs of characters in the same ocrGroups entry
+var ocrConfusable = func() map[[2]rune]bool {
+	confusable := make(map[[2]rune]bool)
+	for _, group := range ocrGroups {
+		for _, a := range group {
+			for _, b := range group {
+				if a != b {
+					confusable[[2]rune{a, b}] = true
+				}
+			}
+		}
+	}
+	return confusable
+}()
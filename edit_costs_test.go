@@ -0,0 +1,92 @@
+package distance
+
+import "testing"
+
+func TestKeyboardCosts(t *testing.T) {
+	costs := KeyboardCosts(0.5)
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"quick", "wuick", 0.5}, // q→w same row
+		{"quick", "auick", 0.5}, // q→a diagonal
+		{"quick", "puick", 1},   // q→p far apart
+		{"gave", "bave", 0.5},   // g→b below
+		{"Hello", "jello", 0.5}, // case-insensitive h→j
+		{"test", "test", 0},
+		{"form", "fprm", 0.5},
+		{"tset", "test", 1}, // s→e and e→s are both adjacent
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			result, err := WeightedEditDistance(tt.a, tt.b, costs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// A typo next to the intended key ranks above an unrelated word
+	near, _ := WeightedEditDistance("hwllo", "hello", costs)
+	far, _ := WeightedEditDistance("hwllo", "hullo", costs)
+	if near >= far {
+		t.Errorf("expected hello (%v) closer than hullo (%v)", near, far)
+	}
+}
+
+func TestOCRCosts(t *testing.T) {
+	costs := OCRCosts(0.2)
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"B0OK", "BOOK", 0.2},
+		{"he1lo", "hello", 0.2},
+		{"5ILVER", "SILVER", 0.2},
+		{"C0DE 8", "CODE B", 0.4},
+		{"hello", "hallo", 1},
+		{"rnodern", "modern", 2}, // rn→m is not a single-rune confusion
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			result, err := WeightedEditDistance(tt.a, tt.b, costs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConfusableCosts(t *testing.T) {
+	costs := ConfusableCosts(0)
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"pаypаl", "paypal", 0},
+		{"ΑΡΡLΕ", "APPLE", 0},
+		{"paypa1", "paypal", 0},
+		{"paypol", "paypal", 1},
+		{"rnicrosoft", "microsoft", 2}, // rn→m spans two runes
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			result, err := WeightedEditDistance(tt.a, tt.b, costs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package distance
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EmbeddingCache stores embedding vectors normalized once at insertion time,
+// caches their norms, and memoizes pairwise cosine similarities with a TTL.
+// It is safe for concurrent use.
+type EmbeddingCache[K comparable] struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	vectors map[K]cachedEmbedding
+	pairs   map[[2]K]cachedPair
+	links   map[K]map[[2]K]struct{} // pair keys involving each key
+	version uint64
+	now     func() time.Time
+}
+
+// ScoredKey is a cache key paired with a similarity score.
+type ScoredKey[K comparable] struct {
+	Key   K
+	Score float64
+}
+
+type cachedEmbedding struct {
+	unit    []float64
+	norm    float64
+	version uint64
+}
+
+type cachedPair struct {
+	similarity float64
+	versionA   uint64
+	versionB   uint64
+	expires    time.Time
+}
+
+// NewEmbeddingCache creates an empty cache. Pairwise results expire after ttl;
+// a ttl of 0 keeps them until either vector is replaced or deleted.
+func NewEmbeddingCache[K comparable](ttl time.Duration) *EmbeddingCache[K] {
+	return &EmbeddingCache[K]{
+		ttl:     ttl,
+		vectors: make(map[K]cachedEmbedding),
+		pairs:   make(map[[2]K]cachedPair),
+		links:   make(map[K]map[[2]K]struct{}),
+		now:     time.Now,
+	}
+}
+
+// Put stores vector under key, normalizing it to unit length.
+// Replacing a key drops every cached pair that involves it.
+// Time: O(d + p) where p = cached pairs involving key, Space: O(d)
+func (c *EmbeddingCache[K]) Put(key K, vector []float64) error {
+	unit, err := unitVector(vector)
+	if err != nil {
+		return err
+	}
+	norm := math.Sqrt(dotF64(vector, vector))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version++
+	c.vectors[key] = cachedEmbedding{unit: unit, norm: norm, version: c.version}
+	c.dropPairs(key)
+	return nil
+}
+
+// Get returns a copy of the unit vector stored under key and its original norm.
+func (c *EmbeddingCache[K]) Get(key K) ([]float64, float64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.vectors[key]
+	if !ok {
+		return nil, 0, false
+	}
+	return append([]float64{}, e.unit...), e.norm, true
+}
+
+// Delete removes key and every cached pair that involves it.
+// Time: O(p) where p = cached pairs involving key
+func (c *EmbeddingCache[K]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.vectors, key)
+	c.dropPairs(key)
+}
+
+// Len returns the number of stored vectors.
+func (c *EmbeddingCache[K]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.vectors)
+}
+
+// Similarity returns the cosine similarity between two cached vectors,
+// serving a memoized result when one is still fresh.
+// Time: O(1) on cache hit, O(d) otherwise
+func (c *EmbeddingCache[K]) Similarity(a, b K) (float64, error) {
+	c.mu.RLock()
+	ea, okA := c.vectors[a]
+	eb, okB := c.vectors[b]
+	pair, hit := c.pairs[[2]K{a, b}]
+	if !hit {
+		pair, hit = c.pairs[[2]K{b, a}]
+		pair.versionA, pair.versionB = pair.versionB, pair.versionA
+	}
+	c.mu.RUnlock()
+
+	if !okA || !okB {
+		return 0, ErrKeyNotFound
+	}
+	now := c.now()
+	if hit && pair.versionA == ea.version && pair.versionB == eb.version &&
+		(c.ttl == 0 || now.Before(pair.expires)) {
+		return pair.similarity, nil
+	}
+
+	sim, err := DotProductF64(ea.unit, eb.unit)
+	if err != nil {
+		return 0, err
+	}
+	sim = clampUnit(sim)
+
+	c.mu.Lock()
+	// Skip storing if either vector was replaced or deleted meanwhile
+	if c.vectors[a].version == ea.version && c.vectors[b].version == eb.version {
+		pk := [2]K{a, b}
+		c.pairs[pk] = cachedPair{
+			similarity: sim,
+			versionA:   ea.version,
+			versionB:   eb.version,
+			expires:    now.Add(c.ttl),
+		}
+		c.link(a, pk)
+		c.link(b, pk)
+	}
+	c.mu.Unlock()
+	return sim, nil
+}
+
+// BatchCosine computes cosine similarity between query and each cached key.
+// The query is normalized once; cached vectors are already unit length.
+// Time: O(kd) where k = len(keys), Space: O(k+d)
+func (c *EmbeddingCache[K]) BatchCosine(query []float64, keys []K) ([]float64, error) {
+	unit, err := unitVector(query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]float64, len(keys))
+	for i, key := range keys {
+		e, ok := c.vectors[key]
+		if !ok {
+			return nil, ErrKeyNotFound
+		}
+		if len(e.unit) != len(unit) {
+			return nil, ErrDimensionMismatch
+		}
+		result[i] = clampUnit(dotF64(unit, e.unit))
+	}
+	return result, nil
+}
+
+// TopK returns the k cached keys most similar to query by cosine similarity,
+// sorted by descending score.
+// Time: O(nd + n log n), Space: O(n)
+func (c *EmbeddingCache[K]) TopK(query []float64, k int) ([]ScoredKey[K], error) {
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	unit, err := unitVector(query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	scored := make([]ScoredKey[K], 0, len(c.vectors))
+	for key, e := range c.vectors {
+		if len(e.unit) != len(unit) {
+			c.mu.RUnlock()
+			return nil, ErrDimensionMismatch
+		}
+		scored = append(scored, ScoredKey[K]{Key: key, Score: clampUnit(dotF64(unit, e.unit))})
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+	if len(scored) > k {
+		scored = scored[:k]
+	}
+	return scored, nil
+}
+
+// PurgeExpired removes expired or stale pairwise results and returns how many were removed.
+func (c *EmbeddingCache[K]) PurgeExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	removed := 0
+	for key, pair := range c.pairs {
+		ea, okA := c.vectors[key[0]]
+		eb, okB := c.vectors[key[1]]
+		stale := !okA || !okB || ea.version != pair.versionA || eb.version != pair.versionB
+		if stale || (c.ttl > 0 && !now.Before(pair.expires)) {
+			c.unlink(key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// link records that pair key pk involves key; callers hold c.mu
+func (c *EmbeddingCache[K]) link(key K, pk [2]K) {
+	if c.links[key] == nil {
+		c.links[key] = make(map[[2]K]struct{})
+	}
+	c.links[key][pk] = struct{}{}
+}
+
+// unlink removes the cached pair pk from pairs and links; callers hold c.mu
+func (c *EmbeddingCache[K]) unlink(pk [2]K) {
+	delete(c.pairs, pk)
+	for _, key := range pk {
+		delete(c.links[key], pk)
+		if len(c.links[key]) == 0 {
+			delete(c.links, key)
+		}
+	}
+}
+
+// dropPairs removes every cached pair involving key; callers hold c.mu
+func (c *EmbeddingCache[K]) dropPairs(key K) {
+	for pk := range c.links[key] {
+		c.unlink(pk)
+	}
+}
+
+// unitVector returns v scaled to unit length
+func unitVector(v []float64) ([]float64, error) {
+	if len(v) == 0 {
+		return nil, ErrEmptyInput
+	}
+	norm := math.Sqrt(dotF64(v, v))
+	if norm == 0 {
+		return nil, ErrZeroVector
+	}
+	unit := make([]float64, len(v))
+	for i, x := range v {
+		unit[i] = x / norm
+	}
+	return unit, nil
+}
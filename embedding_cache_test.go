@@ -0,0 +1,139 @@
+package distance
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEmbeddingCachePutGet(t *testing.T) {
+	c := NewEmbeddingCache[string](time.Minute)
+	if err := c.Put("a", []float64{3, 4}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	unit, norm, ok := c.Get("a")
+	if !ok {
+		t.Fatal("expected key to be present")
+	}
+	if !almostEqual(norm, 5) || !almostEqual(unit[0], 0.6) || !almostEqual(unit[1], 0.8) {
+		t.Errorf("expected unit [0.6 0.8] with norm 5, got %v %v", unit, norm)
+	}
+
+	if err := c.Put("zero", []float64{0, 0}); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+	if c.Len() != 1 {
+		t.Errorf("expected 1 vector, got %d", c.Len())
+	}
+
+	c.Delete("a")
+	if _, _, ok := c.Get("a"); ok {
+		t.Error("expected key to be deleted")
+	}
+}
+
+func TestEmbeddingCacheSimilarity(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := NewEmbeddingCache[int](time.Second)
+	c.now = func() time.Time { return now }
+
+	_ = c.Put(1, []float64{1, 0})
+	_ = c.Put(2, []float64{1, 1})
+
+	sim, err := c.Similarity(1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(sim, 1/math.Sqrt(2)) {
+		t.Errorf("expected %v, got %v", 1/math.Sqrt(2), sim)
+	}
+
+	// Reverse order hits the same cached entry
+	sim, _ = c.Similarity(2, 1)
+	if !almostEqual(sim, 1/math.Sqrt(2)) {
+		t.Errorf("expected symmetric result, got %v", sim)
+	}
+
+	// Replacing a vector invalidates cached pairs
+	_ = c.Put(2, []float64{0, 1})
+	sim, _ = c.Similarity(1, 2)
+	if !almostEqual(sim, 0) {
+		t.Errorf("expected 0 after update, got %v", sim)
+	}
+
+	now = now.Add(2 * time.Second)
+	if removed := c.PurgeExpired(); removed != 1 {
+		t.Errorf("expected 1 expired pair, got %d", removed)
+	}
+
+	if _, err := c.Similarity(1, 99); err != ErrKeyNotFound {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestEmbeddingCacheDropsPairs(t *testing.T) {
+	c := NewEmbeddingCache[int](0)
+	for k := 0; k < 4; k++ {
+		_ = c.Put(k, []float64{1, float64(k)})
+	}
+	for a := 0; a < 4; a++ {
+		for b := a + 1; b < 4; b++ {
+			_, _ = c.Similarity(a, b)
+		}
+	}
+	if len(c.pairs) != 6 {
+		t.Fatalf("expected 6 cached pairs, got %d", len(c.pairs))
+	}
+
+	// Pairs of a replaced or deleted key go immediately, without PurgeExpired
+	_ = c.Put(0, []float64{0, 1})
+	if len(c.pairs) != 3 {
+		t.Errorf("expected 3 pairs after replacing a key, got %d", len(c.pairs))
+	}
+	c.Delete(1)
+	if len(c.pairs) != 1 || len(c.links) != 2 {
+		t.Errorf("expected 1 pair linking 2 keys after deleting a key, got %d pairs, %d links", len(c.pairs), len(c.links))
+	}
+	c.Delete(2)
+	c.Delete(3)
+	if len(c.pairs) != 0 || len(c.links) != 0 {
+		t.Errorf("expected no pairs left, got %d pairs, %d links", len(c.pairs), len(c.links))
+	}
+}
+
+func TestEmbeddingCacheBatchCosineAndTopK(t *testing.T) {
+	c := NewEmbeddingCache[string](0)
+	_ = c.Put("x", []float64{1, 0})
+	_ = c.Put("y", []float64{0, 2})
+	_ = c.Put("xy", []float64{1, 1})
+
+	sims, err := c.BatchCosine([]float64{10, 0}, []string{"x", "y", "xy"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []float64{1, 0, 1 / math.Sqrt(2)}
+	for i := range expected {
+		if !almostEqual(sims[i], expected[i]) {
+			t.Errorf("sims[%d]: expected %v, got %v", i, expected[i], sims[i])
+		}
+	}
+
+	top, err := c.TopK([]float64{2, 1}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(top) != 2 || top[0].Key != "xy" || top[1].Key != "x" {
+		t.Errorf("expected [xy x], got %v", top)
+	}
+
+	if _, err := c.BatchCosine([]float64{1, 0}, []string{"missing"}); err != ErrKeyNotFound {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+	if _, err := c.BatchCosine([]float64{1, 0, 0}, []string{"x"}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := c.TopK([]float64{1, 0}, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
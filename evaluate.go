@@ -0,0 +1,119 @@
+package distance
+
+import (
+	"runtime"
+	"sort"
+	"time"
+)
+
+// IndexEvaluation reports the quality and cost of an approximate index
+// measured against exact ground truth.
+type IndexEvaluation struct {
+	K            int           `json:"k"`
+	RecallAtK    float64       `json:"recall_at_k"`    // mean fraction of true neighbors found
+	PrecisionAtK float64       `json:"precision_at_k"` // mean fraction of returned results that are true neighbors
+	MeanLatency  time.Duration `json:"mean_latency"`
+	P50Latency   time.Duration `json:"p50_latency"`
+	P99Latency   time.Duration `json:"p99_latency"`
+	BuildTime    time.Duration `json:"build_time"`
+	MemoryBytes  int64         `json:"memory_bytes"` // heap growth while building, approximate
+}
+
+// EvaluateIndex builds an index with build, runs every query against it and
+// against truth (typically a BruteForceIndex over the same data), and reports
+// recall@k, precision@k, query latency and build cost. Results are matched by
+// Index, so both searchers must be built from the same data in the same order.
+// Memory is the live-heap growth across build and is only indicative.
+// Time: O(q) searches on both indexes, Space: O(qk)
+func EvaluateIndex[T Number](build func() (Searcher[[]T], error), truth Searcher[[]T], queries [][]T, k int) (*IndexEvaluation, error) {
+	if len(queries) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	approx, err := build()
+	if err != nil {
+		return nil, err
+	}
+	eval := &IndexEvaluation{K: k, BuildTime: time.Since(start)}
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	eval.MemoryBytes = int64(after.HeapAlloc) - int64(before.HeapAlloc)
+
+	latencies := make([]float64, len(queries))
+	var recallSum, precisionSum float64
+	for i, q := range queries {
+		exact, err := truth.Search(q, k)
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		got, err := approx.Search(q, k)
+		latencies[i] = float64(time.Since(start))
+		if err != nil {
+			return nil, err
+		}
+
+		recall, precision := searchOverlap(exact, got)
+		recallSum += recall
+		precisionSum += precision
+	}
+
+	n := float64(len(queries))
+	eval.RecallAtK = recallSum / n
+	eval.PrecisionAtK = precisionSum / n
+	sort.Float64s(latencies)
+	eval.MeanLatency = time.Duration(mean(latencies))
+	eval.P50Latency = time.Duration(percentile(latencies, 0.5))
+	eval.P99Latency = time.Duration(percentile(latencies, 0.99))
+
+	runtime.KeepAlive(approx)
+	return eval, nil
+}
+
+// RecallAtK returns the fraction of truth results also present in got, matched by Index.
+// Time: O(k), Space: O(k)
+func RecallAtK(truth, got []SearchResult) float64 {
+	recall, _ := searchOverlap(truth, got)
+	return recall
+}
+
+// PrecisionAtK returns the fraction of got results present in truth, matched by Index.
+// Time: O(k), Space: O(k)
+func PrecisionAtK(truth, got []SearchResult) float64 {
+	_, precision := searchOverlap(truth, got)
+	return precision
+}
+
+// searchOverlap returns recall and precision of got against truth;
+// empty result sets count as perfect
+func searchOverlap(truth, got []SearchResult) (float64, float64) {
+	relevant := make(map[int]bool, len(truth))
+	for _, r := range truth {
+		relevant[r.Index] = true
+	}
+
+	hits := 0
+	for _, r := range got {
+		if relevant[r.Index] {
+			hits++
+			relevant[r.Index] = false // count duplicates once
+		}
+	}
+
+	recall, precision := 1.0, 1.0
+	if len(truth) > 0 {
+		recall = float64(hits) / float64(len(truth))
+	}
+	if len(got) > 0 {
+		precision = float64(hits) / float64(len(got))
+	}
+	return recall, precision
+}
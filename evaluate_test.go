@@ -0,0 +1,64 @@
+package distance
+
+import "testing"
+
+// truncatedSearcher simulates a lossy index by returning only part of the truth
+type truncatedSearcher struct {
+	inner Searcher[[]float64]
+	keep  int
+}
+
+func (s truncatedSearcher) Search(q []float64, k int) ([]SearchResult, error) {
+	res, err := s.inner.Search(q, k)
+	if err != nil || len(res) <= s.keep {
+		return res, err
+	}
+	return res[:s.keep], nil
+}
+
+func (s truncatedSearcher) SearchRadius(q []float64, radius float64) ([]SearchResult, error) {
+	return s.inner.SearchRadius(q, radius)
+}
+
+func TestRecallPrecisionAtK(t *testing.T) {
+	truth := []SearchResult{{Index: 1}, {Index: 2}, {Index: 3}, {Index: 4}}
+	got := []SearchResult{{Index: 2}, {Index: 9}, {Index: 4}}
+
+	if r := RecallAtK(truth, got); !almostEqual(r, 0.5) {
+		t.Errorf("expected recall 0.5, got %v", r)
+	}
+	if p := PrecisionAtK(truth, got); !almostEqual(p, 2.0/3) {
+		t.Errorf("expected precision %v, got %v", 2.0/3, p)
+	}
+}
+
+func TestEvaluateIndex(t *testing.T) {
+	exact := newTestIndex(t)
+	queries := [][]float64{{0, 0}, {2, 0}, {9, 9}}
+
+	eval, err := EvaluateIndex(func() (Searcher[[]float64], error) {
+		return truncatedSearcher{inner: newTestIndex(t), keep: 1}, nil
+	}, exact, queries, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !almostEqual(eval.RecallAtK, 0.5) {
+		t.Errorf("expected recall 0.5, got %v", eval.RecallAtK)
+	}
+	if !almostEqual(eval.PrecisionAtK, 1) {
+		t.Errorf("expected precision 1, got %v", eval.PrecisionAtK)
+	}
+	if eval.K != 2 || eval.P99Latency < eval.P50Latency {
+		t.Errorf("unexpected evaluation %+v", eval)
+	}
+
+	self, _ := EvaluateIndex(func() (Searcher[[]float64], error) { return exact, nil }, exact, queries, 3)
+	if self.RecallAtK != 1 || self.PrecisionAtK != 1 {
+		t.Errorf("expected perfect scores against itself, got %+v", self)
+	}
+
+	if _, err := EvaluateIndex(nil, exact, nil, 2); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
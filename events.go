@@ -0,0 +1,157 @@
+package distance
+
+import "math"
+
+// Event is a typed occurrence at a point in time, such as a page view in a
+// user session or a message in a device log. Times are in any consistent
+// unit; only differences between them matter.
+type Event struct {
+	Type string  `json:"type"`
+	Time float64 `json:"time"`
+}
+
+// TimeWarpEditDistance computes the time-warp edit distance (TWED, Marteau
+// 2009) between two event sequences sorted by time, with each sequence's
+// times measured from its first event. Matching two events costs their type
+// mismatch (0 or 1) plus stiffness times the difference of their offsets;
+// deleting an event costs penalty plus its type change from the previous
+// event plus stiffness times the time elapsed since it. stiffness 0 ignores
+// timing entirely; larger values make sessions with the same steps at a
+// different pace further apart. TWED is a metric for stiffness, penalty ≥ 0.
+// Time: O(mn), Space: O(n)
+func TimeWarpEditDistance(a, b []Event, stiffness, penalty float64) (float64, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, ErrEmptyInput
+	}
+	if stiffness < 0 || penalty < 0 {
+		return 0, ErrInvalidParameter
+	}
+	if err := validateEvents(a); err != nil {
+		return 0, err
+	}
+	if err := validateEvents(b); err != nil {
+		return 0, err
+	}
+
+	// Index 0 is a virtual start event at offset 0 whose type matches nothing
+	typeCost := func(x []Event, i int, y []Event, j int) float64 {
+		switch {
+		case i == 0 && j == 0:
+			return 0
+		case i == 0 || j == 0 || x[i-1].Type != y[j-1].Type:
+			return 1
+		default:
+			return 0
+		}
+	}
+	offset := func(x []Event, i int) float64 {
+		if i == 0 {
+			return 0
+		}
+		return x[i-1].Time - x[0].Time
+	}
+
+	m, n := len(a), len(b)
+	prev := make([]float64, n+1)
+	curr := make([]float64, n+1)
+	for j := 1; j <= n; j++ {
+		prev[j] = math.Inf(1)
+	}
+	for i := 1; i <= m; i++ {
+		curr[0] = math.Inf(1)
+		deleteA := typeCost(a, i-1, a, i) + stiffness*(offset(a, i)-offset(a, i-1)) + penalty
+		for j := 1; j <= n; j++ {
+			deleteB := typeCost(b, j-1, b, j) + stiffness*(offset(b, j)-offset(b, j-1)) + penalty
+			match := typeCost(a, i, b, j) + typeCost(a, i-1, b, j-1) +
+				stiffness*(math.Abs(offset(a, i)-offset(b, j))+math.Abs(offset(a, i-1)-offset(b, j-1)))
+			curr[j] = math.Min(prev[j-1]+match, math.Min(prev[j]+deleteA, curr[j-1]+deleteB))
+		}
+		prev, curr = curr, prev
+	}
+	return prev[n], nil
+}
+
+// EventLocalAlignment finds the best-scoring local alignment of two event
+// sequences sorted by time (Smith-Waterman over event types), e.g. the
+// shared core of two sessions that start and end differently. Aligned
+// events score match when their types agree and mismatch otherwise, minus
+// timeWeight times the difference between the intervals since each
+// sequence's previous event; gaps score gap. mismatch and gap are normally
+// negative. Returns the score and the aligned pairs in order, with -1 marking
+// a gap.
+// Time: O(mn), Space: O(mn)
+func EventLocalAlignment(a, b []Event, match, mismatch, gap, timeWeight float64) (float64, []AlignedPair, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, nil, ErrEmptyInput
+	}
+	if timeWeight < 0 {
+		return 0, nil, ErrInvalidParameter
+	}
+	if err := validateEvents(a); err != nil {
+		return 0, nil, err
+	}
+	if err := validateEvents(b); err != nil {
+		return 0, nil, err
+	}
+
+	interval := func(x []Event, i int) float64 {
+		if i == 0 {
+			return 0
+		}
+		return x[i].Time - x[i-1].Time
+	}
+	pairScore := func(i, j int) float64 {
+		s := mismatch
+		if a[i].Type == b[j].Type {
+			s = match
+		}
+		return s - timeWeight*math.Abs(interval(a, i)-interval(b, j))
+	}
+
+	m, n := len(a), len(b)
+	H := make([][]float64, m+1)
+	for i := range H {
+		H[i] = make([]float64, n+1)
+	}
+	best, bestI, bestJ := 0.0, 0, 0
+	for i := 1; i <= m; i++ {
+		for j := 1; j <= n; j++ {
+			H[i][j] = max(0, H[i-1][j-1]+pairScore(i-1, j-1), H[i-1][j]+gap, H[i][j-1]+gap)
+			if H[i][j] > best {
+				best, bestI, bestJ = H[i][j], i, j
+			}
+		}
+	}
+
+	var pairs []AlignedPair
+	for i, j := bestI, bestJ; i > 0 && j > 0 && H[i][j] > 0; {
+		switch {
+		case H[i][j] == H[i-1][j-1]+pairScore(i-1, j-1):
+			pairs = append(pairs, AlignedPair{A: i - 1, B: j - 1})
+			i, j = i-1, j-1
+		case H[i][j] == H[i-1][j]+gap:
+			pairs = append(pairs, AlignedPair{A: i - 1, B: -1})
+			i--
+		default:
+			pairs = append(pairs, AlignedPair{A: -1, B: j - 1})
+			j--
+		}
+	}
+	for l, r := 0, len(pairs)-1; l < r; l, r = l+1, r-1 {
+		pairs[l], pairs[r] = pairs[r], pairs[l]
+	}
+	return best, pairs, nil
+}
+
+// validateEvents checks that events are sorted by time with finite timestamps
+func validateEvents(events []Event) error {
+	for i, e := range events {
+		if math.IsNaN(e.Time) || math.IsInf(e.Time, 0) {
+			return ErrInvalidParameter
+		}
+		if i > 0 && e.Time < events[i-1].Time {
+			return ErrInvalidParameter
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package distance
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// eventsAt builds events with the given types at the given times
+func eventsAt(types string, times ...float64) []Event {
+	events := make([]Event, len(types))
+	for i, c := range types {
+		events[i] = Event{Type: string(c), Time: times[i]}
+	}
+	return events
+}
+
+func TestTimeWarpEditDistance(t *testing.T) {
+	session := eventsAt("ABC", 0, 1, 2)
+
+	tests := []struct {
+		name      string
+		b         []Event
+		stiffness float64
+		expected  float64
+	}{
+		{"identical", session, 1, 0},
+		{"shifted start", eventsAt("ABC", 100, 101, 102), 1, 0},
+		// Offsets differ by 1 at B and 2 at C: (1 + 0) + (2 + 1)
+		{"slower pace", eventsAt("ABC", 0, 2, 4), 1, 4},
+		{"pace ignored", eventsAt("ABC", 0, 2, 4), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := TimeWarpEditDistance(session, tt.b, tt.stiffness, 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// An extra step costs more than a change of pace
+	extra, _ := TimeWarpEditDistance(session, eventsAt("ABXC", 0, 1, 1.5, 2), 0.1, 1)
+	pace, _ := TimeWarpEditDistance(session, eventsAt("ABC", 0, 1.5, 2.5), 0.1, 1)
+	if extra <= pace {
+		t.Errorf("expected an inserted event to cost more than a pace change, got %v <= %v", extra, pace)
+	}
+}
+
+func TestTimeWarpEditDistanceMetric(t *testing.T) {
+	rng := testRNG(3)
+	random := func() []Event {
+		events := make([]Event, 1+rng.IntN(6))
+		clock := 0.0
+		for i := range events {
+			clock += rng.Float64() * 5
+			events[i] = Event{Type: string(rune('A' + rng.IntN(3))), Time: clock}
+		}
+		return events
+	}
+
+	for trial := 0; trial < 200; trial++ {
+		x, y, z := random(), random(), random()
+		xy, _ := TimeWarpEditDistance(x, y, 0.5, 1)
+		yx, _ := TimeWarpEditDistance(y, x, 0.5, 1)
+		yz, _ := TimeWarpEditDistance(y, z, 0.5, 1)
+		xz, _ := TimeWarpEditDistance(x, z, 0.5, 1)
+		if !almostEqual(xy, yx) {
+			t.Fatalf("not symmetric: %v vs %v", xy, yx)
+		}
+		if xz > xy+yz+epsilon {
+			t.Fatalf("triangle inequality violated: %v > %v + %v", xz, xy, yz)
+		}
+	}
+}
+
+func TestEventLocalAlignment(t *testing.T) {
+	a := eventsAt("XYABCZ", 0, 1, 2, 3, 4, 5)
+	b := eventsAt("QABCR", 10, 11, 12, 13, 14)
+
+	score, pairs, err := EventLocalAlignment(a, b, 2, -1, -1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(score, 6) {
+		t.Errorf("expected 6, got %v", score)
+	}
+	expected := []AlignedPair{{2, 1}, {3, 2}, {4, 3}}
+	if !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("expected %v, got %v", expected, pairs)
+	}
+
+	// A long pause inside the shared core is penalized by timeWeight
+	slow := eventsAt("QABCR", 10, 11, 12, 20, 21)
+	timed, _, _ := EventLocalAlignment(a, slow, 2, -1, -1, 0.1)
+	if timed >= score {
+		t.Errorf("expected the pause to lower the score, got %v", timed)
+	}
+
+	// With a gap inside the match
+	gapped := eventsAt("ABXC", 0, 1, 2, 3)
+	score, pairs, _ = EventLocalAlignment(eventsAt("ABC", 0, 1, 2), gapped, 2, -1, -1, 0)
+	if !almostEqual(score, 5) || !reflect.DeepEqual(pairs, []AlignedPair{{0, 0}, {1, 1}, {-1, 2}, {2, 3}}) {
+		t.Errorf("unexpected alignment %v (score %v)", pairs, score)
+	}
+}
+
+func TestEventDistanceErrors(t *testing.T) {
+	valid := eventsAt("AB", 0, 1)
+	unsorted := eventsAt("AB", 1, 0)
+	if _, err := TimeWarpEditDistance(nil, valid, 1, 1); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := TimeWarpEditDistance(valid, unsorted, 1, 1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := TimeWarpEditDistance(valid, valid, -1, 1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := TimeWarpEditDistance(valid, []Event{{"A", math.NaN()}}, 1, 1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, _, err := EventLocalAlignment(valid, nil, 2, -1, -1, 0); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, _, err := EventLocalAlignment(unsorted, valid, 2, -1, -1, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
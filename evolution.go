@@ -0,0 +1,133 @@
+package distance
+
+import "math"
+
+// Evolutionary distances over aligned nucleotide sequences. Sequences must
+// have equal length (e.g. rows of a multiple alignment, or two sequences
+// padded with '-' by a global alignment). Letters are case-insensitive and U
+// is read as T; sites where either sequence has a gap or an ambiguous base
+// (anything but A, C, G, T) are skipped (pairwise deletion).
+
+// PDistance returns the proportion of compared sites at which two aligned
+// nucleotide sequences differ, the uncorrected input to JukesCantor.
+// Time: O(n), Space: O(1)
+func PDistance(a, b string) (float64, error) {
+	transitions, transversions, sites, err := countSubstitutions(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return float64(transitions+transversions) / float64(sites), nil
+}
+
+// JukesCantor estimates the number of substitutions per site under the JC69
+// model, which assumes equal base frequencies and substitution rates:
+// d = -3/4·ln(1 - 4p/3), where p is the PDistance. It corrects for multiple
+// substitutions at the same site hidden by the raw mismatch proportion.
+// Returns ErrSaturated if p ≥ 3/4.
+// Time: O(n), Space: O(1)
+func JukesCantor(a, b string) (float64, error) {
+	p, err := PDistance(a, b)
+	if err != nil {
+		return 0, err
+	}
+	d, ok := jukesCantorCorrect(p)
+	if !ok {
+		return 0, ErrSaturated
+	}
+	return d, nil
+}
+
+// Kimura2P estimates substitutions per site under Kimura's two-parameter
+// (K80) model, which rates transitions (A↔G, C↔T) and transversions
+// separately: d = -½·ln(1 - 2P - Q) - ¼·ln(1 - 2Q), where P and Q are the
+// proportions of transition and transversion sites.
+// Returns ErrSaturated if either logarithm is undefined.
+// Time: O(n), Space: O(1)
+func Kimura2P(a, b string) (float64, error) {
+	transitions, transversions, sites, err := countSubstitutions(a, b)
+	if err != nil {
+		return 0, err
+	}
+	p := float64(transitions) / float64(sites)
+	q := float64(transversions) / float64(sites)
+	if 1-2*p-q <= 0 || 1-2*q <= 0 {
+		return 0, ErrSaturated
+	}
+	return -0.5*math.Log(1-2*p-q) - 0.25*math.Log(1-2*q), nil
+}
+
+// SequenceDistanceMatrix computes pairwise distances between aligned
+// sequences with fn (e.g. JukesCantor or Kimura2P), ready for
+// NeighborJoining or UPGMA.
+// Time: O(n²L), Space: O(n²)
+func SequenceDistanceMatrix(seqs []string, fn func(a, b string) (float64, error)) ([][]float64, error) {
+	if len(seqs) == 0 {
+		return nil, ErrEmptyInput
+	}
+	matrix := make([][]float64, len(seqs))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(seqs))
+	}
+	for i := range seqs {
+		for j := i + 1; j < len(seqs); j++ {
+			d, err := fn(seqs[i], seqs[j])
+			if err != nil {
+				return nil, err
+			}
+			matrix[i][j], matrix[j][i] = d, d
+		}
+	}
+	return matrix, nil
+}
+
+// countSubstitutions counts transition and transversion sites among the
+// sites where both sequences have an unambiguous base
+func countSubstitutions(a, b string) (transitions, transversions, sites int, err error) {
+	if len(a) != len(b) {
+		return 0, 0, 0, ErrDimensionMismatch
+	}
+	for i := 0; i < len(a); i++ {
+		x, y := nucleotideIndex(a[i]), nucleotideIndex(b[i])
+		if x < 0 || y < 0 {
+			continue
+		}
+		sites++
+		switch {
+		case x == y:
+		case x%2 == y%2: // Purines A, G are even; pyrimidines C, T odd
+			transitions++
+		default:
+			transversions++
+		}
+	}
+	if sites == 0 {
+		return 0, 0, 0, ErrEmptyInput
+	}
+	return transitions, transversions, sites, nil
+}
+
+// nucleotideIndex maps A, C, G, T (U) to 0, 1, 2, 3 and anything else to -1
+func nucleotideIndex(c byte) int {
+	switch c {
+	case 'A', 'a':
+		return 0
+	case 'C', 'c':
+		return 1
+	case 'G', 'g':
+		return 2
+	case 'T', 't', 'U', 'u':
+		return 3
+	default:
+		return -1
+	}
+}
+
+// jukesCantorCorrect applies d = -3/4·ln(1 - 4p/3), reporting false when
+// the proportion is saturated
+func jukesCantorCorrect(p float64) (float64, bool) {
+	arg := 1 - 4*p/3
+	if arg <= 0 {
+		return 0, false
+	}
+	return -0.75 * math.Log(arg), true
+}
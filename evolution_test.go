@@ -0,0 +1,123 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected float64
+	}{
+		{"identical", "ACGTACGTAC", "ACGTACGTAC", 0},
+		{"one of ten", "ACGTACGTAC", "ACGTACGTAT", 0.1},
+		{"gaps skipped", "AC-TACGTAC", "ACGTAC-TAT", 0.125},
+		{"ambiguous skipped", "ACNT", "ACGA", 1.0 / 3},
+		{"rna and case", "acgu", "ACGT", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PDistance(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestJukesCantor(t *testing.T) {
+	result, err := JukesCantor("ACGTACGTAC", "ACGTACGTAT")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := -0.75 * math.Log(1-4*0.1/3)
+	if !almostEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+	if p, _ := PDistance("ACGTACGTAC", "ACGTACGTAT"); result <= p {
+		t.Errorf("expected correction to exceed p-distance %v, got %v", p, result)
+	}
+
+	if _, err := JukesCantor("AAAA", "CGTC"); err != ErrSaturated {
+		t.Errorf("expected ErrSaturated, got %v", err)
+	}
+}
+
+func TestKimura2P(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected float64
+	}{
+		{"transition", "ACGTACGTAC", "ACGTACGTAT", -0.5 * math.Log(0.8)},
+		{"transversion", "ACGTACGTAC", "ACGTACGTAA", -0.5*math.Log(0.9) - 0.25*math.Log(0.8)},
+		{"identical", "ACGT", "ACGT", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Kimura2P(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// All transitions saturate K2P
+	if _, err := Kimura2P("AC", "GT"); err != ErrSaturated {
+		t.Errorf("expected ErrSaturated, got %v", err)
+	}
+}
+
+func TestSequenceDistanceMatrix(t *testing.T) {
+	seqs := []string{
+		"ACGTACGTACGTACGTACGT",
+		"ACGTACGTACGTACGTACGA",
+		"ACGTACGAACGTACCTACGA",
+		"TCGAACGAACGTACCTTCGA",
+	}
+	matrix, err := SequenceDistanceMatrix(seqs, JukesCantor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range matrix {
+		if matrix[i][i] != 0 {
+			t.Errorf("expected zero diagonal, got %v", matrix[i][i])
+		}
+	}
+	if !(matrix[0][1] < matrix[0][2] && matrix[0][2] < matrix[0][3]) {
+		t.Errorf("expected distances to grow along the series, got %v", matrix[0])
+	}
+
+	tree, err := NeighborJoining(matrix, []string{"s0", "s1", "s2", "s3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tree.Leaves()) != 4 {
+		t.Errorf("expected 4 leaves, got %v", tree.Leaves())
+	}
+}
+
+func TestEvolutionErrors(t *testing.T) {
+	if _, err := PDistance("ACG", "AC"); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := JukesCantor("--N", "AC-"); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := SequenceDistanceMatrix(nil, JukesCantor); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := SequenceDistanceMatrix([]string{"AAAA", "CGTC"}, JukesCantor); err != ErrSaturated {
+		t.Errorf("expected ErrSaturated, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package distance
+
+import "math"
+
+// Forecast accuracy metrics between actual and predicted series. Every metric
+// handles missing data the same way: a time step where either value is NaN
+// is skipped, and ErrEmptyInput is returned if no step remains. Metrics that
+// divide by the data return ErrZeroVector when every denominator is zero.
+
+// ForecastAccuracy collects the standard forecast error metrics for one
+// forecast. A metric that is undefined for the data (e.g. MAPE when every
+// actual is zero, or MASE without training data) is NaN.
+type ForecastAccuracy struct {
+	MAE   float64 `json:"mae"`
+	MSE   float64 `json:"mse"`
+	RMSE  float64 `json:"rmse"`
+	MAPE  float64 `json:"mape"`
+	SMAPE float64 `json:"smape"`
+	MASE  float64 `json:"mase"`
+	Bias  float64 `json:"bias"`  // mean of predicted - actual
+	Steps int     `json:"steps"` // time steps compared
+}
+
+// MAE returns the mean absolute error.
+// Time: O(n), Space: O(n)
+func MAE[T Float](actual, predicted []T) (float64, error) {
+	a, p, err := forecastPairs(actual, predicted)
+	if err != nil {
+		return 0, err
+	}
+	sum := 0.0
+	for i := range a {
+		sum += math.Abs(a[i] - p[i])
+	}
+	return sum / float64(len(a)), nil
+}
+
+// MSE returns the mean squared error.
+// Time: O(n), Space: O(n)
+func MSE[T Float](actual, predicted []T) (float64, error) {
+	a, p, err := forecastPairs(actual, predicted)
+	if err != nil {
+		return 0, err
+	}
+	sum := 0.0
+	for i := range a {
+		sum += (a[i] - p[i]) * (a[i] - p[i])
+	}
+	return sum / float64(len(a)), nil
+}
+
+// RMSE returns the root mean squared error, in the units of the series.
+// Time: O(n), Space: O(n)
+func RMSE[T Float](actual, predicted []T) (float64, error) {
+	mse, err := MSE(actual, predicted)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(mse), nil
+}
+
+// MAPE returns the mean absolute percentage error, 100·mean(|a - p| / |a|).
+// Steps where the actual value is zero are undefined and skipped.
+// Time: O(n), Space: O(n)
+func MAPE[T Float](actual, predicted []T) (float64, error) {
+	a, p, err := forecastPairs(actual, predicted)
+	if err != nil {
+		return 0, err
+	}
+	sum, n := 0.0, 0
+	for i := range a {
+		if a[i] != 0 {
+			sum += math.Abs((a[i] - p[i]) / a[i])
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, ErrZeroVector
+	}
+	return 100 * sum / float64(n), nil
+}
+
+// SMAPE returns the symmetric mean absolute percentage error,
+// 100·mean(2|a - p| / (|a| + |p|)), in [0, 200]. A step where both values
+// are zero is a perfect forecast and contributes 0.
+// Time: O(n), Space: O(n)
+func SMAPE[T Float](actual, predicted []T) (float64, error) {
+	a, p, err := forecastPairs(actual, predicted)
+	if err != nil {
+		return 0, err
+	}
+	sum := 0.0
+	for i := range a {
+		if denom := math.Abs(a[i]) + math.Abs(p[i]); denom != 0 {
+			sum += 2 * math.Abs(a[i]-p[i]) / denom
+		}
+	}
+	return 100 * sum / float64(len(a)), nil
+}
+
+// MASE returns the mean absolute scaled error (Hyndman & Koehler, 2006):
+// the MAE divided by the in-sample MAE of the seasonal naive forecast
+// y[t] = y[t-season] on training, the history preceding the forecast.
+// Use season 1 for non-seasonal data. Values below 1 beat the naive forecast.
+// Returns ErrZeroVector if the naive forecast is perfect on training.
+// Time: O(n + m), Space: O(n)
+func MASE[T Float](actual, predicted, training []T, season int) (float64, error) {
+	if season < 1 {
+		return 0, ErrInvalidParameter
+	}
+	mae, err := MAE(actual, predicted)
+	if err != nil {
+		return 0, err
+	}
+	if len(training) <= season {
+		return 0, ErrEmptyInput
+	}
+	scale, err := MAE(training[season:], training[:len(training)-season])
+	if err != nil {
+		return 0, err
+	}
+	if scale == 0 {
+		return 0, ErrZeroVector
+	}
+	return mae / scale, nil
+}
+
+// EvaluateForecast computes every metric of ForecastAccuracy in one call.
+// MASE is computed from training and season when training is non-empty, and
+// stays NaN when training is too short for the season or its naive forecast
+// is perfect.
+// Time: O(n + m), Space: O(n)
+func EvaluateForecast[T Float](actual, predicted, training []T, season int) (ForecastAccuracy, error) {
+	a, p, err := forecastPairs(actual, predicted)
+	if err != nil {
+		return ForecastAccuracy{}, err
+	}
+
+	acc := ForecastAccuracy{Steps: len(a), MAPE: math.NaN(), MASE: math.NaN()}
+	for i := range a {
+		acc.Bias += p[i] - a[i]
+	}
+	acc.Bias /= float64(len(a))
+	acc.MAE, _ = MAE(a, p)
+	acc.MSE, _ = MSE(a, p)
+	acc.RMSE = math.Sqrt(acc.MSE)
+	acc.SMAPE, _ = SMAPE(a, p)
+	if mape, err := MAPE(a, p); err == nil {
+		acc.MAPE = mape
+	}
+	if len(training) > 0 {
+		mase, err := MASE(actual, predicted, training, season)
+		switch err {
+		case nil:
+			acc.MASE = mase
+		case ErrZeroVector, ErrEmptyInput:
+		default:
+			return ForecastAccuracy{}, err
+		}
+	}
+	return acc, nil
+}
+
+// forecastPairs returns the actual and predicted values at the steps where
+// both are numbers
+func forecastPairs[T Float](actual, predicted []T) ([]float64, []float64, error) {
+	if len(actual) != len(predicted) {
+		return nil, nil, ErrDimensionMismatch
+	}
+	a := make([]float64, 0, len(actual))
+	p := make([]float64, 0, len(actual))
+	for i := range actual {
+		x, y := float64(actual[i]), float64(predicted[i])
+		if math.IsNaN(x) || math.IsNaN(y) {
+			continue
+		}
+		a = append(a, x)
+		p = append(p, y)
+	}
+	if len(a) == 0 {
+		return nil, nil, ErrEmptyInput
+	}
+	return a, p, nil
+}
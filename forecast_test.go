@@ -0,0 +1,118 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestForecastMetrics(t *testing.T) {
+	actual := []float64{100, 200, 0, 400, math.NaN()}
+	predicted := []float64{110, 180, 10, 400, 50}
+	// Compared steps: errors 10, -20, 10, 0 (the NaN step is skipped)
+
+	tests := []struct {
+		name     string
+		fn       func(a, p []float64) (float64, error)
+		expected float64
+	}{
+		{"MAE", MAE[float64], 10},
+		{"MSE", MSE[float64], 150},
+		{"RMSE", RMSE[float64], math.Sqrt(150)},
+		// The zero actual is skipped: (0.1 + 0.1 + 0) / 3
+		{"MAPE", MAPE[float64], 100 * 0.2 / 3},
+		{"SMAPE", SMAPE[float64], 100 * (20.0/210 + 40.0/380 + 2 + 0) / 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fn(actual, predicted)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMASE(t *testing.T) {
+	training := []float64{1, 3, 2, 4, 3, 5}
+	// Naive one-step errors on training: 2, 1, 2, 1, 2 -> scale 1.6
+	result, err := MASE([]float64{6, 7}, []float64{5, 9}, training, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(result, 1.5/1.6) {
+		t.Errorf("expected %v, got %v", 1.5/1.6, result)
+	}
+
+	// Seasonal naive with season 2: errors 1, 1, 1, 1 -> scale 1
+	seasonal, _ := MASE([]float64{6, 7}, []float64{5, 9}, training, 2)
+	if !almostEqual(seasonal, 1.5) {
+		t.Errorf("expected 1.5, got %v", seasonal)
+	}
+
+	if _, err := MASE([]float64{1}, []float64{1}, []float64{2, 2, 2}, 1); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+	if _, err := MASE([]float64{1}, []float64{1}, training, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := MASE([]float64{1}, []float64{1}, []float64{1}, 1); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestEvaluateForecast(t *testing.T) {
+	actual := []float32{0, 0, 0}
+	predicted := []float32{1, -1, 2}
+	acc, err := EvaluateForecast(actual, predicted, nil, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if acc.Steps != 3 || !almostEqual(acc.MAE, 4.0/3) || !almostEqual(acc.Bias, 2.0/3) || !almostEqual(acc.SMAPE, 200) {
+		t.Errorf("unexpected accuracy %+v", acc)
+	}
+	if !math.IsNaN(acc.MAPE) || !math.IsNaN(acc.MASE) {
+		t.Errorf("expected undefined MAPE and MASE to be NaN, got %+v", acc)
+	}
+
+	acc, err = EvaluateForecast([]float64{6, 7}, []float64{5, 9}, []float64{1, 3, 2, 4, 3, 5}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(acc.MASE, 1.5/1.6) || !almostEqual(acc.RMSE, math.Sqrt(2.5)) {
+		t.Errorf("unexpected accuracy %+v", acc)
+	}
+
+	// Training shorter than the season leaves only MASE undefined
+	acc, err = EvaluateForecast([]float64{6, 7}, []float64{5, 9}, []float64{1, 3, 2}, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsNaN(acc.MASE) || !almostEqual(acc.MAE, 1.5) {
+		t.Errorf("expected NaN MASE with the other metrics set, got %+v", acc)
+	}
+	if _, err := EvaluateForecast([]float64{6}, []float64{5}, []float64{1, 3}, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestForecastErrors(t *testing.T) {
+	if _, err := MAE([]float64{1, 2}, []float64{1}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := RMSE([]float64{math.NaN()}, []float64{1}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := MAPE([]float64{0, 0}, []float64{1, 2}); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+	if result, err := SMAPE([]float64{0}, []float64{0}); err != nil || result != 0 {
+		t.Errorf("expected 0 for a perfect zero forecast, got %v (%v)", result, err)
+	}
+	if _, err := EvaluateForecast([]float64{}, []float64{}, nil, 1); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
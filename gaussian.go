@@ -0,0 +1,290 @@
+package distance
+
+import "math"
+
+// jacobiSweeps bounds the Jacobi eigenvalue iteration used for matrix square roots
+const jacobiSweeps = 100
+
+// KLDivergenceGaussian computes KL(N1||N2) between multivariate normal
+// distributions with means mu and covariance matrices sigma, in closed form:
+// ½[tr(Σ2⁻¹Σ1) + (μ2-μ1)ᵀΣ2⁻¹(μ2-μ1) - k + ln(|Σ2|/|Σ1|)]
+// Covariances must be symmetric positive definite.
+// NOTE: Asymmetric
+// Time: O(k³), Space: O(k²)
+func KLDivergenceGaussian(mu1 []float64, sigma1 [][]float64, mu2 []float64, sigma2 [][]float64) (float64, error) {
+	if err := validateGaussians(mu1, sigma1, mu2, sigma2); err != nil {
+		return 0, err
+	}
+	l1, err := cholesky(sigma1)
+	if err != nil {
+		return 0, err
+	}
+	l2, err := cholesky(sigma2)
+	if err != nil {
+		return 0, err
+	}
+
+	k := len(mu1)
+	var trace float64
+	col := make([]float64, k)
+	for j := 0; j < k; j++ {
+		for i := range col {
+			col[i] = sigma1[i][j]
+		}
+		trace += choleskySolve(l2, col)[j]
+	}
+
+	diff := subtract(mu2, mu1)
+	mahalanobis := dotF64(diff, choleskySolve(l2, diff))
+	return (trace + mahalanobis - float64(k) + choleskyLogDet(l2) - choleskyLogDet(l1)) / 2, nil
+}
+
+// BhattacharyyaGaussian computes the Bhattacharyya distance between
+// multivariate normal distributions in closed form, with Σ = (Σ1+Σ2)/2:
+// ⅛(μ1-μ2)ᵀΣ⁻¹(μ1-μ2) + ½ln(|Σ|/√(|Σ1||Σ2|))
+// Time: O(k³), Space: O(k²)
+func BhattacharyyaGaussian(mu1 []float64, sigma1 [][]float64, mu2 []float64, sigma2 [][]float64) (float64, error) {
+	if err := validateGaussians(mu1, sigma1, mu2, sigma2); err != nil {
+		return 0, err
+	}
+	l1, err := cholesky(sigma1)
+	if err != nil {
+		return 0, err
+	}
+	l2, err := cholesky(sigma2)
+	if err != nil {
+		return 0, err
+	}
+
+	k := len(mu1)
+	avg := make([][]float64, k)
+	for i := range avg {
+		avg[i] = make([]float64, k)
+		for j := range avg[i] {
+			avg[i][j] = (sigma1[i][j] + sigma2[i][j]) / 2
+		}
+	}
+	l, err := cholesky(avg)
+	if err != nil {
+		return 0, err
+	}
+
+	diff := subtract(mu1, mu2)
+	mahalanobis := dotF64(diff, choleskySolve(l, diff))
+	return mahalanobis/8 + (choleskyLogDet(l)-(choleskyLogDet(l1)+choleskyLogDet(l2))/2)/2, nil
+}
+
+// WassersteinGaussian computes the 2-Wasserstein distance between
+// multivariate normal distributions in closed form:
+// W2² = ||μ1-μ2||² + tr(Σ1 + Σ2 - 2(Σ2^½ Σ1 Σ2^½)^½)
+// Covariances may be positive semi-definite.
+// Time: O(k³), Space: O(k²)
+func WassersteinGaussian(mu1 []float64, sigma1 [][]float64, mu2 []float64, sigma2 [][]float64) (float64, error) {
+	if err := validateGaussians(mu1, sigma1, mu2, sigma2); err != nil {
+		return 0, err
+	}
+
+	root2, err := sqrtPSD(sigma2)
+	if err != nil {
+		return 0, err
+	}
+	cross, err := sqrtPSD(matMul(matMul(root2, sigma1), root2))
+	if err != nil {
+		return 0, err
+	}
+
+	diff := subtract(mu1, mu2)
+	sq := dotF64(diff, diff)
+	for i := range sigma1 {
+		sq += sigma1[i][i] + sigma2[i][i] - 2*cross[i][i]
+	}
+	return math.Sqrt(math.Max(sq, 0)), nil
+}
+
+// validateGaussians checks that means and covariance matrices agree in dimension
+// and that covariances are symmetric
+func validateGaussians(mu1 []float64, sigma1 [][]float64, mu2 []float64, sigma2 [][]float64) error {
+	if err := Validate(mu1, mu2); err != nil {
+		return err
+	}
+	for _, sigma := range [][][]float64{sigma1, sigma2} {
+		if len(sigma) != len(mu1) {
+			return ErrDimensionMismatch
+		}
+		for i, row := range sigma {
+			if len(row) != len(mu1) {
+				return ErrDimensionMismatch
+			}
+			for j := 0; j < i; j++ {
+				if math.Abs(row[j]-sigma[j][i]) > 1e-9*(1+math.Abs(row[j])) {
+					return ErrInvalidParameter
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// cholesky returns lower-triangular L with LLᵀ = a, or ErrInvalidParameter
+// if a is not positive definite
+func cholesky(a [][]float64) ([][]float64, error) {
+	n := len(a)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+		for j := 0; j <= i; j++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum <= 0 {
+					return nil, ErrInvalidParameter
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, nil
+}
+
+// choleskySolve solves LLᵀx = b
+func choleskySolve(l [][]float64, b []float64) []float64 {
+	n := len(l)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= l[i][k] * y[k]
+		}
+		y[i] = sum / l[i][i]
+	}
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := y[i]
+		for k := i + 1; k < n; k++ {
+			sum -= l[k][i] * x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+	return x
+}
+
+// choleskyLogDet returns ln|LLᵀ| = 2Σ ln L_ii
+func choleskyLogDet(l [][]float64) float64 {
+	var sum float64
+	for i := range l {
+		sum += math.Log(l[i][i])
+	}
+	return 2 * sum
+}
+
+// sqrtPSD returns the symmetric square root of a positive semi-definite matrix
+// via Jacobi eigendecomposition; small negative eigenvalues from rounding are
+// clamped to zero
+func sqrtPSD(a [][]float64) ([][]float64, error) {
+	values, vectors := symmetricEigen(a)
+	n := len(a)
+	scale := 0.0
+	for _, v := range values {
+		scale = math.Max(scale, math.Abs(v))
+	}
+
+	out := make([][]float64, n)
+	for i := range out {
+		out[i] = make([]float64, n)
+	}
+	for k, v := range values {
+		if v < -1e-9*(1+scale) {
+			return nil, ErrInvalidParameter
+		}
+		root := math.Sqrt(math.Max(v, 0))
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				out[i][j] += root * vectors[i][k] * vectors[j][k]
+			}
+		}
+	}
+	return out, nil
+}
+
+// symmetricEigen diagonalizes a symmetric matrix with cyclic Jacobi rotations,
+// returning eigenvalues and eigenvectors as columns
+func symmetricEigen(a [][]float64) ([]float64, [][]float64) {
+	n := len(a)
+	m := make([][]float64, n)
+	v := make([][]float64, n)
+	for i := range m {
+		m[i] = append([]float64(nil), a[i]...)
+		v[i] = make([]float64, n)
+		v[i][i] = 1
+	}
+
+	for sweep := 0; sweep < jacobiSweeps; sweep++ {
+		off := 0.0
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += m[p][q] * m[p][q]
+			}
+		}
+		if off < 1e-30 {
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if m[p][q] == 0 {
+					continue
+				}
+				theta := (m[q][q] - m[p][p]) / (2 * m[p][q])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < n; k++ {
+					mkp, mkq := m[k][p], m[k][q]
+					m[k][p], m[k][q] = c*mkp-s*mkq, s*mkp+c*mkq
+				}
+				for k := 0; k < n; k++ {
+					mpk, mqk := m[p][k], m[q][k]
+					m[p][k], m[q][k] = c*mpk-s*mqk, s*mpk+c*mqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p], v[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = m[i][i]
+	}
+	return values, v
+}
+
+// matMul multiplies two square matrices
+func matMul(a, b [][]float64) [][]float64 {
+	n := len(a)
+	out := make([][]float64, n)
+	for i := range out {
+		out[i] = make([]float64, n)
+		for k := 0; k < n; k++ {
+			for j := 0; j < n; j++ {
+				out[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return out
+}
+
+// subtract returns a - b
+func subtract(a, b []float64) []float64 {
+	out := make([]float64, len(a))
+	for i := range a {
+		out[i] = a[i] - b[i]
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKLDivergenceGaussian(t *testing.T) {
+	// Univariate closed form: ln(s2/s1) + (s1² + (m1-m2)²)/(2s2²) - ½
+	got, err := KLDivergenceGaussian([]float64{1}, [][]float64{{4}}, []float64{0}, [][]float64{{1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := math.Log(1.0/2) + (4+1)/2.0 - 0.5
+	if !almostEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// Independent dimensions add up
+	mu1, mu2 := []float64{1, -1}, []float64{0, 2}
+	s1 := [][]float64{{4, 0}, {0, 0.5}}
+	s2 := [][]float64{{1, 0}, {0, 2}}
+	got, _ = KLDivergenceGaussian(mu1, s1, mu2, s2)
+	d1, _ := KLDivergenceGaussian([]float64{1}, [][]float64{{4}}, []float64{0}, [][]float64{{1}})
+	d2, _ := KLDivergenceGaussian([]float64{-1}, [][]float64{{0.5}}, []float64{2}, [][]float64{{2}})
+	if !almostEqual(got, d1+d2) {
+		t.Errorf("expected %v, got %v", d1+d2, got)
+	}
+
+	corr := [][]float64{{2, 0.8}, {0.8, 1}}
+	self, _ := KLDivergenceGaussian(mu1, corr, mu1, corr)
+	if !almostEqual(self, 0) {
+		t.Errorf("expected 0 for identical gaussians, got %v", self)
+	}
+}
+
+func TestBhattacharyyaGaussian(t *testing.T) {
+	// Univariate: ¼(m1-m2)²/(s1²+s2²) + ½ln((s1²+s2²)/(2s1s2))
+	got, err := BhattacharyyaGaussian([]float64{0}, [][]float64{{1}}, []float64{2}, [][]float64{{4}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := 0.25*4/5 + 0.5*math.Log(5.0/4)
+	if !almostEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestWassersteinGaussian(t *testing.T) {
+	// Univariate: W2² = (m1-m2)² + (s1-s2)²
+	got, err := WassersteinGaussian([]float64{0}, [][]float64{{1}}, []float64{3}, [][]float64{{9}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(got, math.Sqrt(9+4)) {
+		t.Errorf("expected %v, got %v", math.Sqrt(13), got)
+	}
+
+	// Commuting covariances: W2² = ||Δμ||² + ||Σ1^½ - Σ2^½||²_F
+	s1 := [][]float64{{4, 0}, {0, 1}}
+	s2 := [][]float64{{1, 0}, {0, 9}}
+	got, _ = WassersteinGaussian([]float64{0, 0}, s1, []float64{0, 0}, s2)
+	if !almostEqual(got, math.Sqrt(1+4)) {
+		t.Errorf("expected %v, got %v", math.Sqrt(5), got)
+	}
+
+	// Rotation-invariant: identical correlated covariances give zero
+	c := [][]float64{{2, 1.2}, {1.2, 1}}
+	self, _ := WassersteinGaussian([]float64{1, 1}, c, []float64{1, 1}, c)
+	if math.Abs(self) > 1e-6 {
+		t.Errorf("expected 0, got %v", self)
+	}
+
+	// Degenerate (PSD) covariances are allowed
+	if _, err := WassersteinGaussian([]float64{0, 0}, [][]float64{{1, 1}, {1, 1}}, []float64{0, 0}, s2); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGaussianErrors(t *testing.T) {
+	mu := []float64{0, 0}
+	id := [][]float64{{1, 0}, {0, 1}}
+
+	if _, err := KLDivergenceGaussian(mu, id, []float64{0}, [][]float64{{1}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := KLDivergenceGaussian(mu, [][]float64{{1, 0}}, mu, id); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	singular := [][]float64{{1, 1}, {1, 1}}
+	if _, err := KLDivergenceGaussian(mu, id, mu, singular); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter for singular covariance, got %v", err)
+	}
+	asymmetric := [][]float64{{1, 0.5}, {0, 1}}
+	if _, err := BhattacharyyaGaussian(mu, asymmetric, mu, id); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter for asymmetric covariance, got %v", err)
+	}
+	if _, err := WassersteinGaussian(mu, [][]float64{{-1, 0}, {0, 1}}, mu, id); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter for negative variance, got %v", err)
+	}
+}
@@ -0,0 +1,588 @@
+package distance
+
+import "math"
+
+// Karney computes the geodesic distance between a and b on the WGS-84
+// ellipsoid with Karney's algorithm (C. F. F. Karney, "Algorithms for
+// geodesics", J. Geodesy 87, 2013), as used by GeographicLib. Unlike
+// Vincenty it converges for every pair of points, nearly antipodal ones
+// included, and is accurate to about 15 nanometers.
+// Returns distance in meters. If the root finder ever exhausts its
+// iterations (not observed for WGS-84) the best estimate is returned
+// together with ErrMaxIterations.
+// Time: O(1) with iteration, Space: O(1)
+func Karney(a, b Coord) (float64, error) {
+	s12, _, _, converged := geodesicInverse(a, b)
+	if !converged {
+		return s12, ErrMaxIterations
+	}
+	return s12, nil
+}
+
+// KarneyKm computes Karney distance in kilometers.
+// Time: O(1) with iteration, Space: O(1)
+func KarneyKm(a, b Coord) (float64, error) {
+	meters, err := Karney(a, b)
+	return meters / 1000.0, err
+}
+
+// KarneyFull solves the inverse geodesic problem with Karney's algorithm,
+// returning the distance in meters together with the forward azimuth at a
+// (initial bearing) and at b (final bearing), both in degrees clockwise from
+// north in [0, 360); see VincentyFull. For nearly antipodal points many
+// geodesics may be equally short and one of them is chosen.
+// Returns ErrMaxIterations with the best estimate if the root finder fails.
+// Time: O(1) with iteration, Space: O(1)
+func KarneyFull(a, b Coord) (dist, az1, az2 float64, err error) {
+	s12, azi1, azi2, converged := geodesicInverse(a, b)
+	if !converged {
+		err = ErrMaxIterations
+	}
+	return s12, normalizeAzimuth(azi1), normalizeAzimuth(azi2), err
+}
+
+// Series orders and tolerances follow GeographicLib's geodesic.c
+const (
+	geodOrder   = 6
+	geodTol0    = 0x1p-52
+	geodTol1    = 200 * geodTol0
+	geodMaxit1  = 20
+	geodMaxit2  = geodMaxit1 + 53 + 10
+	geodTiny    = 1.4916681462400413e-154 // sqrt of the smallest normal float64
+	geodEllipsA = 6378137.0               // WGS-84 semi-major axis (meters)
+	geodEllipsF = 1 / 298.257223563       // WGS-84 flattening
+)
+
+var (
+	geodTol2    = math.Sqrt(geodTol0)
+	geodTolb    = geodTol0 * geodTol2
+	geodXthresh = 1000 * geodTol2
+)
+
+// wgs84 holds the ellipsoid's derived parameters and series coefficients
+var wgs84 = newGeodesic(geodEllipsA, geodEllipsF)
+
+// geodesic is an oblate ellipsoid prepared for Karney's inverse solution
+type geodesic struct {
+	a, f, f1, e2, ep2, n, b, etol2 float64
+	a3x                            [geodOrder]float64
+	c3x                            [geodOrder * (geodOrder - 1) / 2]float64
+}
+
+func newGeodesic(a, f float64) *geodesic {
+	g := &geodesic{a: a, f: f, f1: 1 - f}
+	g.e2 = f * (2 - f)
+	g.ep2 = g.e2 / (g.f1 * g.f1)
+	g.n = f / (2 - f)
+	g.b = a * g.f1
+	g.etol2 = 0.1 * geodTol2 / math.Sqrt(math.Max(0.001, math.Abs(f))*math.Min(1, 1-f/2)/2)
+
+	// A3 and C3 coefficients as polynomials in n, highest power of eps first
+	a3 := []float64{
+		-3, 128,
+		-2, -3, 64,
+		-1, -3, -1, 16,
+		3, -1, -2, 8,
+		1, -1, 2,
+		1, 1,
+	}
+	o, k := 0, 0
+	for j := geodOrder - 1; j >= 0; j-- {
+		m := min(geodOrder-j-1, j)
+		g.a3x[k] = polyval(a3[o:o+m+1], g.n) / a3[o+m+1]
+		k++
+		o += m + 2
+	}
+
+	c3 := []float64{
+		3, 128,
+		2, 5, 128,
+		-1, 3, 3, 64,
+		-1, 0, 1, 8,
+		-1, 1, 4,
+		5, 256,
+		1, 3, 128,
+		-3, -2, 3, 64,
+		1, -3, 2, 32,
+		7, 512,
+		-10, 9, 384,
+		5, -9, 5, 192,
+		7, 512,
+		-14, 7, 512,
+		21, 2560,
+	}
+	o, k = 0, 0
+	for l := 1; l < geodOrder; l++ {
+		for j := geodOrder - 1; j >= l; j-- {
+			m := min(geodOrder-j-1, j)
+			g.c3x[k] = polyval(c3[o:o+m+1], g.n) / c3[o+m+1]
+			k++
+			o += m + 2
+		}
+	}
+	return g
+}
+
+// geodesicInverse solves the inverse problem between p1 and p2, returning
+// the distance in meters, the forward azimuths in degrees at both ends, and
+// whether the Newton/bisection iteration converged
+//
+//nolint:gocyclo // A direct port of GeographicLib's GenInverse
+func geodesicInverse(p1, p2 Coord) (s12, azi1, azi2 float64, converged bool) {
+	g := wgs84
+	converged = true
+
+	// Bring the points to the canonical configuration
+	// 0 <= lon12 <= 180, -90 <= lat1 <= -0, lat1 <= lat2 <= -lat1
+	lon12 := math.Remainder(p2.Lon-p1.Lon, 360)
+	lonsign := 1.0
+	if math.Signbit(lon12) {
+		lonsign = -1
+	}
+	lon12 = angRound(lon12 * lonsign)
+	lam12 := lon12 * degToRad
+	slam12, clam12 := sincosd(lon12)
+	lon12s := 180 - lon12 // Supplementary longitude difference
+
+	lat1, lat2 := angRound(p1.Lat), angRound(p2.Lat)
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) {
+		swapp = -1
+		lonsign = -lonsign
+		lat1, lat2 = lat2, lat1
+	}
+	latsign := -1.0
+	if math.Signbit(lat1) {
+		latsign = 1
+	}
+	lat1 *= latsign
+	lat2 *= latsign
+
+	sbet1, cbet1 := sincosd(lat1)
+	sbet1 *= g.f1
+	sbet1, cbet1 = norm2(sbet1, cbet1)
+	cbet1 = math.Max(geodTiny, cbet1)
+
+	sbet2, cbet2 := sincosd(lat2)
+	sbet2 *= g.f1
+	sbet2, cbet2 = norm2(sbet2, cbet2)
+	cbet2 = math.Max(geodTiny, cbet2)
+
+	// Force bet2 = ±bet1 exactly when the difference vanishes
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			sbet2 = math.Copysign(sbet1, sbet2)
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+
+	dn1 := math.Sqrt(1 + g.ep2*sbet1*sbet1)
+	dn2 := math.Sqrt(1 + g.ep2*sbet2*sbet2)
+
+	var sig12, salp1, calp1, salp2, calp2, s12x, m12x float64
+	meridian := lat1 == -90 || slam12 == 0
+	if meridian {
+		// Both points on one full meridian: try the meridian itself
+		calp1, salp1 = clam12, slam12
+		calp2, salp2 = 1, 0
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+		sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+		s12x, m12x = g.lengths(g.n, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2)
+		if sig12 < 1 || m12x >= 0 {
+			if sig12 < 3*geodTiny || (sig12 < geodTol0 && (s12x < 0 || m12x < 0)) {
+				sig12, m12x, s12x = 0, 0, 0
+			}
+			s12x *= g.b
+		} else {
+			meridian = false // Too close to antipodal for the meridian to be shortest
+		}
+	}
+
+	switch {
+	case meridian:
+	case sbet1 == 0 && lon12s >= g.f*180:
+		// Along the equator
+		calp1, calp2, salp1, salp2 = 0, 0, 1, 1
+		s12x = g.a * lam12
+	default:
+		var dnm float64
+		sig12, salp1, calp1, salp2, calp2, dnm = g.inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, lam12, slam12, clam12)
+		if sig12 >= 0 {
+			// Short line solved directly
+			s12x = sig12 * g.b * dnm
+			break
+		}
+
+		// Newton's method on alp1, falling back to bisection of a bracket
+		var ssig1, csig1, ssig2, csig2, eps float64
+		salp1a, calp1a, salp1b, calp1b := geodTiny, 1.0, geodTiny, -1.0
+		tripn, tripb := false, false
+		for numit := 0; ; numit++ {
+			var v, dv float64
+			v, dv, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps = g.lambda12(
+				sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam12, clam12, numit < geodMaxit1)
+			tol := 1.0
+			if tripn {
+				tol = 8
+			}
+			if tripb || !(math.Abs(v) >= tol*geodTol0) {
+				break
+			}
+			if numit == geodMaxit2 {
+				converged = false
+				break
+			}
+			if v > 0 && (numit > geodMaxit1 || calp1/salp1 > calp1b/salp1b) {
+				salp1b, calp1b = salp1, calp1
+			} else if v < 0 && (numit > geodMaxit1 || calp1/salp1 < calp1a/salp1a) {
+				salp1a, calp1a = salp1, calp1
+			}
+			if numit < geodMaxit1 && dv > 0 {
+				dalp1 := -v / dv
+				if math.Abs(dalp1) < math.Pi {
+					sdalp1, cdalp1 := math.Sincos(dalp1)
+					if nsalp1 := salp1*cdalp1 + calp1*sdalp1; nsalp1 > 0 {
+						calp1 = calp1*cdalp1 - salp1*sdalp1
+						salp1, calp1 = norm2(nsalp1, calp1)
+						tripn = math.Abs(v) <= 16*geodTol0
+						continue
+					}
+				}
+			}
+			salp1, calp1 = norm2((salp1a+salp1b)/2, (calp1a+calp1b)/2)
+			tripn = false
+			tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < geodTolb ||
+				math.Abs(salp1-salp1b)+(calp1-calp1b) < geodTolb
+		}
+		s12x, _ = g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2)
+		s12x *= g.b
+	}
+
+	// Undo the canonical transformation
+	if swapp < 0 {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+	}
+	salp1 *= swapp * lonsign
+	calp1 *= swapp * latsign
+	salp2 *= swapp * lonsign
+	calp2 *= swapp * latsign
+	return 0 + s12x, math.Atan2(salp1, calp1) / degToRad, math.Atan2(salp2, calp2) / degToRad, converged
+}
+
+// inverseStart returns a starting alp1 for Newton's method; for short lines
+// it also solves the problem directly, returning sig12 >= 0, alp2 and the
+// mean dn, and otherwise sig12 = -1
+func (g *geodesic) inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, lam12, slam12, clam12 float64) (sig12, salp1, calp1, salp2, calp2, dnm float64) {
+	sig12 = -1
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+
+	var somg12, comg12 float64
+	if shortline {
+		sbetm2 := (sbet1 + sbet2) * (sbet1 + sbet2)
+		sbetm2 /= sbetm2 + (cbet1+cbet2)*(cbet1+cbet2)
+		dnm = math.Sqrt(1 + g.ep2*sbetm2)
+		somg12, comg12 = math.Sincos(lam12 / (g.f1 * dnm))
+	} else {
+		somg12, comg12 = slam12, clam12
+	}
+
+	salp1 = cbet2 * somg12
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*somg12*somg12/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+	}
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	switch {
+	case shortline && ssig12 < g.etol2:
+		salp2 = cbet1 * somg12
+		if comg12 >= 0 {
+			calp2 = sbet12 - cbet1*sbet2*somg12*somg12/(1+comg12)
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+		salp2, calp2 = norm2(salp2, calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	case math.Abs(g.n) > 0.1 || csig12 >= 0 || ssig12 >= 6*math.Abs(g.n)*math.Pi*cbet1*cbet1:
+		// The spherical estimate is good enough
+	default:
+		// Nearly antipodal: scale to coordinates where the antipode is the
+		// origin and solve the astroid problem
+		lam12x := math.Atan2(-slam12, -clam12)
+		k2 := sbet1 * sbet1 * g.ep2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		lamscale := g.f * cbet1 * g.a3f(eps) * math.Pi
+		betscale := lamscale * cbet1
+		x := lam12x / lamscale
+		y := sbet12a / betscale
+
+		if y > -geodTol1 && x > -1-geodXthresh {
+			salp1 = math.Min(1, -x)
+			calp1 = -math.Sqrt(1 - salp1*salp1)
+		} else {
+			k := astroid(x, y)
+			omg12a := lamscale * (-x * k / (1 + k))
+			somg12, comg12 = math.Sincos(omg12a)
+			comg12 = -comg12
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+		}
+	}
+
+	if !(salp1 <= 0) {
+		salp1, calp1 = norm2(salp1, calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+	return sig12, salp1, calp1, salp2, calp2, dnm
+}
+
+// lambda12 returns the longitude mismatch v of the geodesic leaving point 1
+// at azimuth alp1, its derivative dv (if diffp) and the geodesic's state
+func (g *geodesic) lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64, diffp bool) (v, dv, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps float64) {
+	if sbet1 == 0 && calp1 == 0 {
+		calp1 = -geodTiny // Break the equatorial degeneracy
+	}
+
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1 = sbet1
+	somg1 := salp0 * sbet1
+	csig1 = calp1 * cbet1
+	comg1 := csig1
+	ssig1, csig1 = norm2(ssig1, csig1)
+
+	if cbet2 != cbet1 {
+		salp2 = salp0 / cbet2
+	} else {
+		salp2 = salp1
+	}
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		var d float64
+		if cbet1 < -sbet1 {
+			d = (cbet2 - cbet1) * (cbet1 + cbet2)
+		} else {
+			d = (sbet1 - sbet2) * (sbet1 + sbet2)
+		}
+		calp2 = math.Sqrt(calp1*cbet1*calp1*cbet1+d) / cbet2
+	} else {
+		calp2 = math.Abs(calp1)
+	}
+
+	ssig2 = sbet2
+	somg2 := salp0 * sbet2
+	csig2 = calp2 * cbet2
+	comg2 := csig2
+	ssig2, csig2 = norm2(ssig2, csig2)
+
+	sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := calp0 * calp0 * g.ep2
+	eps = k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	c3 := g.c3f(eps)
+	b312 := sinSeries(ssig2, csig2, c3) - sinSeries(ssig1, csig1, c3)
+	domg12 := -g.f * g.a3f(eps) * salp0 * (sig12 + b312)
+	v = eta + domg12
+
+	if diffp {
+		if calp2 == 0 {
+			dv = -2 * g.f1 * dn1 / sbet1
+		} else {
+			_, dv = g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2)
+			dv *= g.f1 / (calp2 * cbet2)
+		}
+	}
+	return v, dv, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps
+}
+
+// lengths returns the distance and reduced length, both divided by b, of
+// the geodesic arc from sig1 to sig2
+func (g *geodesic) lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64) (s12b, m12b float64) {
+	a1 := a1m1f(eps)
+	c1 := c1f(eps)
+	a2 := a2m1f(eps)
+	c2 := c2f(eps)
+	m0 := a1 - a2
+	a1++
+	a2++
+
+	b1 := sinSeries(ssig2, csig2, c1) - sinSeries(ssig1, csig1, c1)
+	b2 := sinSeries(ssig2, csig2, c2) - sinSeries(ssig1, csig1, c2)
+	s12b = a1 * (sig12 + b1)
+	j12 := m0*sig12 + (a1*b1 - a2*b2)
+	m12b = dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*j12
+	return s12b, m12b
+}
+
+// a3f evaluates the A3 series
+func (g *geodesic) a3f(eps float64) float64 {
+	return polyval(g.a3x[:], eps)
+}
+
+// c3f evaluates the C3 coefficients; c[0] is unused
+func (g *geodesic) c3f(eps float64) []float64 {
+	c := make([]float64, geodOrder)
+	mult, o := 1.0, 0
+	for l := 1; l < geodOrder; l++ {
+		m := geodOrder - l - 1
+		mult *= eps
+		c[l] = mult * polyval(g.c3x[o:o+m+1], eps)
+		o += m + 1
+	}
+	return c
+}
+
+// a1m1f evaluates A1 - 1
+func a1m1f(eps float64) float64 {
+	t := polyval([]float64{1, 4, 64, 0}, eps*eps) / 256
+	return (t + eps) / (1 - eps)
+}
+
+// a2m1f evaluates A2 - 1
+func a2m1f(eps float64) float64 {
+	t := polyval([]float64{-11, -28, -192, 0}, eps*eps) / 256
+	return (t - eps) / (1 + eps)
+}
+
+// c1f evaluates the C1 coefficients; c[0] is unused
+func c1f(eps float64) []float64 {
+	return seriesCoefficients(eps, []float64{
+		-1, 6, -16, 32,
+		-9, 64, -128, 2048,
+		9, -16, 768,
+		3, -5, 512,
+		-7, 1280,
+		-7, 2048,
+	})
+}
+
+// c2f evaluates the C2 coefficients; c[0] is unused
+func c2f(eps float64) []float64 {
+	return seriesCoefficients(eps, []float64{
+		1, 2, 16, 32,
+		35, 64, 384, 2048,
+		15, 80, 768,
+		7, 35, 512,
+		63, 1280,
+		77, 2048,
+	})
+}
+
+// seriesCoefficients evaluates c[l] = eps^l · p_l(eps²)/d_l for packed
+// polynomials p_l of decreasing order followed by their divisors d_l
+func seriesCoefficients(eps float64, coeff []float64) []float64 {
+	c := make([]float64, geodOrder+1)
+	eps2, d, o := eps*eps, eps, 0
+	for l := 1; l <= geodOrder; l++ {
+		m := (geodOrder - l) / 2
+		c[l] = d * polyval(coeff[o:o+m+1], eps2) / coeff[o+m+1]
+		o += m + 2
+		d *= eps
+	}
+	return c
+}
+
+// sinSeries evaluates sum(c[l]·sin(2l·x), l = 1..len(c)-1) by Clenshaw
+// summation, given sin x and cos x
+func sinSeries(sinx, cosx float64, c []float64) float64 {
+	ar := 2 * (cosx - sinx) * (cosx + sinx)
+	var y0, y1 float64
+	for k := len(c) - 1; k >= 1; k-- {
+		y0, y1 = ar*y0-y1+c[k], y0
+	}
+	return 2 * sinx * cosx * y0
+}
+
+// astroid returns the positive root k of
+// k⁴ + 2k³ - (x² + y² - 1)k² - 2y²k - y² = 0
+func astroid(x, y float64) float64 {
+	p, q := x*x, y*y
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+	s := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := s * (s + 2*r3)
+	u := r
+	if disc >= 0 {
+		t3 := s + r3
+		if t3 < 0 {
+			t3 -= math.Sqrt(disc)
+		} else {
+			t3 += math.Sqrt(disc)
+		}
+		t := math.Cbrt(t3)
+		u += t
+		if t != 0 {
+			u += r2 / t
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(s + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+	v := math.Sqrt(u*u + q)
+	var uv float64
+	if u < 0 {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+	w := (uv - q) / (2 * v)
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
+
+// polyval evaluates the polynomial with coefficients p (highest power first) at x
+func polyval(p []float64, x float64) float64 {
+	y := 0.0
+	for _, c := range p {
+		y = y*x + c
+	}
+	return y
+}
+
+// sincosd returns the sine and cosine of x degrees, exact at multiples of 90
+func sincosd(x float64) (float64, float64) {
+	r := math.Mod(x, 360)
+	q := math.Floor(r/90 + 0.5)
+	s, c := math.Sincos((r - 90*q) * degToRad)
+	switch int(q) & 3 {
+	case 1:
+		s, c = c, -s
+	case 2:
+		s, c = -s, -c
+	case 3:
+		s, c = -c, s
+	}
+	return s + 0, c + 0
+}
+
+// angRound rounds tiny angles to zero so that, e.g., latitudes within
+// 1e-20° of the equator are treated as on it
+func angRound(x float64) float64 {
+	const z = 1.0 / 16
+	y := math.Abs(x)
+	if w := z - y; w > 0 {
+		y = z - w
+	}
+	return math.Copysign(y, x)
+}
+
+// norm2 scales (y, x) to unit length
+func norm2(y, x float64) (float64, float64) {
+	r := math.Hypot(y, x)
+	return y / r, x / r
+}
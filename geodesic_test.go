@@ -0,0 +1,169 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKarney(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      Coord
+		expected  float64
+		tolerance float64
+	}{
+		{"same location", Coord{Lat: 40.7128, Lon: -74.0060}, Coord{Lat: 40.7128, Lon: -74.0060}, 0, 1e-9},
+		// Reference values from GeographicLib's GeodSolve
+		{"JFK to CDG", Coord{Lat: 40.6, Lon: -73.8}, Coord{Lat: 49.01666667, Lon: 2.55}, 5853226.256, 1e-3},
+		{"equator to pole", Coord{Lat: 0, Lon: 0}, Coord{Lat: 90, Lon: 0}, 10001965.729, 1e-3},
+		{"equatorial antipodes", Coord{Lat: 0, Lon: 0}, Coord{Lat: 0, Lon: 180}, 20003931.459, 1e-3},
+		{"pole to pole", Coord{Lat: 90, Lon: 0}, Coord{Lat: -90, Lon: 0}, 20003931.459, 1e-3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Karney(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(result-tt.expected) > tt.tolerance {
+				t.Errorf("expected %v m (±%v), got %v m", tt.expected, tt.tolerance, result)
+			}
+		})
+	}
+}
+
+func TestKarneyAzimuths(t *testing.T) {
+	_, azi1, azi2, converged := geodesicInverse(Coord{Lat: 40.6, Lon: -73.8}, Coord{Lat: 49.01666667, Lon: 2.55})
+	if !converged {
+		t.Fatal("expected convergence")
+	}
+	if math.Abs(azi1-53.47022) > 1e-5 || math.Abs(azi2-111.59367) > 1e-5 {
+		t.Errorf("expected azimuths 53.47022 and 111.59367, got %v and %v", azi1, azi2)
+	}
+}
+
+func TestKarneyMatchesVincenty(t *testing.T) {
+	rng := testRNG(7)
+	for i := 0; i < 2000; i++ {
+		a := Coord{Lat: rng.Float64()*180 - 90, Lon: rng.Float64()*360 - 180}
+		b := Coord{Lat: rng.Float64()*180 - 90, Lon: rng.Float64()*360 - 180}
+		if GreatCircle(a, b) > 18000 {
+			continue // Vincenty itself is least reliable near antipodes
+		}
+		k, err := Karney(a, b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		v, err := Vincenty(a, b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(k-v) > 1e-3 {
+			t.Errorf("Karney(%v, %v) = %v, Vincenty = %v", a, b, k, v)
+		}
+	}
+}
+
+func TestKarneyNearlyAntipodal(t *testing.T) {
+	// Vincenty fails to converge on all of these
+	pairs := [][2]Coord{
+		{{Lat: 0, Lon: 0}, {Lat: 0.5, Lon: 179.7}},
+		{{Lat: -30, Lon: 0}, {Lat: 30, Lon: 179.9}},
+		{{Lat: 10, Lon: 20}, {Lat: -10.1, Lon: -160.05}},
+	}
+	for _, p := range pairs {
+		ab, err := Karney(p[0], p[1])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ba, err := Karney(p[1], p[0])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(ab-ba) > 1e-6 {
+			t.Errorf("not symmetric: %v vs %v", ab, ba)
+		}
+		if ab > 20003931.46 || ab < 19900000 {
+			t.Errorf("Karney(%v, %v) = %v, outside the nearly antipodal range", p[0], p[1], ab)
+		}
+
+		// Continuity: nudging an endpoint moves the distance by at most the nudge
+		nudged, _ := Karney(p[0], Coord{Lat: p[1].Lat + 1e-6, Lon: p[1].Lon})
+		if math.Abs(nudged-ab) > 0.2 {
+			t.Errorf("discontinuity near %v: %v vs %v", p[1], ab, nudged)
+		}
+
+		v, err := Vincenty(p[0], p[1])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(v-ab) > 1e-3 {
+			t.Errorf("Vincenty = %v, want Karney's %v", v, ab)
+		}
+	}
+}
+
+func TestKarneyFull(t *testing.T) {
+	dist, az1, az2, err := KarneyFull(Coord{Lat: 40.6, Lon: -73.8}, Coord{Lat: 49.01666667, Lon: 2.55})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(dist-5853226.256) > 1e-3 {
+		t.Errorf("expected 5853226.256 m, got %v m", dist)
+	}
+	if math.Abs(az1-53.47022) > 1e-5 || math.Abs(az2-111.59367) > 1e-5 {
+		t.Errorf("expected azimuths 53.47022 and 111.59367, got %v and %v", az1, az2)
+	}
+
+	// Westward azimuths are reported in [0, 360)
+	_, az1, az2, err = KarneyFull(Coord{Lat: 0, Lon: 10}, Coord{Lat: 0, Lon: 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if az1 != 270 || az2 != 270 {
+		t.Errorf("expected azimuths 270 and 270, got %v and %v", az1, az2)
+	}
+}
+
+func TestKarneyFullMatchesVincentyFull(t *testing.T) {
+	rng := testRNG(11)
+	for i := 0; i < 2000; i++ {
+		a := Coord{Lat: rng.Float64()*180 - 90, Lon: rng.Float64()*360 - 180}
+		b := Coord{Lat: rng.Float64()*180 - 90, Lon: rng.Float64()*360 - 180}
+		if GreatCircle(a, b) > 18000 {
+			continue
+		}
+		_, k1, k2, err := KarneyFull(a, b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, v1, v2, err := VincentyFull(a, b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(normalizeLon(k1-v1)) > 1e-6 || math.Abs(normalizeLon(k2-v2)) > 1e-6 {
+			t.Errorf("KarneyFull(%v, %v) azimuths (%v, %v), VincentyFull (%v, %v)", a, b, k1, k2, v1, v2)
+		}
+	}
+}
+
+func TestKarneyKm(t *testing.T) {
+	km, err := KarneyKm(Coord{Lat: 0, Lon: 0}, Coord{Lat: 90, Lon: 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(km-10001.965729) > 1e-6 {
+		t.Errorf("expected 10001.965729 km, got %v", km)
+	}
+}
+
+func BenchmarkKarney(b *testing.B) {
+	nyc := Coord{Lat: 40.7128, Lon: -74.0060}
+	london := Coord{Lat: 51.5074, Lon: -0.1278}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Karney(nyc, london)
+	}
+}
@@ -0,0 +1,160 @@
+package distance
+
+import "math"
+
+// Polygons are rings of Coord vertices in order, either orientation; the
+// closing vertex may be repeated or omitted. As in GeoJSON (RFC 7946), edges
+// are straight lines in longitude/latitude, and a ring may cross the
+// antimeridian: consecutive vertices are always joined the short way round,
+// so [{0, 170}, {0, -170}, ...] spans 20° of longitude, not 340°. A ring
+// whose longitudes wind all the way round the globe encloses a pole (the one
+// on the side of its mean latitude).
+
+// PointInPolygon reports whether p lies inside polygon, for geofencing.
+// Points exactly on an edge may be reported either way; use
+// DistanceToPolygon with a tolerance when that matters.
+// Returns ErrEmptyInput for an empty polygon and ErrInvalidParameter for
+// fewer than three vertices.
+// Time: O(n), Space: O(n)
+func PointInPolygon(p Coord, polygon []Coord) (bool, error) {
+	ring, err := unwrapRing(polygon)
+	if err != nil {
+		return false, err
+	}
+
+	// The unwrapped ring may extend past ±180, so test p's copies too
+	for _, shift := range []float64{-360, 0, 360} {
+		if ringContains(ring, p.Lat, p.Lon+shift) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DistanceToPolygon returns the great-circle distance in kilometers from p to
+// the nearest point of polygon, or 0 if p lies inside it. Edges are measured
+// as great-circle arcs, which differ from the straight lon/lat edges used by
+// PointInPolygon only for edges spanning many degrees.
+// Time: O(n), Space: O(n)
+func DistanceToPolygon(p Coord, polygon []Coord) (float64, error) {
+	inside, err := PointInPolygon(p, polygon)
+	if err != nil {
+		return 0, err
+	}
+	if inside {
+		return 0, nil
+	}
+
+	vertices := openRing(polygon)
+	best := math.Inf(1)
+	for i := range vertices {
+		a, b := vertices[i], vertices[(i+1)%len(vertices)]
+		best = math.Min(best, arcDistance(p, a, b))
+	}
+	return best * earthRadiusKm, nil
+}
+
+// openRing drops a repeated closing vertex
+func openRing(polygon []Coord) []Coord {
+	if n := len(polygon); n > 1 && polygon[0] == polygon[n-1] {
+		return polygon[:n-1]
+	}
+	return polygon
+}
+
+// unwrapRing returns the ring's vertices with longitudes made continuous
+// (each within 180° of the previous), closing pole-enclosing rings along
+// the pole
+func unwrapRing(polygon []Coord) ([]Coord, error) {
+	if len(polygon) == 0 {
+		return nil, ErrEmptyInput
+	}
+	vertices := openRing(polygon)
+	if len(vertices) < 3 {
+		return nil, ErrInvalidParameter
+	}
+
+	ring := make([]Coord, len(vertices), len(vertices)+3)
+	ring[0] = vertices[0]
+	meanLat := vertices[0].Lat
+	for i := 1; i < len(vertices); i++ {
+		c := vertices[i]
+		c.Lon = ring[i-1].Lon + normalizeLon(c.Lon-ring[i-1].Lon)
+		ring[i] = c
+		meanLat += c.Lat
+	}
+
+	// Closing edge back to the first vertex; a full turn means a pole is inside
+	last := ring[len(ring)-1]
+	end := last.Lon + normalizeLon(vertices[0].Lon-last.Lon)
+	if winding := end - ring[0].Lon; math.Abs(winding) > 180 {
+		// Close along a line beyond the pole. The ring only feeds ray
+		// casting in the lon/lat plane, where latitudes past ±90 are just
+		// coordinates; a closing line at exactly ±90 would put points at
+		// the pole on the boundary instead of strictly inside
+		pole := 180.0
+		if meanLat < 0 {
+			pole = -180
+		}
+		ring = append(ring, Coord{Lat: vertices[0].Lat, Lon: end},
+			Coord{Lat: pole, Lon: end}, Coord{Lat: pole, Lon: ring[0].Lon})
+	}
+	return ring, nil
+}
+
+// ringContains applies even-odd ray casting in the lon/lat plane
+func ringContains(ring []Coord, lat, lon float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat > lat) != (b.Lat > lat) &&
+			lon < a.Lon+(lat-a.Lat)*(b.Lon-a.Lon)/(b.Lat-a.Lat) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// arcDistance returns the angular distance in radians from p to the
+// great-circle arc from a to b
+func arcDistance(p, a, b Coord) float64 {
+	vp, va, vb := sphereVector(p), sphereVector(a), sphereVector(b)
+	n := cross3(va, vb)
+	norm := math.Sqrt(dot3(n, n))
+	if norm < 1e-15 {
+		return angle3(vp, va) // Degenerate edge
+	}
+	for i := range n {
+		n[i] /= norm
+	}
+
+	// The foot of the perpendicular lies on the arc if it is between a and b
+	h := dot3(vp, n)
+	foot := [3]float64{vp[0] - h*n[0], vp[1] - h*n[1], vp[2] - h*n[2]}
+	if dot3(cross3(va, foot), n) >= 0 && dot3(cross3(foot, vb), n) >= 0 {
+		return math.Asin(math.Min(1, math.Abs(h)))
+	}
+	return math.Min(angle3(vp, va), angle3(vp, vb))
+}
+
+// sphereVector converts a coordinate to a point on the unit sphere
+func sphereVector(c Coord) [3]float64 {
+	lat, lon := c.Lat*degToRad, c.Lon*degToRad
+	return [3]float64{math.Cos(lat) * math.Cos(lon), math.Cos(lat) * math.Sin(lon), math.Sin(lat)}
+}
+
+// dot3 returns the dot product of two 3-vectors
+func dot3(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+// cross3 returns the cross product a × b of two 3-vectors
+func cross3(a, b [3]float64) [3]float64 {
+	return [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+// angle3 returns the angle in radians between two unit vectors
+func angle3(a, b [3]float64) float64 {
+	c := cross3(a, b)
+	return math.Atan2(math.Sqrt(dot3(c, c)), dot3(a, b))
+}
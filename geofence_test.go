@@ -0,0 +1,100 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPointInPolygon(t *testing.T) {
+	square := []Coord{{Lat: 0, Lon: 0}, {Lat: 0, Lon: 10}, {Lat: 10, Lon: 10}, {Lat: 10, Lon: 0}}
+	// Fiji-style fence across the antimeridian, closing vertex repeated
+	dateline := []Coord{{Lat: -20, Lon: 175}, {Lat: -20, Lon: -175}, {Lat: -10, Lon: -175}, {Lat: -10, Lon: 175}, {Lat: -20, Lon: 175}}
+	// Arctic cap north of 70°, winding once round the globe
+	arctic := []Coord{{Lat: 70, Lon: -180}, {Lat: 70, Lon: -90}, {Lat: 70, Lon: 0}, {Lat: 70, Lon: 90}}
+	concave := []Coord{{Lat: 0, Lon: 0}, {Lat: 0, Lon: 10}, {Lat: 10, Lon: 10}, {Lat: 5, Lon: 5}, {Lat: 10, Lon: 0}}
+
+	tests := []struct {
+		name     string
+		p        Coord
+		polygon  []Coord
+		expected bool
+	}{
+		{"inside square", Coord{Lat: 5, Lon: 5}, square, true},
+		{"outside square", Coord{Lat: 5, Lon: 15}, square, false},
+		{"inside east of dateline", Coord{Lat: -15, Lon: 178}, dateline, true},
+		{"inside west of dateline", Coord{Lat: -15, Lon: -178}, dateline, true},
+		{"on dateline", Coord{Lat: -15, Lon: 180}, dateline, true},
+		{"outside dateline fence", Coord{Lat: -15, Lon: 0}, dateline, false},
+		{"north pole", Coord{Lat: 90, Lon: 0}, arctic, true},
+		{"arctic", Coord{Lat: 80, Lon: 135}, arctic, true},
+		{"south of arctic", Coord{Lat: 60, Lon: 135}, arctic, false},
+		{"concave notch", Coord{Lat: 8, Lon: 5}, concave, false},
+		{"concave body", Coord{Lat: 3, Lon: 5}, concave, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PointInPolygon(tt.p, tt.polygon)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// Orientation does not matter
+	reversed := []Coord{square[3], square[2], square[1], square[0]}
+	if in, _ := PointInPolygon(Coord{Lat: 5, Lon: 5}, reversed); !in {
+		t.Errorf("expected a reversed ring to contain its interior")
+	}
+}
+
+func TestDistanceToPolygon(t *testing.T) {
+	square := []Coord{{Lat: 0, Lon: 0}, {Lat: 0, Lon: 10}, {Lat: 10, Lon: 10}, {Lat: 10, Lon: 0}}
+	kmPerDegree := degToRad * earthRadiusKm
+
+	tests := []struct {
+		name     string
+		p        Coord
+		polygon  []Coord
+		expected float64
+	}{
+		{"inside", Coord{Lat: 5, Lon: 5}, square, 0},
+		{"south of edge", Coord{Lat: -1, Lon: 5}, square, kmPerDegree},
+		{"west of meridian edge", Coord{Lat: 5, Lon: -2}, square, math.Asin(math.Cos(5*degToRad)*math.Sin(2*degToRad)) * earthRadiusKm},
+		{"nearest vertex", Coord{Lat: -1, Lon: -1}, square, Haversine(Coord{Lat: -1, Lon: -1}, Coord{})},
+		{
+			"across dateline",
+			Coord{Lat: 0, Lon: 178},
+			[]Coord{{Lat: -5, Lon: -179}, {Lat: -5, Lon: -170}, {Lat: 5, Lon: -170}, {Lat: 5, Lon: -179}},
+			3 * kmPerDegree,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := DistanceToPolygon(tt.p, tt.polygon)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqualTolerance(result, tt.expected, 1e-6) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPolygonErrors(t *testing.T) {
+	if _, err := PointInPolygon(Coord{}, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	line := []Coord{{Lat: 0, Lon: 0}, {Lat: 1, Lon: 1}, {Lat: 0, Lon: 0}}
+	if _, err := PointInPolygon(Coord{}, line); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := DistanceToPolygon(Coord{}, line); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
@@ -97,11 +97,77 @@ func EquirectangularWithRadius(a, b Coord, radius float64) float64 {
 	return radius * math.Sqrt(x*x+y*y)
 }
 
+// InitialBearing computes the forward azimuth of the great circle from a to b,
+// in degrees clockwise from north [0, 360). The bearing changes along the
+// route; this is the heading at a. Coincident points give 0.
+// Time: O(1), Space: O(1)
+func InitialBearing(a, b Coord) float64 {
+	lat1 := a.Lat * degToRad
+	lat2 := b.Lat * degToRad
+	deltaLon := (b.Lon - a.Lon) * degToRad
+
+	y := math.Sin(deltaLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(deltaLon)
+
+	return math.Mod(math.Atan2(y, x)/degToRad+360, 360)
+}
+
+// Midpoint computes the point halfway along the great circle from a to b.
+// Longitude is normalized to [-180, 180).
+// Time: O(1), Space: O(1)
+func Midpoint(a, b Coord) Coord {
+	lat1 := a.Lat * degToRad
+	lat2 := b.Lat * degToRad
+	lon1 := a.Lon * degToRad
+	deltaLon := (b.Lon - a.Lon) * degToRad
+
+	bx := math.Cos(lat2) * math.Cos(deltaLon)
+	by := math.Cos(lat2) * math.Sin(deltaLon)
+
+	lat := math.Atan2(math.Sin(lat1)+math.Sin(lat2), math.Hypot(math.Cos(lat1)+bx, by))
+	lon := lon1 + math.Atan2(by, math.Cos(lat1)+bx)
+
+	return Coord{Lat: lat / degToRad, Lon: normalizeLon(lon / degToRad)}
+}
+
+// Destination computes the point reached by travelling distanceKm along a
+// great circle from start with initial bearing in degrees clockwise from
+// north. Longitude is normalized to [-180, 180).
+// Time: O(1), Space: O(1)
+func Destination(start Coord, bearing, distanceKm float64) Coord {
+	lat1 := start.Lat * degToRad
+	theta := bearing * degToRad
+	delta := distanceKm / earthRadiusKm
+
+	sinLat := math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta)
+	lat := math.Asin(math.Max(-1, math.Min(1, sinLat)))
+	lon := start.Lon*degToRad + math.Atan2(
+		math.Sin(theta)*math.Sin(delta)*math.Cos(lat1),
+		math.Cos(delta)-math.Sin(lat1)*sinLat,
+	)
+
+	return Coord{Lat: lat / degToRad, Lon: normalizeLon(lon / degToRad)}
+}
+
 // Vincenty computes geodesic distance using Vincenty formula.
 // More accurate than Haversine for oblate spheroid (WGS-84 ellipsoid).
-// Returns distance in meters.
+// Returns distance in meters. The iteration fails to converge for nearly
+// antipodal points; those are solved with Karney instead, so the result
+// stays accurate to well under a millimeter.
 // Time: O(1) with iteration, Space: O(1)
 func Vincenty(a, b Coord) (float64, error) {
+	dist, _, _, err := VincentyFull(a, b)
+	return dist, err
+}
+
+// VincentyFull solves the inverse geodesic problem with Vincenty's formula,
+// returning the distance in meters together with the forward azimuth at a
+// (initial bearing) and at b (final bearing, the direction of travel on
+// arrival), both in degrees clockwise from north in [0, 360). The reverse
+// azimuth, from b back to a, is az2 ± 180. Coincident points have both
+// azimuths 0. Nearly antipodal points are solved with KarneyFull.
+// Time: O(1) with iteration, Space: O(1)
+func VincentyFull(a, b Coord) (dist, az1, az2 float64, err error) {
 	const (
 		majorAxis     = 6378137.0         // WGS-84 semi-major axis (meters)
 		minorAxis     = 6356752.314245    // WGS-84 semi-minor axis (meters)
@@ -127,10 +193,11 @@ func Vincenty(a, b Coord) (float64, error) {
 	var lambdaP float64
 
 	var sinSigma, cosSigma, sigma, sinAlpha, cosSqAlpha, cos2SigmaM float64
+	var sinLambda, cosLambda float64
 	converged := false
 
 	for i := 0; i < maxIterations; i++ {
-		sinLambda, cosLambda := math.Sin(lambda), math.Cos(lambda)
+		sinLambda, cosLambda = math.Sin(lambda), math.Cos(lambda)
 
 		sinSigma = math.Sqrt(
 			(cosU2*sinLambda)*(cosU2*sinLambda) +
@@ -138,7 +205,7 @@ func Vincenty(a, b Coord) (float64, error) {
 		)
 
 		if sinSigma == 0 {
-			return 0, nil // Coincident points
+			return 0, 0, 0, nil // Coincident points
 		}
 
 		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
@@ -165,9 +232,8 @@ func Vincenty(a, b Coord) (float64, error) {
 
 	// Check if algorithm converged
 	if !converged {
-		// For antipodal points or nearly antipodal points, formula may not converge
-		// Fall back to Haversine as approximation
-		return HaversineWithRadius(a, b, majorAxis/1000.0) * 1000.0, nil
+		// Nearly antipodal points defeat the iteration; solve them with Karney
+		return KarneyFull(a, b)
 	}
 
 	uSq := cosSqAlpha * (majorAxis*majorAxis - minorAxis*minorAxis) / (minorAxis * minorAxis)
@@ -179,7 +245,10 @@ func Vincenty(a, b Coord) (float64, error) {
 
 	s := minorAxis * A * (sigma - deltaSigma)
 
-	return s, nil
+	az1 = math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda) / degToRad
+	az2 = math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda) / degToRad
+
+	return s, normalizeAzimuth(az1), normalizeAzimuth(az2), nil
 }
 
 // VincentyKm computes Vincenty distance in kilometers.
@@ -191,3 +260,17 @@ func VincentyKm(a, b Coord) (float64, error) {
 	}
 	return meters / 1000.0, nil
 }
+
+// normalizeAzimuth wraps an azimuth in degrees to [0, 360)
+func normalizeAzimuth(az float64) float64 {
+	az = math.Mod(az+360, 360)
+	if az >= 360 {
+		return 0 // -tiny + 360 rounds up to 360
+	}
+	return az
+}
+
+// normalizeLon wraps a longitude in degrees to [-180, 180)
+func normalizeLon(lon float64) float64 {
+	return math.Mod(math.Mod(lon+180, 360)+360, 360) - 180
+}
@@ -200,6 +200,54 @@ func TestVincenty(t *testing.T) {
 	}
 }
 
+func TestVincentyFull(t *testing.T) {
+	tests := []struct {
+		name             string
+		a, b             Coord
+		expected         float64
+		az1, az2         float64
+		distTol, azimTol float64
+	}{
+		{"same location", Coord{Lat: 40.7128, Lon: -74.0060}, Coord{Lat: 40.7128, Lon: -74.0060}, 0, 0, 0, 1e-9, 1e-9},
+		{"due north", Coord{Lat: 0, Lon: 0}, Coord{Lat: 10, Lon: 0}, 1105854.833, 0, 0, 1e-3, 1e-9},
+		{"due west on the equator", Coord{Lat: 0, Lon: 10}, Coord{Lat: 0, Lon: 0}, 1113194.908, 270, 270, 1e-3, 1e-9},
+		// Reference values from GeographicLib's GeodSolve
+		{"JFK to CDG", Coord{Lat: 40.6, Lon: -73.8}, Coord{Lat: 49.01666667, Lon: 2.55}, 5853226.256, 53.47022, 111.59367, 1e-3, 1e-5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dist, az1, az2, err := VincentyFull(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(dist-tt.expected) > tt.distTol {
+				t.Errorf("expected %v m, got %v m", tt.expected, dist)
+			}
+			if math.Abs(az1-tt.az1) > tt.azimTol || math.Abs(az2-tt.az2) > tt.azimTol {
+				t.Errorf("expected azimuths %v and %v, got %v and %v", tt.az1, tt.az2, az1, az2)
+			}
+		})
+	}
+}
+
+func TestVincentyFullReverse(t *testing.T) {
+	// Swapping the endpoints reverses both azimuths
+	a, b := Coord{Lat: -33.8688, Lon: 151.2093}, Coord{Lat: 35.6762, Lon: 139.6503}
+	_, ab1, ab2, err := VincentyFull(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, ba1, ba2, err := VincentyFull(b, a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqualTolerance(normalizeAzimuth(ab2+180), ba1, 1e-9) ||
+		!almostEqualTolerance(normalizeAzimuth(ab1+180), ba2, 1e-9) {
+		t.Errorf("azimuths not reversed: (%v, %v) vs (%v, %v)", ab1, ab2, ba1, ba2)
+	}
+}
+
 func TestVincentyKm(t *testing.T) {
 	nyc := Coord{Lat: 40.7128, Lon: -74.0060}
 	london := Coord{Lat: 51.5074, Lon: -0.1278}
@@ -311,6 +359,99 @@ func TestGeographicConsistency(t *testing.T) {
 	}
 }
 
+func TestInitialBearing(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Coord
+		expected float64
+	}{
+		{"due north", Coord{Lat: 0, Lon: 0}, Coord{Lat: 10, Lon: 0}, 0},
+		{"due east on equator", Coord{Lat: 0, Lon: 0}, Coord{Lat: 0, Lon: 10}, 90},
+		{"due south", Coord{Lat: 10, Lon: 5}, Coord{Lat: -10, Lon: 5}, 180},
+		{"due west across antimeridian", Coord{Lat: 0, Lon: -179}, Coord{Lat: 0, Lon: 179}, 270},
+		{"london to new york", Coord{Lat: 51.5074, Lon: -0.1278}, Coord{Lat: 40.7128, Lon: -74.0060}, 288.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := InitialBearing(tt.a, tt.b)
+			if !almostEqualTolerance(result, tt.expected, 0.1) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMidpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Coord
+		expected Coord
+	}{
+		{"equator", Coord{Lat: 0, Lon: 0}, Coord{Lat: 0, Lon: 90}, Coord{Lat: 0, Lon: 45}},
+		{"meridian", Coord{Lat: -20, Lon: 10}, Coord{Lat: 40, Lon: 10}, Coord{Lat: 10, Lon: 10}},
+		{"across antimeridian", Coord{Lat: 0, Lon: 170}, Coord{Lat: 0, Lon: -170}, Coord{Lat: 0, Lon: -180}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Midpoint(tt.a, tt.b)
+			if !almostEqualTolerance(result.Lat, tt.expected.Lat, 1e-9) ||
+				!almostEqualTolerance(result.Lon, tt.expected.Lon, 1e-9) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// The midpoint is equidistant and halfway along the route
+	london := Coord{Lat: 51.5074, Lon: -0.1278}
+	nyc := Coord{Lat: 40.7128, Lon: -74.0060}
+	mid := Midpoint(london, nyc)
+	total := Haversine(london, nyc)
+	if !almostEqualTolerance(Haversine(london, mid), total/2, 1e-6) ||
+		!almostEqualTolerance(Haversine(mid, nyc), total/2, 1e-6) {
+		t.Errorf("expected midpoint %v halfway along %v km", mid, total)
+	}
+}
+
+func TestDestination(t *testing.T) {
+	// A quarter of the circumference due east along the equator
+	quarter := math.Pi / 2 * earthRadiusKm
+	result := Destination(Coord{Lat: 0, Lon: 0}, 90, quarter)
+	if !almostEqualTolerance(result.Lat, 0, 1e-9) || !almostEqualTolerance(result.Lon, 90, 1e-9) {
+		t.Errorf("expected (0, 90), got %v", result)
+	}
+
+	// Wraps across the antimeridian
+	result = Destination(Coord{Lat: 0, Lon: 179}, 90, 2*degToRad*earthRadiusKm)
+	if !almostEqualTolerance(result.Lon, -179, 1e-9) {
+		t.Errorf("expected longitude -179, got %v", result.Lon)
+	}
+
+	// Inverse of Haversine and InitialBearing
+	starts := []Coord{
+		{Lat: 51.5074, Lon: -0.1278},
+		{Lat: -33.8688, Lon: 151.2093},
+		{Lat: 35.6762, Lon: 139.6503},
+	}
+	ends := []Coord{
+		{Lat: 40.7128, Lon: -74.0060},
+		{Lat: -41.2865, Lon: 174.7762},
+		{Lat: 64.1466, Lon: -21.9426},
+	}
+	for i := range starts {
+		d := Haversine(starts[i], ends[i])
+		got := Destination(starts[i], InitialBearing(starts[i], ends[i]), d)
+		if Haversine(got, ends[i]) > 1e-6 {
+			t.Errorf("expected %v, got %v", ends[i], got)
+		}
+	}
+
+	if got := Destination(Coord{Lat: 12, Lon: 34}, 45, 0); !almostEqual(got.Lat, 12) || !almostEqual(got.Lon, 34) {
+		t.Errorf("expected start for zero distance, got %v", got)
+	}
+}
+
 // Benchmarks
 func BenchmarkHaversine(b *testing.B) {
 	nyc := Coord{Lat: 40.7128, Lon: -74.0060}
@@ -0,0 +1,169 @@
+package distance
+
+import (
+	"math"
+	"strings"
+)
+
+// geohashAlphabet is the base-32 alphabet of geohash (no a, i, l, o)
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// geohashMaxPrecision keeps cells above float64 resolution (~19 mm at 12)
+const geohashMaxPrecision = 12
+
+// Geohash encodes c as a geohash of precision characters, clamped to
+// [1, 12]. Each character halves the cell 5 times, alternating longitude
+// and latitude; nearby points usually share a prefix, so hashes work as
+// coarse proximity buckets (use GeohashCover for lookups that must not miss).
+// Time: O(precision), Space: O(precision)
+func Geohash(c Coord, precision int) string {
+	precision = min(max(precision, 1), geohashMaxPrecision)
+	latLo, latHi := -90.0, 90.0
+	lonLo, lonHi := -180.0, 180.0
+
+	var sb strings.Builder
+	bit, ch, even := 0, 0, true
+	for sb.Len() < precision {
+		if even {
+			mid := (lonLo + lonHi) / 2
+			if c.Lon >= mid {
+				ch = ch<<1 | 1
+				lonLo = mid
+			} else {
+				ch <<= 1
+				lonHi = mid
+			}
+		} else {
+			mid := (latLo + latHi) / 2
+			if c.Lat >= mid {
+				ch = ch<<1 | 1
+				latLo = mid
+			} else {
+				ch <<= 1
+				latHi = mid
+			}
+		}
+		even = !even
+		if bit++; bit == 5 {
+			sb.WriteByte(geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return sb.String()
+}
+
+// GeohashBox returns the south-west and north-east corners of the cell
+// covered by hash (case-insensitive).
+// Returns ErrInvalidParameter for an empty hash or a character outside the
+// geohash alphabet.
+// Time: O(len(hash)), Space: O(1)
+func GeohashBox(hash string) (Coord, Coord, error) {
+	if hash == "" {
+		return Coord{}, Coord{}, ErrInvalidParameter
+	}
+	latLo, latHi := -90.0, 90.0
+	lonLo, lonHi := -180.0, 180.0
+	even := true
+	for _, r := range strings.ToLower(hash) {
+		v := strings.IndexRune(geohashAlphabet, r)
+		if v < 0 {
+			return Coord{}, Coord{}, ErrInvalidParameter
+		}
+		for mask := 16; mask > 0; mask >>= 1 {
+			if even {
+				mid := (lonLo + lonHi) / 2
+				if v&mask != 0 {
+					lonLo = mid
+				} else {
+					lonHi = mid
+				}
+			} else {
+				mid := (latLo + latHi) / 2
+				if v&mask != 0 {
+					latLo = mid
+				} else {
+					latHi = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return Coord{Lat: latLo, Lon: lonLo}, Coord{Lat: latHi, Lon: lonHi}, nil
+}
+
+// DecodeGeohash returns the center of the cell covered by hash.
+// Time: O(len(hash)), Space: O(1)
+func DecodeGeohash(hash string) (Coord, error) {
+	sw, ne, err := GeohashBox(hash)
+	if err != nil {
+		return Coord{}, err
+	}
+	return Coord{Lat: (sw.Lat + ne.Lat) / 2, Lon: (sw.Lon + ne.Lon) / 2}, nil
+}
+
+// GeohashNeighbors returns the distinct cells of the same precision
+// surrounding hash, clockwise from north: N, NE, E, SE, S, SW, W, NW.
+// Longitude wraps across the antimeridian; cells beyond a pole are omitted.
+// Time: O(len(hash)), Space: O(1)
+func GeohashNeighbors(hash string) ([]string, error) {
+	sw, ne, err := GeohashBox(hash)
+	if err != nil {
+		return nil, err
+	}
+	height, width := ne.Lat-sw.Lat, ne.Lon-sw.Lon
+	center := Coord{Lat: (sw.Lat + ne.Lat) / 2, Lon: (sw.Lon + ne.Lon) / 2}
+	self := strings.ToLower(hash)
+
+	offsets := [8][2]float64{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+	seen := map[string]bool{self: true}
+	neighbors := make([]string, 0, 8)
+	for _, o := range offsets {
+		lat := center.Lat + o[0]*height
+		if lat > 90 || lat < -90 {
+			continue
+		}
+		lon := math.Mod(center.Lon+o[1]*width+540, 360) - 180
+		h := Geohash(Coord{Lat: lat, Lon: lon}, len(hash))
+		if !seen[h] {
+			seen[h] = true
+			neighbors = append(neighbors, h)
+		}
+	}
+	return neighbors, nil
+}
+
+// GeohashCover returns the cell containing c and its neighbors at the
+// finest precision whose cells are at least radiusKm tall and wide, so
+// every point within radiusKm of c lies in one of the returned cells. The
+// guarantee holds for radii up to about 2,000 km away from the poles; a
+// circle reaching over a pole also needs the cells across it.
+// Index points by Geohash at the same precision (the length of the
+// returned hashes) and filter candidates with Haversine.
+// Time: O(1), Space: O(1)
+func GeohashCover(c Coord, radiusKm float64) ([]string, error) {
+	if radiusKm < 0 || math.IsNaN(radiusKm) {
+		return nil, ErrInvalidParameter
+	}
+
+	// Cells are narrowest at the highest latitude the circle reaches
+	kmPerDegree := earthRadiusKm * degToRad
+	reach := math.Min(90, math.Abs(c.Lat)+radiusKm/kmPerDegree)
+	precision := 1
+	for p := 2; p <= geohashMaxPrecision; p++ {
+		lonBits := (5*p + 1) / 2
+		latBits := 5 * p / 2
+		height := 180 / math.Ldexp(1, latBits) * kmPerDegree
+		width := 360 / math.Ldexp(1, lonBits) * kmPerDegree * math.Cos(reach*degToRad)
+		if height < radiusKm || width < radiusKm {
+			break
+		}
+		precision = p
+	}
+
+	hash := Geohash(c, precision)
+	neighbors, err := GeohashNeighbors(hash)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{hash}, neighbors...), nil
+}
@@ -0,0 +1,112 @@
+package distance
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGeohash(t *testing.T) {
+	tests := []struct {
+		c         Coord
+		precision int
+		expected  string
+	}{
+		{Coord{Lat: 57.64911, Lon: 10.40744}, 11, "u4pruydqqvj"},
+		{Coord{Lat: 42.605, Lon: -5.603}, 5, "ezs42"},
+		{Coord{Lat: -33.8688, Lon: 151.2093}, 6, "r3gx2f"},
+		{Coord{Lat: 0, Lon: 0}, 1, "s"},
+		{Coord{Lat: 57.64911, Lon: 10.40744}, 0, "u"},
+		{Coord{Lat: 57.64911, Lon: 10.40744}, 20, "u4pruydqqvj8"},
+	}
+	for _, tt := range tests {
+		if got := Geohash(tt.c, tt.precision); got != tt.expected {
+			t.Errorf("%v at %d: expected %q, got %q", tt.c, tt.precision, tt.expected, got)
+		}
+	}
+}
+
+func TestDecodeGeohash(t *testing.T) {
+	c, err := DecodeGeohash("EZS42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(c.Lat-42.605) > 0.03 || math.Abs(c.Lon-(-5.603)) > 0.03 {
+		t.Errorf("expected about (42.605, -5.603), got %v", c)
+	}
+
+	rng := testRNG(4)
+	for i := 0; i < 100; i++ {
+		p := Coord{Lat: rng.Float64()*180 - 90, Lon: rng.Float64()*360 - 180}
+		sw, ne, err := GeohashBox(Geohash(p, 8))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if p.Lat < sw.Lat || p.Lat > ne.Lat || p.Lon < sw.Lon || p.Lon > ne.Lon {
+			t.Fatalf("%v outside its cell %v-%v", p, sw, ne)
+		}
+	}
+
+	for _, bad := range []string{"", "u4a", "u4 "} {
+		if _, err := DecodeGeohash(bad); err != ErrInvalidParameter {
+			t.Errorf("%q: expected ErrInvalidParameter, got %v", bad, err)
+		}
+	}
+}
+
+func TestGeohashNeighbors(t *testing.T) {
+	neighbors, err := GeohashNeighbors("ezs42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "ezs48,ezs49,ezs43,ezs41,ezs40,ezefp,ezefr,ezefx"
+	if strings.Join(neighbors, ",") != expected {
+		t.Errorf("expected %s, got %v", expected, neighbors)
+	}
+
+	// Across the antimeridian
+	east := Geohash(Coord{Lat: 10, Lon: 179.99}, 4)
+	west := Geohash(Coord{Lat: 10, Lon: -179.99}, 4)
+	wrapped, _ := GeohashNeighbors(east)
+	if !strings.Contains(strings.Join(wrapped, ","), west) {
+		t.Errorf("expected %s among the neighbors of %s, got %v", west, east, wrapped)
+	}
+
+	// At the pole the northern row is missing
+	polar, _ := GeohashNeighbors(Geohash(Coord{Lat: 89.99, Lon: 0}, 3))
+	if len(polar) != 5 {
+		t.Errorf("expected 5 neighbors at the pole, got %v", polar)
+	}
+}
+
+func TestGeohashCover(t *testing.T) {
+	rng := testRNG(8)
+	for _, radius := range []float64{0.05, 1, 20, 500} {
+		for trial := 0; trial < 50; trial++ {
+			center := Coord{Lat: rng.Float64()*140 - 70, Lon: rng.Float64()*360 - 180}
+			cover, err := GeohashCover(center, radius)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cells := strings.Join(cover, ",")
+			precision := len(cover[0])
+
+			// Random points on the circle's edge must fall in a covered cell
+			for k := 0; k < 20; k++ {
+				bearing := rng.Float64() * 2 * math.Pi
+				d := radius / earthRadiusKm
+				lat1, lon1 := center.Lat*degToRad, center.Lon*degToRad
+				lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(bearing))
+				lon2 := lon1 + math.Atan2(math.Sin(bearing)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat2))
+				p := Coord{Lat: lat2 / degToRad, Lon: math.Mod(lon2/degToRad+540, 360) - 180}
+				if h := Geohash(p, precision); !strings.Contains(cells, h) {
+					t.Fatalf("radius %v: %v (%s) not covered by %v around %v", radius, p, h, cover, center)
+				}
+			}
+		}
+	}
+
+	if _, err := GeohashCover(Coord{}, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
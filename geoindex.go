@@ -0,0 +1,179 @@
+package distance
+
+import "math"
+
+// GeoIndex is a Searcher[Coord] answering radius and nearest-neighbor
+// queries over geographic points without scanning them all. Points are
+// bucketed in a latitude/longitude grid; a query visits only the cells that
+// can intersect its search circle and checks their points with Haversine.
+// Distances are in kilometers. A GeoIndex is not safe for concurrent
+// Insert; concurrent queries are safe.
+type GeoIndex struct {
+	cellDeg  float64 // row height in degrees
+	lonDeg   float64 // column width in degrees, dividing 360 exactly
+	lonCells int
+	cells    map[[2]int][]int
+	ids      []string
+	coords   []Coord
+	byID     map[string]int
+}
+
+// NewGeoIndex creates an empty index whose grid cells span about cellKm
+// north to south (and less east to west away from the equator). Choose a
+// size near typical query radii: much smaller cells mean many empty cells
+// per query, much larger ones many Haversine checks per cell.
+func NewGeoIndex(cellKm float64) (*GeoIndex, error) {
+	if cellKm <= 0 || math.IsNaN(cellKm) || math.IsInf(cellKm, 0) {
+		return nil, ErrInvalidParameter
+	}
+	cellDeg := math.Min(cellKm/(earthRadiusKm*degToRad), 180)
+	lonCells := int(math.Ceil(360 / cellDeg))
+	return &GeoIndex{
+		cellDeg:  cellDeg,
+		lonDeg:   360 / float64(lonCells),
+		lonCells: lonCells,
+		cells:    make(map[[2]int][]int),
+		byID:     make(map[string]int),
+	}, nil
+}
+
+// Insert adds a point under id. IDs must be unique.
+// Returns ErrInvalidParameter for a duplicate ID or a coordinate outside
+// latitude [-90, 90] and longitude [-180, 180].
+// Time: O(1), Space: O(1)
+func (g *GeoIndex) Insert(id string, c Coord) error {
+	if !(c.Lat >= -90 && c.Lat <= 90 && c.Lon >= -180 && c.Lon <= 180) {
+		return ErrInvalidParameter
+	}
+	if _, ok := g.byID[id]; ok {
+		return ErrInvalidParameter
+	}
+
+	index := len(g.coords)
+	g.byID[id] = index
+	g.ids = append(g.ids, id)
+	g.coords = append(g.coords, c)
+	key := g.cell(c)
+	g.cells[key] = append(g.cells[key], index)
+	return nil
+}
+
+// Len returns the number of stored points.
+func (g *GeoIndex) Len() int {
+	return len(g.coords)
+}
+
+// Within returns every point within radiusKm of center, nearest first.
+// Time: O(cells covered + m log m) where m = candidates, Space: O(m)
+func (g *GeoIndex) Within(center Coord, radiusKm float64) ([]SearchResult, error) {
+	if radiusKm < 0 || math.IsNaN(radiusKm) {
+		return nil, ErrInvalidParameter
+	}
+
+	var results []SearchResult
+	g.visit(center, radiusKm, func(i int) {
+		if d := Haversine(center, g.coords[i]); d <= radiusKm {
+			results = append(results, g.result(i, d))
+		}
+	})
+	sortSearchResults(results)
+	return results, nil
+}
+
+// KNearest returns the k points nearest to center, nearest first. The
+// search radius starts at one cell and doubles until k points are found.
+// Time: O(cells covered + m log m) per round, Space: O(m)
+func (g *GeoIndex) KNearest(center Coord, k int) ([]SearchResult, error) {
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	halfCircumference := math.Pi * earthRadiusKm
+	radius := g.cellDeg * earthRadiusKm * degToRad
+	for {
+		results, err := g.Within(center, radius)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) >= k || radius >= halfCircumference {
+			return results[:min(k, len(results))], nil
+		}
+		radius = math.Min(2*radius, halfCircumference)
+	}
+}
+
+// Search returns the k points nearest to query, as KNearest.
+func (g *GeoIndex) Search(query Coord, k int) ([]SearchResult, error) {
+	return g.KNearest(query, k)
+}
+
+// SearchRadius returns every point within radius kilometers of query, as Within.
+func (g *GeoIndex) SearchRadius(query Coord, radius float64) ([]SearchResult, error) {
+	return g.Within(query, radius)
+}
+
+// visit calls fn for every point in a cell that may intersect the circle
+func (g *GeoIndex) visit(center Coord, radiusKm float64, fn func(i int)) {
+	angular := radiusKm / earthRadiusKm // radians
+	latLo := center.Lat - angular/degToRad
+	latHi := center.Lat + angular/degToRad
+
+	// Widest longitude span of a spherical cap, unless it covers a pole
+	allLon := latLo <= -90 || latHi >= 90
+	var lonSpan float64
+	if !allLon {
+		s := math.Sin(angular) / math.Cos(center.Lat*degToRad)
+		if s >= 1 || angular >= math.Pi/2 {
+			allLon = true
+		} else {
+			lonSpan = math.Asin(s) / degToRad
+		}
+	}
+
+	rowLo := g.row(math.Max(latLo, -90))
+	rowHi := g.row(math.Min(latHi, 90))
+	colLo, colHi := 0, g.lonCells-1
+	if !allLon {
+		colLo = int(math.Floor((center.Lon - lonSpan + 180) / g.lonDeg))
+		colHi = int(math.Floor((center.Lon + lonSpan + 180) / g.lonDeg))
+		if colHi-colLo+1 >= g.lonCells {
+			colLo, colHi = 0, g.lonCells-1
+		}
+	}
+
+	// Scanning the occupied cells is cheaper than probing a huge empty range
+	if (rowHi-rowLo+1)*(colHi-colLo+1) > len(g.cells) {
+		for key, points := range g.cells {
+			if key[0] >= rowLo && key[0] <= rowHi {
+				for _, i := range points {
+					fn(i)
+				}
+			}
+		}
+		return
+	}
+	for r := rowLo; r <= rowHi; r++ {
+		for c := colLo; c <= colHi; c++ {
+			col := ((c % g.lonCells) + g.lonCells) % g.lonCells
+			for _, i := range g.cells[[2]int{r, col}] {
+				fn(i)
+			}
+		}
+	}
+}
+
+// cell returns the grid cell containing c
+func (g *GeoIndex) cell(c Coord) [2]int {
+	col := int(math.Floor((c.Lon + 180) / g.lonDeg))
+	return [2]int{g.row(c.Lat), col % g.lonCells}
+}
+
+// row returns the grid row containing latitude lat
+func (g *GeoIndex) row(lat float64) int {
+	return int(math.Floor((lat + 90) / g.cellDeg))
+}
+
+// result wraps the stored point at index i as a SearchResult
+func (g *GeoIndex) result(i int, dist float64) SearchResult {
+	return SearchResult{ID: g.ids[i], Index: i, Distance: dist}
+}
@@ -0,0 +1,142 @@
+package distance
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestGeoIndexWithin(t *testing.T) {
+	g, err := NewGeoIndex(50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cities := map[string]Coord{
+		"london":    {Lat: 51.5074, Lon: -0.1278},
+		"paris":     {Lat: 48.8566, Lon: 2.3522},
+		"brussels":  {Lat: 50.8503, Lon: 4.3517},
+		"new york":  {Lat: 40.7128, Lon: -74.0060},
+		"amsterdam": {Lat: 52.3676, Lon: 4.9041},
+	}
+	for _, name := range []string{"london", "paris", "brussels", "new york", "amsterdam"} {
+		if err := g.Insert(name, cities[name]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := g.Insert("paris", cities["paris"]); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter for duplicate, got %v", err)
+	}
+
+	results, err := g.Within(cities["brussels"], 320)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, r := range results {
+		got = append(got, r.ID)
+	}
+	if !reflect.DeepEqual(got, []string{"brussels", "amsterdam", "paris"}) {
+		t.Errorf("unexpected results %v", results)
+	}
+
+	nearest, err := g.KNearest(Coord{Lat: 40, Lon: -75}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nearest) != 2 || nearest[0].ID != "new york" || nearest[1].ID != "london" {
+		t.Errorf("unexpected nearest %v", nearest)
+	}
+}
+
+func TestGeoIndexMatchesBruteForce(t *testing.T) {
+	rng := testRNG(6)
+	random := func() Coord {
+		return Coord{Lat: rng.Float64()*180 - 90, Lon: rng.Float64()*360 - 180}
+	}
+
+	g, _ := NewGeoIndex(300)
+	var points []Coord
+	for i := 0; i < 3000; i++ {
+		p := random()
+		// Crowd the antimeridian and the poles
+		switch i % 10 {
+		case 0:
+			p.Lon = 179.5 + rng.Float64()
+			if p.Lon > 180 {
+				p.Lon -= 360
+			}
+		case 1:
+			p.Lat = 88 + 2*rng.Float64()
+		}
+		points = append(points, p)
+		_ = g.Insert(fmt.Sprint(i), p)
+	}
+
+	queries := []Coord{{Lat: 0, Lon: 180}, {Lat: 10, Lon: -179.9}, {Lat: 89.5, Lon: 45}, {Lat: -60, Lon: 0}}
+	for i := 0; i < 20; i++ {
+		queries = append(queries, random())
+	}
+	for _, q := range queries {
+		for _, radius := range []float64{100, 1000, 8000} {
+			var want []SearchResult
+			for i, p := range points {
+				if d := Haversine(q, p); d <= radius {
+					want = append(want, SearchResult{ID: fmt.Sprint(i), Index: i, Distance: d})
+				}
+			}
+			sortSearchResults(want)
+			got, err := g.Within(q, radius)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+				t.Fatalf("%v radius %v: expected %d results, got %d", q, radius, len(want), len(got))
+			}
+		}
+
+		var all []SearchResult
+		for i, p := range points {
+			all = append(all, SearchResult{ID: fmt.Sprint(i), Index: i, Distance: Haversine(q, p)})
+		}
+		sortSearchResults(all)
+		var s Searcher[Coord] = g
+		nearest, err := s.Search(q, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(nearest, all[:5]) {
+			t.Errorf("%v: expected %v, got %v", q, all[:5], nearest)
+		}
+	}
+}
+
+func TestGeoIndexErrors(t *testing.T) {
+	if _, err := NewGeoIndex(0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	g, _ := NewGeoIndex(10)
+	if err := g.Insert("x", Coord{Lat: 91}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := g.Within(Coord{}, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := g.KNearest(Coord{}, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if results, err := g.KNearest(Coord{}, 3); err != nil || len(results) != 0 {
+		t.Errorf("expected no results from an empty index, got %v (%v)", results, err)
+	}
+}
+
+func BenchmarkGeoIndexWithin(b *testing.B) {
+	rng := testRNG(6)
+	g, _ := NewGeoIndex(50)
+	for i := 0; i < 100000; i++ {
+		_ = g.Insert(fmt.Sprint(i), Coord{Lat: rng.Float64()*180 - 90, Lon: rng.Float64()*360 - 180})
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.Within(Coord{Lat: 48.8566, Lon: 2.3522}, 50)
+	}
+}
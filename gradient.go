@@ -0,0 +1,171 @@
+package distance
+
+import "math"
+
+// DistanceGradFunc computes a distance between a and b together with its
+// gradient with respect to a.
+type DistanceGradFunc func(a, b []float64) (float64, []float64, error)
+
+// EuclideanGradient returns ‖a - b‖ and its gradient (a - b) / ‖a - b‖ with
+// respect to a. The zero subgradient is returned when a == b.
+// Time: O(n), Space: O(n)
+func EuclideanGradient(a, b []float64) (float64, []float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, nil, err
+	}
+
+	grad := make([]float64, len(a))
+	dist, _ := EuclideanF64(a, b)
+	if dist == 0 {
+		return 0, grad, nil
+	}
+	for i := range a {
+		grad[i] = (a[i] - b[i]) / dist
+	}
+	return dist, grad, nil
+}
+
+// CosineGradient returns the cosine distance 1 - a·b / (‖a‖‖b‖) and its
+// gradient (a·b / ‖a‖² · a - b) / (‖a‖‖b‖) with respect to a.
+// Time: O(n), Space: O(n)
+func CosineGradient(a, b []float64) (float64, []float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, nil, err
+	}
+
+	dot := dotF64(a, b)
+	normA, normB := math.Sqrt(dotF64(a, a)), math.Sqrt(dotF64(b, b))
+	if normA == 0 || normB == 0 {
+		return 0, nil, ErrZeroVector
+	}
+
+	denom := normA * normB
+	grad := make([]float64, len(a))
+	for i := range a {
+		grad[i] = (dot/(normA*normA)*a[i] - b[i]) / denom
+	}
+	return 1 - clampUnit(dot/denom), grad, nil
+}
+
+// KLDivergenceGradient returns KL(p||q) and its gradient ln(pᵢ/qᵢ) + 1 with
+// respect to p. All entries of p and q must be strictly positive.
+// NOTE: the gradient ignores the simplex constraint Σp = 1; project or
+// reparameterize (e.g. softmax) when optimizing p directly.
+// Time: O(n), Space: O(n)
+func KLDivergenceGradient(p, q []float64) (float64, []float64, error) {
+	if err := Validate(p, q); err != nil {
+		return 0, nil, err
+	}
+
+	var kl float64
+	grad := make([]float64, len(p))
+	for i := range p {
+		if p[i] < 0 || q[i] < 0 {
+			return 0, nil, ErrNegativeValue
+		}
+		if p[i] == 0 || q[i] == 0 {
+			return 0, nil, ErrInvalidParameter // ln(pᵢ/qᵢ) is unbounded
+		}
+		logRatio := math.Log(p[i] / q[i])
+		kl += p[i] * logRatio
+		grad[i] = logRatio + 1
+	}
+	return kl, grad, nil
+}
+
+// SoftDTWGradient returns the soft-DTW discrepancy of SoftDTW and its gradient
+// with respect to a, using the backward recursion of Cuturi & Blondel (2017).
+// Time: O(nm), Space: O(nm)
+func SoftDTWGradient(a, b []float64, gamma float64) (float64, []float64, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, nil, ErrEmptyInput
+	}
+	if gamma <= 0 {
+		return 0, nil, ErrInvalidParameter
+	}
+
+	n, m := len(a), len(b)
+	// R and E are padded by one on every side so boundaries need no special cases
+	R := make([][]float64, n+2)
+	E := make([][]float64, n+2)
+	for i := range R {
+		R[i] = make([]float64, m+2)
+		E[i] = make([]float64, m+2)
+		for j := range R[i] {
+			R[i][j] = math.Inf(1)
+		}
+	}
+	R[0][0] = 0
+	cost := func(i, j int) float64 {
+		if i > n || j > m {
+			return 0
+		}
+		d := a[i-1] - b[j-1]
+		return d * d
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			R[i][j] = cost(i, j) + softMin3(R[i-1][j], R[i][j-1], R[i-1][j-1], gamma)
+		}
+	}
+	value := R[n][m]
+
+	for i := 1; i <= n; i++ {
+		R[i][m+1] = math.Inf(-1)
+	}
+	for j := 1; j <= m; j++ {
+		R[n+1][j] = math.Inf(-1)
+	}
+	R[n+1][m+1] = value
+	E[n+1][m+1] = 1
+
+	for j := m; j >= 1; j-- {
+		for i := n; i >= 1; i-- {
+			down := math.Exp((R[i+1][j] - R[i][j] - cost(i+1, j)) / gamma)
+			right := math.Exp((R[i][j+1] - R[i][j] - cost(i, j+1)) / gamma)
+			diag := math.Exp((R[i+1][j+1] - R[i][j] - cost(i+1, j+1)) / gamma)
+			E[i][j] = E[i+1][j]*down + E[i][j+1]*right + E[i+1][j+1]*diag
+		}
+	}
+
+	grad := make([]float64, n)
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			grad[i-1] += E[i][j] * 2 * (a[i-1] - b[j-1])
+		}
+	}
+	return value, grad, nil
+}
+
+// GradientObjective fixes the target and returns x ↦ dist(x, target) with its
+// gradient, ready to pass to GradientDescent, Adam, ConjugateGradient or BFGS.
+// Evaluation errors (e.g. a dimension mismatch) surface as a NaN objective and
+// an empty gradient, which the optimizers report as an error.
+func GradientObjective(target []float64, fn DistanceGradFunc) (OptimizationFunc, GradientFunc) {
+	f := func(x []float64) float64 {
+		d, _, err := fn(x, target)
+		if err != nil {
+			return math.NaN()
+		}
+		return d
+	}
+	grad := func(x []float64) []float64 {
+		_, g, err := fn(x, target)
+		if err != nil {
+			return nil
+		}
+		return g
+	}
+	return f, grad
+}
+
+// softMin3 computes -γ·ln(e^(-a/γ) + e^(-b/γ) + e^(-c/γ)) without underflow
+func softMin3(a, b, c, gamma float64) float64 {
+	lo := math.Min(a, math.Min(b, c))
+	if math.IsInf(lo, 1) {
+		return lo
+	}
+	sum := math.Exp(-(a-lo)/gamma) + math.Exp(-(b-lo)/gamma) + math.Exp(-(c-lo)/gamma)
+	return lo - gamma*math.Log(sum)
+}
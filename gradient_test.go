@@ -0,0 +1,123 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+// numericGradient approximates the gradient of fn(·, b) at a by central differences
+func numericGradient(fn DistanceGradFunc, a, b []float64) []float64 {
+	const h = 1e-6
+	grad := make([]float64, len(a))
+	x := append([]float64{}, a...)
+	for i := range x {
+		x[i] = a[i] + h
+		up, _, _ := fn(x, b)
+		x[i] = a[i] - h
+		down, _, _ := fn(x, b)
+		x[i] = a[i]
+		grad[i] = (up - down) / (2 * h)
+	}
+	return grad
+}
+
+func TestDistanceGradients(t *testing.T) {
+	softDTW := func(a, b []float64) (float64, []float64, error) {
+		return SoftDTWGradient(a, b, 0.5)
+	}
+
+	tests := []struct {
+		name string
+		fn   DistanceGradFunc
+		a, b []float64
+	}{
+		{"Euclidean", EuclideanGradient, []float64{1, 2, 3}, []float64{4, 0, -1}},
+		{"Cosine", CosineGradient, []float64{1, 2, 3}, []float64{4, 0, -1}},
+		{"KL", KLDivergenceGradient, []float64{0.2, 0.5, 0.3}, []float64{0.4, 0.4, 0.2}},
+		{"SoftDTW", softDTW, []float64{0, 1, 2, 1}, []float64{0, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, grad, err := tt.fn(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := numericGradient(tt.fn, tt.a, tt.b)
+			for i := range want {
+				if math.Abs(grad[i]-want[i]) > 1e-5 {
+					t.Errorf("component %d: expected %v, got %v", i, want[i], grad[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGradientValuesMatchDistances(t *testing.T) {
+	a, b := []float64{0.2, 0.5, 0.3}, []float64{0.4, 0.4, 0.2}
+
+	pairs := []struct {
+		name string
+		fn   DistanceGradFunc
+		want func() (float64, error)
+	}{
+		{"Euclidean", EuclideanGradient, func() (float64, error) { return Euclidean(a, b) }},
+		{"Cosine", CosineGradient, func() (float64, error) { return Cosine(a, b) }},
+		{"KL", KLDivergenceGradient, func() (float64, error) { return KLDivergence(a, b) }},
+	}
+	for _, p := range pairs {
+		got, _, err := p.fn(a, b)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", p.name, err)
+		}
+		want, _ := p.want()
+		if !almostEqual(got, want) {
+			t.Errorf("%s: expected %v, got %v", p.name, want, got)
+		}
+	}
+
+	got, _, err := SoftDTWGradient([]float64{0, 1, 2}, []float64{0, 2}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, _ := SoftDTW([]float64{0, 1, 2}, []float64{0, 2}, 1)
+	if !almostEqual(got, want) {
+		t.Errorf("SoftDTW: expected %v, got %v", want, got)
+	}
+}
+
+func TestGradientObjective(t *testing.T) {
+	target := []float64{3, -1, 2}
+	f, grad := GradientObjective(target, EuclideanGradient)
+
+	result, err := Adam(f, grad, []float64{0, 0, 0}, 0.05, 0.9, 0.999, 1e-8, 500)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d, _ := Euclidean(result, target); d > 0.1 {
+		t.Errorf("expected near %v, got %v", target, result)
+	}
+
+	// Mismatched dimensions surface through the optimizer
+	if _, err := GradientDescent(f, grad, []float64{0, 0}, 0.1, 10); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestGradientErrors(t *testing.T) {
+	if _, _, err := CosineGradient([]float64{0, 0}, []float64{1, 1}); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+	if _, _, err := KLDivergenceGradient([]float64{-0.5, 1.5}, []float64{0.5, 0.5}); err != ErrNegativeValue {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+	if _, _, err := KLDivergenceGradient([]float64{0, 1}, []float64{0.5, 0.5}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, _, err := SoftDTWGradient([]float64{1}, []float64{1}, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, grad, err := EuclideanGradient([]float64{1, 2}, []float64{1, 2}); err != nil || grad[0] != 0 || grad[1] != 0 {
+		t.Errorf("expected zero subgradient, got %v, %v", grad, err)
+	}
+}
@@ -19,6 +19,21 @@ func NewGraph() *Graph {
 	}
 }
 
+// clone returns a deep copy of g
+func (g *Graph) clone() *Graph {
+	c := NewGraph()
+	for node := range g.nodes {
+		c.nodes[node] = true
+	}
+	for from, edges := range g.adjacency {
+		c.adjacency[from] = make(map[int]float64, len(edges))
+		for to, w := range edges {
+			c.adjacency[from][to] = w
+		}
+	}
+	return c
+}
+
 // AddEdge adds a weighted edge between two nodes
 func (g *Graph) AddEdge(from, to int, weight float64) {
 	g.nodes[from] = true
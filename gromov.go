@@ -0,0 +1,158 @@
+package distance
+
+import "math"
+
+const (
+	// gromovSinkhornIters caps the Sinkhorn iterations inside each
+	// Gromov-Wasserstein step; warm potentials are not kept between steps
+	gromovSinkhornIters = 1000
+	// gromovTolerance stops the outer iterations once no entry of the
+	// coupling moves by more than this
+	gromovTolerance = 1e-9
+)
+
+// GromovWasserstein compares two metric spaces given only their internal
+// distance matrices, e.g. shortest-path matrices of two graphs or pairwise
+// distances of two point clouds in different coordinate systems, with
+// uniform mass on their points. It needs no correspondence between the two
+// sides and is invariant to relabeling points and to isometries, so
+// structures with no common node IDs can still be compared. Returns the
+// entropic squared-loss discrepancy Σ (c1[i][k] - c2[j][l])² T[i][j] T[k][l]
+// for the coupling T found by GromovWassersteinPlan; 0 means the spaces
+// match. See GromovWassersteinPlan for epsilon and iters.
+// Time: O(iters·(m²n + mn²)), Space: O(mn)
+func GromovWasserstein(c1, c2 [][]float64, epsilon float64, iters int) (float64, error) {
+	p := make([]float64, len(c1))
+	q := make([]float64, len(c2))
+	for i := range p {
+		p[i] = 1
+	}
+	for j := range q {
+		q[j] = 1
+	}
+	_, cost, err := GromovWassersteinPlan(c1, c2, p, q, epsilon, iters)
+	return cost, err
+}
+
+// GromovWassersteinPlan computes entropic Gromov-Wasserstein between two
+// metric spaces with point masses p and q (normalized to unit mass), by the
+// projected mirror descent of Peyré, Cuturi and Solomon (2016): each step
+// linearizes the quadratic objective around the current coupling and solves
+// the resulting transport problem with Sinkhorn at regularization epsilon.
+// Returns the coupling, whose row i spreads point i of the first space over
+// its matches in the second (a soft graph matching), and its discrepancy as
+// in GromovWasserstein. epsilon is in units of squared distance: too small
+// relative to the squared distances needs many Sinkhorn iterations, too large
+// blurs the matching. The problem is non-convex, so the result is a local
+// optimum; perfectly symmetric spaces may stay at the uninformative product
+// coupling. Stops after iters steps or once the coupling stops changing.
+// Returns ErrEmptyInput for an empty matrix, ErrDimensionMismatch for a
+// non-square matrix or masses of the wrong length, ErrNegativeValue for
+// negative distances and ErrInvalidParameter for non-finite distances,
+// epsilon <= 0 or iters <= 0.
+// Time: O(iters·(m²n + mn²)), Space: O(mn)
+func GromovWassersteinPlan(c1, c2 [][]float64, p, q []float64, epsilon float64, iters int) ([][]float64, float64, error) {
+	if epsilon <= 0 || math.IsNaN(epsilon) || iters <= 0 {
+		return nil, 0, ErrInvalidParameter
+	}
+	for _, c := range [][][]float64{c1, c2} {
+		if err := validateDistanceMatrix(c); err != nil {
+			return nil, 0, err
+		}
+		for _, row := range c {
+			for _, d := range row {
+				if math.IsNaN(d) || math.IsInf(d, 0) {
+					return nil, 0, ErrInvalidParameter
+				}
+			}
+		}
+	}
+	if len(p) != len(c1) || len(q) != len(c2) {
+		return nil, 0, ErrDimensionMismatch
+	}
+	a, err := NormalizeToDistribution(p)
+	if err != nil {
+		return nil, 0, err
+	}
+	b, err := NormalizeToDistribution(q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	m, n := len(a), len(b)
+	plan := make([][]float64, m)
+	for i := range plan {
+		plan[i] = make([]float64, n)
+		for j := range plan[i] {
+			plan[i][j] = a[i] * b[j]
+		}
+	}
+
+	logA, logB := logMass(a), logMass(b)
+	for iter := 0; iter < iters; iter++ {
+		grad := gromovGradient(c1, c2, a, b, plan)
+		f, g := sinkhornPotentials(logA, logB, grad, epsilon, gromovSinkhornIters)
+
+		delta := 0.0
+		for i := range plan {
+			for j := range plan[i] {
+				next := math.Exp(logA[i] + logB[j] + (f[i]+g[j]-grad[i][j])/epsilon)
+				delta = math.Max(delta, math.Abs(next-plan[i][j]))
+				plan[i][j] = next
+			}
+		}
+		if delta < gromovTolerance {
+			break
+		}
+	}
+
+	grad := gromovGradient(c1, c2, a, b, plan)
+	var cost float64
+	for i := range plan {
+		for j := range plan[i] {
+			cost += grad[i][j] * plan[i][j]
+		}
+	}
+	return plan, math.Max(cost, 0), nil
+}
+
+// gromovGradient returns Σₖₗ (c1[i][k] - c2[j][l])² plan[k][l] for each i, j,
+// the cost matrix of the linearized problem; with plan's marginals a and b
+// it expands to (c1²a)[i] + (c2²b)[j] - 2(c1·plan·c2ᵀ)[i][j]
+func gromovGradient(c1, c2 [][]float64, a, b []float64, plan [][]float64) [][]float64 {
+	m, n := len(a), len(b)
+	rowTerm := make([]float64, m)
+	for i := range rowTerm {
+		for k, d := range c1[i] {
+			rowTerm[i] += d * d * a[k]
+		}
+	}
+	colTerm := make([]float64, n)
+	for j := range colTerm {
+		for l, d := range c2[j] {
+			colTerm[j] += d * d * b[l]
+		}
+	}
+
+	// c1·plan, then multiplied by c2ᵀ
+	left := make([][]float64, m)
+	for i := range left {
+		left[i] = make([]float64, n)
+		for k, d := range c1[i] {
+			if d == 0 {
+				continue
+			}
+			for l, t := range plan[k] {
+				left[i][l] += d * t
+			}
+		}
+	}
+	grad := make([][]float64, m)
+	for i := range grad {
+		grad[i] = make([]float64, n)
+		for j := range grad[i] {
+			grad[i][j] = rowTerm[i] + colTerm[j] - 2*dotF64(left[i], c2[j])
+		}
+	}
+	return grad
+}
@@ -0,0 +1,123 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGromovWassersteinIsometry(t *testing.T) {
+	// The same shape, rotated, translated and relabeled
+	points := [][]float64{{0, 0}, {1, 0}, {3, 0}, {3, 2}, {7, 1}}
+	moved := make([][]float64, len(points))
+	order := []int{3, 0, 4, 1, 2}
+	for i, k := range order {
+		x, y := points[k][0], points[k][1]
+		moved[i] = []float64{10 - y, 5 + x}
+	}
+	c1, _ := BatchCompute(points, Euclidean[float64])
+	c2, _ := BatchCompute(moved, Euclidean[float64])
+
+	plan, cost, err := GromovWassersteinPlan(c1, c2, []float64{1, 1, 1, 1, 1}, []float64{1, 1, 1, 1, 1}, 0.05, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cost > 1e-3 {
+		t.Errorf("expected near-zero discrepancy for isometric spaces, got %v", cost)
+	}
+
+	// The coupling recovers the relabeling
+	for i, k := range order {
+		best := 0
+		for j := range plan[k] {
+			if plan[k][j] > plan[k][best] {
+				best = j
+			}
+		}
+		if best != i {
+			t.Errorf("point %d: expected match %d, got %d", k, i, best)
+		}
+	}
+}
+
+func TestGromovWassersteinDiscriminates(t *testing.T) {
+	line, _ := BatchCompute([][]float64{{0}, {1}, {2}, {3}}, Euclidean[float64])
+	line2, _ := BatchCompute([][]float64{{0}, {1.1}, {2}, {3.1}}, Euclidean[float64])
+	star, _ := BatchCompute([][]float64{{0, 0}, {3, 0}, {-1.5, 2.6}, {-1.5, -2.6}}, Euclidean[float64])
+
+	near, err := GromovWasserstein(line, line2, 0.05, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	far, err := GromovWasserstein(line, star, 0.05, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if near >= far {
+		t.Errorf("expected similar line to be closer: %v >= %v", near, far)
+	}
+}
+
+func TestGromovWassersteinDifferentSizes(t *testing.T) {
+	// Graphs with no shared IDs: a 4-cycle and a 5-cycle, via KeyedGraph
+	cycle := func(names []string) [][]float64 {
+		g := NewKeyedGraph[string]()
+		for i := range names {
+			g.AddUndirectedEdge(names[i], names[(i+1)%len(names)], 1)
+		}
+		all := g.AllPairsShortestPaths()
+		matrix := make([][]float64, len(names))
+		for i, a := range names {
+			matrix[i] = make([]float64, len(names))
+			for j, b := range names {
+				matrix[i][j] = all[a][b]
+			}
+		}
+		return matrix
+	}
+	c4 := cycle([]string{"a", "b", "c", "d"})
+	c5 := cycle([]string{"v", "w", "x", "y", "z"})
+
+	plan, cost, err := GromovWassersteinPlan(c4, c5, []float64{1, 1, 1, 1}, []float64{1, 1, 1, 1, 1}, 0.1, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cost <= 0 || math.IsNaN(cost) {
+		t.Errorf("expected positive discrepancy, got %v", cost)
+	}
+	for i, row := range plan {
+		var sum float64
+		for _, v := range row {
+			sum += v
+		}
+		if !almostEqualTolerance(sum, 0.25, 1e-6) {
+			t.Errorf("row %d: expected mass 0.25, got %v", i, sum)
+		}
+	}
+}
+
+func TestGromovWassersteinErrors(t *testing.T) {
+	c := [][]float64{{0, 1}, {1, 0}}
+	tests := []struct {
+		name   string
+		c1, c2 [][]float64
+		p      []float64
+		eps    float64
+		iters  int
+		err    error
+	}{
+		{"empty", [][]float64{}, c, []float64{}, 0.1, 10, ErrEmptyInput},
+		{"non-square", [][]float64{{0, 1}}, c, []float64{1}, 0.1, 10, ErrDimensionMismatch},
+		{"negative", [][]float64{{0, -1}, {-1, 0}}, c, []float64{1, 1}, 0.1, 10, ErrNegativeValue},
+		{"nan", [][]float64{{0, math.NaN()}, {1, 0}}, c, []float64{1, 1}, 0.1, 10, ErrInvalidParameter},
+		{"mass length", c, c, []float64{1}, 0.1, 10, ErrDimensionMismatch},
+		{"zero epsilon", c, c, []float64{1, 1}, 0, 10, ErrInvalidParameter},
+		{"zero iters", c, c, []float64{1, 1}, 0.1, 0, ErrInvalidParameter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := GromovWassersteinPlan(tt.c1, tt.c2, tt.p, []float64{1, 1}, tt.eps, tt.iters); err != tt.err {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
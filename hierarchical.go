@@ -0,0 +1,173 @@
+package distance
+
+import "math"
+
+// Linkage selects how distances between merged clusters are computed.
+type Linkage int
+
+const (
+	// SingleLinkage uses the minimum pairwise distance between clusters.
+	SingleLinkage Linkage = iota
+	// CompleteLinkage uses the maximum pairwise distance between clusters.
+	CompleteLinkage
+	// AverageLinkage uses the mean pairwise distance (UPGMA).
+	AverageLinkage
+)
+
+// Merge records one agglomeration step. Leaves are numbered 0..n-1 and the
+// cluster created by the i-th merge is numbered n+i (SciPy convention).
+type Merge struct {
+	A, B     int
+	Distance float64
+	Size     int // number of leaves in the merged cluster
+}
+
+// AgglomerativeCluster performs hierarchical clustering on a symmetric distance
+// matrix, returning the n-1 merges in order of increasing height.
+// Ties go to the pair whose smallest leaves come first: the cluster with
+// the lowest leaf index, then its partner with the lowest leaf index.
+// Time: O(n³), Space: O(n²)
+func AgglomerativeCluster(matrix [][]float64, linkage Linkage) ([]Merge, error) {
+	if err := validateSquare(matrix); err != nil {
+		return nil, err
+	}
+	if linkage < SingleLinkage || linkage > AverageLinkage {
+		return nil, ErrInvalidParameter
+	}
+
+	n := len(matrix)
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = append([]float64(nil), matrix[i]...)
+	}
+
+	// Slot i holds cluster ids[i] with sizes[i] leaves while active[i]
+	ids := make([]int, n)
+	sizes := make([]int, n)
+	active := make([]bool, n)
+	for i := range ids {
+		ids[i], sizes[i], active[i] = i, 1, true
+	}
+
+	merges := make([]Merge, 0, n-1)
+	for step := 0; step < n-1; step++ {
+		bi, bj := -1, -1
+		best := math.Inf(1)
+		for i := 0; i < n; i++ {
+			if !active[i] {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if active[j] && (dist[i][j] < best || bi < 0) {
+					bi, bj, best = i, j, dist[i][j]
+				}
+			}
+		}
+
+		a, b := ids[bi], ids[bj]
+		if a > b {
+			a, b = b, a
+		}
+		merges = append(merges, Merge{A: a, B: b, Distance: best, Size: sizes[bi] + sizes[bj]})
+
+		// Lance-Williams update into slot bi
+		for k := 0; k < n; k++ {
+			if !active[k] || k == bi || k == bj {
+				continue
+			}
+			var d float64
+			switch linkage {
+			case SingleLinkage:
+				d = math.Min(dist[bi][k], dist[bj][k])
+			case CompleteLinkage:
+				d = math.Max(dist[bi][k], dist[bj][k])
+			case AverageLinkage:
+				d = (float64(sizes[bi])*dist[bi][k] + float64(sizes[bj])*dist[bj][k]) /
+					float64(sizes[bi]+sizes[bj])
+			}
+			dist[bi][k], dist[k][bi] = d, d
+		}
+		ids[bi] = n + step
+		sizes[bi] += sizes[bj]
+		active[bj] = false
+	}
+
+	return merges, nil
+}
+
+// LeafOrder returns leaves in dendrogram order (left-to-right traversal of merges).
+// Time: O(n), Space: O(n)
+func LeafOrder(merges []Merge) []int {
+	n := len(merges) + 1
+	if len(merges) == 0 {
+		return []int{0}
+	}
+
+	order := make([]int, 0, n)
+	stack := []int{n + len(merges) - 1}
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if c < n {
+			order = append(order, c)
+			continue
+		}
+		m := merges[c-n]
+		stack = append(stack, m.B, m.A)
+	}
+	return order
+}
+
+// CutTree cuts a dendrogram at height, keeping every merge with
+// Distance <= height, and returns a flat cluster label per leaf. Labels are
+// numbered 0, 1, ... in order of each cluster's lowest leaf. Merge heights
+// must be non-decreasing, as AgglomerativeCluster returns them.
+// Time: O(n), Space: O(n)
+func CutTree(merges []Merge, height float64) []int {
+	n := len(merges) + 1
+	// cluster[c] is the representative leaf of cluster id c
+	cluster := make([]int, n+len(merges))
+	for i := 0; i < n; i++ {
+		cluster[i] = i
+	}
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	for step, m := range merges {
+		a, b := cluster[m.A], cluster[m.B]
+		cluster[n+step] = a
+		if m.Distance <= height {
+			parent[b] = a
+		}
+	}
+
+	labels := make([]int, n)
+	ids := make(map[int]int)
+	for i := range labels {
+		root := i
+		for parent[root] != root {
+			root = parent[root]
+		}
+		id, ok := ids[root]
+		if !ok {
+			id = len(ids)
+			ids[root] = id
+		}
+		labels[i] = id
+	}
+	return labels
+}
+
+// validateSquare checks that matrix is non-empty and square
+func validateSquare(matrix [][]float64) error {
+	if len(matrix) == 0 {
+		return ErrEmptyInput
+	}
+	for _, row := range matrix {
+		if len(row) != len(matrix) {
+			return ErrDimensionMismatch
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package distance
+
+import "testing"
+
+func TestAgglomerativeCluster(t *testing.T) {
+	points := [][]float64{{0}, {1}, {5}, {6}, {20}}
+	matrix, _ := BatchCompute(points, Euclidean[float64])
+
+	tests := []struct {
+		name     string
+		linkage  Linkage
+		expected []Merge
+	}{
+		{"single", SingleLinkage, []Merge{{0, 1, 1, 2}, {2, 3, 1, 2}, {5, 6, 4, 4}, {4, 7, 14, 5}}},
+		{"complete", CompleteLinkage, []Merge{{0, 1, 1, 2}, {2, 3, 1, 2}, {5, 6, 6, 4}, {4, 7, 20, 5}}},
+		{"average", AverageLinkage, []Merge{{0, 1, 1, 2}, {2, 3, 1, 2}, {5, 6, 5, 4}, {4, 7, 17, 5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merges, err := AgglomerativeCluster(matrix, tt.linkage)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(merges) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, merges)
+			}
+			for i := range merges {
+				if merges[i] != tt.expected[i] {
+					t.Errorf("step %d: expected %v, got %v", i, tt.expected[i], merges[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAgglomerativeClusterErrors(t *testing.T) {
+	if _, err := AgglomerativeCluster(nil, SingleLinkage); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := AgglomerativeCluster([][]float64{{0, 1}}, SingleLinkage); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := AgglomerativeCluster([][]float64{{0}}, Linkage(7)); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestCutTree(t *testing.T) {
+	points := [][]float64{{20}, {0}, {5}, {1}, {6}}
+	matrix, _ := BatchCompute(points, Euclidean[float64])
+	merges, _ := AgglomerativeCluster(matrix, AverageLinkage)
+
+	tests := []struct {
+		height   float64
+		expected []int
+	}{
+		{0.5, []int{0, 1, 2, 3, 4}},
+		{1, []int{0, 1, 2, 1, 2}},
+		{5, []int{0, 1, 1, 1, 1}},
+		{100, []int{0, 0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		got := CutTree(merges, tt.height)
+		for i := range tt.expected {
+			if got[i] != tt.expected[i] {
+				t.Errorf("height %v: expected %v, got %v", tt.height, tt.expected, got)
+				break
+			}
+		}
+	}
+
+	if got := CutTree(nil, 1); len(got) != 1 || got[0] != 0 {
+		t.Errorf("expected a single leaf, got %v", got)
+	}
+}
+
+func TestLeafOrder(t *testing.T) {
+	merges := []Merge{{0, 2, 1, 2}, {1, 3, 2, 2}, {4, 5, 3, 4}}
+	got := LeafOrder(merges)
+	want := []int{0, 2, 1, 3}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package distance
+
+import "math"
+
+// Histogram bins data into bins equal-width buckets spanning [min, max] and
+// returns the counts together with the bins+1 bucket edges. The maximum value
+// falls in the last bucket. NaNs and infinities are ignored, as in
+// HistogramRange; data with no finite value returns ErrEmptyInput. Use
+// HistogramRange with shared bounds when the result will be compared against
+// another sample's histogram.
+// Time: O(n), Space: O(bins)
+func Histogram(data []float64, bins int) ([]float64, []float64, error) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, x := range data {
+		if !math.IsNaN(x) && !math.IsInf(x, 0) {
+			lo = math.Min(lo, x)
+			hi = math.Max(hi, x)
+		}
+	}
+	if lo > hi {
+		return nil, nil, ErrEmptyInput
+	}
+
+	counts, err := HistogramRange(data, bins, lo, hi)
+	if err != nil {
+		return nil, nil, err
+	}
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = lo + (hi-lo)*float64(i)/float64(bins)
+	}
+	return counts, edges, nil
+}
+
+// HistogramRange bins data into bins equal-width buckets spanning [lo, hi].
+// Values outside the range and NaNs are ignored; hi falls in the last bucket.
+// Returns ErrInvalidParameter unless lo and hi are finite with lo <= hi.
+// Time: O(n), Space: O(bins)
+func HistogramRange(data []float64, bins int, lo, hi float64) ([]float64, error) {
+	if bins <= 0 || !(lo <= hi) || math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+		return nil, ErrInvalidParameter
+	}
+
+	counts := make([]float64, bins)
+	for _, x := range data {
+		if x < lo || x > hi || math.IsNaN(x) {
+			continue
+		}
+		idx := 0
+		if hi > lo {
+			idx = int(float64(bins) * (x - lo) / (hi - lo))
+		}
+		counts[min(idx, bins-1)]++
+	}
+	return counts, nil
+}
+
+// NormalizeToDistribution scales non-negative values to sum to 1, turning
+// counts or weights into a probability vector for KLDivergence, Hellinger,
+// ChiSquare and friends.
+// Time: O(n), Space: O(n)
+func NormalizeToDistribution[T Float](v []T) ([]T, error) {
+	if len(v) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	var sum float64
+	for _, x := range v {
+		if x < 0 {
+			return nil, ErrNegativeValue
+		}
+		sum += float64(x)
+	}
+	if sum == 0 {
+		return nil, ErrZeroVector
+	}
+
+	out := make([]T, len(v))
+	for i, x := range v {
+		out[i] = T(float64(x) / sum)
+	}
+	return out, nil
+}
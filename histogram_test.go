@@ -0,0 +1,113 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	counts, edges, err := Histogram([]float64{0, 1, 1, 2, 3, 4}, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantCounts := []float64{1, 2, 1, 2}
+	wantEdges := []float64{0, 1, 2, 3, 4}
+	for i := range wantCounts {
+		if counts[i] != wantCounts[i] {
+			t.Errorf("counts: expected %v, got %v", wantCounts, counts)
+			break
+		}
+	}
+	for i := range wantEdges {
+		if !almostEqual(edges[i], wantEdges[i]) {
+			t.Errorf("edges: expected %v, got %v", wantEdges, edges)
+			break
+		}
+	}
+
+	constant, _, _ := Histogram([]float64{5, 5, 5}, 3)
+	if constant[0] != 3 {
+		t.Errorf("expected all values in first bin, got %v", constant)
+	}
+
+	if _, _, err := Histogram(nil, 3); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, _, err := Histogram([]float64{1}, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+
+	// NaNs are skipped when choosing bounds, not just when binning
+	withNaN, edges, err := Histogram([]float64{math.NaN(), 0, 1, 2}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if withNaN[0] != 1 || withNaN[1] != 2 || edges[0] != 0 || edges[2] != 2 {
+		t.Errorf("expected counts [1 2] over [0, 2], got %v over %v", withNaN, edges)
+	}
+	if _, _, err := Histogram([]float64{math.NaN()}, 2); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+
+	// Infinities are skipped too, instead of producing an infinite range
+	withInf, edges, err := Histogram([]float64{0, math.Inf(1), 2, math.Inf(-1)}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if withInf[0] != 1 || withInf[1] != 1 || edges[0] != 0 || edges[2] != 2 {
+		t.Errorf("expected counts [1 1] over [0, 2], got %v over %v", withInf, edges)
+	}
+	if _, _, err := Histogram([]float64{math.Inf(1)}, 2); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestHistogramRange(t *testing.T) {
+	counts, err := HistogramRange([]float64{-1, 0, 0.5, 1, 2, math.NaN()}, 2, 0, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counts[0] != 1 || counts[1] != 2 {
+		t.Errorf("expected [1 2], got %v", counts)
+	}
+
+	if _, err := HistogramRange([]float64{1}, 2, 1, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	for _, bounds := range [][2]float64{{0, math.Inf(1)}, {math.Inf(-1), 0}, {math.NaN(), 1}} {
+		if _, err := HistogramRange([]float64{0, 1}, 2, bounds[0], bounds[1]); err != ErrInvalidParameter {
+			t.Errorf("%v: expected ErrInvalidParameter, got %v", bounds, err)
+		}
+	}
+}
+
+func TestNormalizeToDistribution(t *testing.T) {
+	p, err := NormalizeToDistribution([]float64{1, 3, 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(p[0], 0.25) || !almostEqual(p[1], 0.75) || p[2] != 0 {
+		t.Errorf("expected [0.25 0.75 0], got %v", p)
+	}
+
+	// Histograms feed straight into divergences
+	ha, _ := HistogramRange([]float64{0, 0.1, 0.9}, 2, 0, 1)
+	hb, _ := HistogramRange([]float64{0.2, 0.8, 0.9}, 2, 0, 1)
+	pa, _ := NormalizeToDistribution(ha)
+	pb, _ := NormalizeToDistribution(hb)
+	h, err := Hellinger(pa, pb)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h <= 0 {
+		t.Errorf("expected positive distance, got %v", h)
+	}
+
+	if _, err := NormalizeToDistribution([]float64{1, -1}); err != ErrNegativeValue {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+	if _, err := NormalizeToDistribution([]float64{0, 0}); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package distance
+
+import "math"
+
+// The transformations below rescale a distance matrix so that neighborhoods
+// become symmetric, removing much of the hubness reported by
+// DiagnoseConcentration before kNN search or clustering. Each returns a new
+// matrix with a zero diagonal; the input is not modified.
+
+// LocalScaling rescales distances by each point's neighborhood radius
+// (Zelnik-Manor and Perona): d'ᵢⱼ = 1 - exp(-dᵢⱼ² / (σᵢσⱼ)), where σᵢ is the
+// distance from i to its k-th nearest neighbor. Results lie in [0, 1).
+// Points with σᵢ = 0 (k or more duplicates) keep 0 to their duplicates and 1
+// to everything else.
+// Time: O(n² log n), Space: O(n²)
+func LocalScaling(matrix [][]float64, k int) ([][]float64, error) {
+	if err := validateDistanceMatrix(matrix); err != nil {
+		return nil, err
+	}
+	n := len(matrix)
+	if k <= 0 || k >= n {
+		return nil, ErrInvalidParameter
+	}
+
+	sigma := make([]float64, n)
+	for i, nb := range matrixNeighbors(matrix, k) {
+		sigma[i] = matrix[i][nb[k-1]]
+	}
+
+	return transformPairs(n, func(i, j int) float64 {
+		d := matrix[i][j]
+		scale := sigma[i] * sigma[j]
+		if scale == 0 {
+			if d == 0 {
+				return 0
+			}
+			return 1
+		}
+		return 1 - math.Exp(-d*d/scale)
+	}), nil
+}
+
+// MutualProximity turns distances into the empirical probability that two
+// points are not each other's neighbors (Schnitzer et al.):
+// d'ᵢⱼ = 1 - |{k : dᵢₖ > dᵢⱼ and dⱼₖ > dⱼᵢ}| / (n-2), over k ≠ i, j.
+// Results lie in [0, 1]. Requires at least 3 points.
+// Time: O(n³), Space: O(n²)
+func MutualProximity(matrix [][]float64) ([][]float64, error) {
+	if err := validateDistanceMatrix(matrix); err != nil {
+		return nil, err
+	}
+	n := len(matrix)
+	if n < 3 {
+		return nil, ErrInvalidParameter
+	}
+
+	return transformPairs(n, func(i, j int) float64 {
+		d := matrix[i][j]
+		shared := 0
+		for k := 0; k < n; k++ {
+			if k != i && k != j && matrix[i][k] > d && matrix[j][k] > d {
+				shared++
+			}
+		}
+		return 1 - float64(shared)/float64(n-2)
+	}), nil
+}
+
+// MutualProximityGaussian approximates MutualProximity by modelling each
+// point's distances as independent normals with the row's mean and standard
+// deviation: d'ᵢⱼ = 1 - (1 - Φᵢ(dᵢⱼ))(1 - Φⱼ(dᵢⱼ)). Much faster for large n.
+// Requires at least 3 points.
+// Time: O(n²), Space: O(n²)
+func MutualProximityGaussian(matrix [][]float64) ([][]float64, error) {
+	if err := validateDistanceMatrix(matrix); err != nil {
+		return nil, err
+	}
+	n := len(matrix)
+	if n < 3 {
+		return nil, ErrInvalidParameter
+	}
+
+	mu := make([]float64, n)
+	sd := make([]float64, n)
+	for i, row := range matrix {
+		for j, d := range row {
+			if j != i {
+				mu[i] += d
+			}
+		}
+		mu[i] /= float64(n - 1)
+		for j, d := range row {
+			if j != i {
+				sd[i] += (d - mu[i]) * (d - mu[i])
+			}
+		}
+		sd[i] = math.Sqrt(sd[i] / float64(n-1))
+	}
+
+	// above returns P(X > d) for X ~ N(mu[i], sd[i]²)
+	above := func(i int, d float64) float64 {
+		if sd[i] == 0 {
+			if d < mu[i] {
+				return 1
+			}
+			return 0
+		}
+		return 0.5 * math.Erfc((d-mu[i])/(sd[i]*math.Sqrt2))
+	}
+
+	return transformPairs(n, func(i, j int) float64 {
+		d := matrix[i][j]
+		return 1 - above(i, d)*above(j, d)
+	}), nil
+}
+
+// validateDistanceMatrix checks for a non-empty, square, non-negative matrix
+func validateDistanceMatrix(matrix [][]float64) error {
+	if err := validateSquare(matrix); err != nil {
+		return err
+	}
+	for _, row := range matrix {
+		for _, d := range row {
+			if d < 0 {
+				return ErrNegativeValue
+			}
+		}
+	}
+	return nil
+}
+
+// transformPairs builds a symmetric n×n matrix with zero diagonal from fn(i, j), i < j
+func transformPairs(n int, fn func(i, j int) float64) [][]float64 {
+	out := make([][]float64, n)
+	for i := range out {
+		out[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			v := fn(i, j)
+			out[i][j] = v
+			out[j][i] = v
+		}
+	}
+	return out
+}
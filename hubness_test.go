@@ -0,0 +1,128 @@
+package distance
+
+import "testing"
+
+// lineMatrix is the distance matrix of points 0, 1, 2, 10 on a line
+var lineMatrix = [][]float64{
+	{0, 1, 2, 10},
+	{1, 0, 1, 9},
+	{2, 1, 0, 8},
+	{10, 9, 8, 0},
+}
+
+func TestLocalScaling(t *testing.T) {
+	result, err := LocalScaling(lineMatrix, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// σ = 1, 1, 1, 8
+	tests := []struct {
+		i, j     int
+		expected float64
+	}{
+		{0, 1, 0.6321205588285577}, // 1 - e^-1
+		{0, 2, 0.9816843611112658}, // 1 - e^-4
+		{2, 3, 0.9996645373720975}, // 1 - e^-8
+		{1, 1, 0},
+	}
+	for _, tt := range tests {
+		if !almostEqual(result[tt.i][tt.j], tt.expected) || result[tt.i][tt.j] != result[tt.j][tt.i] {
+			t.Errorf("(%d,%d): expected %v, got %v", tt.i, tt.j, tt.expected, result[tt.i][tt.j])
+		}
+	}
+}
+
+func TestMutualProximity(t *testing.T) {
+	result, err := MutualProximity(lineMatrix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// One minus the fraction of the other two points farther than d from both ends
+	tests := []struct {
+		i, j     int
+		expected float64
+	}{
+		{0, 1, 0.5}, // 3 is farther from both; 2 ties with d(1,2)
+		{0, 2, 0.5}, // 3 is farther from both; 1 is closer
+		{0, 3, 1},
+		{2, 3, 1},
+	}
+	for _, tt := range tests {
+		if !almostEqual(result[tt.i][tt.j], tt.expected) || result[tt.i][tt.j] != result[tt.j][tt.i] {
+			t.Errorf("(%d,%d): expected %v, got %v", tt.i, tt.j, tt.expected, result[tt.i][tt.j])
+		}
+	}
+}
+
+func TestMutualProximityGaussian(t *testing.T) {
+	result, err := MutualProximityGaussian(lineMatrix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range result {
+		if result[i][i] != 0 {
+			t.Errorf("expected zero diagonal, got %v", result[i][i])
+		}
+		for j := range result {
+			if result[i][j] < 0 || result[i][j] > 1 || result[i][j] != result[j][i] {
+				t.Errorf("(%d,%d): expected a symmetric value in [0,1], got %v", i, j, result[i][j])
+			}
+		}
+	}
+	if result[0][1] >= result[2][3] {
+		t.Errorf("expected close pair below far pair: %v >= %v", result[0][1], result[2][3])
+	}
+}
+
+func TestHubnessReduction(t *testing.T) {
+	points := randomCube(3, 200, 300)
+	matrix, err := BatchCompute(points, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before, err := DiagnoseConcentrationMatrix(matrix, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	transforms := map[string]func([][]float64) ([][]float64, error){
+		"local scaling": func(m [][]float64) ([][]float64, error) { return LocalScaling(m, 10) },
+		"mp empirical":  MutualProximity,
+		"mp gaussian":   MutualProximityGaussian,
+	}
+	for name, transform := range transforms {
+		t.Run(name, func(t *testing.T) {
+			scaled, err := transform(matrix)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			after, err := DiagnoseConcentrationMatrix(scaled, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if after.HubnessSkewness >= before.HubnessSkewness {
+				t.Errorf("expected skewness to drop below %v, got %v", before.HubnessSkewness, after.HubnessSkewness)
+			}
+		})
+	}
+}
+
+func TestHubnessErrors(t *testing.T) {
+	pair := [][]float64{{0, 1}, {1, 0}}
+
+	if _, err := LocalScaling(nil, 1); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := LocalScaling(lineMatrix, 4); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := MutualProximity(pair); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := MutualProximityGaussian(pair); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := MutualProximity([][]float64{{0, 1, -1}, {1, 0, 1}, {-1, 1, 0}}); err != ErrNegativeValue {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
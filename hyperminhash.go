@@ -0,0 +1,208 @@
+package distance
+
+import (
+	"math"
+	"math/bits"
+	"strings"
+)
+
+// HyperMinHash is a mergeable set sketch (Yu & Weber, 2017) that estimates
+// cardinality like HyperLogLog and Jaccard similarity like MinHash from the
+// same 2^precision 16-bit registers. Each register keeps the smallest hash
+// routed to its bucket, compressed to its leading-zero count plus 10
+// mantissa bits, so accuracy depends on the number of registers rather than
+// the set size. Two sketches built with the same precision are comparable.
+type HyperMinHash struct {
+	precision int
+	registers []uint16
+}
+
+const (
+	// hmhMantissaBits is the number of hash bits kept after the leading one
+	hmhMantissaBits = 10
+	// hmhMaxRho caps the leading-zero count + 1 so it fits the 6 remaining bits
+	hmhMaxRho = 1<<(16-hmhMantissaBits) - 1
+)
+
+// NewHyperMinHash creates an empty sketch with 2^precision registers,
+// precision in [4, 16]. The standard error of Jaccard estimates is about
+// 1/sqrt(2^precision); 12 (4096 registers, 8 KiB) is a typical choice.
+func NewHyperMinHash(precision int) (*HyperMinHash, error) {
+	if precision < 4 || precision > 16 {
+		return nil, ErrInvalidParameter
+	}
+	return &HyperMinHash{precision: precision, registers: make([]uint16, 1<<precision)}, nil
+}
+
+// Add records item in the set.
+// Time: O(len(item)), Space: O(1)
+func (s *HyperMinHash) Add(item []byte) {
+	_, h := sketchHashes(item)
+	bucket := h >> (64 - s.precision)
+	w := h << s.precision
+	lz := bits.LeadingZeros64(w)
+	rho := min(lz+1, 64-s.precision+1, hmhMaxRho)
+	mantissa := (w << (lz + 1)) >> (64 - hmhMantissaBits)
+
+	// Larger keys mean smaller hashes: more leading zeros, then a smaller mantissa
+	key := uint16(rho)<<hmhMantissaBits | uint16(1<<hmhMantissaBits-1-mantissa)
+	s.registers[bucket] = max(s.registers[bucket], key)
+}
+
+// AddString records a string item in the set.
+// Time: O(len(item)), Space: O(1)
+func (s *HyperMinHash) AddString(item string) {
+	s.Add([]byte(item))
+}
+
+// AddAll records every item, e.g. the output of Shingle.
+// Time: O(total length), Space: O(1)
+func (s *HyperMinHash) AddAll(items []string) {
+	for _, item := range items {
+		s.AddString(item)
+	}
+}
+
+// AddKMers records every length-k substring (k-mer) of seq, bytes as given.
+// For nucleotide sequences, normalize case first; sequences shorter than k
+// add nothing.
+// Time: O(len(seq)·k), Space: O(1)
+func (s *HyperMinHash) AddKMers(seq string, k int) error {
+	if k <= 0 {
+		return ErrInvalidParameter
+	}
+	for i := 0; i+k <= len(seq); i++ {
+		s.AddString(seq[i : i+k])
+	}
+	return nil
+}
+
+// AddShingles records every run of k consecutive tokens (w-shingle), the
+// text counterpart of AddKMers for detecting quoted or copied passages.
+// Shingles are built by Shingle with word set, so a sketch fed this way
+// matches one fed Shingle's output through AddAll. Tokens are words:
+// whitespace inside a token splits it.
+// Time: O(len(tokens)·k), Space: O(len(tokens)·k)
+func (s *HyperMinHash) AddShingles(tokens []string, k int) error {
+	shingles, err := Shingle(strings.Join(tokens, " "), k, true)
+	if err != nil {
+		return err
+	}
+	s.AddAll(shingles)
+	return nil
+}
+
+// Merge adds the set summarized by other into s (set union).
+// Time: O(2^precision), Space: O(1)
+func (s *HyperMinHash) Merge(other *HyperMinHash) error {
+	if err := validateHyperMinHash(s, other); err != nil {
+		return err
+	}
+	for i, r := range other.registers {
+		s.registers[i] = max(s.registers[i], r)
+	}
+	return nil
+}
+
+// Cardinality estimates the number of distinct items added, using the
+// HyperLogLog estimator with linear counting for small sets.
+// Time: O(2^precision), Space: O(1)
+func (s *HyperMinHash) Cardinality() float64 {
+	return hmhCardinality(s.registers, nil)
+}
+
+// HyperMinHashJaccard estimates the Jaccard similarity |A∩B| / |A∪B| of the
+// sets summarized by two sketches: the fraction of non-empty buckets in
+// which both keep the same minimum. Register collisions between different
+// minima add a bias of roughly 2^-10·(1 - J).
+// Returns ErrEmptyInput if both sketches are empty.
+// Time: O(2^precision), Space: O(1)
+func HyperMinHashJaccard(a, b *HyperMinHash) (float64, error) {
+	if err := validateHyperMinHash(a, b); err != nil {
+		return 0, err
+	}
+	same, union := 0, 0
+	for i, ra := range a.registers {
+		rb := b.registers[i]
+		if ra == 0 && rb == 0 {
+			continue
+		}
+		union++
+		if ra == rb {
+			same++
+		}
+	}
+	if union == 0 {
+		return 0, ErrEmptyInput
+	}
+	return float64(same) / float64(union), nil
+}
+
+// HyperMinHashContainment estimates the containment |A∩B| / |A|, the
+// fraction of a's items that also occur in b: close to 1 when sequence A is
+// (approximately) a substring of B, whatever B's size, where Jaccard
+// similarity would be small. Computed as J·|A∪B| / |A| and clamped to [0, 1].
+// Returns ErrEmptyInput if a is empty.
+// Time: O(2^precision), Space: O(1)
+func HyperMinHashContainment(a, b *HyperMinHash) (float64, error) {
+	if err := validateHyperMinHash(a, b); err != nil {
+		return 0, err
+	}
+	sizeA := a.Cardinality()
+	if sizeA == 0 {
+		return 0, ErrEmptyInput
+	}
+	jaccard, err := HyperMinHashJaccard(a, b)
+	if err != nil {
+		return 0, err
+	}
+	union := hmhCardinality(a.registers, b.registers)
+	return math.Min(1, jaccard*union/sizeA), nil
+}
+
+// hmhCardinality estimates the cardinality of the union of one or two
+// register sets (other may be nil)
+func hmhCardinality(registers, other []uint16) float64 {
+	m := float64(len(registers))
+	sum, zeros := 0.0, 0
+	for i, r := range registers {
+		if other != nil {
+			r = max(r, other[i])
+		}
+		rho := int(r >> hmhMantissaBits)
+		if rho == 0 {
+			zeros++
+		}
+		sum += math.Ldexp(1, -rho)
+	}
+	if zeros == len(registers) {
+		return 0
+	}
+
+	var alpha float64
+	switch len(registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		return m * math.Log(m/float64(zeros))
+	}
+	return estimate
+}
+
+func validateHyperMinHash(a, b *HyperMinHash) error {
+	if a == nil || b == nil {
+		return ErrEmptyInput
+	}
+	if a.precision != b.precision {
+		return ErrDimensionMismatch
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package distance
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestHyperMinHashCardinality(t *testing.T) {
+	for _, n := range []int{10, 1000, 100000} {
+		s, err := NewHyperMinHash(12)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i := 0; i < n; i++ {
+			s.AddString(fmt.Sprintf("item-%d", i))
+			s.AddString(fmt.Sprintf("item-%d", i)) // duplicates are not counted
+		}
+		if got := s.Cardinality(); math.Abs(got-float64(n)) > 0.05*float64(n) {
+			t.Errorf("expected about %d distinct items, got %v", n, got)
+		}
+	}
+
+	empty, _ := NewHyperMinHash(8)
+	if empty.Cardinality() != 0 {
+		t.Errorf("expected 0 for an empty sketch, got %v", empty.Cardinality())
+	}
+}
+
+func TestHyperMinHashJaccard(t *testing.T) {
+	// A = [0, 6000), B = [3000, 9000): J = 3000/9000
+	a, _ := NewHyperMinHash(12)
+	b, _ := NewHyperMinHash(12)
+	for i := 0; i < 9000; i++ {
+		if i < 6000 {
+			a.AddString(fmt.Sprintf("item-%d", i))
+		}
+		if i >= 3000 {
+			b.AddString(fmt.Sprintf("item-%d", i))
+		}
+	}
+
+	j, err := HyperMinHashJaccard(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(j-1.0/3) > 0.03 {
+		t.Errorf("expected Jaccard near 1/3, got %v", j)
+	}
+	c, err := HyperMinHashContainment(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(c-0.5) > 0.05 {
+		t.Errorf("expected containment near 0.5, got %v", c)
+	}
+
+	// Merge produces the union
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := a.Cardinality(); math.Abs(got-9000) > 450 {
+		t.Errorf("expected union of about 9000, got %v", got)
+	}
+}
+
+func TestHyperMinHashContainmentKMers(t *testing.T) {
+	rng := testRNG(1)
+	genome := string(randomDNA(rng, 50000))
+	read := genome[20000:22000]
+
+	g, _ := NewHyperMinHash(12)
+	r, _ := NewHyperMinHash(12)
+	other, _ := NewHyperMinHash(12)
+	if err := g.AddKMers(genome, 21); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = r.AddKMers(read, 21)
+	_ = other.AddKMers(string(randomDNA(rng, 2000)), 21)
+
+	inside, err := HyperMinHashContainment(r, g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inside < 0.9 {
+		t.Errorf("expected the read to be contained in the genome, got %v", inside)
+	}
+	if j, _ := HyperMinHashJaccard(r, g); j > 0.1 {
+		t.Errorf("expected a small Jaccard for very different sizes, got %v", j)
+	}
+	outside, _ := HyperMinHashContainment(other, g)
+	if outside > 0.05 {
+		t.Errorf("expected an unrelated read not to be contained, got %v", outside)
+	}
+}
+
+func TestHyperMinHashShingles(t *testing.T) {
+	source := strings.Fields(strings.Repeat("the quick brown fox jumps over the lazy dog while ", 3) +
+		"a stitch in time saves nine and every cloud has a silver lining")
+	essay := append(strings.Fields("in my essay I argue that"), source[10:25]...)
+
+	a, _ := NewHyperMinHash(10)
+	b, _ := NewHyperMinHash(10)
+	_ = a.AddShingles(essay, 3)
+	_ = b.AddShingles(source, 3)
+	c, err := HyperMinHashContainment(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 13 of the essay's 18 shingles are quoted from the source
+	if math.Abs(c-13.0/18) > 0.15 {
+		t.Errorf("expected containment near %v, got %v", 13.0/18, c)
+	}
+}
+
+func TestHyperMinHashShinglesMatchShingle(t *testing.T) {
+	text := "the quick brown fox jumps over the lazy dog"
+	a, _ := NewHyperMinHash(8)
+	b, _ := NewHyperMinHash(8)
+	if err := a.AddShingles(strings.Fields(text), 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	shingles, _ := Shingle(text, 3, true)
+	b.AddAll(shingles)
+
+	sim, err := HyperMinHashJaccard(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sim != 1 {
+		t.Errorf("expected identical sketches, got Jaccard %v", sim)
+	}
+}
+
+func TestHyperMinHashErrors(t *testing.T) {
+	if _, err := NewHyperMinHash(3); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := NewHyperMinHash(17); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	a, _ := NewHyperMinHash(8)
+	b, _ := NewHyperMinHash(10)
+	if _, err := HyperMinHashJaccard(a, b); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if err := a.Merge(nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	c, _ := NewHyperMinHash(8)
+	if _, err := HyperMinHashJaccard(a, c); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := HyperMinHashContainment(a, c); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if err := a.AddKMers("ACGT", 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if err := a.AddShingles([]string{"a"}, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
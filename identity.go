@@ -0,0 +1,128 @@
+package distance
+
+// GapTreatment selects which alignment columns count towards the length used
+// by PercentIdentity and PercentSimilarity.
+type GapTreatment int
+
+const (
+	// GapsIgnored divides by the number of gap-free columns, so only aligned
+	// residues are judged (BLAST-style identity over the aligned region).
+	GapsIgnored GapTreatment = iota
+	// GapsPenalized divides by the full alignment length, so every gap
+	// column counts as a mismatch (EMBOSS needle-style).
+	GapsPenalized
+	// GapsEndFree divides by the alignment length excluding leading and
+	// trailing gap columns, so overhangs of unequal-length sequences are not
+	// penalized but internal gaps are.
+	GapsEndFree
+)
+
+// PercentIdentity returns the percentage [0, 100] of alignment columns
+// pairing identical residues, with the denominator chosen by gaps.
+// alignment lists columns as returned by Hirschberg or AlignedColumns.
+// Time: O(L), Space: O(1)
+func PercentIdentity[T comparable](a, b []T, alignment []AlignedPair, gaps GapTreatment) (float64, error) {
+	return PercentSimilarity(a, b, alignment, gaps, nil)
+}
+
+// PercentSimilarity returns the percentage [0, 100] of alignment columns
+// pairing residues that are identical or similar according to similar
+// (e.g. ConservativeAminoAcids); a nil similar counts identity only.
+// Time: O(L), Space: O(1)
+func PercentSimilarity[T comparable](a, b []T, alignment []AlignedPair, gaps GapTreatment, similar func(x, y T) bool) (float64, error) {
+	if gaps < GapsIgnored || gaps > GapsEndFree {
+		return 0, ErrInvalidParameter
+	}
+
+	start, end := 0, len(alignment)
+	if gaps == GapsEndFree {
+		for start < end && (alignment[start].A < 0 || alignment[start].B < 0) {
+			start++
+		}
+		for end > start && (alignment[end-1].A < 0 || alignment[end-1].B < 0) {
+			end--
+		}
+	}
+
+	matches, columns := 0, 0
+	for _, col := range alignment[start:end] {
+		if col.A >= len(a) || col.B >= len(b) || (col.A < 0 && col.B < 0) {
+			return 0, ErrInvalidParameter
+		}
+		if col.A < 0 || col.B < 0 {
+			if gaps != GapsIgnored {
+				columns++
+			}
+			continue
+		}
+		columns++
+		x, y := a[col.A], b[col.B]
+		if x == y || (similar != nil && similar(x, y)) {
+			matches++
+		}
+	}
+	if columns == 0 {
+		return 0, ErrEmptyInput
+	}
+	return 100 * float64(matches) / float64(columns), nil
+}
+
+// AlignedColumns converts two gapped alignment rows (e.g. "AC-GT" and
+// "ACTG-") into the ungapped sequences and their alignment columns, for use
+// with PercentIdentity. gap is the gap character, usually '-'; columns
+// where both rows have a gap are dropped.
+// Time: O(L), Space: O(L)
+func AlignedColumns(rowA, rowB string, gap byte) ([]byte, []byte, []AlignedPair, error) {
+	if len(rowA) != len(rowB) {
+		return nil, nil, nil, ErrDimensionMismatch
+	}
+	var a, b []byte
+	alignment := make([]AlignedPair, 0, len(rowA))
+	for i := 0; i < len(rowA); i++ {
+		col := AlignedPair{A: -1, B: -1}
+		if rowA[i] != gap {
+			col.A = len(a)
+			a = append(a, rowA[i])
+		}
+		if rowB[i] != gap {
+			col.B = len(b)
+			b = append(b, rowB[i])
+		}
+		if col.A >= 0 || col.B >= 0 {
+			alignment = append(alignment, col)
+		}
+	}
+	return a, b, alignment, nil
+}
+
+// aminoAcidGroups are the "strong" conservation groups used by Clustal to
+// mark conservative substitutions
+var aminoAcidGroups = []string{"STA", "NEQK", "NHQK", "NDEQ", "QHRK", "MILV", "MILF", "HY", "FYW"}
+
+// conservativePairs holds ordered pairs of residues sharing an aminoAcidGroups entry
+var conservativePairs = func() map[[2]byte]bool {
+	pairs := make(map[[2]byte]bool)
+	for _, group := range aminoAcidGroups {
+		for i := 0; i < len(group); i++ {
+			for j := 0; j < len(group); j++ {
+				pairs[[2]byte{group[i], group[j]}] = true
+			}
+		}
+	}
+	return pairs
+}()
+
+// ConservativeAminoAcids reports whether two one-letter amino-acid codes
+// (case-insensitive) belong to the same Clustal strong conservation group,
+// e.g. I/L/V/M or D/E/N/Q, for use with PercentSimilarity.
+func ConservativeAminoAcids(x, y byte) bool {
+	return conservativePairs[[2]byte{upperASCII(x), upperASCII(y)}]
+}
+
+// upperASCII uppercases an ASCII letter
+func upperASCII(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
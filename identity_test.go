@@ -0,0 +1,108 @@
+package distance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAlignedColumns(t *testing.T) {
+	a, b, alignment, err := AlignedColumns("-AC-GT", "TACG-T", '-')
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(a) != "ACGT" || string(b) != "TACGT" {
+		t.Errorf("expected ACGT/TACGT, got %s/%s", a, b)
+	}
+	expected := []AlignedPair{{-1, 0}, {0, 1}, {1, 2}, {-1, 3}, {2, -1}, {3, 4}}
+	if !reflect.DeepEqual(alignment, expected) {
+		t.Errorf("expected %v, got %v", expected, alignment)
+	}
+
+	if _, _, _, err := AlignedColumns("AC", "A", '-'); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestPercentIdentity(t *testing.T) {
+	// 11 columns: 2 leading gap columns, 1 internal gap, 1 mismatch, 7 identities
+	a, b, alignment, _ := AlignedColumns("--KLVAG-DEF", "MRKLVSGQDEF", '-')
+
+	tests := []struct {
+		name     string
+		gaps     GapTreatment
+		expected float64
+	}{
+		{"ignored", GapsIgnored, 100 * 7.0 / 8},
+		{"penalized", GapsPenalized, 100 * 7.0 / 11},
+		{"end free", GapsEndFree, 100 * 7.0 / 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PercentIdentity(a, b, alignment, tt.gaps)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPercentSimilarity(t *testing.T) {
+	// I/L and D/E are conservative, K/W is not
+	a, b, alignment, _ := AlignedColumns("AIDK", "ALEW", '-')
+	identity, _ := PercentIdentity(a, b, alignment, GapsIgnored)
+	similarity, err := PercentSimilarity(a, b, alignment, GapsIgnored, ConservativeAminoAcids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(identity, 25) || !almostEqual(similarity, 75) {
+		t.Errorf("expected 25%% identity and 75%% similarity, got %v and %v", identity, similarity)
+	}
+
+	// Works directly on Hirschberg output
+	x, y := []byte("HEAGAWGHEE"), []byte("PAWHEAE")
+	_, cols, err := Hirschberg(x, y, 1, -1, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pct, err := PercentIdentity(x, y, cols, GapsPenalized); err != nil || pct <= 0 || pct >= 100 {
+		t.Errorf("expected identity in (0, 100), got %v (%v)", pct, err)
+	}
+}
+
+func TestConservativeAminoAcids(t *testing.T) {
+	tests := []struct {
+		x, y     byte
+		expected bool
+	}{
+		{'I', 'L', true}, {'l', 'v', true}, {'D', 'E', true}, {'S', 'T', true},
+		{'F', 'W', true}, {'K', 'W', false}, {'G', 'A', false}, {'C', 'C', false},
+	}
+	for _, tt := range tests {
+		if got := ConservativeAminoAcids(tt.x, tt.y); got != tt.expected {
+			t.Errorf("%c/%c: expected %v, got %v", tt.x, tt.y, tt.expected, got)
+		}
+	}
+}
+
+func TestPercentIdentityErrors(t *testing.T) {
+	a, b := []byte("AC"), []byte("AC")
+	if _, err := PercentIdentity(a, b, nil, GapsIgnored); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := PercentIdentity(a, b, []AlignedPair{{-1, 0}}, GapsIgnored); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := PercentIdentity(a, b, []AlignedPair{{0, 5}}, GapsIgnored); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := PercentIdentity(a, b, []AlignedPair{{-1, -1}}, GapsIgnored); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := PercentIdentity(a, b, []AlignedPair{{0, 0}}, GapTreatment(9)); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package distance
+
+import (
+	"fmt"
+	"math"
+)
+
+// Thresholds used by DiagnoseEmbeddings to flag a degenerate embedding space.
+// Like the concentration thresholds they are rules of thumb, not tests.
+const (
+	// embeddingCosineWarn is the mean pairwise cosine similarity above which
+	// vectors crowd into a narrow cone
+	embeddingCosineWarn = 0.5
+	// embeddingIsotropyWarn is the isotropy below which variance lives in
+	// only a handful of directions; fewer than two effective dimensions
+	// (a collapse onto a line or point) is flagged at any dimensionality
+	embeddingIsotropyWarn = 0.01
+	// embeddingNormCVWarn is the coefficient of variation of norms above
+	// which norms, not directions, dominate Euclidean and dot-product rankings
+	embeddingNormCVWarn = 0.5
+)
+
+// EmbeddingReport describes the geometry of a set of embeddings. Healthy
+// embedding spaces spread vectors over many directions; degenerate ones
+// (a collapsed model, a pooling bug, un-normalized outputs) crowd them into a
+// narrow cone or a low-dimensional subspace, where cosine similarities are
+// uniformly high and nearest-neighbor retrieval stops discriminating.
+type EmbeddingReport struct {
+	Vectors    int `json:"vectors"`
+	Dimensions int `json:"dimensions"`
+	// MeanCosine is the average cosine similarity over all pairs of nonzero
+	// vectors: near 0 for isotropic embeddings, near 1 when they share a
+	// dominant direction.
+	MeanCosine float64 `json:"mean_cosine"`
+	NormMean   float64 `json:"norm_mean"`
+	NormStdDev float64 `json:"norm_std_dev"`
+	NormMin    float64 `json:"norm_min"`
+	NormMax    float64 `json:"norm_max"`
+	// EffectiveDimensions is the participation ratio (Σλ)²/Σλ² of the
+	// covariance eigenvalues: the number of directions the variance is
+	// spread over, from 1 (a line) to Dimensions (a sphere).
+	EffectiveDimensions float64 `json:"effective_dimensions"`
+	// Isotropy is EffectiveDimensions / Dimensions, in [0, 1]; 0 if all
+	// vectors are equal.
+	Isotropy    float64  `json:"isotropy"`
+	ZeroVectors int      `json:"zero_vectors"`
+	NonFinite   int      `json:"non_finite"` // vectors with NaN or ±Inf components, excluded from the statistics
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// Degenerate reports whether any warning was raised.
+func (r *EmbeddingReport) Degenerate() bool {
+	return len(r.Warnings) > 0
+}
+
+// DiagnoseEmbeddings computes diagnostics over a set of embeddings: the mean
+// pairwise cosine similarity, the distribution of norms and an isotropy
+// score. Warnings are added for crowded or low-rank spaces, widely varying
+// norms, zero vectors and NaN/Inf components, so a broken model can be
+// caught before distance-based retrieval silently degrades.
+// Returns ErrEmptyInput for no vectors or zero dimensions and
+// ErrDimensionMismatch for vectors of different lengths.
+// Time: O(nd·min(n, d)), Space: O(min(n, d)²)
+func DiagnoseEmbeddings[T Number](vectors [][]T) (*EmbeddingReport, error) {
+	if len(vectors) == 0 || len(vectors[0]) == 0 {
+		return nil, ErrEmptyInput
+	}
+	d := len(vectors[0])
+	report := &EmbeddingReport{Vectors: len(vectors), Dimensions: d}
+
+	// Keep finite vectors as float64
+	var data [][]float64
+	for _, v := range vectors {
+		if len(v) != d {
+			return nil, ErrDimensionMismatch
+		}
+		x := make([]float64, d)
+		finite := true
+		for i, c := range v {
+			x[i] = float64(c)
+			if math.IsNaN(x[i]) || math.IsInf(x[i], 0) {
+				finite = false
+			}
+		}
+		if !finite {
+			report.NonFinite++
+			continue
+		}
+		data = append(data, x)
+	}
+
+	if len(data) > 0 {
+		embeddingNorms(report, data)
+		report.EffectiveDimensions = participationRatio(data)
+		report.Isotropy = report.EffectiveDimensions / float64(d)
+	}
+	embeddingWarnings(report)
+	return report, nil
+}
+
+// embeddingNorms fills in the norm statistics and mean pairwise cosine
+func embeddingNorms(report *EmbeddingReport, data [][]float64) {
+	d := len(data[0])
+	norms := make([]float64, len(data))
+	unitSum := make([]float64, d)
+	nonzero := 0
+	report.NormMin = math.Inf(1)
+	for i, x := range data {
+		var sq float64
+		for _, c := range x {
+			sq += c * c
+		}
+		norms[i] = math.Sqrt(sq)
+		report.NormMin = math.Min(report.NormMin, norms[i])
+		report.NormMax = math.Max(report.NormMax, norms[i])
+		if norms[i] == 0 {
+			report.ZeroVectors++
+			continue
+		}
+		nonzero++
+		for j, c := range x {
+			unitSum[j] += c / norms[i]
+		}
+	}
+
+	report.NormMean = mean(norms)
+	var ss float64
+	for _, v := range norms {
+		ss += (v - report.NormMean) * (v - report.NormMean)
+	}
+	report.NormStdDev = math.Sqrt(ss / float64(len(norms)))
+
+	// Σᵢ≠ⱼ uᵢ·uⱼ = |Σ uᵢ|² - m, so the mean cosine needs no pairwise loop
+	if nonzero > 1 {
+		var sq float64
+		for _, c := range unitSum {
+			sq += c * c
+		}
+		m := float64(nonzero)
+		report.MeanCosine = (sq - m) / (m * (m - 1))
+	}
+}
+
+// participationRatio returns (tr C)²/‖C‖²_F for the covariance C of data,
+// computed from whichever of the covariance and Gram matrices is smaller,
+// as both share their nonzero eigenvalues
+func participationRatio(data [][]float64) float64 {
+	n, d := len(data), len(data[0])
+	mu := make([]float64, d)
+	for _, x := range data {
+		for j, c := range x {
+			mu[j] += c / float64(n)
+		}
+	}
+	centered := make([][]float64, n)
+	for i, x := range data {
+		centered[i] = make([]float64, d)
+		for j, c := range x {
+			centered[i][j] = c - mu[j]
+		}
+	}
+
+	var trace, frob float64
+	if n <= d {
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				g := dotF64(centered[i], centered[j])
+				if i == j {
+					trace += g
+					frob += g * g
+				} else {
+					frob += 2 * g * g
+				}
+			}
+		}
+	} else {
+		cov := make([]float64, d*d)
+		for _, x := range centered {
+			for a := 0; a < d; a++ {
+				for b := a; b < d; b++ {
+					cov[a*d+b] += x[a] * x[b]
+				}
+			}
+		}
+		for a := 0; a < d; a++ {
+			trace += cov[a*d+a]
+			frob += cov[a*d+a] * cov[a*d+a]
+			for b := a + 1; b < d; b++ {
+				frob += 2 * cov[a*d+b] * cov[a*d+b]
+			}
+		}
+	}
+	if frob == 0 {
+		return 0
+	}
+	return trace * trace / frob
+}
+
+// embeddingWarnings adds a warning for each threshold the report crosses
+func embeddingWarnings(report *EmbeddingReport) {
+	if report.NonFinite > 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"%d vector(s) contain NaN or Inf components", report.NonFinite))
+	}
+	if report.ZeroVectors > 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"%d zero vector(s) have no direction: cosine distance is undefined for them", report.ZeroVectors))
+	}
+	if report.MeanCosine > embeddingCosineWarn {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"mean pairwise cosine %.3f exceeds %.2f: vectors share a dominant direction; consider mean-centering",
+			report.MeanCosine, embeddingCosineWarn))
+	}
+	lowRank := report.Isotropy < embeddingIsotropyWarn || report.EffectiveDimensions < 2
+	if report.Vectors-report.NonFinite > 1 && report.Dimensions > 1 && lowRank {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"isotropy %.4f is below %.2f: variance spans only %.1f of %d dimensions",
+			report.Isotropy, embeddingIsotropyWarn, report.EffectiveDimensions, report.Dimensions))
+	}
+	if report.NormMean > 0 && report.NormStdDev/report.NormMean > embeddingNormCVWarn {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"norm coefficient of variation %.3f exceeds %.2f: norms dominate Euclidean and dot-product rankings",
+			report.NormStdDev/report.NormMean, embeddingNormCVWarn))
+	}
+}
@@ -0,0 +1,172 @@
+package distance
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+// gaussianEmbeddings returns n standard normal vectors of dimension d, each
+// shifted by offset along every axis
+func gaussianEmbeddings(seed uint64, n, d int, offset float64) [][]float64 {
+	rng := testRNG(seed)
+	vectors := make([][]float64, n)
+	for i := range vectors {
+		vectors[i] = make([]float64, d)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.NormFloat64() + offset
+		}
+	}
+	return vectors
+}
+
+func TestDiagnoseEmbeddingsIsotropic(t *testing.T) {
+	report, err := DiagnoseEmbeddings(gaussianEmbeddings(1, 2000, 16, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(report.MeanCosine) > 0.01 {
+		t.Errorf("expected mean cosine near 0, got %v", report.MeanCosine)
+	}
+	if report.Isotropy < 0.9 || report.EffectiveDimensions > 16 {
+		t.Errorf("expected isotropy near 1, got %v (%v dimensions)", report.Isotropy, report.EffectiveDimensions)
+	}
+	// Norms of 16-dimensional normals concentrate around 4
+	if !almostEqualTolerance(report.NormMean, 4, 0.1) || report.NormMin <= 0 || report.NormMax <= report.NormMean {
+		t.Errorf("unexpected norm statistics %+v", report)
+	}
+	if report.Degenerate() {
+		t.Errorf("expected no warnings, got %v", report.Warnings)
+	}
+}
+
+func TestDiagnoseEmbeddingsAnisotropic(t *testing.T) {
+	// A common offset crowds the vectors into a cone
+	report, err := DiagnoseEmbeddings(gaussianEmbeddings(2, 500, 16, 3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// E[cos] ≈ 9/(1+9) for offset 3 and unit variance
+	if !almostEqualTolerance(report.MeanCosine, 0.9, 0.02) {
+		t.Errorf("expected mean cosine near 0.9, got %v", report.MeanCosine)
+	}
+	// Centering removes the offset, so isotropy is unaffected
+	if report.Isotropy < 0.8 {
+		t.Errorf("expected high isotropy after centering, got %v", report.Isotropy)
+	}
+	if !report.Degenerate() || !strings.Contains(report.Warnings[0], "mean pairwise cosine") {
+		t.Errorf("expected a cosine warning, got %v", report.Warnings)
+	}
+}
+
+func TestDiagnoseEmbeddingsCollapsed(t *testing.T) {
+	// Every vector on one line through the origin
+	vectors := make([][]float32, 50)
+	for i := range vectors {
+		s := float32(i%5 + 1)
+		vectors[i] = []float32{s, -s, 2 * s, 0}
+	}
+	report, err := DiagnoseEmbeddings(vectors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(report.EffectiveDimensions, 1) || !almostEqual(report.Isotropy, 0.25) {
+		t.Errorf("expected 1 effective dimension, got %v", report.EffectiveDimensions)
+	}
+	if !almostEqual(report.MeanCosine, 1) {
+		t.Errorf("expected mean cosine 1, got %v", report.MeanCosine)
+	}
+	found := false
+	for _, w := range report.Warnings {
+		found = found || strings.Contains(w, "isotropy")
+	}
+	if !found {
+		t.Errorf("expected an isotropy warning, got %v", report.Warnings)
+	}
+}
+
+func TestDiagnoseEmbeddingsMeanCosine(t *testing.T) {
+	// Matches the pairwise definition
+	vectors := gaussianEmbeddings(3, 30, 5, 0.5)
+	var sum float64
+	var pairs int
+	for i := range vectors {
+		for j := i + 1; j < len(vectors); j++ {
+			sim, err := CosineSimilarity(vectors[i], vectors[j])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			sum += sim
+			pairs++
+		}
+	}
+	report, err := DiagnoseEmbeddings(vectors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(report.MeanCosine, sum/float64(pairs)) {
+		t.Errorf("expected %v, got %v", sum/float64(pairs), report.MeanCosine)
+	}
+}
+
+func TestDiagnoseEmbeddingsGramAndCovariance(t *testing.T) {
+	// Padding with zero dimensions keeps the eigenvalues but switches from
+	// the covariance path (n > d) to the Gram path (n <= d)
+	vectors := gaussianEmbeddings(4, 10, 6, 0)
+	vectors[0][0] = 30 // Make one direction dominant
+	padded := make([][]float64, len(vectors))
+	for i, v := range vectors {
+		padded[i] = append(append([]float64(nil), v...), make([]float64, 6)...)
+	}
+	cov, gram := participationRatio(vectors), participationRatio(padded)
+	if !almostEqual(cov, gram) {
+		t.Errorf("covariance path %v and Gram path %v disagree", cov, gram)
+	}
+
+	small := [][]float64{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
+	if pr := participationRatio(small); !almostEqual(pr, 2) {
+		t.Errorf("expected 2 effective dimensions, got %v", pr)
+	}
+	if pr := participationRatio(small[:2]); !almostEqual(pr, 1) {
+		t.Errorf("expected 1 effective dimension from the Gram path, got %v", pr)
+	}
+}
+
+func TestDiagnoseEmbeddingsBadVectors(t *testing.T) {
+	vectors := [][]float64{{1, 0}, {0, 0}, {math.NaN(), 1}, {0, 1}, {math.Inf(1), 0}}
+	report, err := DiagnoseEmbeddings(vectors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.NonFinite != 2 || report.ZeroVectors != 1 || report.NormMin != 0 {
+		t.Errorf("unexpected counts %+v", report)
+	}
+	if len(report.Warnings) < 2 {
+		t.Errorf("expected NaN and zero-vector warnings, got %v", report.Warnings)
+	}
+	if _, err := json.Marshal(report); err != nil {
+		t.Errorf("report should encode as JSON: %v", err)
+	}
+}
+
+func TestDiagnoseEmbeddingsErrors(t *testing.T) {
+	if _, err := DiagnoseEmbeddings([][]float64{}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := DiagnoseEmbeddings([][]float64{{}}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := DiagnoseEmbeddings([][]float64{{1, 2}, {1}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func BenchmarkDiagnoseEmbeddings(b *testing.B) {
+	vectors := gaussianEmbeddings(5, 1000, 128, 0)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = DiagnoseEmbeddings(vectors)
+	}
+}
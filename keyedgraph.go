@@ -0,0 +1,188 @@
+package distance
+
+import "math"
+
+// KeyedGraph is a Graph whose nodes are identified by any comparable key,
+// such as airport codes or user IDs, instead of ints. It maintains the
+// key↔ID mapping and delegates to an underlying Graph, so it supports the
+// same algorithms with the same complexity plus an O(1) map lookup per key.
+type KeyedGraph[K comparable] struct {
+	graph *Graph
+	ids   map[K]int
+	keys  []K
+}
+
+// NewKeyedGraph creates an empty graph keyed by K.
+func NewKeyedGraph[K comparable]() *KeyedGraph[K] {
+	return &KeyedGraph[K]{graph: NewGraph(), ids: make(map[K]int)}
+}
+
+// AddEdge adds a weighted edge between two nodes
+func (g *KeyedGraph[K]) AddEdge(from, to K, weight float64) {
+	g.graph.AddEdge(g.intern(from), g.intern(to), weight)
+}
+
+// AddUndirectedEdge adds an undirected edge
+func (g *KeyedGraph[K]) AddUndirectedEdge(a, b K, weight float64) {
+	g.graph.AddUndirectedEdge(g.intern(a), g.intern(b), weight)
+}
+
+// Nodes returns the keys of all nodes in insertion order.
+func (g *KeyedGraph[K]) Nodes() []K {
+	return append([]K(nil), g.keys...)
+}
+
+// ID returns the int ID of key in the underlying Graph, and whether the key
+// is a node of the graph.
+func (g *KeyedGraph[K]) ID(key K) (int, bool) {
+	id, ok := g.ids[key]
+	return id, ok
+}
+
+// Key returns the key of the node with the given int ID. IDs are assigned
+// densely from 0 in insertion order.
+func (g *KeyedGraph[K]) Key(id int) (K, bool) {
+	if id < 0 || id >= len(g.keys) {
+		var zero K
+		return zero, false
+	}
+	return g.keys[id], true
+}
+
+// Graph returns a copy of the underlying int-keyed graph, for algorithms
+// that work on IDs. Edits to the copy do not affect the KeyedGraph.
+// Time: O(V+E), Space: O(V+E)
+func (g *KeyedGraph[K]) Graph() *Graph {
+	return g.graph.clone()
+}
+
+// Dijkstra computes shortest path distance from source to target
+// Returns distance and path. Returns inf if no path exists or either key is
+// not a node.
+// Time: O((V+E)logV), Space: O(V)
+func (g *KeyedGraph[K]) Dijkstra(source, target K) (float64, []K) {
+	s, t, ok := g.endpoints(source, target)
+	if !ok {
+		return math.Inf(1), nil
+	}
+	dist, path := g.graph.Dijkstra(s, t)
+	return dist, g.keysOf(path)
+}
+
+// AStar computes shortest path using A* with heuristic
+// Time: O(E log V) with good heuristic, Space: O(V)
+func (g *KeyedGraph[K]) AStar(source, target K, heuristic func(K, K) float64) (float64, []K) {
+	s, t, ok := g.endpoints(source, target)
+	if !ok {
+		return math.Inf(1), nil
+	}
+	dist, path := g.graph.AStar(s, t, func(a, b int) float64 {
+		return heuristic(g.keys[a], g.keys[b])
+	})
+	return dist, g.keysOf(path)
+}
+
+// BFS computes shortest path in unweighted graph
+// Returns -1 and a nil path if no path exists or either key is not a node.
+// Time: O(V+E), Space: O(V)
+func (g *KeyedGraph[K]) BFS(source, target K) (int, []K) {
+	s, t, ok := g.endpoints(source, target)
+	if !ok {
+		return -1, nil
+	}
+	hops, path := g.graph.BFS(s, t)
+	return hops, g.keysOf(path)
+}
+
+// BellmanFord computes shortest paths handling negative weights
+// Returns distances and whether negative cycle exists; nil distances if
+// source is not a node.
+// Time: O(VE), Space: O(V)
+func (g *KeyedGraph[K]) BellmanFord(source K) (map[K]float64, bool) {
+	s, ok := g.ids[source]
+	if !ok {
+		return nil, false
+	}
+	dist, negative := g.graph.BellmanFord(s)
+	return g.byKey(dist), negative
+}
+
+// AllPairsShortestPaths computes all-pairs shortest path distances via
+// Graph.AllPairsShortestPaths. Unreachable pairs are +Inf.
+// Time: O(min(V³, V(V+E)logV)), Space: O(V²)
+func (g *KeyedGraph[K]) AllPairsShortestPaths() map[K]map[K]float64 {
+	all := g.graph.AllPairsShortestPaths()
+	dist := make(map[K]map[K]float64, len(all))
+	for from, row := range all {
+		dist[g.keys[from]] = g.byKey(row)
+	}
+	return dist
+}
+
+// GraphDiameter computes the diameter (maximum shortest path)
+// Time: O(V³), Space: O(V²)
+func (g *KeyedGraph[K]) GraphDiameter() float64 {
+	return g.graph.GraphDiameter()
+}
+
+// GraphRadius computes the radius (minimum eccentricity)
+// Time: O(V³), Space: O(V²)
+func (g *KeyedGraph[K]) GraphRadius() float64 {
+	return g.graph.GraphRadius()
+}
+
+// ConnectedComponents finds connected components
+// Time: O(V+E), Space: O(V)
+func (g *KeyedGraph[K]) ConnectedComponents() [][]K {
+	components := g.graph.ConnectedComponents()
+	result := make([][]K, len(components))
+	for i, c := range components {
+		result[i] = g.keysOf(c)
+	}
+	return result
+}
+
+// IsConnected checks if graph is connected
+// Time: O(V+E), Space: O(V)
+func (g *KeyedGraph[K]) IsConnected() bool {
+	return g.graph.IsConnected()
+}
+
+// intern returns the ID of key, assigning the next one if it is new
+func (g *KeyedGraph[K]) intern(key K) int {
+	if id, ok := g.ids[key]; ok {
+		return id
+	}
+	id := len(g.keys)
+	g.ids[key] = id
+	g.keys = append(g.keys, key)
+	return id
+}
+
+// endpoints looks up the IDs of source and target
+func (g *KeyedGraph[K]) endpoints(source, target K) (int, int, bool) {
+	s, okS := g.ids[source]
+	t, okT := g.ids[target]
+	return s, t, okS && okT
+}
+
+// keysOf maps a path or component of IDs to keys, preserving nil
+func (g *KeyedGraph[K]) keysOf(ids []int) []K {
+	if ids == nil {
+		return nil
+	}
+	keys := make([]K, len(ids))
+	for i, id := range ids {
+		keys[i] = g.keys[id]
+	}
+	return keys
+}
+
+// byKey re-keys a map of per-node values
+func (g *KeyedGraph[K]) byKey(values map[int]float64) map[K]float64 {
+	result := make(map[K]float64, len(values))
+	for id, v := range values {
+		result[g.keys[id]] = v
+	}
+	return result
+}
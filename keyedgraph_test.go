@@ -0,0 +1,120 @@
+package distance
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func airportGraph() *KeyedGraph[string] {
+	g := NewKeyedGraph[string]()
+	g.AddUndirectedEdge("JFK", "ORD", 1188)
+	g.AddUndirectedEdge("ORD", "DEN", 1474)
+	g.AddUndirectedEdge("JFK", "DEN", 2620)
+	g.AddUndirectedEdge("DEN", "SFO", 1555)
+	g.AddUndirectedEdge("LHR", "CDG", 344)
+	return g
+}
+
+func TestKeyedGraphShortestPaths(t *testing.T) {
+	g := airportGraph()
+
+	dist, path := g.Dijkstra("JFK", "SFO")
+	if dist != 4175 {
+		t.Errorf("expected distance 4175, got %v", dist)
+	}
+	if !slices.Equal(path, []string{"JFK", "DEN", "SFO"}) {
+		t.Errorf("expected path [JFK DEN SFO], got %v", path)
+	}
+
+	dist, path = g.AStar("JFK", "SFO", func(a, b string) float64 { return 0 })
+	if dist != 4175 || len(path) != 3 {
+		t.Errorf("expected A* to match Dijkstra, got %v %v", dist, path)
+	}
+
+	hops, path := g.BFS("JFK", "SFO")
+	if hops != 2 || path[0] != "JFK" || path[2] != "SFO" {
+		t.Errorf("expected 2 hops, got %d %v", hops, path)
+	}
+
+	bf, negative := g.BellmanFord("ORD")
+	if negative || bf["SFO"] != 3029 || !math.IsInf(bf["LHR"], 1) {
+		t.Errorf("unexpected Bellman-Ford result %v %v", bf, negative)
+	}
+
+	all := g.AllPairsShortestPaths()
+	if all["SFO"]["JFK"] != 4175 || all["CDG"]["LHR"] != 344 || !math.IsInf(all["JFK"]["CDG"], 1) {
+		t.Errorf("unexpected all-pairs result %v", all)
+	}
+	if g.GraphDiameter() != 4175 {
+		t.Errorf("expected diameter 4175, got %v", g.GraphDiameter())
+	}
+}
+
+func TestKeyedGraphUnknownKeys(t *testing.T) {
+	g := airportGraph()
+
+	if dist, path := g.Dijkstra("JFK", "NRT"); !math.IsInf(dist, 1) || path != nil {
+		t.Errorf("expected +Inf and no path, got %v %v", dist, path)
+	}
+	if dist, path := g.Dijkstra("JFK", "LHR"); !math.IsInf(dist, 1) || len(path) != 0 {
+		t.Errorf("expected +Inf and empty path, got %v %v", dist, path)
+	}
+	if hops, path := g.BFS("NRT", "JFK"); hops != -1 || path != nil {
+		t.Errorf("expected -1 and no path, got %d %v", hops, path)
+	}
+	if dist, _ := g.BellmanFord("NRT"); dist != nil {
+		t.Errorf("expected nil distances, got %v", dist)
+	}
+}
+
+func TestKeyedGraphMapping(t *testing.T) {
+	g := airportGraph()
+
+	if !slices.Equal(g.Nodes(), []string{"JFK", "ORD", "DEN", "SFO", "LHR", "CDG"}) {
+		t.Errorf("unexpected nodes %v", g.Nodes())
+	}
+	id, ok := g.ID("DEN")
+	if !ok || id != 2 {
+		t.Errorf("expected ID 2, got %d %v", id, ok)
+	}
+	if key, ok := g.Key(id); !ok || key != "DEN" {
+		t.Errorf("expected DEN, got %q %v", key, ok)
+	}
+	if _, ok := g.Key(6); ok {
+		t.Error("expected no key for out-of-range ID")
+	}
+	if _, ok := g.ID("NRT"); ok {
+		t.Error("expected no ID for unknown key")
+	}
+	if d, _ := g.Graph().Dijkstra(0, 3); d != 4175 {
+		t.Errorf("expected underlying graph distance 4175, got %v", d)
+	}
+	// Unkeyed IDs added through the copy never reach the keyed graph
+	g.Graph().AddEdge(50, 60, 1)
+	if len(g.Nodes()) != 6 || len(g.AllPairsShortestPaths()) != 6 {
+		t.Errorf("expected 6 nodes after editing the copy, got %v", g.Nodes())
+	}
+
+	components := g.ConnectedComponents()
+	if len(components) != 2 || g.IsConnected() {
+		t.Fatalf("expected 2 components, got %v", components)
+	}
+	sizes := []int{len(components[0]), len(components[1])}
+	slices.Sort(sizes)
+	if !slices.Equal(sizes, []int{2, 4}) {
+		t.Errorf("expected component sizes [2 4], got %v", sizes)
+	}
+}
+
+func TestKeyedGraphStructKeys(t *testing.T) {
+	type cell struct{ row, col int }
+	g := NewKeyedGraph[cell]()
+	g.AddUndirectedEdge(cell{0, 0}, cell{0, 1}, 1)
+	g.AddUndirectedEdge(cell{0, 1}, cell{1, 1}, 1)
+
+	dist, path := g.Dijkstra(cell{0, 0}, cell{1, 1})
+	if dist != 2 || path[1] != (cell{0, 1}) {
+		t.Errorf("expected distance 2 via (0,1), got %v %v", dist, path)
+	}
+}
@@ -0,0 +1,230 @@
+package distance
+
+import (
+	"math"
+	"sort"
+)
+
+// ClassicalMDS embeds a distance matrix into dims dimensions with Torgerson's
+// classical multidimensional scaling: the top eigenvectors of the
+// double-centered squared distances. Exact (up to rotation) for Euclidean
+// distances; the starting layout for SMACOF and SammonMapping.
+// Time: O(n³), Space: O(n²)
+func ClassicalMDS(matrix [][]float64, dims int) ([][]float64, error) {
+	if err := validateLayout(matrix, dims); err != nil {
+		return nil, err
+	}
+
+	n := len(matrix)
+	b := make([][]float64, n)
+	for i, row := range matrix {
+		b[i] = make([]float64, n)
+		for j, d := range row {
+			b[i][j] = -0.5 * d * d
+		}
+	}
+	doubleCenter(b)
+
+	values, vectors := symmetricEigen(b)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(x, y int) bool { return values[order[x]] > values[order[y]] })
+
+	coords := make([][]float64, n)
+	for i := range coords {
+		coords[i] = make([]float64, dims)
+	}
+	for k := 0; k < dims && k < n; k++ {
+		lambda := values[order[k]]
+		if lambda <= 0 {
+			break // Remaining axes carry no (or imaginary) variance
+		}
+		scale := math.Sqrt(lambda)
+		for i := range coords {
+			coords[i][k] = vectors[i][order[k]] * scale
+		}
+	}
+	return coords, nil
+}
+
+// SMACOF embeds a distance matrix into dims dimensions by stress majorization
+// (de Leeuw's Guttman transform), starting from ClassicalMDS. Iteration stops
+// when stress improves by less than tolerance (relative) or after iterations.
+// Returns the coordinates and Kruskal's stress-1, sqrt(Σ(δᵢⱼ - dᵢⱼ)² / Σδᵢⱼ²).
+// Time: O(iterations·n²·dims + n³), Space: O(n²)
+func SMACOF(matrix [][]float64, dims, iterations int, tolerance float64) ([][]float64, float64, error) {
+	if iterations < 0 || tolerance < 0 {
+		return nil, 0, ErrInvalidParameter
+	}
+	x, err := ClassicalMDS(matrix, dims)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	n := len(matrix)
+	var total float64
+	for i := range matrix {
+		for j := i + 1; j < n; j++ {
+			total += matrix[i][j] * matrix[i][j]
+		}
+	}
+	if total == 0 {
+		return x, 0, nil
+	}
+
+	stress := rawStress(matrix, x)
+	for iter := 0; iter < iterations; iter++ {
+		next := make([][]float64, n)
+		for i := range next {
+			next[i] = make([]float64, dims)
+			for j := 0; j < n; j++ {
+				if i == j {
+					continue
+				}
+				d, _ := EuclideanF64(x[i], x[j])
+				if d == 0 {
+					continue
+				}
+				// Row i of (1/n)·B(X)·X, with B's diagonal folded into the difference
+				w := matrix[i][j] / d
+				for k := range next[i] {
+					next[i][k] += w * (x[i][k] - x[j][k])
+				}
+			}
+			for k := range next[i] {
+				next[i][k] /= float64(n)
+			}
+		}
+
+		nextStress := rawStress(matrix, next)
+		x = next
+		if stress-nextStress <= tolerance*stress {
+			stress = nextStress
+			break
+		}
+		stress = nextStress
+	}
+
+	return x, math.Sqrt(stress / total), nil
+}
+
+// SammonMapping embeds a distance matrix into dims dimensions by minimizing
+// Sammon's stress, (1/Σδᵢⱼ) Σ (δᵢⱼ - dᵢⱼ)² / δᵢⱼ, which weights small distances
+// more heavily than SMACOF and so preserves local structure. The layout starts
+// from ClassicalMDS and is refined with ConjugateGradient. Pairs at distance
+// zero are ignored. Returns the coordinates and the final Sammon stress.
+// Time: O(iterations·n²·dims + n³), Space: O(n²)
+func SammonMapping(matrix [][]float64, dims, iterations int) ([][]float64, float64, error) {
+	if iterations < 0 {
+		return nil, 0, ErrInvalidParameter
+	}
+	init, err := ClassicalMDS(matrix, dims)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Work on distances scaled to unit mean so the optimizer's step sizes fit
+	n := len(matrix)
+	var sum float64
+	var pairs int
+	for i := range matrix {
+		for j := i + 1; j < n; j++ {
+			if matrix[i][j] > 0 {
+				sum += matrix[i][j]
+				pairs++
+			}
+		}
+	}
+	if pairs == 0 {
+		return init, 0, nil
+	}
+	scale := sum / float64(pairs)
+
+	stress := func(flat []float64) float64 {
+		var e float64
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				delta := matrix[i][j] / scale
+				if delta <= 0 {
+					continue
+				}
+				d, _ := EuclideanF64(flat[i*dims:(i+1)*dims], flat[j*dims:(j+1)*dims])
+				r := delta - d
+				e += r * r / delta
+			}
+		}
+		return e / (sum / scale)
+	}
+	gradient := func(flat []float64) []float64 {
+		g := make([]float64, len(flat))
+		for i := 0; i < n; i++ {
+			yi := flat[i*dims : (i+1)*dims]
+			for j := 0; j < n; j++ {
+				delta := matrix[i][j] / scale
+				if i == j || delta <= 0 {
+					continue
+				}
+				yj := flat[j*dims : (j+1)*dims]
+				d, _ := EuclideanF64(yi, yj)
+				if d == 0 {
+					continue
+				}
+				w := 2 * (d - delta) / (delta * d) / (sum / scale)
+				for k := range yi {
+					g[i*dims+k] += w * (yi[k] - yj[k])
+				}
+			}
+		}
+		return g
+	}
+
+	start := make([]float64, 0, n*dims)
+	for _, p := range init {
+		for _, v := range p {
+			start = append(start, v/scale)
+		}
+	}
+	best, err := ConjugateGradient(stress, gradient, start, iterations, 1e-9)
+	if err != nil && err != ErrMaxIterations {
+		return nil, 0, err
+	}
+	// Backtracking may stall on a worse point; never return worse than the start
+	if stress(best) > stress(start) {
+		best = start
+	}
+
+	coords := make([][]float64, n)
+	for i := range coords {
+		coords[i] = make([]float64, dims)
+		for k := range coords[i] {
+			coords[i][k] = best[i*dims+k] * scale
+		}
+	}
+	return coords, stress(best), nil
+}
+
+// validateLayout checks for a non-empty square distance matrix and dims ≥ 1
+func validateLayout(matrix [][]float64, dims int) error {
+	if err := validateDistanceMatrix(matrix); err != nil {
+		return err
+	}
+	if dims <= 0 {
+		return ErrInvalidParameter
+	}
+	return nil
+}
+
+// rawStress returns Σᵢ<ⱼ (δᵢⱼ - ‖xᵢ - xⱼ‖)²
+func rawStress(matrix, x [][]float64) float64 {
+	var s float64
+	for i := range x {
+		for j := i + 1; j < len(x); j++ {
+			d, _ := EuclideanF64(x[i], x[j])
+			r := matrix[i][j] - d
+			s += r * r
+		}
+	}
+	return s
+}
@@ -0,0 +1,158 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+// layoutMatrix returns the Euclidean distance matrix of points
+func layoutMatrix(points [][]float64) [][]float64 {
+	m := make([][]float64, len(points))
+	for i := range points {
+		m[i] = make([]float64, len(points))
+		for j := range points {
+			m[i][j], _ = EuclideanF64(points[i], points[j])
+		}
+	}
+	return m
+}
+
+func TestClassicalMDS(t *testing.T) {
+	points := [][]float64{{0, 0}, {3, 0}, {3, 4}, {0, 4}, {1.5, 2}, {5, 1}}
+	matrix := layoutMatrix(points)
+
+	coords, err := ClassicalMDS(matrix, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Planar points are recovered up to rotation and reflection
+	got := layoutMatrix(coords)
+	for i := range matrix {
+		for j := range matrix {
+			if math.Abs(got[i][j]-matrix[i][j]) > 1e-6 {
+				t.Errorf("d(%d,%d): expected %v, got %v", i, j, matrix[i][j], got[i][j])
+			}
+		}
+	}
+
+	// Extra dimensions of a planar configuration are zero
+	coords, err = ClassicalMDS(matrix, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, p := range coords {
+		if math.Abs(p[2]) > 1e-6 {
+			t.Errorf("point %d: expected third coordinate 0, got %v", i, p[2])
+		}
+	}
+}
+
+func TestSMACOF(t *testing.T) {
+	points := [][]float64{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 1}, {2, 0, 1}}
+	matrix := layoutMatrix(points)
+
+	coords, stress, err := SMACOF(matrix, 3, 100, 1e-9)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(coords) != len(points) || len(coords[0]) != 3 {
+		t.Fatalf("expected %dx3 coordinates, got %dx%d", len(points), len(coords), len(coords[0]))
+	}
+	if stress > 1e-6 {
+		t.Errorf("expected near-zero stress for a Euclidean embedding, got %v", stress)
+	}
+
+	// Non-Euclidean input (a cycle of string edit distances) squeezed into 2D
+	words := []string{"kitten", "sitting", "mitten", "bitten", "fitting", "knitting"}
+	edit := make([][]float64, len(words))
+	for i := range words {
+		edit[i] = make([]float64, len(words))
+		for j := range words {
+			d, _ := Levenshtein(words[i], words[j])
+			edit[i][j] = float64(d)
+		}
+	}
+	_, initial, err := SMACOF(edit, 2, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, refined, err := SMACOF(edit, 2, 200, 1e-9)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refined > initial+1e-12 {
+		t.Errorf("expected majorization not to increase stress: %v > %v", refined, initial)
+	}
+}
+
+func TestSammonMapping(t *testing.T) {
+	points := [][]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {5, 5}, {2, 7}}
+	matrix := layoutMatrix(points)
+
+	coords, stress, err := SammonMapping(matrix, 2, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stress > 1e-6 {
+		t.Errorf("expected near-zero stress for a Euclidean embedding, got %v", stress)
+	}
+	got := layoutMatrix(coords)
+	for i := range matrix {
+		for j := range matrix {
+			if math.Abs(got[i][j]-matrix[i][j]) > 1e-3 {
+				t.Errorf("d(%d,%d): expected %v, got %v", i, j, matrix[i][j], got[i][j])
+			}
+		}
+	}
+
+	// A 3D simplex cannot be flattened exactly; refinement must not hurt
+	tetra := [][]float64{
+		{0, 1, 1, 1},
+		{1, 0, 1, 1},
+		{1, 1, 0, 1},
+		{1, 1, 1, 0},
+	}
+	_, initial, err := SammonMapping(tetra, 2, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, refined, err := SammonMapping(tetra, 2, 200)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refined <= 0 || refined > initial {
+		t.Errorf("expected 0 < stress <= %v, got %v", initial, refined)
+	}
+}
+
+func TestLayoutErrors(t *testing.T) {
+	valid := [][]float64{{0, 1}, {1, 0}}
+
+	if _, err := ClassicalMDS(nil, 2); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := ClassicalMDS([][]float64{{0, 1}, {1}}, 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := ClassicalMDS(valid, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := ClassicalMDS([][]float64{{0, -1}, {-1, 0}}, 2); err != ErrNegativeValue {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+	if _, _, err := SMACOF(valid, 2, -1, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, _, err := SMACOF(valid, 2, 10, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, _, err := SammonMapping(valid, 2, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+
+	// All-zero distances collapse to a single point without error
+	coords, stress, err := SammonMapping([][]float64{{0, 0}, {0, 0}}, 2, 10)
+	if err != nil || stress != 0 || len(coords) != 2 {
+		t.Errorf("expected collapsed layout, got %v, %v, %v", coords, stress, err)
+	}
+}
@@ -0,0 +1,138 @@
+package distance
+
+import "math"
+
+// MarkovMetric selects how MarkovDistance compares transition matrices.
+type MarkovMetric int
+
+const (
+	// MarkovJensenShannon averages the Jensen-Shannon divergence between
+	// corresponding rows, weighted by how often each state is visited.
+	// Range [0, ln 2].
+	MarkovJensenShannon MarkovMetric = iota
+	// MarkovFrobenius is the Frobenius norm of the difference between the
+	// matrices with each row scaled by the square root of its state's
+	// visit frequency, so rarely visited states contribute little.
+	// Range [0, √2].
+	MarkovFrobenius
+)
+
+// TransitionMatrix estimates the first-order Markov transition matrix of
+// seq: entry [i][j] is the probability that states[i] is followed by
+// states[j]. smoothing is a pseudo-count added to every transition
+// (Laplace smoothing); a state never followed by another gets a uniform row.
+// Returns ErrKeyNotFound if seq contains a symbol missing from states.
+// Time: O(n + k²), Space: O(k²)
+func TransitionMatrix[T comparable](seq, states []T, smoothing float64) ([][]float64, error) {
+	if len(states) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if smoothing < 0 || math.IsNaN(smoothing) {
+		return nil, ErrInvalidParameter
+	}
+	index := make(map[T]int, len(states))
+	for i, s := range states {
+		index[s] = i
+	}
+
+	k := len(states)
+	matrix := make([][]float64, k)
+	for i := range matrix {
+		matrix[i] = make([]float64, k)
+	}
+	for t := range seq {
+		to, ok := index[seq[t]]
+		if !ok {
+			return nil, ErrKeyNotFound
+		}
+		if t > 0 {
+			matrix[index[seq[t-1]]][to]++
+		}
+	}
+
+	for _, row := range matrix {
+		total := 0.0
+		for j := range row {
+			row[j] += smoothing
+			total += row[j]
+		}
+		for j := range row {
+			if total == 0 {
+				row[j] = 1 / float64(k)
+			} else {
+				row[j] /= total
+			}
+		}
+	}
+	return matrix, nil
+}
+
+// MarkovDistance compares the behavior of two symbol sequences, e.g. user
+// sessions or machine states, through their empirical transition matrices
+// over the union of their symbols. Rows are weighted by each state's visit
+// frequency averaged over both sequences, so the comparison reflects how the
+// sequences actually move rather than states one of them barely visits.
+// smoothing is passed to TransitionMatrix; a small value such as 0.1 keeps
+// unseen transitions from dominating short sequences.
+// Returns ErrEmptyInput if either sequence has no transition.
+// Time: O(n + m + k²), Space: O(k²)
+func MarkovDistance[T comparable](a, b []T, metric MarkovMetric, smoothing float64) (float64, error) {
+	if len(a) < 2 || len(b) < 2 {
+		return 0, ErrEmptyInput
+	}
+	if metric != MarkovJensenShannon && metric != MarkovFrobenius {
+		return 0, ErrInvalidParameter
+	}
+
+	var states []T
+	index := make(map[T]int)
+	for _, seq := range [][]T{a, b} {
+		for _, s := range seq {
+			if _, ok := index[s]; !ok {
+				index[s] = len(states)
+				states = append(states, s)
+			}
+		}
+	}
+
+	pa, err := TransitionMatrix(a, states, smoothing)
+	if err != nil {
+		return 0, err
+	}
+	pb, err := TransitionMatrix(b, states, smoothing)
+	if err != nil {
+		return 0, err
+	}
+
+	// Visit frequency of each state as the origin of a transition
+	weights := make([]float64, len(states))
+	for _, seq := range [][]T{a, b} {
+		for _, s := range seq[:len(seq)-1] {
+			weights[index[s]] += 0.5 / float64(len(seq)-1)
+		}
+	}
+
+	total := 0.0
+	for i := range states {
+		if weights[i] == 0 {
+			continue
+		}
+		switch metric {
+		case MarkovJensenShannon:
+			js, err := JensenShannonDivergence(pa[i], pb[i])
+			if err != nil {
+				return 0, err
+			}
+			total += weights[i] * js
+		case MarkovFrobenius:
+			for j := range states {
+				d := pa[i][j] - pb[i][j]
+				total += weights[i] * d * d
+			}
+		}
+	}
+	if metric == MarkovFrobenius {
+		return math.Sqrt(total), nil
+	}
+	return total, nil
+}
@@ -0,0 +1,87 @@
+package distance
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTransitionMatrix(t *testing.T) {
+	seq := strings.Split("a b a c a b", " ")
+	matrix, err := TransitionMatrix(seq, []string{"a", "b", "c", "d"}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := [][]float64{
+		{0, 2.0 / 3, 1.0 / 3, 0}, // a -> b, c, b
+		{1, 0, 0, 0},             // b -> a
+		{1, 0, 0, 0},             // c -> a
+		{0.25, 0.25, 0.25, 0.25}, // d never left: uniform
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if !almostEqual(matrix[i][j], expected[i][j]) {
+				t.Errorf("[%d][%d]: expected %v, got %v", i, j, expected[i][j], matrix[i][j])
+			}
+		}
+	}
+
+	smoothed, _ := TransitionMatrix(seq, []string{"a", "b", "c", "d"}, 1)
+	if !almostEqual(smoothed[1][0], 2.0/5) || !almostEqual(smoothed[1][3], 1.0/5) {
+		t.Errorf("unexpected smoothed row %v", smoothed[1])
+	}
+
+	if _, err := TransitionMatrix(seq, []string{"a", "b"}, 0); err != ErrKeyNotFound {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+	if _, err := TransitionMatrix(seq, []string{"a"}, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestMarkovDistance(t *testing.T) {
+	cycle := strings.Split(strings.Repeat("home search product cart ", 20), " ")
+	cycle = cycle[:len(cycle)-1]
+	shifted := append(append([]string{}, cycle[2:]...), cycle[:2]...)
+	browsing := strings.Split(strings.Repeat("home search product search product home ", 13), " ")
+	browsing = browsing[:len(browsing)-1]
+
+	for _, metric := range []MarkovMetric{MarkovJensenShannon, MarkovFrobenius} {
+		// Same dynamics from a different starting point
+		same, err := MarkovDistance(cycle, shifted, metric, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if same > 0.05 {
+			t.Errorf("metric %d: expected near 0 for the same cycle, got %v", metric, same)
+		}
+
+		different, _ := MarkovDistance(cycle, browsing, metric, 0)
+		if different < 0.1 {
+			t.Errorf("metric %d: expected different behavior to be far, got %v", metric, different)
+		}
+		reverse, _ := MarkovDistance(browsing, cycle, metric, 0)
+		if !almostEqual(different, reverse) {
+			t.Errorf("metric %d: expected symmetry, got %v and %v", metric, different, reverse)
+		}
+	}
+
+	// State 0 always stays in a but always leaves in b (JS = ln 2, weight 3/4);
+	// state 1 is never left in a (uniform row) and returns to 0 in b
+	js, _ := MarkovDistance([]int{0, 0, 0}, []int{0, 1, 0}, MarkovJensenShannon, 0)
+	if expected := 0.75*math.Log(2) + 0.25*0.75*math.Log(4.0/3); !almostEqual(js, expected) {
+		t.Errorf("expected %v, got %v", expected, js)
+	}
+}
+
+func TestMarkovDistanceErrors(t *testing.T) {
+	if _, err := MarkovDistance([]int{1}, []int{1, 2}, MarkovFrobenius, 0); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := MarkovDistance([]int{1, 2}, []int{1, 2}, MarkovMetric(7), 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := MarkovDistance([]int{1, 2}, []int{1, 2}, MarkovFrobenius, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package distance
+
+import (
+	"sort"
+	"strings"
+)
+
+// MatcherOptions configures a Matcher.
+type MatcherOptions struct {
+	// Preprocess is applied to every candidate once and to each query.
+	Preprocess StringOptions
+	// Shortlist, when positive, scores only the candidates sharing the most
+	// trigrams with the query, up to this many. Much faster for large
+	// candidate lists, but approximate: a match with few shared trigrams
+	// can be missed. 0 scores every candidate.
+	Shortlist int
+}
+
+// MatchResult is a candidate scored against a query.
+type MatchResult struct {
+	Candidate string  `json:"candidate"`
+	Index     int     `json:"index"` // position in the candidate list
+	Score     float64 `json:"score"`
+}
+
+// Matcher finds the candidates most similar to a query string, like
+// fuzzywuzzy's process.extract. Candidates are preprocessed and indexed once;
+// identical preprocessed candidates are scored only once per query.
+// A Matcher is safe for concurrent queries.
+type Matcher struct {
+	candidates []string
+	metric     func(a, b string) (float64, error)
+	opts       MatcherOptions
+
+	unique  []string         // distinct preprocessed candidates
+	members [][]int          // candidate indices per unique entry
+	grams   map[string][]int // trigram -> unique entries containing it
+}
+
+// NewMatcher indexes candidates for matching with metric, a similarity in
+// [0, 1] such as TokenSortRatio, TokenSetRatio or a JaroWinkler closure.
+// A nil metric uses TokenSortRatio.
+// Time: O(n·L) where L = candidate length, Space: O(n·L)
+func NewMatcher(candidates []string, metric func(a, b string) (float64, error), opts MatcherOptions) (*Matcher, error) {
+	if len(candidates) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if opts.Shortlist < 0 {
+		return nil, ErrInvalidParameter
+	}
+	if metric == nil {
+		metric = TokenSortRatio
+	}
+
+	m := &Matcher{
+		candidates: candidates,
+		metric:     metric,
+		opts:       opts,
+		grams:      make(map[string][]int),
+	}
+	position := make(map[string]int)
+	for i, c := range candidates {
+		p := opts.Preprocess.Apply(c)
+		u, ok := position[p]
+		if !ok {
+			u = len(m.unique)
+			position[p] = u
+			m.unique = append(m.unique, p)
+			m.members = append(m.members, nil)
+			for g := range extractQGrams(strings.ToLower(p), 3) {
+				m.grams[g] = append(m.grams[g], u)
+			}
+		}
+		m.members[u] = append(m.members[u], i)
+	}
+	return m, nil
+}
+
+// Best returns the highest-scoring candidate for query. Returns
+// ErrKeyNotFound if a Shortlist is set and no candidate shares a trigram
+// with the query.
+// Time: O(n) metric evaluations, Space: O(n)
+func (m *Matcher) Best(query string) (MatchResult, error) {
+	results, err := m.TopN(query, 1)
+	if err != nil {
+		return MatchResult{}, err
+	}
+	if len(results) == 0 {
+		return MatchResult{}, ErrKeyNotFound
+	}
+	return results[0], nil
+}
+
+// TopN returns the n highest-scoring candidates for query, sorted by
+// descending score, ties broken by lower Index.
+// Time: O(n) metric evaluations plus O(n log n), Space: O(n)
+func (m *Matcher) TopN(query string, n int) ([]MatchResult, error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	results, err := m.score(query)
+	if err != nil {
+		return nil, err
+	}
+	return results[:min(n, len(results))], nil
+}
+
+// AboveThreshold returns every candidate scoring at least threshold,
+// sorted by descending score, ties broken by lower Index.
+// Time: O(n) metric evaluations plus O(n log n), Space: O(n)
+func (m *Matcher) AboveThreshold(query string, threshold float64) ([]MatchResult, error) {
+	results, err := m.score(query)
+	if err != nil {
+		return nil, err
+	}
+	cut := sort.Search(len(results), func(i int) bool { return results[i].Score < threshold })
+	return results[:cut], nil
+}
+
+// score rates the query against the shortlisted candidates, best first
+func (m *Matcher) score(query string) ([]MatchResult, error) {
+	q := m.opts.Preprocess.Apply(query)
+
+	var results []MatchResult
+	for _, u := range m.shortlist(q) {
+		s, err := m.metric(q, m.unique[u])
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range m.members[u] {
+			results = append(results, MatchResult{Candidate: m.candidates[i], Index: i, Score: s})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Index < results[j].Index
+	})
+	return results, nil
+}
+
+// shortlist returns the unique entries to score for preprocessed query q
+func (m *Matcher) shortlist(q string) []int {
+	if m.opts.Shortlist == 0 || m.opts.Shortlist >= len(m.unique) {
+		all := make([]int, len(m.unique))
+		for u := range all {
+			all[u] = u
+		}
+		return all
+	}
+
+	shared := make(map[int]int)
+	for g := range extractQGrams(strings.ToLower(q), 3) {
+		for _, u := range m.grams[g] {
+			shared[u]++
+		}
+	}
+	ranked := make([]int, 0, len(shared))
+	for u := range shared {
+		ranked = append(ranked, u)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if shared[ranked[i]] != shared[ranked[j]] {
+			return shared[ranked[i]] > shared[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+	return ranked[:min(m.opts.Shortlist, len(ranked))]
+}
@@ -0,0 +1,133 @@
+package distance
+
+import (
+	"fmt"
+	"testing"
+)
+
+var matcherTeams = []string{
+	"New York Mets", "New York Yankees", "New York Giants", "Atlanta Braves",
+	"Atlanta Falcons", "Dallas Cowboys", "New York Mets",
+}
+
+func TestMatcherBest(t *testing.T) {
+	m, err := NewMatcher(matcherTeams, nil, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"cowboys dallas", "Dallas Cowboys"},
+		{"yankees new york", "New York Yankees"},
+		{"atlanta falcon", "Atlanta Falcons"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			best, err := m.Best(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if best.Candidate != tt.expected || best.Candidate != matcherTeams[best.Index] {
+				t.Errorf("expected %q, got %+v", tt.expected, best)
+			}
+		})
+	}
+
+	// Exact duplicates share a score and are ordered by index
+	top, err := m.TopN("new york mets", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(top) != 2 || top[0].Index != 0 || top[1].Index != 6 || !almostEqual(top[0].Score, 1) || !almostEqual(top[1].Score, 1) {
+		t.Errorf("expected both duplicates with score 1, got %+v", top)
+	}
+}
+
+func TestMatcherTopNAndThreshold(t *testing.T) {
+	jw := func(a, b string) (float64, error) { return JaroWinkler(a, b, 0.1) }
+	m, err := NewMatcher(matcherTeams, jw, MatcherOptions{Preprocess: StringOptions{CaseInsensitive: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	top, err := m.TopN("NEW YORK", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(top) != len(matcherTeams) {
+		t.Fatalf("expected %d results, got %d", len(matcherTeams), len(top))
+	}
+	for i := 1; i < len(top); i++ {
+		if top[i].Score > top[i-1].Score {
+			t.Errorf("results not sorted by score: %+v", top)
+		}
+	}
+
+	above, err := m.AboveThreshold("new york", 0.9)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(above) != 4 {
+		t.Errorf("expected the 4 New York teams, got %+v", above)
+	}
+	for _, r := range above {
+		if r.Score < 0.9 {
+			t.Errorf("score %v below threshold", r.Score)
+		}
+	}
+}
+
+func TestMatcherShortlist(t *testing.T) {
+	candidates := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		candidates = append(candidates, fmt.Sprintf("customer %04d", i))
+	}
+	candidates = append(candidates, "Acme Corporation")
+
+	m, err := NewMatcher(candidates, nil, MatcherOptions{Shortlist: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	best, err := m.Best("acme corp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if best.Candidate != "Acme Corporation" {
+		t.Errorf("expected Acme Corporation, got %+v", best)
+	}
+	top, _ := m.TopN("customer 0042", 100)
+	if len(top) != 10 || top[0].Candidate != "customer 0042" {
+		t.Errorf("expected 10 shortlisted results led by customer 0042, got %d: %+v", len(top), top[:min(len(top), 1)])
+	}
+	if _, err := m.Best("zzzzzz"); err != ErrKeyNotFound {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestMatcherErrors(t *testing.T) {
+	if _, err := NewMatcher(nil, nil, MatcherOptions{}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := NewMatcher(matcherTeams, nil, MatcherOptions{Shortlist: -1}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	m, _ := NewMatcher(matcherTeams, nil, MatcherOptions{})
+	if _, err := m.TopN("x", 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func BenchmarkMatcherBest(b *testing.B) {
+	candidates := make([]string, 0, 5000)
+	for i := 0; i < 5000; i++ {
+		candidates = append(candidates, fmt.Sprintf("product %d widget", i))
+	}
+	m, _ := NewMatcher(candidates, nil, MatcherOptions{Shortlist: 50})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = m.Best("widget product 2500")
+	}
+}
@@ -0,0 +1,200 @@
+package distance
+
+import (
+	"math"
+	"sort"
+)
+
+// MatrixProfile holds, for every length-Window subsequence of a series, the
+// z-normalized Euclidean distance to its nearest non-overlapping match
+// elsewhere in the series. Low values mark repeated patterns (motifs), high
+// values subsequences unlike anything else (discords, i.e. anomalies).
+type MatrixProfile struct {
+	Window    int       `json:"window"`
+	Distances []float64 `json:"distances"`
+	Indices   []int     `json:"indices"` // start of each nearest match, -1 if none
+}
+
+// ProfileMatch is a discord or motif found in a MatrixProfile.
+type ProfileMatch struct {
+	Index    int     `json:"index"`    // subsequence start
+	Neighbor int     `json:"neighbor"` // start of its nearest match
+	Distance float64 `json:"distance"`
+	// Score is Distance divided by its maximum 2·sqrt(Window), a value in
+	// [0, 1] comparable across window lengths
+	Score float64 `json:"score"`
+}
+
+// ComputeMatrixProfile computes the self-join matrix profile of series with
+// the STOMP algorithm, updating sliding dot products in O(1) per pair.
+// Matches starting within Window/4 of each other are trivial and excluded.
+// A constant subsequence is at distance 0 from another constant one and
+// sqrt(Window) from anything else.
+// Time: O(n²), Space: O(n)
+func ComputeMatrixProfile[T Number](series []T, window int) (*MatrixProfile, error) {
+	if len(series) == 0 {
+		return nil, ErrEmptyInput
+	}
+	n := len(series)
+	count := n - window + 1
+	exclusion := (window + 3) / 4
+	if window < 2 || count <= exclusion+1 {
+		return nil, ErrInvalidParameter
+	}
+
+	x := make([]float64, n)
+	for i, v := range series {
+		x[i] = float64(v)
+	}
+	means, stds := slidingMeanStd(x, window)
+
+	// first[j] is the dot product of subsequence 0 with subsequence j
+	first := make([]float64, count)
+	for j := range first {
+		first[j] = dotF64(x[:window], x[j:j+window])
+	}
+
+	mp := &MatrixProfile{
+		Window:    window,
+		Distances: make([]float64, count),
+		Indices:   make([]int, count),
+	}
+	for i := range mp.Distances {
+		mp.Distances[i] = math.Inf(1)
+		mp.Indices[i] = -1
+	}
+
+	qt := append([]float64(nil), first...)
+	m := float64(window)
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			for j := count - 1; j > 0; j-- {
+				qt[j] = qt[j-1] - x[i-1]*x[j-1] + x[i+window-1]*x[j+window-1]
+			}
+			qt[0] = first[i]
+		}
+		for j := 0; j < count; j++ {
+			if j-i <= exclusion && i-j <= exclusion {
+				continue
+			}
+			var d float64
+			switch {
+			case stds[i] == 0 && stds[j] == 0:
+				d = 0
+			case stds[i] == 0 || stds[j] == 0:
+				d = math.Sqrt(m)
+			default:
+				corr := (qt[j] - m*means[i]*means[j]) / (m * stds[i] * stds[j])
+				d = math.Sqrt(math.Max(0, 2*m*(1-corr)))
+			}
+			if d < mp.Distances[i] {
+				mp.Distances[i], mp.Indices[i] = d, j
+			}
+		}
+	}
+	return mp, nil
+}
+
+// Discords returns up to k subsequences farthest from their nearest match,
+// most anomalous first, keeping only those with Score at least minScore
+// (0 keeps all). Chosen discords do not overlap: each excludes the Window
+// positions either side of it.
+// Time: O(n log n + nk), Space: O(n)
+func (p *MatrixProfile) Discords(k int, minScore float64) []ProfileMatch {
+	order := p.order(func(a, b float64) bool { return a > b })
+	var found []ProfileMatch
+	var chosen []int
+	for _, i := range order {
+		if len(found) >= k {
+			break
+		}
+		match := p.match(i)
+		if match.Score < minScore {
+			break
+		}
+		if p.overlaps(chosen, i) {
+			continue
+		}
+		chosen = append(chosen, i)
+		found = append(found, match)
+	}
+	return found
+}
+
+// Motifs returns up to k pairs of closest-matching subsequences, best
+// first, keeping only those with Score at most maxScore (1 keeps all). Each
+// pair appears once, and no subsequence of a later motif overlaps either
+// member of an earlier one.
+// Time: O(n log n + nk), Space: O(n)
+func (p *MatrixProfile) Motifs(k int, maxScore float64) []ProfileMatch {
+	order := p.order(func(a, b float64) bool { return a < b })
+	var found []ProfileMatch
+	var chosen []int
+	for _, i := range order {
+		if len(found) >= k {
+			break
+		}
+		match := p.match(i)
+		if match.Score > maxScore {
+			break
+		}
+		if p.overlaps(chosen, i) || p.overlaps(chosen, match.Neighbor) {
+			continue
+		}
+		chosen = append(chosen, i, match.Neighbor)
+		found = append(found, match)
+	}
+	return found
+}
+
+// order returns the indices with a match sorted by distance using less,
+// ties broken by lower index
+func (p *MatrixProfile) order(less func(a, b float64) bool) []int {
+	order := make([]int, 0, len(p.Distances))
+	for i, j := range p.Indices {
+		if j >= 0 {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return less(p.Distances[order[a]], p.Distances[order[b]])
+	})
+	return order
+}
+
+// overlaps reports whether subsequence i lies within Window of any chosen one
+func (p *MatrixProfile) overlaps(chosen []int, i int) bool {
+	for _, c := range chosen {
+		if i-c < p.Window && c-i < p.Window {
+			return true
+		}
+	}
+	return false
+}
+
+// match describes subsequence i and its nearest neighbor as a ProfileMatch
+func (p *MatrixProfile) match(i int) ProfileMatch {
+	d := p.Distances[i]
+	return ProfileMatch{Index: i, Neighbor: p.Indices[i], Distance: d, Score: d / (2 * math.Sqrt(float64(p.Window)))}
+}
+
+// slidingMeanStd returns the mean and population standard deviation of
+// every length-window subsequence of x
+func slidingMeanStd(x []float64, window int) ([]float64, []float64) {
+	count := len(x) - window + 1
+	means := make([]float64, count)
+	stds := make([]float64, count)
+	for s := range means {
+		means[s] = mean(x[s : s+window])
+		variance := 0.0
+		for _, v := range x[s : s+window] {
+			variance += (v - means[s]) * (v - means[s])
+		}
+		variance /= float64(window)
+		// Treat rounding residue as a constant subsequence
+		if variance > 1e-24*math.Max(1, means[s]*means[s]) {
+			stds[s] = math.Sqrt(variance)
+		}
+	}
+	return means, stds
+}
@@ -0,0 +1,164 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+// zNormDistance is the z-normalized Euclidean distance between two equal-length windows
+func zNormDistance(a, b []float64) float64 {
+	za, zb := zNormalize(a), zNormalize(b)
+	d, _ := Euclidean(za, zb)
+	return d
+}
+
+func zNormalize(x []float64) []float64 {
+	m := mean(x)
+	variance := 0.0
+	for _, v := range x {
+		variance += (v - m) * (v - m)
+	}
+	std := math.Sqrt(variance / float64(len(x)))
+	z := make([]float64, len(x))
+	for i, v := range x {
+		z[i] = (v - m) / std
+	}
+	return z
+}
+
+func TestMatrixProfileMatchesBruteForce(t *testing.T) {
+	rng := testRNG(5)
+	series := make([]float64, 80)
+	for i := range series {
+		series[i] = rng.NormFloat64() + 100 // offset exercises the mean correction
+	}
+	const window = 8
+
+	mp, err := ComputeMatrixProfile(series, window)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range mp.Distances {
+		best := math.Inf(1)
+		for j := 0; j+window <= len(series); j++ {
+			if j-i > 2 || i-j > 2 {
+				best = math.Min(best, zNormDistance(series[i:i+window], series[j:j+window]))
+			}
+		}
+		if math.Abs(mp.Distances[i]-best) > 1e-6 {
+			t.Errorf("position %d: expected %v, got %v", i, best, mp.Distances[i])
+		}
+		if got := zNormDistance(series[i:i+window], series[mp.Indices[i]:mp.Indices[i]+window]); math.Abs(got-best) > 1e-6 {
+			t.Errorf("position %d: neighbor %d is at %v, not %v", i, mp.Indices[i], got, best)
+		}
+	}
+}
+
+func TestMatrixProfileDiscords(t *testing.T) {
+	series := make([]float64, 400)
+	for i := range series {
+		series[i] = math.Sin(2 * math.Pi * float64(i) / 20)
+	}
+	for i := 250; i < 255; i++ {
+		series[i] = 0.2 // flattened glitch
+	}
+
+	mp, err := ComputeMatrixProfile(series, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	discords := mp.Discords(3, 0)
+	if len(discords) == 0 || discords[0].Index < 231 || discords[0].Index > 254 {
+		t.Fatalf("expected the top discord to cover the glitch, got %+v", discords)
+	}
+	for i := 1; i < len(discords); i++ {
+		if discords[i].Distance > discords[i-1].Distance {
+			t.Errorf("discords not sorted: %+v", discords)
+		}
+		for j := 0; j < i; j++ {
+			if gap := discords[i].Index - discords[j].Index; gap > -20 && gap < 20 {
+				t.Errorf("discords %d and %d overlap", discords[i].Index, discords[j].Index)
+			}
+		}
+	}
+
+	// The clean periodic part scores near 0, so a threshold keeps only the glitch
+	strong := mp.Discords(3, 0.1)
+	if len(strong) != 1 || strong[0].Index != discords[0].Index {
+		t.Errorf("expected only the glitch above the threshold, got %+v", strong)
+	}
+	if discords[0].Score <= 0 || discords[0].Score > 1 {
+		t.Errorf("expected a score in (0, 1], got %v", discords[0].Score)
+	}
+}
+
+func TestMatrixProfileMotifs(t *testing.T) {
+	rng := testRNG(9)
+	series := make([]float64, 300)
+	for i := range series {
+		series[i] = rng.NormFloat64()
+	}
+	for i := 0; i < 16; i++ {
+		pattern := 3 * math.Sin(float64(i)/2)
+		series[50+i] = pattern
+		series[200+i] = 2*pattern + 5 // same shape at another scale and offset
+	}
+
+	mp, err := ComputeMatrixProfile(series, 16)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	motifs := mp.Motifs(2, 1)
+	if len(motifs) != 2 {
+		t.Fatalf("expected 2 motifs, got %+v", motifs)
+	}
+	top := motifs[0]
+	if min(top.Index, top.Neighbor) != 50 || max(top.Index, top.Neighbor) != 200 || top.Distance > 1e-6 {
+		t.Errorf("expected the planted pair (50, 200), got %+v", top)
+	}
+	if second := motifs[1]; second.Distance < top.Distance {
+		t.Errorf("motifs not sorted: %+v", motifs)
+	}
+	if none := mp.Motifs(2, 0); len(none) != 1 {
+		t.Errorf("expected only the exact motif at score 0, got %+v", none)
+	}
+}
+
+func TestMatrixProfileConstant(t *testing.T) {
+	series := []float64{1, 1, 1, 1, 1, 1, 1, 1, 2, 3, 1, 1, 1, 1, 1, 1}
+	mp, err := ComputeMatrixProfile(series, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mp.Distances[0] != 0 {
+		t.Errorf("expected constant windows to match at 0, got %v", mp.Distances[0])
+	}
+	for _, d := range mp.Distances {
+		if math.IsNaN(d) {
+			t.Fatalf("unexpected NaN in %v", mp.Distances)
+		}
+	}
+}
+
+func TestMatrixProfileErrors(t *testing.T) {
+	if _, err := ComputeMatrixProfile([]float64{}, 4); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := ComputeMatrixProfile([]float64{1, 2, 3}, 1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := ComputeMatrixProfile([]float64{1, 2, 3, 4, 5}, 4); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func BenchmarkMatrixProfile(b *testing.B) {
+	series := make([]float64, 2000)
+	for i := range series {
+		series[i] = math.Sin(float64(i) / 7)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ComputeMatrixProfile(series, 50)
+	}
+}
@@ -0,0 +1,165 @@
+package distance
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Built-in Metric implementations. Vector metrics accept []float64, []float32,
+// []int, []int32 and []int64 inputs; string metrics accept string inputs.
+var (
+	EuclideanMetric          Metric = vectorMetric{"euclidean", Euclidean[float64], true, true}
+	SquaredEuclideanMetric   Metric = vectorMetric{"squared-euclidean", EuclideanSquared[float64], true, false}
+	ManhattanMetric          Metric = vectorMetric{"manhattan", Manhattan[float64], true, true}
+	ChebyshevMetric          Metric = vectorMetric{"chebyshev", Chebyshev[float64], true, true}
+	CosineMetric             Metric = vectorMetric{"cosine", Cosine[float64], true, false}
+	CanberraMetric           Metric = vectorMetric{"canberra", Canberra[float64], true, true}
+	BrayCurtisMetric         Metric = vectorMetric{"bray-curtis", BrayCurtis[float64], true, false}
+	HammingMetric            Metric = vectorMetric{"hamming", Hamming[float64], true, true}
+	KLDivergenceMetric       Metric = vectorMetric{"kl-divergence", KLDivergence[float64], false, false}
+	JensenShannonMetric      Metric = vectorMetric{"jensen-shannon", JensenShannonDivergence[float64], true, false}
+	HellingerMetric          Metric = vectorMetric{"hellinger", Hellinger[float64], true, true}
+	BhattacharyyaMetric      Metric = vectorMetric{"bhattacharyya", Bhattacharyya[float64], true, false}
+	TotalVariationMetric     Metric = vectorMetric{"total-variation", TotalVariation[float64], true, true}
+	ChiSquareMetric          Metric = vectorMetric{"chi-square", ChiSquare[float64], true, false}
+	LevenshteinMetric        Metric = stringMetric{"levenshtein", Levenshtein, true}
+	DamerauLevenshteinMetric Metric = stringMetric{"damerau-levenshtein", DamerauLevenshtein, false}
+	HammingStringMetric      Metric = stringMetric{"hamming-string", HammingString, true}
+	LCSMetric                Metric = stringMetric{"lcs", LCSDistance, true}
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Metric{}
+)
+
+func init() {
+	for _, m := range []Metric{
+		EuclideanMetric, SquaredEuclideanMetric, ManhattanMetric, ChebyshevMetric,
+		CosineMetric, CanberraMetric, BrayCurtisMetric, HammingMetric,
+		KLDivergenceMetric, JensenShannonMetric, HellingerMetric, BhattacharyyaMetric,
+		TotalVariationMetric, ChiSquareMetric,
+		LevenshteinMetric, DamerauLevenshteinMetric, HammingStringMetric, LCSMetric,
+	} {
+		registry[m.Name()] = m
+	}
+}
+
+// Lookup returns the registered metric with the given name (case-insensitive).
+// Returns ErrUnknownMetric if no metric is registered under name.
+func Lookup(name string) (Metric, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	m, ok := registry[strings.ToLower(name)]
+	if !ok {
+		return nil, ErrUnknownMetric
+	}
+	return m, nil
+}
+
+// Register adds a metric to the registry so it can be selected by Lookup.
+// Returns ErrInvalidParameter if the name is empty or already registered.
+func Register(m Metric) error {
+	if m == nil || m.Name() == "" {
+		return ErrInvalidParameter
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	name := strings.ToLower(m.Name())
+	if _, exists := registry[name]; exists {
+		return ErrInvalidParameter
+	}
+	registry[name] = m
+	return nil
+}
+
+// MetricNames returns the names of all registered metrics in sorted order.
+func MetricNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// vectorMetric adapts a DistanceFunc to the Metric interface
+type vectorMetric struct {
+	name      string
+	fn        DistanceFunc[float64]
+	symmetric bool
+	metric    bool
+}
+
+func (m vectorMetric) Name() string      { return m.name }
+func (m vectorMetric) IsSymmetric() bool { return m.symmetric }
+func (m vectorMetric) IsMetric() bool    { return m.metric }
+
+func (m vectorMetric) Distance(a, b any) (float64, error) {
+	va, err := toFloat64Slice(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := toFloat64Slice(b)
+	if err != nil {
+		return 0, err
+	}
+	return m.fn(va, vb)
+}
+
+// stringMetric adapts a StringDistanceFunc to the Metric interface
+type stringMetric struct {
+	name   string
+	fn     StringDistanceFunc
+	metric bool
+}
+
+func (m stringMetric) Name() string      { return m.name }
+func (m stringMetric) IsSymmetric() bool { return true }
+func (m stringMetric) IsMetric() bool    { return m.metric }
+
+func (m stringMetric) Distance(a, b any) (float64, error) {
+	sa, ok := a.(string)
+	if !ok {
+		return 0, ErrUnsupportedType
+	}
+	sb, ok := b.(string)
+	if !ok {
+		return 0, ErrUnsupportedType
+	}
+	d, err := m.fn(sa, sb)
+	return float64(d), err
+}
+
+// toFloat64Slice converts supported numeric slice types to []float64
+func toFloat64Slice(v any) ([]float64, error) {
+	switch s := v.(type) {
+	case []float64:
+		return s, nil
+	case []float32:
+		return convertSlice(s), nil
+	case []int:
+		return convertSlice(s), nil
+	case []int32:
+		return convertSlice(s), nil
+	case []int64:
+		return convertSlice(s), nil
+	default:
+		return nil, ErrUnsupportedType
+	}
+}
+
+func convertSlice[T Number](s []T) []float64 {
+	out := make([]float64, len(s))
+	for i, x := range s {
+		out[i] = float64(x)
+	}
+	return out
+}
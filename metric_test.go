@@ -0,0 +1,95 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     any
+		expected float64
+	}{
+		{"euclidean", []float64{0, 0}, []float64{3, 4}, 5},
+		{"Euclidean", []int{0, 0}, []int{3, 4}, 5},
+		{"manhattan", []float32{0, 0}, []float32{3, 4}, 7},
+		{"cosine", []float64{1, 0}, []float64{0, 1}, 1},
+		{"total-variation", []float64{1, 0}, []float64{0, 1}, 1},
+		{"levenshtein", "kitten", "sitting", 3},
+		{"lcs", "abc", "abd", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Lookup(tt.name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			result, err := m.Distance(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLookupUnknown(t *testing.T) {
+	if _, err := Lookup("does-not-exist"); err != ErrUnknownMetric {
+		t.Errorf("expected ErrUnknownMetric, got %v", err)
+	}
+}
+
+func TestMetricProperties(t *testing.T) {
+	if KLDivergenceMetric.IsSymmetric() {
+		t.Error("KL divergence should not be symmetric")
+	}
+	if !EuclideanMetric.IsMetric() || !EuclideanMetric.IsSymmetric() {
+		t.Error("Euclidean should be a symmetric metric")
+	}
+	if CosineMetric.IsMetric() {
+		t.Error("cosine distance violates the triangle inequality")
+	}
+	if LevenshteinMetric.Name() != "levenshtein" {
+		t.Errorf("expected name levenshtein, got %s", LevenshteinMetric.Name())
+	}
+}
+
+func TestMetricUnsupportedType(t *testing.T) {
+	if _, err := EuclideanMetric.Distance("a", "b"); err != ErrUnsupportedType {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+	if _, err := LevenshteinMetric.Distance([]float64{1}, "b"); err != ErrUnsupportedType {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+	result, _ := KLDivergenceMetric.Distance([]float64{1, 0}, []float64{0, 1})
+	if !math.IsInf(result, 1) {
+		t.Errorf("expected +Inf, got %v", result)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	custom := vectorMetric{"test-custom", Euclidean[float64], true, true}
+	if err := Register(custom); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Register(custom); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter on duplicate, got %v", err)
+	}
+	if _, err := Lookup("test-custom"); err != nil {
+		t.Errorf("expected registered metric, got %v", err)
+	}
+
+	found := false
+	for _, name := range MetricNames() {
+		if name == "test-custom" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected MetricNames to include registered metric")
+	}
+}
@@ -0,0 +1,170 @@
+package distance
+
+import "math"
+
+// multilaterationIterations bounds the BFGS refinement of a position fix
+const multilaterationIterations = 200
+
+// Multilaterate estimates the point whose Euclidean distances to the given
+// anchors best match distances, minimizing Σ (‖x - aᵢ‖ - dᵢ)². A closed-form
+// linearized least-squares fix seeds BFGS refinement, so exact distances are
+// recovered exactly and noisy ones give the least-squares position.
+// At least d+1 anchors not lying in a common hyperplane are needed in d
+// dimensions; ErrInvalidParameter is returned for fewer or degenerate
+// anchors and for non-finite coordinates or distances.
+// Time: O(n·d² + iterations·(n·d + d²)), Space: O(d²)
+func Multilaterate(anchors [][]float64, distances []float64) ([]float64, error) {
+	if len(anchors) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if len(anchors) != len(distances) {
+		return nil, ErrDimensionMismatch
+	}
+	dim := len(anchors[0])
+	if dim == 0 {
+		return nil, ErrEmptyInput
+	}
+	if len(anchors) < dim+1 {
+		return nil, ErrInvalidParameter
+	}
+	for i, a := range anchors {
+		if len(a) != dim {
+			return nil, ErrDimensionMismatch
+		}
+		if distances[i] < 0 {
+			return nil, ErrNegativeValue
+		}
+		if !allFinite(a) || math.IsNaN(distances[i]) || math.IsInf(distances[i], 1) {
+			return nil, ErrInvalidParameter
+		}
+	}
+	initial, err := linearFix(anchors, distances)
+	if err != nil {
+		return nil, err
+	}
+
+	objective := func(x []float64) float64 {
+		var sum float64
+		for i, a := range anchors {
+			d, _ := EuclideanF64(x, a)
+			r := d - distances[i]
+			sum += r * r
+		}
+		return sum
+	}
+	gradient := func(x []float64) []float64 {
+		g := make([]float64, dim)
+		for i, a := range anchors {
+			d, _ := EuclideanF64(x, a)
+			if d == 0 {
+				continue
+			}
+			scale := 2 * (d - distances[i]) / d
+			for j := range g {
+				g[j] += scale * (x[j] - a[j])
+			}
+		}
+		return g
+	}
+
+	var scale float64
+	for _, d := range distances {
+		scale = math.Max(scale, d)
+	}
+	x, err := BFGS(objective, gradient, initial, multilaterationIterations, 1e-10*(1+scale))
+	if err != nil && err != ErrMaxIterations {
+		return nil, err
+	}
+	return x, nil
+}
+
+// MultilaterateGeo estimates the coordinate whose great-circle distances in
+// kilometers to the anchors best match distancesKm, on the same spherical
+// Earth as Haversine. Surface distances are converted to chords and solved in
+// Earth-centered coordinates with the Earth's center as an extra anchor at one
+// radius, so three anchors suffice. Returns ErrInvalidParameter for an
+// anchor outside latitude [-90, 90] and longitude [-180, 180], a NaN or
+// infinite distance, or anchors on a common great circle through the center.
+// Time: O(n + iterations), Space: O(n)
+func MultilaterateGeo(anchors []Coord, distancesKm []float64) (Coord, error) {
+	if len(anchors) == 0 {
+		return Coord{}, ErrEmptyInput
+	}
+	if len(anchors) != len(distancesKm) {
+		return Coord{}, ErrDimensionMismatch
+	}
+
+	points := make([][]float64, 0, len(anchors)+1)
+	chords := make([]float64, 0, len(anchors)+1)
+	for i, c := range anchors {
+		if !(c.Lat >= -90 && c.Lat <= 90 && c.Lon >= -180 && c.Lon <= 180) {
+			return Coord{}, ErrInvalidParameter
+		}
+		if distancesKm[i] < 0 {
+			return Coord{}, ErrNegativeValue
+		}
+		if math.IsNaN(distancesKm[i]) || math.IsInf(distancesKm[i], 1) {
+			return Coord{}, ErrInvalidParameter
+		}
+		lat, lon := c.Lat*degToRad, c.Lon*degToRad
+		points = append(points, []float64{
+			earthRadiusKm * math.Cos(lat) * math.Cos(lon),
+			earthRadiusKm * math.Cos(lat) * math.Sin(lon),
+			earthRadiusKm * math.Sin(lat),
+		})
+		chords = append(chords, 2*earthRadiusKm*math.Sin(math.Min(distancesKm[i]/(2*earthRadiusKm), math.Pi/2)))
+	}
+	points = append(points, []float64{0, 0, 0})
+	chords = append(chords, earthRadiusKm)
+
+	x, err := Multilaterate(points, chords)
+	if err != nil {
+		return Coord{}, err
+	}
+	r := math.Sqrt(x[0]*x[0] + x[1]*x[1] + x[2]*x[2])
+	if r == 0 {
+		return Coord{}, ErrDivergence
+	}
+	return Coord{
+		Lat: math.Asin(x[2]/r) / degToRad,
+		Lon: math.Atan2(x[1], x[0]) / degToRad,
+	}, nil
+}
+
+// linearFix solves the multilateration equations linearized against the first
+// anchor, 2(aᵢ - a₀)·x = ‖aᵢ‖² - ‖a₀‖² - dᵢ² + d₀², by least squares.
+// Returns ErrInvalidParameter when the anchors are degenerate.
+func linearFix(anchors [][]float64, distances []float64) ([]float64, error) {
+	dim := len(anchors[0])
+	ata := make([][]float64, dim)
+	for i := range ata {
+		ata[i] = make([]float64, dim)
+	}
+	atb := make([]float64, dim)
+
+	a0 := anchors[0]
+	n0 := dotF64(a0, a0)
+	for i := 1; i < len(anchors); i++ {
+		row := make([]float64, dim)
+		for j := range row {
+			row[j] = 2 * (anchors[i][j] - a0[j])
+		}
+		rhs := dotF64(anchors[i], anchors[i]) - n0 - distances[i]*distances[i] + distances[0]*distances[0]
+		for j := range row {
+			atb[j] += row[j] * rhs
+			for k := range row {
+				ata[j][k] += row[j] * row[k]
+			}
+		}
+	}
+
+	l, err := cholesky(ata)
+	if err != nil {
+		return nil, ErrInvalidParameter
+	}
+	x := choleskySolve(l, atb)
+	if !allFinite(x) {
+		return nil, ErrInvalidParameter
+	}
+	return x, nil
+}
@@ -0,0 +1,131 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMultilaterate(t *testing.T) {
+	tests := []struct {
+		name    string
+		anchors [][]float64
+		target  []float64
+	}{
+		{"2D trilateration", [][]float64{{0, 0}, {10, 0}, {0, 10}}, []float64{3, 4}},
+		{"2D overdetermined", [][]float64{{0, 0}, {10, 0}, {0, 10}, {10, 10}, {5, -5}}, []float64{7.5, 2.25}},
+		{"3D", [][]float64{{0, 0, 0}, {5, 0, 0}, {0, 5, 0}, {0, 0, 5}}, []float64{1, 2, 3}},
+		{"target outside anchors", [][]float64{{0, 0}, {1, 0}, {0, 1}}, []float64{-20, 35}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distances := make([]float64, len(tt.anchors))
+			for i, a := range tt.anchors {
+				distances[i], _ = EuclideanF64(a, tt.target)
+			}
+
+			result, err := Multilaterate(tt.anchors, distances)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d, _ := EuclideanF64(result, tt.target); d > 1e-6 {
+				t.Errorf("expected %v, got %v", tt.target, result)
+			}
+		})
+	}
+}
+
+func TestMultilaterateNoisy(t *testing.T) {
+	anchors := [][]float64{{0, 0}, {10, 0}, {0, 10}, {10, 10}}
+	target := []float64{4, 6}
+	noise := []float64{0.1, -0.05, 0.08, -0.1}
+
+	distances := make([]float64, len(anchors))
+	for i, a := range anchors {
+		d, _ := EuclideanF64(a, target)
+		distances[i] = d + noise[i]
+	}
+
+	result, err := Multilaterate(anchors, distances)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d, _ := EuclideanF64(result, target); d > 0.2 {
+		t.Errorf("expected near %v, got %v", target, result)
+	}
+
+	// The refined fix must be at least as good as the target itself
+	residual := func(x []float64) float64 {
+		var sum float64
+		for i, a := range anchors {
+			d, _ := EuclideanF64(x, a)
+			r := d - distances[i]
+			sum += r * r
+		}
+		return sum
+	}
+	if residual(result) > residual(target) {
+		t.Errorf("fit residual %v exceeds residual at target %v", residual(result), residual(target))
+	}
+}
+
+func TestMultilaterateGeo(t *testing.T) {
+	target := Coord{Lat: 48.8566, Lon: 2.3522} // Paris
+	anchors := []Coord{
+		{Lat: 51.5074, Lon: -0.1278}, // London
+		{Lat: 52.5200, Lon: 13.4050}, // Berlin
+		{Lat: 40.4168, Lon: -3.7038}, // Madrid
+	}
+	distances := make([]float64, len(anchors))
+	for i, a := range anchors {
+		distances[i] = Haversine(a, target)
+	}
+
+	result, err := MultilaterateGeo(anchors, distances)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := Haversine(result, target); d > 0.01 {
+		t.Errorf("expected %v, got %v (%v km off)", target, result, d)
+	}
+}
+
+func TestMultilaterateErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		anchors   [][]float64
+		distances []float64
+		want      error
+	}{
+		{"empty", nil, nil, ErrEmptyInput},
+		{"length mismatch", [][]float64{{0, 0}, {1, 0}, {0, 1}}, []float64{1, 1}, ErrDimensionMismatch},
+		{"too few anchors", [][]float64{{0, 0}, {1, 0}}, []float64{1, 1}, ErrInvalidParameter},
+		{"ragged anchors", [][]float64{{0, 0}, {1, 0}, {0}}, []float64{1, 1, 1}, ErrDimensionMismatch},
+		{"negative distance", [][]float64{{0, 0}, {1, 0}, {0, 1}}, []float64{1, -1, 1}, ErrNegativeValue},
+		{"collinear anchors", [][]float64{{0, 0}, {1, 0}, {2, 0}}, []float64{1, 1, 1}, ErrInvalidParameter},
+		{"collinear fractional anchors", [][]float64{{0.1, 0.2}, {0.3, 0.6}, {0.7, 1.4}, {1.1, 2.2}}, []float64{1, 1, 1, 1}, ErrInvalidParameter},
+		{"NaN distance", [][]float64{{0, 0}, {1, 0}, {0, 1}}, []float64{1, math.NaN(), 1}, ErrInvalidParameter},
+		{"infinite anchor", [][]float64{{0, 0}, {math.Inf(1), 0}, {0, 1}}, []float64{1, 1, 1}, ErrInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Multilaterate(tt.anchors, tt.distances); err != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := MultilaterateGeo([]Coord{{}, {Lat: 1}, {Lon: 1}}, []float64{1, math.Inf(-1), 1}); err != ErrNegativeValue {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+	if _, err := MultilaterateGeo([]Coord{{}, {Lat: 1}, {Lon: 1}}, []float64{1, math.NaN(), 1}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := MultilaterateGeo([]Coord{{}, {Lat: 91}, {Lon: 1}}, []float64{1, 1, 1}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := MultilaterateGeo([]Coord{{}, {Lon: 1}, {Lon: 200}}, []float64{1, 1, 1}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package distance
+
+// NewKNNGraph builds the undirected k-nearest-neighbor graph of vectors:
+// node i is vectors[i], and i and j are joined by an edge weighted by their
+// distance whenever either is among the other's k nearest neighbors. Its
+// shortest paths approximate geodesic distances along the manifold the data
+// lies on (as in Isomap), and its connected components give a simple
+// clustering. Every vector is a node even if it ends up isolated; k is
+// capped at len(vectors)-1.
+// Returns ErrEmptyInput for no vectors and ErrInvalidParameter for k <= 0.
+// Time: O(n²d + n² log k), Space: O(nk)
+func NewKNNGraph[T Number](vectors [][]T, k int, distFn DistanceFunc[T]) (*Graph, error) {
+	if len(vectors) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	neighbors, err := KNearestNeighborsWithDistances(vectors, k, distFn)
+	if err != nil {
+		return nil, err
+	}
+	return knnGraph(len(vectors), neighbors), nil
+}
+
+// NewKNNGraphMatrix is NewKNNGraph for a precomputed distance matrix.
+// Returns ErrEmptyInput for an empty matrix, ErrDimensionMismatch for a
+// non-square one and ErrInvalidParameter for k <= 0.
+// Time: O(n² log k), Space: O(nk)
+func NewKNNGraphMatrix(matrix [][]float64, k int) (*Graph, error) {
+	if err := validateSquare(matrix); err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	n := len(matrix)
+	k = min(k, n-1)
+	neighbors := make([][]Neighbor, n)
+	for i, row := range matrix {
+		neighbors[i], _ = nearestIndices(n, i, k, func(j int) (float64, error) {
+			return row[j], nil
+		})
+	}
+	return knnGraph(n, neighbors), nil
+}
+
+// NewEpsilonGraph builds the undirected ε-neighborhood graph of vectors:
+// node i is vectors[i], and i and j are joined by an edge weighted by their
+// distance whenever it is at most eps. Unlike the k-NN graph every edge has
+// the same scale, which suits density-based clustering, but sparse regions
+// may fall apart into isolated nodes.
+// Returns ErrEmptyInput for no vectors and ErrInvalidParameter for a
+// negative or NaN eps.
+// Time: O(n²d), Space: O(n + E)
+func NewEpsilonGraph[T Number](vectors [][]T, eps float64, distFn DistanceFunc[T]) (*Graph, error) {
+	if len(vectors) == 0 {
+		return nil, ErrEmptyInput
+	}
+	return epsilonGraph(len(vectors), eps, func(i, j int) (float64, error) {
+		return distFn(vectors[i], vectors[j])
+	})
+}
+
+// NewEpsilonGraphMatrix is NewEpsilonGraph for a precomputed distance
+// matrix; only the upper triangle is read.
+// Returns ErrEmptyInput for an empty matrix, ErrDimensionMismatch for a
+// non-square one and ErrInvalidParameter for a negative or NaN eps.
+// Time: O(n²), Space: O(n + E)
+func NewEpsilonGraphMatrix(matrix [][]float64, eps float64) (*Graph, error) {
+	if err := validateSquare(matrix); err != nil {
+		return nil, err
+	}
+	return epsilonGraph(len(matrix), eps, func(i, j int) (float64, error) {
+		return matrix[i][j], nil
+	})
+}
+
+// knnGraph symmetrizes per-node neighbor lists into an undirected graph
+func knnGraph(n int, neighbors [][]Neighbor) *Graph {
+	g := NewGraph()
+	for i := 0; i < n; i++ {
+		g.nodes[i] = true
+	}
+	for i, row := range neighbors {
+		for _, nb := range row {
+			g.AddUndirectedEdge(i, nb.Index, nb.Distance)
+		}
+	}
+	return g
+}
+
+// epsilonGraph joins every pair i < j whose distance is at most eps
+func epsilonGraph(n int, eps float64, dist func(i, j int) (float64, error)) (*Graph, error) {
+	if !(eps >= 0) {
+		return nil, ErrInvalidParameter
+	}
+	g := NewGraph()
+	for i := 0; i < n; i++ {
+		g.nodes[i] = true
+		for j := i + 1; j < n; j++ {
+			d, err := dist(i, j)
+			if err != nil {
+				return nil, err
+			}
+			if d <= eps {
+				g.AddUndirectedEdge(i, j, d)
+			}
+		}
+	}
+	return g, nil
+}
@@ -0,0 +1,130 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewKNNGraph(t *testing.T) {
+	// Two clusters on a line
+	points := [][]float64{{0}, {1}, {2}, {10}, {11}}
+	g, err := NewKNNGraph(points, 1, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(g.nodes) != 5 {
+		t.Errorf("expected 5 nodes, got %d", len(g.nodes))
+	}
+	// 0-1, 1-0 (or 1-2), 2-1, 3-4, 4-3: point 1 ties between 0 and 2
+	if g.adjacency[0][1] != 1 || g.adjacency[1][0] != 1 || g.adjacency[2][1] != 1 {
+		t.Errorf("expected symmetric unit edges around node 1, got %v", g.adjacency)
+	}
+	if g.adjacency[3][4] != 1 || g.adjacency[4][3] != 1 {
+		t.Errorf("expected edge 3-4, got %v", g.adjacency)
+	}
+	if components := g.ConnectedComponents(); len(components) != 2 {
+		t.Errorf("expected 2 components, got %v", components)
+	}
+
+	// Larger k bridges the clusters, and geodesic distance follows the edges
+	g, _ = NewKNNGraph(points, 3, Euclidean[float64])
+	if !g.IsConnected() {
+		t.Fatal("expected a connected graph with k=3")
+	}
+	if d, _ := g.Dijkstra(0, 4); d != 11 {
+		t.Errorf("expected geodesic distance 11, got %v", d)
+	}
+
+	matrix, _ := BatchCompute(points, Euclidean[float64])
+	fromMatrix, err := NewKNNGraphMatrix(matrix, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for from, edges := range g.adjacency {
+		for to, w := range edges {
+			if fromMatrix.adjacency[from][to] != w {
+				t.Errorf("edge %d-%d: expected %v, got %v", from, to, w, fromMatrix.adjacency[from][to])
+			}
+		}
+	}
+
+	// Equidistant candidates are broken by index in both builders
+	var grid [][]float64
+	for i := 0; i < 36; i++ {
+		grid = append(grid, []float64{float64(i % 6), float64(i / 6)})
+	}
+	g, _ = NewKNNGraph(grid, 2, Manhattan[float64])
+	matrix, _ = BatchCompute(grid, Manhattan[float64])
+	fromMatrix, _ = NewKNNGraphMatrix(matrix, 2)
+	for i := range grid {
+		if len(g.adjacency[i]) != len(fromMatrix.adjacency[i]) {
+			t.Errorf("node %d: expected edges %v, got %v", i, g.adjacency[i], fromMatrix.adjacency[i])
+		}
+		for to := range g.adjacency[i] {
+			if _, ok := fromMatrix.adjacency[i][to]; !ok {
+				t.Errorf("node %d: missing edge to %d", i, to)
+			}
+		}
+	}
+
+	// k beyond n-1 yields the complete graph
+	g, _ = NewKNNGraph(points, 10, Euclidean[float64])
+	if len(g.adjacency[0]) != 4 {
+		t.Errorf("expected complete graph, got %v", g.adjacency)
+	}
+}
+
+func TestNewEpsilonGraph(t *testing.T) {
+	points := [][]float64{{0, 0}, {1, 0}, {1, 1}, {5, 5}}
+	g, err := NewEpsilonGraph(points, 1, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(g.nodes) != 4 {
+		t.Errorf("expected isolated node kept, got %d nodes", len(g.nodes))
+	}
+	if _, ok := g.adjacency[0][2]; ok {
+		t.Error("expected no edge longer than eps")
+	}
+	if d, _ := g.Dijkstra(0, 2); d != 2 {
+		t.Errorf("expected path length 2, got %v", d)
+	}
+	if d, _ := g.Dijkstra(0, 3); !math.IsInf(d, 1) {
+		t.Errorf("expected unreachable node, got %v", d)
+	}
+
+	matrix, _ := BatchCompute(points, Euclidean[float64])
+	fromMatrix, err := NewEpsilonGraphMatrix(matrix, 1.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(fromMatrix.adjacency[2][0], math.Sqrt2) {
+		t.Errorf("expected diagonal edge, got %v", fromMatrix.adjacency[2][0])
+	}
+}
+
+func TestNeighborGraphErrors(t *testing.T) {
+	points := [][]float64{{0}, {1}}
+	if _, err := NewKNNGraph([][]float64{}, 1, Euclidean[float64]); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := NewKNNGraph(points, 0, Euclidean[float64]); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := NewKNNGraphMatrix([][]float64{{0, 1}}, 1); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := NewEpsilonGraph([][]float64{}, 1, Euclidean[float64]); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := NewEpsilonGraph(points, math.NaN(), Euclidean[float64]); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := NewEpsilonGraphMatrix([][]float64{{0, 1}, {1, 0}}, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := NewEpsilonGraph([][]float64{{0}, {1, 2}}, 1, Euclidean[float64]); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
@@ -26,18 +26,27 @@ func GradientDescent(
 	initial []float64,
 	learningRate float64,
 	iterations int,
-) []float64 {
+) ([]float64, error) {
+	if err := validateGradientRun(initial, learningRate, iterations); err != nil {
+		return nil, err
+	}
 	x := make([]float64, len(initial))
 	copy(x, initial)
 
 	for i := 0; i < iterations; i++ {
 		gradient := grad(x)
+		if err := checkGradient(gradient, len(x)); err != nil {
+			return nil, err
+		}
 		for j := range x {
 			x[j] -= learningRate * gradient[j]
 		}
+		if !allFinite(x) {
+			return nil, ErrDivergence
+		}
 	}
 
-	return x
+	return x, nil
 }
 
 // GradientDescentWithMomentum performs gradient descent with momentum
@@ -49,7 +58,13 @@ func GradientDescentWithMomentum(
 	learningRate float64,
 	momentum float64,
 	iterations int,
-) []float64 {
+) ([]float64, error) {
+	if err := validateGradientRun(initial, learningRate, iterations); err != nil {
+		return nil, err
+	}
+	if momentum < 0 || momentum >= 1 {
+		return nil, ErrInvalidParameter
+	}
 	x := make([]float64, len(initial))
 	copy(x, initial)
 
@@ -57,13 +72,19 @@ func GradientDescentWithMomentum(
 
 	for i := 0; i < iterations; i++ {
 		gradient := grad(x)
+		if err := checkGradient(gradient, len(x)); err != nil {
+			return nil, err
+		}
 		for j := range x {
 			velocity[j] = momentum*velocity[j] - learningRate*gradient[j]
 			x[j] += velocity[j]
 		}
+		if !allFinite(x) {
+			return nil, ErrDivergence
+		}
 	}
 
-	return x
+	return x, nil
 }
 
 // Adam optimizer (Adaptive Moment Estimation)
@@ -76,7 +97,13 @@ func Adam(
 	beta1, beta2 float64,
 	epsilon float64,
 	iterations int,
-) []float64 {
+) ([]float64, error) {
+	if err := validateGradientRun(initial, learningRate, iterations); err != nil {
+		return nil, err
+	}
+	if beta1 < 0 || beta1 >= 1 || beta2 < 0 || beta2 >= 1 || epsilon < 0 {
+		return nil, ErrInvalidParameter
+	}
 	x := make([]float64, len(initial))
 	copy(x, initial)
 
@@ -85,6 +112,9 @@ func Adam(
 
 	for t := 1; t <= iterations; t++ {
 		gradient := grad(x)
+		if err := checkGradient(gradient, len(x)); err != nil {
+			return nil, err
+		}
 
 		for j := range x {
 			// Update biased first moment estimate
@@ -99,12 +129,17 @@ func Adam(
 			// Update parameters
 			x[j] -= learningRate * mHat / (math.Sqrt(vHat) + epsilon)
 		}
+		if !allFinite(x) {
+			return nil, ErrDivergence
+		}
 	}
 
-	return x
+	return x, nil
 }
 
-// SimulatedAnnealing performs simulated annealing optimization
+// SimulatedAnnealing performs simulated annealing optimization with uniform
+// perturbations of up to ±stepSize and geometric cooling.
+// See SimulatedAnnealingWithOptions for custom neighbors and schedules.
 // Time: O(iterations * d), Space: O(d)
 func SimulatedAnnealing(
 	f OptimizationFunc,
@@ -113,7 +148,69 @@ func SimulatedAnnealing(
 	coolingRate float64,
 	iterations int,
 	stepSize float64,
-) []float64 {
+) ([]float64, error) {
+	if len(initial) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if coolingRate <= 0 || coolingRate > 1 || stepSize <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	return SimulatedAnnealingWithOptions(f, initial, AnnealingOptions{
+		InitialTemp: initialTemp,
+		Iterations:  iterations,
+		Neighbor:    UniformNeighbor(stepSize),
+		Schedule:    GeometricCooling(coolingRate),
+	})
+}
+
+// NeighborFunc proposes a candidate solution near x. It must return a new
+// slice of the same length and leave x unchanged.
+type NeighborFunc func(x []float64) []float64
+
+// CoolingState is the annealing state passed to a CoolingSchedule.
+type CoolingState struct {
+	Initial    float64 // Temperature at the start of the current heating cycle
+	Temp       float64 // Current temperature
+	Step       int     // Steps taken since the current cycle started
+	Acceptance float64 // Fraction of proposals accepted in the current cycle
+}
+
+// CoolingSchedule returns the temperature for the next step.
+type CoolingSchedule func(s CoolingState) float64
+
+// AnnealingOptions configures SimulatedAnnealingWithOptions. A zero
+// ReheatTemp reheats to InitialTemp, since reheating to zero temperature
+// would only freeze the search.
+type AnnealingOptions struct {
+	InitialTemp float64         // Starting temperature (must be positive)
+	Iterations  int             // Number of proposals to evaluate
+	Neighbor    NeighborFunc    // Proposal generator (required)
+	Schedule    CoolingSchedule // Cooling schedule (default GeometricCooling(0.95))
+	ReheatAfter int             // Reheat after this many steps without a new best (0 disables)
+	ReheatTemp  float64         // Temperature after reheating (0 selects InitialTemp)
+}
+
+// SimulatedAnnealingWithOptions performs simulated annealing with a custom
+// neighbor function and cooling schedule, so non-continuous encodings such as
+// permutations (see SwapNeighbor and TwoOptNeighbor) can be optimized.
+// When ReheatAfter is set, a run that stalls is restarted from the best
+// solution found at ReheatTemp, and the schedule begins a new cycle.
+// Time: O(iterations * d), Space: O(d)
+func SimulatedAnnealingWithOptions(f OptimizationFunc, initial []float64, opts AnnealingOptions) ([]float64, error) {
+	if len(initial) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if opts.Schedule == nil {
+		opts.Schedule = GeometricCooling(0.95)
+	}
+	if opts.ReheatTemp == 0 {
+		opts.ReheatTemp = opts.InitialTemp
+	}
+	if opts.InitialTemp <= 0 || opts.Iterations < 0 || opts.Neighbor == nil ||
+		opts.ReheatAfter < 0 || opts.ReheatTemp < 0 {
+		return nil, ErrInvalidParameter
+	}
+
 	current := make([]float64, len(initial))
 	copy(current, initial)
 	currentEnergy := f(current)
@@ -122,34 +219,117 @@ func SimulatedAnnealing(
 	copy(best, current)
 	bestEnergy := currentEnergy
 
-	temp := initialTemp
+	state := CoolingState{Initial: opts.InitialTemp, Temp: opts.InitialTemp}
+	accepted, stalled := 0, 0
 
-	for i := 0; i < iterations; i++ {
+	for i := 0; i < opts.Iterations; i++ {
 		// Generate neighbor solution
-		neighbor := make([]float64, len(current))
-		for j := range current {
-			neighbor[j] = current[j] + (rand.Float64()-0.5)*2*stepSize
+		neighbor := opts.Neighbor(current)
+		if len(neighbor) != len(current) {
+			return nil, ErrDimensionMismatch
 		}
 
 		neighborEnergy := f(neighbor)
 		delta := neighborEnergy - currentEnergy
 
 		// Accept or reject
-		if delta < 0 || rand.Float64() < math.Exp(-delta/temp) {
+		stalled++
+		if delta < 0 || rand.Float64() < math.Exp(-delta/state.Temp) {
 			copy(current, neighbor)
 			currentEnergy = neighborEnergy
+			accepted++
 
 			if currentEnergy < bestEnergy {
 				copy(best, current)
 				bestEnergy = currentEnergy
+				stalled = 0
 			}
 		}
 
-		// Cool down
-		temp *= coolingRate
+		// Reheat from the best solution, or cool down
+		if opts.ReheatAfter > 0 && stalled >= opts.ReheatAfter {
+			copy(current, best)
+			currentEnergy = bestEnergy
+			state = CoolingState{Initial: opts.ReheatTemp, Temp: opts.ReheatTemp}
+			accepted, stalled = 0, 0
+			continue
+		}
+		state.Step++
+		state.Acceptance = float64(accepted) / float64(state.Step)
+		state.Temp = opts.Schedule(state)
+	}
+
+	return best, nil
+}
+
+// UniformNeighbor perturbs every coordinate uniformly within ±stepSize.
+func UniformNeighbor(stepSize float64) NeighborFunc {
+	return func(x []float64) []float64 {
+		neighbor := make([]float64, len(x))
+		for j := range x {
+			neighbor[j] = x[j] + (rand.Float64()-0.5)*2*stepSize
+		}
+		return neighbor
 	}
+}
 
-	return best
+// SwapNeighbor exchanges two random positions, for permutation encodings.
+func SwapNeighbor() NeighborFunc {
+	return func(x []float64) []float64 {
+		neighbor := append([]float64{}, x...)
+		if len(x) > 1 {
+			i, j := rand.IntN(len(x)), rand.IntN(len(x)-1)
+			if j >= i {
+				j++
+			}
+			neighbor[i], neighbor[j] = neighbor[j], neighbor[i]
+		}
+		return neighbor
+	}
+}
+
+// TwoOptNeighbor reverses a random segment, the classic 2-opt move for
+// tours encoded as permutations.
+func TwoOptNeighbor() NeighborFunc {
+	return func(x []float64) []float64 {
+		neighbor := append([]float64{}, x...)
+		if len(x) > 1 {
+			i, j := rand.IntN(len(x)), rand.IntN(len(x))
+			if i > j {
+				i, j = j, i
+			}
+			for ; i < j; i, j = i+1, j-1 {
+				neighbor[i], neighbor[j] = neighbor[j], neighbor[i]
+			}
+		}
+		return neighbor
+	}
+}
+
+// GeometricCooling multiplies the temperature by rate each step.
+func GeometricCooling(rate float64) CoolingSchedule {
+	return func(s CoolingState) float64 {
+		return s.Temp * rate
+	}
+}
+
+// LogarithmicCooling sets the temperature to Initial / ln(e + step). It cools
+// far more slowly than geometric cooling, trading speed for robustness.
+func LogarithmicCooling() CoolingSchedule {
+	return func(s CoolingState) float64 {
+		return s.Initial / math.Log(math.E+float64(s.Step))
+	}
+}
+
+// AdaptiveCooling multiplies the temperature by rate while the acceptance
+// ratio is above target, and by √rate (cooling more slowly) once it falls below.
+func AdaptiveCooling(rate, target float64) CoolingSchedule {
+	return func(s CoolingState) float64 {
+		if s.Acceptance > target {
+			return s.Temp * rate
+		}
+		return s.Temp * math.Sqrt(rate)
+	}
 }
 
 // Individual represents a genetic algorithm individual
@@ -158,7 +338,8 @@ type Individual struct {
 	Fitness float64
 }
 
-// GeneticAlgorithm performs genetic algorithm optimization
+// GeneticAlgorithm performs genetic algorithm optimization over continuous genes.
+// Use MixedGeneticAlgorithm for integer or categorical genes.
 // Time: O(generations * popSize * d), Space: O(popSize * d)
 func GeneticAlgorithm(
 	f OptimizationFunc,
@@ -168,31 +349,63 @@ func GeneticAlgorithm(
 	generations int,
 	mutationRate float64,
 	crossoverRate float64,
-) []float64 {
+) ([]float64, error) {
+	genes, err := continuousGenes(dimensions, bounds)
+	if err != nil {
+		return nil, err
+	}
+	return MixedGeneticAlgorithm(f, genes, popSize, generations, mutationRate, crossoverRate)
+}
+
+// MixedGeneticAlgorithm performs genetic algorithm optimization over genes of
+// any GeneKind. Crossover is single-point; mutation resamples continuous and
+// integer genes within their range and switches categorical genes to a
+// different category. Integer and categorical genes are always passed to f as
+// whole numbers.
+// Time: O(generations * popSize * d), Space: O(popSize * d)
+func MixedGeneticAlgorithm(
+	f OptimizationFunc,
+	genes []GeneSpec,
+	popSize int,
+	generations int,
+	mutationRate float64,
+	crossoverRate float64,
+) ([]float64, error) {
+	if err := validateGenes(genes); err != nil {
+		return nil, err
+	}
+	if popSize < 2 || generations < 0 || !isProbability(mutationRate) || !isProbability(crossoverRate) {
+		return nil, ErrInvalidParameter
+	}
+	dimensions := len(genes)
+
 	// Initialize population
 	population := make([]Individual, popSize)
 	for i := range population {
-		genes := make([]float64, dimensions)
-		for j := range genes {
-			genes[j] = bounds[j][0] + rand.Float64()*(bounds[j][1]-bounds[j][0])
+		x := make([]float64, dimensions)
+		for j, g := range genes {
+			x[j] = g.random()
 		}
 		population[i] = Individual{
-			Genes:   genes,
-			Fitness: f(genes),
+			Genes:   x,
+			Fitness: f(x),
 		}
 	}
 
 	for gen := 0; gen < generations; gen++ {
-		// Selection (tournament)
+		// Selection (tournament); winners are copied so crossover
+		// cannot alter an individual selected more than once
 		newPopulation := make([]Individual, popSize)
 		for i := 0; i < popSize; i++ {
-			// Tournament selection
 			a := rand.IntN(popSize)
 			b := rand.IntN(popSize)
+			winner := population[b]
 			if population[a].Fitness < population[b].Fitness {
-				newPopulation[i] = population[a]
-			} else {
-				newPopulation[i] = population[b]
+				winner = population[a]
+			}
+			newPopulation[i] = Individual{
+				Genes:   append([]float64{}, winner.Genes...),
+				Fitness: winner.Fitness,
 			}
 		}
 
@@ -209,10 +422,9 @@ func GeneticAlgorithm(
 
 		// Mutation
 		for i := range newPopulation {
-			for j := range newPopulation[i].Genes {
+			for j, g := range genes {
 				if rand.Float64() < mutationRate {
-					newPopulation[i].Genes[j] = bounds[j][0] +
-						rand.Float64()*(bounds[j][1]-bounds[j][0])
+					newPopulation[i].Genes[j] = g.mutate(newPopulation[i].Genes[j])
 				}
 			}
 			newPopulation[i].Fitness = f(newPopulation[i].Genes)
@@ -229,7 +441,7 @@ func GeneticAlgorithm(
 		}
 	}
 
-	return best.Genes
+	return best.Genes, nil
 }
 
 // Particle represents a PSO particle
@@ -252,7 +464,14 @@ func ParticleSwarmOptimization(
 	inertia float64,
 	cognitive float64,
 	social float64,
-) []float64 {
+) ([]float64, error) {
+	if err := validateBounds(dimensions, bounds); err != nil {
+		return nil, err
+	}
+	if swarmSize < 1 || iterations < 0 {
+		return nil, ErrInvalidParameter
+	}
+
 	// Initialize swarm
 	swarm := make([]Particle, swarmSize)
 	globalBest := make([]float64, dimensions)
@@ -323,7 +542,7 @@ func ParticleSwarmOptimization(
 		}
 	}
 
-	return globalBest
+	return globalBest, nil
 }
 
 // NelderMead performs Nelder-Mead simplex optimization
@@ -333,7 +552,13 @@ func NelderMead(
 	initial []float64,
 	iterations int,
 	alpha, gamma, rho, sigma float64,
-) []float64 {
+) ([]float64, error) {
+	if len(initial) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if iterations < 0 || alpha <= 0 || gamma <= 1 || rho <= 0 || rho >= 1 || sigma <= 0 || sigma >= 1 {
+		return nil, ErrInvalidParameter
+	}
 	n := len(initial)
 
 	// Initialize simplex
@@ -440,10 +665,15 @@ func NelderMead(
 		}
 	}
 
-	return simplex[bestIdx]
+	if !allFinite(simplex[bestIdx]) || math.IsNaN(values[bestIdx]) {
+		return nil, ErrDivergence
+	}
+	return simplex[bestIdx], nil
 }
 
-// ConjugateGradient performs conjugate gradient optimization
+// ConjugateGradient performs conjugate gradient optimization.
+// If the gradient norm is still above tolerance after iterations steps, the
+// last iterate is returned together with ErrMaxIterations.
 // Time: O(iterations * d), Space: O(d)
 func ConjugateGradient(
 	f OptimizationFunc,
@@ -451,11 +681,20 @@ func ConjugateGradient(
 	initial []float64,
 	iterations int,
 	tolerance float64,
-) []float64 {
+) ([]float64, error) {
+	if err := validateIterationRun(initial, iterations); err != nil {
+		return nil, err
+	}
+	if tolerance < 0 {
+		return nil, ErrInvalidParameter
+	}
 	x := make([]float64, len(initial))
 	copy(x, initial)
 
 	g := grad(x)
+	if err := checkGradient(g, len(x)); err != nil {
+		return nil, err
+	}
 	d := make([]float64, len(g))
 	for i := range d {
 		d[i] = -g[i]
@@ -482,6 +721,12 @@ func ConjugateGradient(
 
 		// Compute new gradient
 		gNew := grad(x)
+		if err := checkGradient(gNew, len(x)); err != nil {
+			return nil, err
+		}
+		if !allFinite(x) {
+			return nil, ErrDivergence
+		}
 
 		// Check convergence
 		norm := 0.0
@@ -489,7 +734,7 @@ func ConjugateGradient(
 			norm += gNew[i] * gNew[i]
 		}
 		if math.Sqrt(norm) < tolerance {
-			break
+			return x, nil
 		}
 
 		// Compute beta (Fletcher-Reeves)
@@ -509,10 +754,12 @@ func ConjugateGradient(
 		g = gNew
 	}
 
-	return x
+	return x, ErrMaxIterations
 }
 
-// BFGS performs BFGS quasi-Newton optimization
+// BFGS performs BFGS quasi-Newton optimization.
+// If the gradient norm is still above tolerance after iterations steps, the
+// last iterate is returned together with ErrMaxIterations.
 // Time: O(iterations * d²), Space: O(d²)
 func BFGS(
 	f OptimizationFunc,
@@ -520,7 +767,13 @@ func BFGS(
 	initial []float64,
 	iterations int,
 	tolerance float64,
-) []float64 {
+) ([]float64, error) {
+	if err := validateIterationRun(initial, iterations); err != nil {
+		return nil, err
+	}
+	if tolerance < 0 {
+		return nil, ErrInvalidParameter
+	}
 	n := len(initial)
 	x := make([]float64, n)
 	copy(x, initial)
@@ -533,6 +786,9 @@ func BFGS(
 	}
 
 	g := grad(x)
+	if err := checkGradient(g, n); err != nil {
+		return nil, err
+	}
 
 	for iter := 0; iter < iterations; iter++ {
 		// Compute search direction: d = -H * g
@@ -565,6 +821,12 @@ func BFGS(
 
 		// Compute new gradient
 		gNew := grad(x)
+		if err := checkGradient(gNew, n); err != nil {
+			return nil, err
+		}
+		if !allFinite(x) {
+			return nil, ErrDivergence
+		}
 
 		// Compute gradient difference
 		y := make([]float64, n)
@@ -578,7 +840,7 @@ func BFGS(
 			norm += gNew[i] * gNew[i]
 		}
 		if math.Sqrt(norm) < tolerance {
-			break
+			return x, nil
 		}
 
 		// BFGS update: H_{k+1} = (I - rho*s*y^T) * H_k * (I - rho*y*s^T) + rho*s*s^T
@@ -640,10 +902,11 @@ func BFGS(
 		g = gNew
 	}
 
-	return x
+	return x, ErrMaxIterations
 }
 
-// DifferentialEvolution performs differential evolution
+// DifferentialEvolution performs differential evolution over continuous genes.
+// Use MixedDifferentialEvolution for integer or categorical genes.
 // Time: O(generations * popSize * d), Space: O(popSize * d)
 func DifferentialEvolution(
 	f OptimizationFunc,
@@ -653,15 +916,45 @@ func DifferentialEvolution(
 	generations int,
 	mutationFactor float64,
 	crossoverProb float64,
-) []float64 {
+) ([]float64, error) {
+	genes, err := continuousGenes(dimensions, bounds)
+	if err != nil {
+		return nil, err
+	}
+	return MixedDifferentialEvolution(f, genes, popSize, generations, mutationFactor, crossoverProb)
+}
+
+// MixedDifferentialEvolution performs DE/rand/1/bin over genes of any GeneKind.
+// Continuous genes use the usual a + F·(b - c) mutant clamped to bounds;
+// integer genes round that mutant to the nearest integer. Categorical genes
+// have no meaningful difference, so the mutant takes a's category, replaced by
+// a random one with probability min(F, 1) when b and c disagree.
+// Time: O(generations * popSize * d), Space: O(popSize * d)
+func MixedDifferentialEvolution(
+	f OptimizationFunc,
+	genes []GeneSpec,
+	popSize int,
+	generations int,
+	mutationFactor float64,
+	crossoverProb float64,
+) ([]float64, error) {
+	if err := validateGenes(genes); err != nil {
+		return nil, err
+	}
+	// Three distinct donors besides the target are required
+	if popSize < 4 || generations < 0 || mutationFactor <= 0 || !isProbability(crossoverProb) {
+		return nil, ErrInvalidParameter
+	}
+	dimensions := len(genes)
+
 	// Initialize population
 	population := make([][]float64, popSize)
 	fitness := make([]float64, popSize)
 
 	for i := range population {
 		population[i] = make([]float64, dimensions)
-		for j := range population[i] {
-			population[i][j] = bounds[j][0] + rand.Float64()*(bounds[j][1]-bounds[j][0])
+		for j, g := range genes {
+			population[i][j] = g.random()
 		}
 		fitness[i] = f(population[i])
 	}
@@ -685,21 +978,20 @@ func DifferentialEvolution(
 			trial := make([]float64, dimensions)
 			jrand := rand.IntN(dimensions)
 
-			for j := 0; j < dimensions; j++ {
-				if rand.Float64() < crossoverProb || j == jrand {
-					trial[j] = population[a][j] +
-						mutationFactor*(population[b][j]-population[c][j])
-
-					// Clamp to bounds
-					if trial[j] < bounds[j][0] {
-						trial[j] = bounds[j][0]
-					}
-					if trial[j] > bounds[j][1] {
-						trial[j] = bounds[j][1]
-					}
-				} else {
+			for j, g := range genes {
+				if rand.Float64() >= crossoverProb && j != jrand {
 					trial[j] = population[i][j]
+					continue
+				}
+				if g.Kind == GeneCategorical {
+					trial[j] = population[a][j]
+					if population[b][j] != population[c][j] && rand.Float64() < mutationFactor {
+						trial[j] = g.random()
+					}
+					continue
 				}
+				trial[j] = g.repair(population[a][j] +
+					mutationFactor*(population[b][j]-population[c][j]))
 			}
 
 			// Selection
@@ -719,5 +1011,180 @@ func DifferentialEvolution(
 		}
 	}
 
-	return population[bestIdx]
+	return population[bestIdx], nil
+}
+
+// GeneKind selects how a gene is sampled, mutated and kept in range by
+// MixedGeneticAlgorithm and MixedDifferentialEvolution.
+type GeneKind int
+
+const (
+	// GeneContinuous is a real value in [Min, Max].
+	GeneContinuous GeneKind = iota
+	// GeneInteger is a whole number in [Min, Max].
+	GeneInteger
+	// GeneCategorical is a category index in [0, Categories).
+	GeneCategorical
+)
+
+// GeneSpec describes the domain of one gene. A binary feature-selection mask
+// is a slice of CategoricalGene(2) (or IntegerGene(0, 1)) specs.
+type GeneSpec struct {
+	Kind       GeneKind
+	Min, Max   float64 // Range of continuous and integer genes
+	Categories int     // Number of categories of a categorical gene
+}
+
+// ContinuousGene returns a real-valued gene in [lo, hi].
+func ContinuousGene(lo, hi float64) GeneSpec {
+	return GeneSpec{Kind: GeneContinuous, Min: lo, Max: hi}
+}
+
+// IntegerGene returns an integer-valued gene in [lo, hi].
+func IntegerGene(lo, hi int) GeneSpec {
+	return GeneSpec{Kind: GeneInteger, Min: float64(lo), Max: float64(hi)}
+}
+
+// CategoricalGene returns a gene taking one of n unordered categories 0..n-1.
+func CategoricalGene(n int) GeneSpec {
+	return GeneSpec{Kind: GeneCategorical, Categories: n}
+}
+
+// random samples a value uniformly from the gene's domain
+func (g GeneSpec) random() float64 {
+	switch g.Kind {
+	case GeneInteger:
+		lo, hi := math.Ceil(g.Min), math.Floor(g.Max)
+		return lo + float64(rand.IntN(int(hi-lo)+1))
+	case GeneCategorical:
+		return float64(rand.IntN(g.Categories))
+	default:
+		return g.Min + rand.Float64()*(g.Max-g.Min)
+	}
+}
+
+// mutate returns a new random value for the gene; categorical genes always
+// move to a different category when one exists
+func (g GeneSpec) mutate(current float64) float64 {
+	if g.Kind != GeneCategorical || g.Categories < 2 {
+		return g.random()
+	}
+	next := rand.IntN(g.Categories - 1)
+	if next >= int(current) {
+		next++
+	}
+	return float64(next)
+}
+
+// repair clamps x to the gene's range, rounding integer genes
+func (g GeneSpec) repair(x float64) float64 {
+	lo, hi := g.Min, g.Max
+	switch g.Kind {
+	case GeneInteger:
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+		x = math.Round(x)
+	case GeneCategorical:
+		lo, hi = 0, float64(g.Categories-1)
+		x = math.Round(x)
+	}
+	return math.Max(lo, math.Min(hi, x))
+}
+
+// continuousGenes converts [min, max] bounds into continuous gene specs
+func continuousGenes(dimensions int, bounds [][]float64) ([]GeneSpec, error) {
+	if err := validateBounds(dimensions, bounds); err != nil {
+		return nil, err
+	}
+	genes := make([]GeneSpec, dimensions)
+	for j, b := range bounds {
+		genes[j] = ContinuousGene(b[0], b[1])
+	}
+	return genes, nil
+}
+
+// validateGenes checks that every gene has a non-empty, finite domain
+func validateGenes(genes []GeneSpec) error {
+	if len(genes) == 0 {
+		return ErrInvalidBounds
+	}
+	for _, g := range genes {
+		switch g.Kind {
+		case GeneContinuous:
+			if !allFinite([]float64{g.Min, g.Max}) || g.Min > g.Max {
+				return ErrInvalidBounds
+			}
+		case GeneInteger:
+			if !allFinite([]float64{g.Min, g.Max}) || math.Ceil(g.Min) > math.Floor(g.Max) {
+				return ErrInvalidBounds
+			}
+		case GeneCategorical:
+			if g.Categories < 1 {
+				return ErrInvalidBounds
+			}
+		default:
+			return ErrInvalidParameter
+		}
+	}
+	return nil
+}
+
+// validateGradientRun checks the arguments of optimizers that take a learning rate
+func validateGradientRun(initial []float64, learningRate float64, iterations int) error {
+	if err := validateIterationRun(initial, iterations); err != nil {
+		return err
+	}
+	if learningRate <= 0 || math.IsNaN(learningRate) {
+		return ErrInvalidParameter
+	}
+	return nil
+}
+
+// validateIterationRun checks the arguments shared by every iterative optimizer
+func validateIterationRun(initial []float64, iterations int) error {
+	if len(initial) == 0 {
+		return ErrEmptyInput
+	}
+	if iterations < 0 {
+		return ErrInvalidParameter
+	}
+	return nil
+}
+
+// validateBounds checks that bounds holds a finite [min, max] pair per dimension
+func validateBounds(dimensions int, bounds [][]float64) error {
+	if dimensions <= 0 || len(bounds) != dimensions {
+		return ErrInvalidBounds
+	}
+	for _, b := range bounds {
+		if len(b) != 2 || !allFinite(b) || b[0] > b[1] {
+			return ErrInvalidBounds
+		}
+	}
+	return nil
+}
+
+// checkGradient rejects gradients of the wrong size or with non-finite entries
+func checkGradient(g []float64, n int) error {
+	if len(g) != n {
+		return ErrDimensionMismatch
+	}
+	if !allFinite(g) {
+		return ErrDivergence
+	}
+	return nil
+}
+
+// allFinite reports whether every element of x is neither NaN nor ±Inf
+func allFinite(x []float64) bool {
+	for _, v := range x {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// isProbability reports whether p lies in [0, 1]
+func isProbability(p float64) bool {
+	return p >= 0 && p <= 1
 }
@@ -46,7 +46,10 @@ func rosenbrockGrad(x []float64) []float64 {
 
 func TestGradientDescent(t *testing.T) {
 	initial := []float64{5.0, 5.0}
-	result := GradientDescent(quadratic, quadraticGrad, initial, 0.1, 100)
+	result, err := GradientDescent(quadratic, quadraticGrad, initial, 0.1, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Should be close to [0, 0]
 	if math.Abs(result[0]) > 0.1 || math.Abs(result[1]) > 0.1 {
@@ -56,9 +59,12 @@ func TestGradientDescent(t *testing.T) {
 
 func TestGradientDescentWithMomentum(t *testing.T) {
 	initial := []float64{5.0, 5.0}
-	result := GradientDescentWithMomentum(
+	result, err := GradientDescentWithMomentum(
 		quadratic, quadraticGrad, initial, 0.01, 0.9, 100,
 	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Should be close to [0, 0]
 	if math.Abs(result[0]) > 0.1 || math.Abs(result[1]) > 0.1 {
@@ -68,13 +74,16 @@ func TestGradientDescentWithMomentum(t *testing.T) {
 
 func TestAdam(t *testing.T) {
 	initial := []float64{5.0, 5.0}
-	result := Adam(
+	result, err := Adam(
 		quadratic, quadraticGrad, initial,
 		0.1,        // learning rate
 		0.9, 0.999, // beta1, beta2
 		1e-8, // epsilon
 		100,  // iterations
 	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Should be close to [0, 0]
 	if math.Abs(result[0]) > 0.1 || math.Abs(result[1]) > 0.1 {
@@ -84,7 +93,7 @@ func TestAdam(t *testing.T) {
 
 func TestSimulatedAnnealing(t *testing.T) {
 	initial := []float64{5.0, 5.0}
-	result := SimulatedAnnealing(
+	result, err := SimulatedAnnealing(
 		quadratic,
 		initial,
 		100.0, // initial temperature
@@ -92,6 +101,9 @@ func TestSimulatedAnnealing(t *testing.T) {
 		1000,  // iterations
 		1.0,   // step size
 	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Should be reasonably close to [0, 0]
 	if math.Abs(result[0]) > 1.0 || math.Abs(result[1]) > 1.0 {
@@ -99,13 +111,135 @@ func TestSimulatedAnnealing(t *testing.T) {
 	}
 }
 
+func TestSimulatedAnnealingTSP(t *testing.T) {
+	// Cities on a unit circle: the optimal tour visits them in angular order
+	const cities = 10
+	xs, ys := make([]float64, cities), make([]float64, cities)
+	for i := range xs {
+		xs[i] = math.Cos(2 * math.Pi * float64(i) / cities)
+		ys[i] = math.Sin(2 * math.Pi * float64(i) / cities)
+	}
+	tourLength := func(tour []float64) float64 {
+		var length float64
+		for i := range tour {
+			a, b := int(tour[i]), int(tour[(i+1)%len(tour)])
+			length += math.Hypot(xs[a]-xs[b], ys[a]-ys[b])
+		}
+		return length
+	}
+
+	initial := []float64{0, 5, 2, 7, 4, 9, 6, 1, 8, 3}
+	result, err := SimulatedAnnealingWithOptions(tourLength, initial, AnnealingOptions{
+		InitialTemp: 1,
+		Iterations:  5000,
+		Neighbor:    TwoOptNeighbor(),
+		Schedule:    GeometricCooling(0.999),
+		ReheatAfter: 1000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	optimal := cities * 2 * math.Sin(math.Pi/cities)
+	if got := tourLength(result); math.Abs(got-optimal) > 1e-9 {
+		t.Errorf("expected tour length %v, got %v (tour %v)", optimal, got, result)
+	}
+	seen := make(map[float64]bool)
+	for _, c := range result {
+		seen[c] = true
+	}
+	if len(seen) != cities {
+		t.Errorf("result is not a permutation: %v", result)
+	}
+}
+
+func TestPermutationNeighbors(t *testing.T) {
+	x := []float64{0, 1, 2, 3, 4, 5}
+	for name, neighbor := range map[string]NeighborFunc{"swap": SwapNeighbor(), "2-opt": TwoOptNeighbor()} {
+		for range 50 {
+			y := neighbor(x)
+			sum := 0.0
+			for _, v := range y {
+				sum += v
+			}
+			if len(y) != len(x) || sum != 15 {
+				t.Fatalf("%s: expected a permutation, got %v", name, y)
+			}
+		}
+		if x[0] != 0 || x[5] != 5 {
+			t.Fatalf("%s: input was modified: %v", name, x)
+		}
+	}
+}
+
+func TestCoolingSchedules(t *testing.T) {
+	s := CoolingState{Initial: 10, Temp: 4, Step: 3, Acceptance: 0.2}
+
+	if got := GeometricCooling(0.5)(s); !almostEqual(got, 2) {
+		t.Errorf("geometric: expected 2, got %v", got)
+	}
+	if got := LogarithmicCooling()(s); !almostEqual(got, 10/math.Log(math.E+3)) {
+		t.Errorf("logarithmic: expected %v, got %v", 10/math.Log(math.E+3), got)
+	}
+	if got := LogarithmicCooling()(CoolingState{Initial: 10}); !almostEqual(got, 10) {
+		t.Errorf("logarithmic at step 0: expected 10, got %v", got)
+	}
+	if got := AdaptiveCooling(0.81, 0.1)(s); !almostEqual(got, 4*0.81) {
+		t.Errorf("adaptive above target: expected %v, got %v", 4*0.81, got)
+	}
+	if got := AdaptiveCooling(0.81, 0.5)(s); !almostEqual(got, 4*0.9) {
+		t.Errorf("adaptive below target: expected %v, got %v", 4*0.9, got)
+	}
+}
+
+func TestSimulatedAnnealingReheat(t *testing.T) {
+	// A flat objective never improves, so every ReheatAfter steps restart the cycle
+	maxStep, cycles := 0, 0
+	schedule := func(s CoolingState) float64 {
+		maxStep = max(maxStep, s.Step)
+		if s.Step == 1 {
+			cycles++
+			if s.Initial != 7 && cycles > 1 {
+				t.Errorf("expected reheat temperature 7, got %v", s.Initial)
+			}
+		}
+		return s.Temp * 0.5
+	}
+	flat := func([]float64) float64 { return 1 }
+
+	_, err := SimulatedAnnealingWithOptions(flat, []float64{0}, AnnealingOptions{
+		InitialTemp: 1,
+		Iterations:  100,
+		Neighbor:    UniformNeighbor(1),
+		Schedule:    schedule,
+		ReheatAfter: 10,
+		ReheatTemp:  7,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxStep != 9 || cycles != 10 {
+		t.Errorf("expected 10 cycles of 9 cooling steps, got %d cycles, max step %d", cycles, maxStep)
+	}
+}
+
+func TestSimulatedAnnealingOptionsErrors(t *testing.T) {
+	if _, err := SimulatedAnnealingWithOptions(quadratic, []float64{1}, AnnealingOptions{InitialTemp: 1, Iterations: 10}); err != ErrInvalidParameter {
+		t.Errorf("missing neighbor: expected ErrInvalidParameter, got %v", err)
+	}
+	shrink := func([]float64) []float64 { return nil }
+	if _, err := SimulatedAnnealingWithOptions(quadratic, []float64{1}, AnnealingOptions{InitialTemp: 1, Iterations: 10, Neighbor: shrink}); err != ErrDimensionMismatch {
+		t.Errorf("bad neighbor: expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
 func TestGeneticAlgorithm(t *testing.T) {
 	bounds := [][]float64{
 		{-10, 10},
 		{-10, 10},
 	}
 
-	result := GeneticAlgorithm(
+	result, err := GeneticAlgorithm(
 		quadratic,
 		2,      // dimensions
 		bounds, // bounds
@@ -114,6 +248,9 @@ func TestGeneticAlgorithm(t *testing.T) {
 		0.1,    // mutation rate
 		0.7,    // crossover rate
 	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Should be reasonably close to [0, 0] (relaxed for stochastic algorithm)
 	distance := math.Sqrt(result[0]*result[0] + result[1]*result[1])
@@ -128,7 +265,7 @@ func TestParticleSwarmOptimization(t *testing.T) {
 		{-10, 10},
 	}
 
-	result := ParticleSwarmOptimization(
+	result, err := ParticleSwarmOptimization(
 		quadratic,
 		2,      // dimensions
 		bounds, // bounds
@@ -138,6 +275,9 @@ func TestParticleSwarmOptimization(t *testing.T) {
 		1.5,    // cognitive
 		1.5,    // social
 	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Should be reasonably close to [0, 0]
 	if math.Abs(result[0]) > 0.5 || math.Abs(result[1]) > 0.5 {
@@ -147,12 +287,15 @@ func TestParticleSwarmOptimization(t *testing.T) {
 
 func TestNelderMead(t *testing.T) {
 	initial := []float64{5.0, 5.0}
-	result := NelderMead(
+	result, err := NelderMead(
 		quadratic,
 		initial,
 		100,                // iterations
 		1.0, 2.0, 0.5, 0.5, // alpha, gamma, rho, sigma
 	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Should be close to [0, 0]
 	if math.Abs(result[0]) > 0.1 || math.Abs(result[1]) > 0.1 {
@@ -162,13 +305,16 @@ func TestNelderMead(t *testing.T) {
 
 func TestConjugateGradient(t *testing.T) {
 	initial := []float64{5.0, 5.0}
-	result := ConjugateGradient(
+	result, err := ConjugateGradient(
 		quadratic,
 		quadraticGrad,
 		initial,
 		100,  // iterations
 		1e-6, // tolerance
 	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Should be close to [0, 0]
 	if math.Abs(result[0]) > 0.1 || math.Abs(result[1]) > 0.1 {
@@ -178,13 +324,16 @@ func TestConjugateGradient(t *testing.T) {
 
 func TestBFGS(t *testing.T) {
 	initial := []float64{5.0, 5.0}
-	result := BFGS(
+	result, err := BFGS(
 		quadratic,
 		quadraticGrad,
 		initial,
 		100,  // iterations
 		1e-6, // tolerance
 	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Should be close to [0, 0]
 	if math.Abs(result[0]) > 0.1 || math.Abs(result[1]) > 0.1 {
@@ -198,7 +347,7 @@ func TestDifferentialEvolution(t *testing.T) {
 		{-10, 10},
 	}
 
-	result := DifferentialEvolution(
+	result, err := DifferentialEvolution(
 		quadratic,
 		2,      // dimensions
 		bounds, // bounds
@@ -207,6 +356,9 @@ func TestDifferentialEvolution(t *testing.T) {
 		0.8,    // mutation factor
 		0.7,    // crossover probability
 	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Should be reasonably close to [0, 0]
 	if math.Abs(result[0]) > 0.5 || math.Abs(result[1]) > 0.5 {
@@ -224,13 +376,16 @@ func TestRosenbrock(t *testing.T) {
 
 	// Test optimization on Rosenbrock (harder problem)
 	initial := []float64{0.0, 0.0}
-	result := BFGS(
+	result, err := BFGS(
 		rosenbrock,
 		rosenbrockGrad,
 		initial,
 		500,  // more iterations for harder problem
 		1e-4, // relaxed tolerance
 	)
+	if err != nil && err != ErrMaxIterations {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
 	// Minimum is at (1, 1)
 	if math.Abs(result[0]-1.0) > 0.2 || math.Abs(result[1]-1.0) > 0.2 {
@@ -243,26 +398,29 @@ func TestOptimizationComparison(t *testing.T) {
 	// Compare multiple algorithms on the same problem
 	initial := []float64{5.0, 5.0}
 
-	algorithms := map[string]func() []float64{
-		"GradientDescent": func() []float64 {
+	algorithms := map[string]func() ([]float64, error){
+		"GradientDescent": func() ([]float64, error) {
 			return GradientDescent(quadratic, quadraticGrad, initial, 0.1, 100)
 		},
-		"Adam": func() []float64 {
+		"Adam": func() ([]float64, error) {
 			return Adam(quadratic, quadraticGrad, initial, 0.1, 0.9, 0.999, 1e-8, 100)
 		},
-		"ConjugateGradient": func() []float64 {
+		"ConjugateGradient": func() ([]float64, error) {
 			return ConjugateGradient(quadratic, quadraticGrad, initial, 100, 1e-6)
 		},
-		"BFGS": func() []float64 {
+		"BFGS": func() ([]float64, error) {
 			return BFGS(quadratic, quadraticGrad, initial, 100, 1e-6)
 		},
-		"NelderMead": func() []float64 {
+		"NelderMead": func() ([]float64, error) {
 			return NelderMead(quadratic, initial, 100, 1.0, 2.0, 0.5, 0.5)
 		},
 	}
 
 	for name, algo := range algorithms {
-		result := algo()
+		result, err := algo()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
 		distance := math.Sqrt(result[0]*result[0] + result[1]*result[1])
 		t.Logf("%s: result=%v, distance from origin=%.6f", name, result, distance)
 
@@ -271,3 +429,236 @@ func TestOptimizationComparison(t *testing.T) {
 		}
 	}
 }
+
+func TestOptimizerInvalidBounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		dims   int
+		bounds [][]float64
+	}{
+		{"too few bounds", 3, [][]float64{{-1, 1}, {-1, 1}}},
+		{"short pair", 2, [][]float64{{-1, 1}, {-1}}},
+		{"min above max", 2, [][]float64{{-1, 1}, {2, 1}}},
+		{"non-finite", 2, [][]float64{{-1, 1}, {math.Inf(-1), 1}}},
+		{"zero dimensions", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GeneticAlgorithm(quadratic, tt.dims, tt.bounds, 10, 5, 0.1, 0.7); err != ErrInvalidBounds {
+				t.Errorf("GeneticAlgorithm: expected ErrInvalidBounds, got %v", err)
+			}
+			if _, err := ParticleSwarmOptimization(quadratic, tt.dims, tt.bounds, 10, 5, 0.7, 1.5, 1.5); err != ErrInvalidBounds {
+				t.Errorf("ParticleSwarmOptimization: expected ErrInvalidBounds, got %v", err)
+			}
+			if _, err := DifferentialEvolution(quadratic, tt.dims, tt.bounds, 10, 5, 0.8, 0.7); err != ErrInvalidBounds {
+				t.Errorf("DifferentialEvolution: expected ErrInvalidBounds, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOptimizerInvalidParameters(t *testing.T) {
+	bounds := [][]float64{{-1, 1}, {-1, 1}}
+	initial := []float64{1, 1}
+
+	tests := []struct {
+		name string
+		run  func() ([]float64, error)
+		want error
+	}{
+		{"GradientDescent empty", func() ([]float64, error) {
+			return GradientDescent(quadratic, quadraticGrad, nil, 0.1, 10)
+		}, ErrEmptyInput},
+		{"GradientDescent zero rate", func() ([]float64, error) {
+			return GradientDescent(quadratic, quadraticGrad, initial, 0, 10)
+		}, ErrInvalidParameter},
+		{"Momentum out of range", func() ([]float64, error) {
+			return GradientDescentWithMomentum(quadratic, quadraticGrad, initial, 0.1, 1, 10)
+		}, ErrInvalidParameter},
+		{"Adam bad beta", func() ([]float64, error) {
+			return Adam(quadratic, quadraticGrad, initial, 0.1, 1.5, 0.999, 1e-8, 10)
+		}, ErrInvalidParameter},
+		{"SimulatedAnnealing zero temperature", func() ([]float64, error) {
+			return SimulatedAnnealing(quadratic, initial, 0, 0.9, 10, 0.1)
+		}, ErrInvalidParameter},
+		{"SimulatedAnnealing empty before bad step", func() ([]float64, error) {
+			return SimulatedAnnealing(quadratic, nil, 1, 0.9, 10, 0)
+		}, ErrEmptyInput},
+		{"GeneticAlgorithm tiny population", func() ([]float64, error) {
+			return GeneticAlgorithm(quadratic, 2, bounds, 1, 10, 0.1, 0.7)
+		}, ErrInvalidParameter},
+		{"DifferentialEvolution tiny population", func() ([]float64, error) {
+			return DifferentialEvolution(quadratic, 2, bounds, 3, 10, 0.8, 0.7)
+		}, ErrInvalidParameter},
+		{"NelderMead bad contraction", func() ([]float64, error) {
+			return NelderMead(quadratic, initial, 10, 1, 2, 1.5, 0.5)
+		}, ErrInvalidParameter},
+		{"BFGS negative iterations", func() ([]float64, error) {
+			return BFGS(quadratic, quadraticGrad, initial, -1, 1e-6)
+		}, ErrInvalidParameter},
+		{"BFGS gradient size", func() ([]float64, error) {
+			return BFGS(quadratic, func([]float64) []float64 { return []float64{1} }, initial, 10, 1e-6)
+		}, ErrDimensionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.run()
+			if err != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if result != nil {
+				t.Errorf("expected nil result, got %v", result)
+			}
+		})
+	}
+}
+
+func TestOptimizerDivergence(t *testing.T) {
+	initial := []float64{5.0, 5.0}
+
+	// A learning rate this large overshoots further on every step
+	if _, err := GradientDescent(quadratic, quadraticGrad, initial, 10, 1000); err != ErrDivergence {
+		t.Errorf("GradientDescent: expected ErrDivergence, got %v", err)
+	}
+	if _, err := GradientDescentWithMomentum(quadratic, quadraticGrad, initial, 10, 0.9, 1000); err != ErrDivergence {
+		t.Errorf("GradientDescentWithMomentum: expected ErrDivergence, got %v", err)
+	}
+
+	nanGrad := func(x []float64) []float64 { return []float64{math.NaN(), 0} }
+	if _, err := Adam(quadratic, nanGrad, initial, 0.1, 0.9, 0.999, 1e-8, 10); err != ErrDivergence {
+		t.Errorf("Adam: expected ErrDivergence, got %v", err)
+	}
+	if _, err := ConjugateGradient(quadratic, nanGrad, initial, 10, 1e-6); err != ErrDivergence {
+		t.Errorf("ConjugateGradient: expected ErrDivergence, got %v", err)
+	}
+}
+
+func TestOptimizerMaxIterations(t *testing.T) {
+	initial := []float64{0.0, 0.0}
+
+	result, err := BFGS(rosenbrock, rosenbrockGrad, initial, 2, 1e-12)
+	if err != ErrMaxIterations {
+		t.Fatalf("expected ErrMaxIterations, got %v", err)
+	}
+	if len(result) != 2 || !allFinite(result) {
+		t.Errorf("expected the last finite iterate, got %v", result)
+	}
+
+	result, err = ConjugateGradient(rosenbrock, rosenbrockGrad, initial, 2, 1e-12)
+	if err != ErrMaxIterations {
+		t.Fatalf("expected ErrMaxIterations, got %v", err)
+	}
+	if len(result) != 2 || !allFinite(result) {
+		t.Errorf("expected the last finite iterate, got %v", result)
+	}
+}
+
+func TestMixedGeneticAlgorithm(t *testing.T) {
+	// Feature selection: recover a target mask, with an integer and a
+	// continuous parameter alongside it
+	target := []float64{1, 0, 1, 1, 0, 0, 1, 0}
+	genes := make([]GeneSpec, 0, len(target)+2)
+	for range target {
+		genes = append(genes, CategoricalGene(2))
+	}
+	genes = append(genes, IntegerGene(-10, 10), ContinuousGene(-5, 5))
+
+	objective := func(x []float64) float64 {
+		var cost float64
+		for j, want := range target {
+			if x[j] != want {
+				cost++
+			}
+		}
+		k, c := x[len(target)], x[len(target)+1]
+		return cost + (k-3.4)*(k-3.4) + c*c
+	}
+
+	result, err := MixedGeneticAlgorithm(objective, genes, 60, 150, 0.05, 0.8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for j, want := range target {
+		if result[j] != want {
+			t.Errorf("gene %d: expected %v, got %v", j, want, result[j])
+		}
+	}
+	if result[len(target)] != 3 {
+		t.Errorf("expected integer gene 3, got %v", result[len(target)])
+	}
+	if math.Abs(result[len(target)+1]) > 1 {
+		t.Errorf("expected continuous gene near 0, got %v", result[len(target)+1])
+	}
+}
+
+func TestMixedDifferentialEvolution(t *testing.T) {
+	// Pick one of four categories (2 is best) and an integer near 7
+	genes := []GeneSpec{CategoricalGene(4), IntegerGene(0, 20), ContinuousGene(-3, 3)}
+	categoryCost := []float64{3, 1, 0, 2}
+	objective := func(x []float64) float64 {
+		k := x[1] - 7
+		return categoryCost[int(x[0])] + k*k + x[2]*x[2]
+	}
+
+	result, err := MixedDifferentialEvolution(objective, genes, 30, 100, 0.8, 0.7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result[0] != 2 || result[1] != 7 {
+		t.Errorf("expected category 2 and integer 7, got %v", result)
+	}
+	if math.Abs(result[2]) > 0.5 {
+		t.Errorf("expected continuous gene near 0, got %v", result[2])
+	}
+}
+
+func TestMixedGenesStayInDomain(t *testing.T) {
+	genes := []GeneSpec{IntegerGene(-2, 2), CategoricalGene(3), ContinuousGene(1, 2)}
+	check := func(x []float64) float64 {
+		if x[0] != math.Round(x[0]) || x[0] < -2 || x[0] > 2 {
+			t.Fatalf("integer gene out of domain: %v", x[0])
+		}
+		if x[1] != math.Round(x[1]) || x[1] < 0 || x[1] > 2 {
+			t.Fatalf("categorical gene out of domain: %v", x[1])
+		}
+		if x[2] < 1 || x[2] > 2 {
+			t.Fatalf("continuous gene out of domain: %v", x[2])
+		}
+		return x[0] + x[1] + x[2]
+	}
+
+	if _, err := MixedGeneticAlgorithm(check, genes, 20, 30, 0.3, 0.9); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A large mutation factor pushes integer mutants past the bounds
+	if _, err := MixedDifferentialEvolution(check, genes, 20, 30, 2.5, 0.9); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMixedGenesValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		genes []GeneSpec
+		want  error
+	}{
+		{"no genes", nil, ErrInvalidBounds},
+		{"empty integer range", []GeneSpec{{Kind: GeneInteger, Min: 0.2, Max: 0.8}}, ErrInvalidBounds},
+		{"no categories", []GeneSpec{CategoricalGene(0)}, ErrInvalidBounds},
+		{"inverted range", []GeneSpec{ContinuousGene(1, -1)}, ErrInvalidBounds},
+		{"unknown kind", []GeneSpec{{Kind: GeneKind(9)}}, ErrInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MixedGeneticAlgorithm(quadratic, tt.genes, 10, 5, 0.1, 0.7); err != tt.want {
+				t.Errorf("MixedGeneticAlgorithm: expected %v, got %v", tt.want, err)
+			}
+			if _, err := MixedDifferentialEvolution(quadratic, tt.genes, 10, 5, 0.8, 0.7); err != tt.want {
+				t.Errorf("MixedDifferentialEvolution: expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
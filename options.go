@@ -0,0 +1,131 @@
+package distance
+
+import (
+	"math"
+	"runtime"
+)
+
+// Compute applies metric to a and b honoring opts.
+// Vectors are converted to float64 and each dimension is scaled by
+// √opts.Weights[i] (when set) before metric is applied, so weights act on
+// squared differences: Compute with Euclidean and Weights w equals
+// WeightedEuclidean with w.
+// Unweighted float64 vectors are passed to metric without copying.
+// If opts.MaxDistance > 0 and the distance exceeds it, +Inf is returned so
+// pruned pairs compare greater than any real distance. With opts.Bounded set
+// the distance is computed by opts.Bounded instead of metric, which stops as
+// soon as the bound is exceeded; otherwise the finished distance is filtered.
+// If opts.Normalize is set, the distance d is mapped to d/(1+d) in [0,1], and
+// pruned pairs to 1.
+// Time: O(n) plus metric cost, Space: O(n) with weights or non-float64
+// vectors, O(1) otherwise
+func Compute[T Number](a, b []T, metric DistanceFunc[float64], opts Options) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+	if err := validateOptions(a, opts); err != nil {
+		return 0, err
+	}
+
+	return optionsMetric(metric, opts)(scaleVector(a, opts.Weights), scaleVector(b, opts.Weights))
+}
+
+// BatchComputeWithOptions computes a distance matrix honoring opts.
+// Weights, MaxDistance and Normalize behave as in Compute; opts.Parallel
+// distributes work across runtime.NumCPU() workers.
+// Time: O(n²d), Space: O(n²+nd)
+func BatchComputeWithOptions[T Number](vectors [][]T, metric DistanceFunc[float64], opts Options) ([][]float64, error) {
+	if len(vectors) == 0 {
+		return [][]float64{}, nil
+	}
+
+	scaled, err := scaleVectors(vectors, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	distFn := optionsMetric(metric, opts)
+	if opts.Parallel {
+		return BatchComputeParallel(scaled, distFn, runtime.NumCPU())
+	}
+	return BatchCompute(scaled, distFn)
+}
+
+// ComputeToPointWithOptions computes distances from all vectors to point honoring opts.
+// Time: O(nd), Space: O(nd)
+func ComputeToPointWithOptions[T Number](vectors [][]T, point []T, metric DistanceFunc[float64], opts Options) ([]float64, error) {
+	if len(vectors) == 0 {
+		return []float64{}, nil
+	}
+
+	scaled, err := scaleVectors(vectors, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateOptions(point, opts); err != nil {
+		return nil, err
+	}
+
+	return ComputeToPoint(scaled, scaleVector(point, opts.Weights), optionsMetric(metric, opts))
+}
+
+// validateOptions checks option values against a vector
+func validateOptions[T Number](v []T, opts Options) error {
+	if opts.MaxDistance < 0 {
+		return ErrInvalidParameter
+	}
+	return ValidateWeights(v, opts.Weights)
+}
+
+// optionsMetric wraps metric with MaxDistance pruning and normalization
+func optionsMetric(metric DistanceFunc[float64], opts Options) DistanceFunc[float64] {
+	if opts.MaxDistance > 0 && opts.Bounded != nil {
+		metric = func(a, b []float64) (float64, error) {
+			return opts.Bounded(a, b, opts.MaxDistance)
+		}
+	}
+	return func(a, b []float64) (float64, error) {
+		dist, err := metric(a, b)
+		if err != nil {
+			return 0, err
+		}
+		if opts.MaxDistance > 0 && dist > opts.MaxDistance {
+			dist = math.Inf(1)
+		}
+		if opts.Normalize {
+			if math.IsInf(dist, 1) {
+				return 1, nil
+			}
+			return dist / (1 + dist), nil
+		}
+		return dist, nil
+	}
+}
+
+// scaleVectors converts vectors to float64 and applies weights once up front
+func scaleVectors[T Number](vectors [][]T, opts Options) ([][]float64, error) {
+	scaled := make([][]float64, len(vectors))
+	for i, v := range vectors {
+		if err := validateOptions(v, opts); err != nil {
+			return nil, err
+		}
+		scaled[i] = scaleVector(v, opts.Weights)
+	}
+	return scaled, nil
+}
+
+// scaleVector converts v to float64, multiplying by the square roots of
+// weights when present; unweighted float64 vectors are returned as is
+func scaleVector[T Number](v []T, weights []float64) []float64 {
+	if f, ok := any(v).([]float64); ok && len(weights) == 0 {
+		return f
+	}
+	out := make([]float64, len(v))
+	for i, x := range v {
+		out[i] = float64(x)
+		if len(weights) > 0 {
+			out[i] *= math.Sqrt(weights[i])
+		}
+	}
+	return out
+}
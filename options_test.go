@@ -0,0 +1,161 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	a := []int{0, 0}
+	b := []int{3, 4}
+
+	tests := []struct {
+		name     string
+		opts     Options
+		expected float64
+	}{
+		{"no options", Options{}, 5},
+		{"weights", Options{Weights: []float64{2, 0}}, math.Sqrt(18)},
+		{"normalize", Options{Normalize: true}, 5.0 / 6.0},
+		{"within max distance", Options{MaxDistance: 5}, 5},
+		{"exceeds max distance", Options{MaxDistance: 4}, math.Inf(1)},
+		{"normalize pruned", Options{MaxDistance: 4, Normalize: true}, 1},
+		{"bounded within", Options{MaxDistance: 5, Bounded: EuclideanBounded[float64]}, 5},
+		{"bounded exceeds", Options{MaxDistance: 4, Bounded: EuclideanBounded[float64]}, math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Compute(a, b, Euclidean[float64], tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.IsInf(tt.expected, 1) {
+				if !math.IsInf(result, 1) {
+					t.Errorf("expected +Inf, got %v", result)
+				}
+				return
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestComputeErrors(t *testing.T) {
+	a := []float64{1, 2}
+	b := []float64{3, 4}
+
+	if _, err := Compute(a, b, Euclidean[float64], Options{Weights: []float64{1}}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := Compute(a, b, Euclidean[float64], Options{Weights: []float64{1, -1}}); err != ErrNegativeValue {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+	if _, err := Compute(a, b, Euclidean[float64], Options{MaxDistance: -1}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := Compute(a, []float64{1}, Euclidean[float64], Options{}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestBatchComputeWithOptions(t *testing.T) {
+	vectors := [][]float64{
+		{0, 0},
+		{3, 4},
+		{6, 8},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		result, err := BatchComputeWithOptions(vectors, Euclidean[float64], Options{
+			Parallel:    parallel,
+			MaxDistance: 6,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !almostEqual(result[0][1], 5) || !almostEqual(result[1][2], 5) {
+			t.Errorf("parallel=%v: expected neighbors at 5, got %v", parallel, result)
+		}
+		if !math.IsInf(result[0][2], 1) || !math.IsInf(result[2][0], 1) {
+			t.Errorf("parallel=%v: expected pruned pair to be +Inf, got %v", parallel, result[0][2])
+		}
+	}
+}
+
+func TestComputeToPointWithOptions(t *testing.T) {
+	vectors := [][]float64{{1, 1}, {2, 2}}
+	point := []float64{0, 0}
+
+	result, err := ComputeToPointWithOptions(vectors, point, Manhattan[float64], Options{
+		Weights:   []float64{1, 3},
+		Normalize: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Manhattan sees differences scaled by √w: 1 + √3 and 2 + 2√3
+	near, far := 1+math.Sqrt(3), 2+2*math.Sqrt(3)
+	if !almostEqual(result[0], near/(1+near)) || !almostEqual(result[1], far/(1+far)) {
+		t.Errorf("expected [%v %v], got %v", near/(1+near), far/(1+far), result)
+	}
+}
+
+func TestComputeWeightsMatchWeightedEuclidean(t *testing.T) {
+	a := []float64{1, -2, 3.5, 0}
+	b := []float64{4, 0, -1, 2}
+	weights := []float64{0.5, 2, 0, 3}
+
+	expected, _ := WeightedEuclidean(a, b, weights)
+	got, err := Compute(a, b, Euclidean[float64], Options{Weights: weights})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestComputeBoundedStopsEarly(t *testing.T) {
+	a := []float64{0, 0, 0, 0}
+	b := []float64{10, 1, 1, 1}
+
+	// The bounded metric sees the bound and the caller's own slices
+	var gotBound float64
+	calls := 0
+	opts := Options{MaxDistance: 2, Bounded: func(x, y []float64, bound float64) (float64, error) {
+		calls++
+		gotBound = bound
+		if &x[0] != &a[0] || &y[0] != &b[0] {
+			t.Error("expected unweighted float64 vectors to be passed without copying")
+		}
+		return EuclideanBounded(x, y, bound)
+	}}
+	unbounded := func(x, y []float64) (float64, error) {
+		t.Error("expected the bounded metric to replace metric")
+		return Euclidean(x, y)
+	}
+
+	result, err := Compute(a, b, unbounded, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsInf(result, 1) || calls != 1 || gotBound != 2 {
+		t.Errorf("expected +Inf from one bounded call with bound 2, got %v (%d calls, bound %v)", result, calls, gotBound)
+	}
+
+	// Without MaxDistance the plain metric is used
+	if result, _ := Compute(a, b, Euclidean[float64], Options{Bounded: opts.Bounded}); !almostEqual(result, math.Sqrt(103)) || calls != 1 {
+		t.Errorf("expected %v without a bounded call, got %v", math.Sqrt(103), result)
+	}
+
+	matrix, err := BatchComputeWithOptions([][]float64{a, b, a}, unbounded, Options{MaxDistance: 2, Normalize: true, Bounded: EuclideanBounded[float64]})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if matrix[0][1] != 1 || matrix[0][2] != 0 {
+		t.Errorf("expected pruned pairs normalized to 1, got %v", matrix)
+	}
+}
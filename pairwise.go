@@ -0,0 +1,43 @@
+package distance
+
+import "iter"
+
+// PairDistance is the distance between vectors I and J.
+type PairDistance struct {
+	I, J     int
+	Distance float64
+}
+
+// Pairwise lazily yields the distance of every unordered pair (i < j) in
+// row-major order, so results can be streamed without materializing the matrix.
+// If distFn fails, the error is yielded with the failing pair's indices and
+// iteration stops.
+// Time: O(n²d) when fully consumed, Space: O(1)
+func Pairwise[T Number](vectors [][]T, distFn DistanceFunc[T]) iter.Seq2[PairDistance, error] {
+	return func(yield func(PairDistance, error) bool) {
+		for i := range vectors {
+			for j := i + 1; j < len(vectors); j++ {
+				dist, err := distFn(vectors[i], vectors[j])
+				if !yield(PairDistance{I: i, J: j, Distance: dist}, err) || err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// PairwiseCross lazily yields the distance between every x[i] and y[j],
+// in row-major order. Errors behave as in Pairwise.
+// Time: O(nmd) when fully consumed, Space: O(1)
+func PairwiseCross[T Number](x, y [][]T, distFn DistanceFunc[T]) iter.Seq2[PairDistance, error] {
+	return func(yield func(PairDistance, error) bool) {
+		for i := range x {
+			for j := range y {
+				dist, err := distFn(x[i], y[j])
+				if !yield(PairDistance{I: i, J: j, Distance: dist}, err) || err != nil {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package distance
+
+import "testing"
+
+func TestPairwise(t *testing.T) {
+	vectors := [][]float64{{0}, {1}, {3}}
+
+	var got []PairDistance
+	for pd, err := range Pairwise(vectors, Euclidean[float64]) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, pd)
+	}
+
+	want := []PairDistance{{0, 1, 1}, {0, 2, 3}, {1, 2, 2}}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want[i], got[i])
+		}
+	}
+}
+
+func TestPairwiseEarlyStopAndErrors(t *testing.T) {
+	vectors := [][]float64{{0}, {1}, {3}, {6}}
+
+	count := 0
+	for range Pairwise(vectors, Euclidean[float64]) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected to stop after 2, got %d", count)
+	}
+
+	bad := [][]float64{{0}, {1, 2}, {3}}
+	var errs int
+	for pd, err := range Pairwise(bad, Euclidean[float64]) {
+		if err != nil {
+			errs++
+			if err != ErrDimensionMismatch || pd.I != 0 || pd.J != 1 {
+				t.Errorf("unexpected error %v at %v", err, pd)
+			}
+		}
+	}
+	if errs != 1 {
+		t.Errorf("expected iteration to stop after one error, got %d", errs)
+	}
+}
+
+func TestPairwiseCross(t *testing.T) {
+	x := [][]float64{{0}, {10}}
+	y := [][]float64{{1}, {2}, {3}}
+	want, _ := CrossCompute(x, y, Manhattan[float64])
+
+	n := 0
+	for pd, err := range PairwiseCross(x, y, Manhattan[float64]) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pd.Distance != want[pd.I][pd.J] {
+			t.Errorf("[%d][%d]: expected %v, got %v", pd.I, pd.J, want[pd.I][pd.J], pd.Distance)
+		}
+		n++
+	}
+	if n != 6 {
+		t.Errorf("expected 6 pairs, got %d", n)
+	}
+}
@@ -0,0 +1,123 @@
+package distance
+
+import "regexp/syntax"
+
+// PatternMatcher measures how far strings are from the language of a regular
+// expression: the fewest rune insertions, deletions and substitutions that
+// turn a string into one the pattern matches in full. A validation pipeline
+// can then report "one character off" instead of a bare mismatch.
+//
+// The pattern uses Go regexp syntax and must match the whole string, as if
+// wrapped in ^(?:...)$. Zero-width assertions (^, $, \b, \B) are ignored,
+// since edits can always satisfy them. A PatternMatcher is safe for
+// concurrent use.
+type PatternMatcher struct {
+	prog *syntax.Prog
+}
+
+// CompilePattern parses pattern into a PatternMatcher.
+// Returns ErrInvalidParameter if pattern is not a valid regular expression.
+// Time: O(p), Space: O(p)
+func CompilePattern(pattern string) (*PatternMatcher, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, ErrInvalidParameter
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil, ErrInvalidParameter
+	}
+	return &PatternMatcher{prog: prog}, nil
+}
+
+// Distance returns the minimum number of edits turning s into a string the
+// pattern matches, 0 if it already matches. Returns -1 if the pattern
+// matches nothing at all (e.g. an empty character class).
+// The search is a shortest path over (position in s, automaton state) pairs:
+// reading a rune the state accepts is free, substituting or deleting a rune
+// and inserting one the state accepts cost 1.
+// Time: O(n·p) where p = compiled program size, Space: O(n·p)
+func (m *PatternMatcher) Distance(s string) int {
+	runes := []rune(s)
+	insts := m.prog.Inst
+	width := len(insts)
+	dist := make([]int, (len(runes)+1)*width)
+	for i := range dist {
+		dist[i] = -1
+	}
+
+	// 0-1 BFS: zero-cost edges go to the front of the deque, unit-cost to the back
+	type node struct{ pos, pc, cost int }
+	front := []node{{0, m.prog.Start, 0}}
+	var back []node
+	relax := func(pos, pc, cost int, free bool) {
+		if d := dist[pos*width+pc]; d >= 0 && d <= cost {
+			return
+		}
+		if free {
+			front = append(front, node{pos, pc, cost})
+		} else {
+			back = append(back, node{pos, pc, cost})
+		}
+	}
+
+	for len(front) > 0 || len(back) > 0 {
+		if len(front) == 0 {
+			front, back = back, front[:0]
+			continue
+		}
+		nd := front[len(front)-1]
+		front = front[:len(front)-1]
+		idx := nd.pos*width + nd.pc
+		if dist[idx] >= 0 && dist[idx] <= nd.cost {
+			continue
+		}
+		dist[idx] = nd.cost
+
+		inst := &insts[nd.pc]
+		if nd.pos < len(runes) {
+			relax(nd.pos+1, nd.pc, nd.cost+1, false) // Delete runes[pos]
+		}
+		switch inst.Op {
+		case syntax.InstMatch:
+			if nd.pos == len(runes) {
+				return nd.cost
+			}
+		case syntax.InstAlt, syntax.InstAltMatch:
+			relax(nd.pos, int(inst.Out), nd.cost, true)
+			relax(nd.pos, int(inst.Arg), nd.cost, true)
+		case syntax.InstCapture, syntax.InstEmptyWidth, syntax.InstNop:
+			relax(nd.pos, int(inst.Out), nd.cost, true)
+		case syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			if inst.Op == syntax.InstRune && len(inst.Rune) == 0 {
+				continue // Empty class: nothing to read or insert
+			}
+			relax(nd.pos, int(inst.Out), nd.cost+1, false) // Insert an accepted rune
+			if nd.pos < len(runes) {
+				if inst.MatchRune(runes[nd.pos]) {
+					relax(nd.pos+1, int(inst.Out), nd.cost, true)
+				} else {
+					relax(nd.pos+1, int(inst.Out), nd.cost+1, false) // Substitute
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// PatternDistance returns the minimum number of rune edits turning s into a
+// full match of pattern; see PatternMatcher. Compile once with
+// CompilePattern when checking many strings against the same pattern.
+// Returns ErrInvalidParameter if pattern is invalid or matches nothing.
+// Time: O(n·p), Space: O(n·p)
+func PatternDistance(s, pattern string) (int, error) {
+	m, err := CompilePattern(pattern)
+	if err != nil {
+		return 0, err
+	}
+	d := m.Distance(s)
+	if d < 0 {
+		return 0, ErrInvalidParameter
+	}
+	return d, nil
+}
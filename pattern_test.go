@@ -0,0 +1,83 @@
+package distance
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestPatternDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		pattern  string
+		expected int
+	}{
+		{"matches", "2024-01-15", `\d{4}-\d{2}-\d{2}`, 0},
+		{"wrong separator", "2024/01/15", `\d{4}-\d{2}-\d{2}`, 2},
+		{"missing digit", "2024-1-15", `\d{4}-\d{2}-\d{2}`, 1},
+		{"extra digit", "20245-01-15", `\d{4}-\d{2}-\d{2}`, 1},
+		{"empty input", "", `\d{3}`, 3},
+		{"alternation", "gray", `gr(a|e)y`, 0},
+		{"alternation edit", "grey", `gr(a|o)y`, 1},
+		{"star", "abbbbc", `ab*c`, 0},
+		{"star deletes", "abxbc", `ab*c`, 1},
+		{"optional", "colour", `colou?r`, 0},
+		{"case folding", "HELLO", `(?i)hello`, 0},
+		{"anchors ignored", "abc", `^abc$`, 0},
+		{"email", "user@example", `[a-z]+@[a-z]+\.[a-z]{2,}`, 1}, // "user@examp.le"
+		{"literal", "kitten", `sitting`, 3},
+		{"unicode", "naïve", `naive`, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PatternDistance(tt.s, tt.pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPatternMatcherAgreesWithRegexp(t *testing.T) {
+	patterns := []string{`a(b|cd)*e?`, `[ab]{2,4}c`, `(ab|ba)+`, `a.b`}
+	rng := testRNG(8)
+	for _, pattern := range patterns {
+		m, err := CompilePattern(pattern)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		full := regexp.MustCompile(`^(?:` + pattern + `)$`)
+		for i := 0; i < 200; i++ {
+			b := make([]byte, rng.IntN(7))
+			for j := range b {
+				b[j] = "abcde"[rng.IntN(5)]
+			}
+			s := string(b)
+			if d := m.Distance(s); (d == 0) != full.MatchString(s) {
+				t.Errorf("%s on %q: distance %d but regexp match %v", pattern, s, d, full.MatchString(s))
+			}
+		}
+	}
+
+	// Literal patterns reduce to Levenshtein distance
+	m, _ := CompilePattern("saturday")
+	for _, s := range []string{"sunday", "", "saturdays", "tuesday"} {
+		want, _ := LevenshteinRunes(s, "saturday")
+		if got := m.Distance(s); got != want {
+			t.Errorf("%q: expected %d, got %d", s, want, got)
+		}
+	}
+}
+
+func TestPatternDistanceErrors(t *testing.T) {
+	if _, err := PatternDistance("abc", `a(b`); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := PatternDistance("abc", `[^\x00-\x{10FFFF}]`); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter for an empty language, got %v", err)
+	}
+}
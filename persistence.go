@@ -0,0 +1,268 @@
+package distance
+
+import (
+	"math"
+	"sort"
+)
+
+// PersistencePair is a point of a persistence diagram: a topological
+// feature (a component, loop or void) born at filtration value Birth and
+// dying at Death. Death is +Inf for essential features that never die.
+type PersistencePair struct {
+	Birth, Death float64
+}
+
+// Persistence returns the lifetime Death - Birth of the feature.
+func (p PersistencePair) Persistence() float64 {
+	return p.Death - p.Birth
+}
+
+// ZeroDimensionalPersistence computes the 0-dimensional persistence diagram
+// of the Vietoris-Rips filtration of a distance matrix: every point is born
+// at 0 and each single-linkage merge kills a component at the merge height,
+// leaving one essential component. This tracks how the data's clusters
+// appear and merge as the scale grows, and can be compared across datasets
+// with PersistenceBottleneck or PersistenceWasserstein.
+// Returns ErrEmptyInput for an empty matrix and ErrDimensionMismatch for a
+// non-square one.
+// Time: O(n³), Space: O(n²)
+func ZeroDimensionalPersistence(matrix [][]float64) ([]PersistencePair, error) {
+	merges, err := AgglomerativeCluster(matrix, SingleLinkage)
+	if err != nil {
+		return nil, err
+	}
+	diagram := make([]PersistencePair, 0, len(merges)+1)
+	for _, m := range merges {
+		diagram = append(diagram, PersistencePair{Birth: 0, Death: m.Distance})
+	}
+	return append(diagram, PersistencePair{Birth: 0, Death: math.Inf(1)}), nil
+}
+
+// PersistenceBottleneck computes the bottleneck distance between two
+// persistence diagrams: the smallest ε such that the points of a and b can
+// be matched with every pair within ε in the L∞ norm, where any point may
+// instead be matched to its nearest point on the diagonal Birth = Death at
+// distance Persistence/2. Diagrams may have different sizes. By the
+// stability theorem it is at most the L∞ distance between the functions
+// that produced them, so small perturbations of the data give small changes.
+// Essential points (Death = +Inf) match only each other, by birth; the
+// distance is +Inf when a and b have different numbers of them.
+// Returns ErrInvalidParameter for NaN or infinite births, NaN deaths or
+// Death < Birth.
+// Time: O(n³ log n) for n = len(a) + len(b), Space: O(n²)
+func PersistenceBottleneck(a, b []PersistencePair) (float64, error) {
+	finiteA, essentialA, err := splitDiagram(a)
+	if err != nil {
+		return 0, err
+	}
+	finiteB, essentialB, err := splitDiagram(b)
+	if err != nil {
+		return 0, err
+	}
+	if len(essentialA) != len(essentialB) {
+		return math.Inf(1), nil
+	}
+
+	essential := 0.0
+	for i := range essentialA {
+		essential = math.Max(essential, math.Abs(essentialA[i]-essentialB[i]))
+	}
+
+	cost := diagramCostMatrix(finiteA, finiteB)
+	if len(cost) == 0 {
+		return essential, nil
+	}
+
+	// Binary search the smallest candidate threshold admitting a perfect matching
+	var candidates []float64
+	for _, row := range cost {
+		candidates = append(candidates, row...)
+	}
+	sort.Float64s(candidates)
+	lo, hi := 0, len(candidates)-1
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if perfectMatching(cost, candidates[mid]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return math.Max(essential, candidates[lo]), nil
+}
+
+// PersistenceWasserstein computes the p-Wasserstein distance between two
+// persistence diagrams: (Σ ‖x - m(x)‖∞^p)^(1/p) minimized over matchings m
+// of a with b, where as in PersistenceBottleneck points may be matched to
+// the diagonal and essential points match each other by birth. Unlike the
+// bottleneck distance it accounts for every feature, not only the worst
+// matched one; p = 1 and p = 2 are common choices and p = +Inf is the
+// bottleneck distance. Solved exactly with the Hungarian algorithm.
+// Returns ErrInvalidParameter for p < 1 or NaN and for invalid points as in
+// PersistenceBottleneck.
+// Time: O(n³) for n = len(a) + len(b), Space: O(n²)
+func PersistenceWasserstein(a, b []PersistencePair, p float64) (float64, error) {
+	if !(p >= 1) {
+		return 0, ErrInvalidParameter
+	}
+	if math.IsInf(p, 1) {
+		return PersistenceBottleneck(a, b)
+	}
+	finiteA, essentialA, err := splitDiagram(a)
+	if err != nil {
+		return 0, err
+	}
+	finiteB, essentialB, err := splitDiagram(b)
+	if err != nil {
+		return 0, err
+	}
+	if len(essentialA) != len(essentialB) {
+		return math.Inf(1), nil
+	}
+
+	var total float64
+	for i := range essentialA {
+		total += math.Pow(math.Abs(essentialA[i]-essentialB[i]), p)
+	}
+
+	cost := diagramCostMatrix(finiteA, finiteB)
+	for _, row := range cost {
+		for j := range row {
+			row[j] = math.Pow(row[j], p)
+		}
+	}
+	total += minCostAssignment(cost)
+	return math.Pow(total, 1/p), nil
+}
+
+// splitDiagram validates a diagram and separates its finite points from the
+// sorted births of its essential points
+func splitDiagram(diagram []PersistencePair) ([]PersistencePair, []float64, error) {
+	var finite []PersistencePair
+	var essential []float64
+	for _, pt := range diagram {
+		if math.IsNaN(pt.Birth) || math.IsInf(pt.Birth, 0) || math.IsNaN(pt.Death) || pt.Death < pt.Birth {
+			return nil, nil, ErrInvalidParameter
+		}
+		if math.IsInf(pt.Death, 1) {
+			essential = append(essential, pt.Birth)
+		} else {
+			finite = append(finite, pt)
+		}
+	}
+	sort.Float64s(essential)
+	return finite, essential, nil
+}
+
+// diagramCostMatrix builds the square (m+n)×(m+n) matching cost between
+// diagrams a and b augmented with diagonal slots: rows are a's points then
+// n diagonal slots for b, columns are b's points then m diagonal slots for a
+func diagramCostMatrix(a, b []PersistencePair) [][]float64 {
+	m, n := len(a), len(b)
+	cost := make([][]float64, m+n)
+	for i := range cost {
+		cost[i] = make([]float64, m+n)
+		for j := range cost[i] {
+			switch {
+			case i < m && j < n:
+				cost[i][j] = math.Max(math.Abs(a[i].Birth-b[j].Birth), math.Abs(a[i].Death-b[j].Death))
+			case i < m:
+				cost[i][j] = a[i].Persistence() / 2
+			case j < n:
+				cost[i][j] = b[j].Persistence() / 2
+			}
+		}
+	}
+	return cost
+}
+
+// perfectMatching reports whether the bipartite graph of entries of cost at
+// most threshold has a perfect matching, using Kuhn's augmenting paths
+func perfectMatching(cost [][]float64, threshold float64) bool {
+	n := len(cost)
+	matchCol := make([]int, n)
+	for j := range matchCol {
+		matchCol[j] = -1
+	}
+	visited := make([]bool, n)
+	var augment func(i int) bool
+	augment = func(i int) bool {
+		for j, c := range cost[i] {
+			if c > threshold || visited[j] {
+				continue
+			}
+			visited[j] = true
+			if matchCol[j] < 0 || augment(matchCol[j]) {
+				matchCol[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range cost {
+		for j := range visited {
+			visited[j] = false
+		}
+		if !augment(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// minCostAssignment returns the minimum total cost of a perfect matching in
+// a square cost matrix (the Hungarian algorithm with potentials)
+func minCostAssignment(cost [][]float64) float64 {
+	n := len(cost)
+	// 1-indexed potentials u (rows) and v (columns); column 0 is a sentinel
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	match := make([]int, n+1) // match[j] is the row assigned to column j
+	way := make([]int, n+1)
+	minv := make([]float64, n+1)
+	used := make([]bool, n+1)
+
+	for i := 1; i <= n; i++ {
+		match[0] = i
+		j0 := 0
+		for j := range minv {
+			minv[j] = math.Inf(1)
+			used[j] = false
+		}
+		for match[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := match[j0], math.Inf(1), 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := cost[i0-1][j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j], way[j] = cur, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			match[j0] = match[j1]
+			j0 = j1
+		}
+	}
+
+	var total float64
+	for j := 1; j <= n; j++ {
+		total += cost[match[j]-1][j-1]
+	}
+	return total
+}
@@ -0,0 +1,143 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPersistenceDistances(t *testing.T) {
+	inf := math.Inf(1)
+	tests := []struct {
+		name        string
+		a, b        []PersistencePair
+		bottleneck  float64
+		wasserstein float64 // p = 1
+	}{
+		{"both empty", nil, nil, 0, 0},
+		{"one to diagonal", []PersistencePair{{0, 2}}, nil, 1, 1},
+		{"shifted point", []PersistencePair{{0, 2}}, []PersistencePair{{0, 3}}, 1, 1},
+		{"extra feature", []PersistencePair{{0, 4}, {1, 2}}, []PersistencePair{{0, 4.5}}, 0.5, 1},
+		{"diagonal cheaper", []PersistencePair{{0, 1}}, []PersistencePair{{5, 6}}, 0.5, 1},
+		{"essential", []PersistencePair{{0, inf}, {0, 1}}, []PersistencePair{{0.5, inf}}, 0.5, 1},
+		{"essential mismatch", []PersistencePair{{0, inf}}, nil, inf, inf},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PersistenceBottleneck(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.bottleneck && !almostEqual(got, tt.bottleneck) {
+				t.Errorf("bottleneck: expected %v, got %v", tt.bottleneck, got)
+			}
+			got, err = PersistenceWasserstein(tt.a, tt.b, 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.wasserstein && !almostEqual(got, tt.wasserstein) {
+				t.Errorf("wasserstein: expected %v, got %v", tt.wasserstein, got)
+			}
+		})
+	}
+}
+
+func TestPersistenceAgainstBruteForce(t *testing.T) {
+	rng := testRNG(7)
+	diagram := func(n int) []PersistencePair {
+		d := make([]PersistencePair, n)
+		for i := range d {
+			birth := rng.Float64() * 5
+			d[i] = PersistencePair{Birth: birth, Death: birth + rng.Float64()*3}
+		}
+		return d
+	}
+
+	for trial := 0; trial < 20; trial++ {
+		a, b := diagram(rng.IntN(4)), diagram(rng.IntN(4))
+		cost := diagramCostMatrix(a, b)
+		bestMax, bestSum := math.Inf(1), math.Inf(1)
+		perm := make([]int, len(cost))
+		for i := range perm {
+			perm[i] = i
+		}
+		permute(perm, 0, func(perm []int) {
+			worst, sum := 0.0, 0.0
+			for i, j := range perm {
+				worst = math.Max(worst, cost[i][j])
+				sum += cost[i][j] * cost[i][j]
+			}
+			bestMax, bestSum = math.Min(bestMax, worst), math.Min(bestSum, sum)
+		})
+
+		bottleneck, _ := PersistenceBottleneck(a, b)
+		if !almostEqual(bottleneck, bestMax) {
+			t.Errorf("trial %d: expected bottleneck %v, got %v", trial, bestMax, bottleneck)
+		}
+		w2, _ := PersistenceWasserstein(a, b, 2)
+		if !almostEqualTolerance(w2, math.Sqrt(bestSum), 1e-9) {
+			t.Errorf("trial %d: expected W2 %v, got %v", trial, math.Sqrt(bestSum), w2)
+		}
+		if w2 < bottleneck-epsilon {
+			t.Errorf("trial %d: W2 %v below bottleneck %v", trial, w2, bottleneck)
+		}
+		if inf, _ := PersistenceWasserstein(a, b, math.Inf(1)); inf != bottleneck {
+			t.Errorf("trial %d: expected p=Inf to match bottleneck", trial)
+		}
+	}
+}
+
+func TestZeroDimensionalPersistence(t *testing.T) {
+	points := [][]float64{{0}, {1}, {10}, {12}}
+	matrix, _ := BatchCompute(points, Euclidean[float64])
+	diagram, err := ZeroDimensionalPersistence(matrix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []PersistencePair{{0, 1}, {0, 2}, {0, 9}, {0, math.Inf(1)}}
+	if len(diagram) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, diagram)
+	}
+	for i := range expected {
+		if diagram[i] != expected[i] {
+			t.Errorf("pair %d: expected %v, got %v", i, expected[i], diagram[i])
+		}
+	}
+
+	// Stability: perturbing every distance by at most δ moves the diagram by at most δ
+	shifted, _ := BatchCompute([][]float64{{0.1}, {1}, {10}, {12.2}}, Euclidean[float64])
+	other, _ := ZeroDimensionalPersistence(shifted)
+	d, _ := PersistenceBottleneck(diagram, other)
+	if d > 0.3+epsilon {
+		t.Errorf("expected bottleneck at most 0.3, got %v", d)
+	}
+
+	if _, err := ZeroDimensionalPersistence(nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestPersistenceErrors(t *testing.T) {
+	valid := []PersistencePair{{0, 1}}
+	invalid := [][]PersistencePair{
+		{{math.NaN(), 1}},
+		{{0, math.NaN()}},
+		{{math.Inf(-1), 1}},
+		{{2, 1}},
+	}
+	for _, d := range invalid {
+		if _, err := PersistenceBottleneck(valid, d); err != ErrInvalidParameter {
+			t.Errorf("%v: expected ErrInvalidParameter, got %v", d, err)
+		}
+		if _, err := PersistenceWasserstein(d, valid, 1); err != ErrInvalidParameter {
+			t.Errorf("%v: expected ErrInvalidParameter, got %v", d, err)
+		}
+	}
+	for _, p := range []float64{0.5, math.NaN()} {
+		if _, err := PersistenceWasserstein(valid, valid, p); err != ErrInvalidParameter {
+			t.Errorf("p=%v: expected ErrInvalidParameter, got %v", p, err)
+		}
+	}
+	if (PersistencePair{Birth: 1, Death: 3}).Persistence() != 2 {
+		t.Error("expected persistence 2")
+	}
+}
@@ -0,0 +1,369 @@
+package distance
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// refinedSoundexCodes maps A-Z to Refined Soundex digits
+const refinedSoundexCodes = "01360240043788015936020505"
+
+// RefinedSoundex computes the Refined Soundex encoding: the first letter
+// followed by one digit per letter (vowels included as 0), with adjacent
+// repeats collapsed and no length limit. Finer-grained than Soundex, so
+// fewer unrelated names collide. Cyrillic, Greek and Arabic are romanized,
+// non-letters ignored and accents stripped first. Returns "" if s contains
+// no letters.
+// Time: O(n), Space: O(n)
+func RefinedSoundex(s string) string {
+	letters := phoneticLetters(s)
+	if len(letters) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte(letters[0])
+	var last byte
+	for _, c := range []byte(letters) {
+		code := refinedSoundexCodes[c-'A']
+		if code != last {
+			b.WriteByte(code)
+			last = code
+		}
+	}
+	return b.String()
+}
+
+// DaitchMokotoffSoundex computes the Daitch-Mokotoff Soundex codes of s, a
+// variant designed for Slavic, Germanic and Yiddish surnames. Letter groups
+// such as SZ, CZ, RZ and TSCH are coded as units, coding depends on whether
+// the group starts the name or precedes a vowel, and ambiguous groups (CH, CK,
+// C, J, RS, RZ) branch into alternatives, so several six-digit codes may be
+// returned, sorted and without duplicates. Cyrillic, Greek and Arabic are
+// romanized, non-letters ignored and accents stripped first. Returns nil if s
+// contains no letters.
+// Time: O(n·b) where b = number of branches, Space: O(b)
+func DaitchMokotoffSoundex(s string) []string {
+	letters := phoneticLetters(s)
+	if len(letters) == 0 {
+		return nil
+	}
+
+	type branch struct {
+		code []byte
+		last string
+	}
+	branches := []branch{{}}
+	for i := 0; i < len(letters); {
+		rule, ok := dmRuleAt(letters, i)
+		if !ok {
+			i++ // Letters with no rule are skipped
+			continue
+		}
+
+		alternatives := rule.other
+		switch end := i + len(rule.pattern); {
+		case i == 0:
+			alternatives = rule.start
+		case end < len(letters) && strings.IndexByte("AEIOU", letters[end]) >= 0:
+			alternatives = rule.vowel
+		}
+
+		next := make([]branch, 0, len(branches)*len(alternatives))
+		for _, br := range branches {
+			for _, alt := range alternatives {
+				code := br.code
+				if !strings.HasSuffix(br.last, alt) && len(code) < dmCodeLength {
+					code = append(append([]byte(nil), code...), alt...)
+				}
+				next = append(next, branch{code: code, last: alt})
+			}
+		}
+		branches = next
+		i += len(rule.pattern)
+	}
+
+	seen := make(map[string]bool, len(branches))
+	codes := make([]string, 0, len(branches))
+	for _, br := range branches {
+		code := string(br.code) + strings.Repeat("0", max(dmCodeLength-len(br.code), 0))
+		code = code[:dmCodeLength]
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// DaitchMokotoffMatch reports whether a and b share at least one
+// Daitch-Mokotoff code, the usual test for a possible surname match.
+// Time: O(n·b), Space: O(b)
+func DaitchMokotoffMatch(a, b string) bool {
+	codes := make(map[string]bool)
+	for _, c := range DaitchMokotoffSoundex(a) {
+		codes[c] = true
+	}
+	for _, c := range DaitchMokotoffSoundex(b) {
+		if codes[c] {
+			return true
+		}
+	}
+	return false
+}
+
+// NamePair is a pair of names labelled as the same person or not, used to
+// calibrate phonetic matching against a corpus.
+type NamePair struct {
+	A, B string
+	Same bool
+}
+
+// PhoneticCalibration turns the raw PhoneticDistance between two names into
+// an empirical probability that they refer to the same name, learned from
+// labelled pairs. Collision rates differ widely between encoders and
+// populations (Soundex codes of short surnames collide far more often than
+// Metaphone codes of long ones), so the table should be fitted on a corpus
+// resembling the data being matched. A PhoneticCalibration is not safe for
+// concurrent Add; concurrent Probability calls are safe.
+type PhoneticCalibration struct {
+	encoder     func(string) string
+	same, total []int     // labelled pair counts by code distance
+	table       []float64 // calibrated probability by code distance
+}
+
+// NewPhoneticCalibration fits a calibration for encoder (e.g. Soundex or
+// Metaphone) on pairs. Returns ErrEmptyInput if pairs is empty.
+// Time: O(n·e) where e = encoder cost, Space: O(d) where d = max code distance
+func NewPhoneticCalibration(encoder func(string) string, pairs []NamePair) (*PhoneticCalibration, error) {
+	if len(pairs) == 0 {
+		return nil, ErrEmptyInput
+	}
+	c := &PhoneticCalibration{encoder: encoder}
+	for _, p := range pairs {
+		c.count(p)
+	}
+	c.refit()
+	return c, nil
+}
+
+// Add folds one more labelled pair into the table, so a calibration can be
+// refined as reviewed matches accumulate.
+// Time: O(e + d), Space: O(1) amortized
+func (c *PhoneticCalibration) Add(pair NamePair) {
+	c.count(pair)
+	c.refit()
+}
+
+// Probability returns the calibrated probability in [0, 1] that a and b are
+// the same name. Each code distance's match rate is smoothed towards the
+// corpus base rate and then made non-increasing in the distance (isotonic
+// regression), so sparse buckets cannot rank a worse code match above a
+// better one; distances beyond those seen in the corpus take the rate of the
+// largest one seen.
+// Time: O(e), Space: O(1)
+func (c *PhoneticCalibration) Probability(a, b string) float64 {
+	d := PhoneticDistance(a, b, c.encoder)
+	return c.table[min(d, len(c.table)-1)]
+}
+
+// Table returns the calibrated probability for each code distance 0, 1, ...
+// up to the largest distance in the corpus.
+func (c *PhoneticCalibration) Table() []float64 {
+	return append([]float64(nil), c.table...)
+}
+
+// count adds a labelled pair to its code distance bucket
+func (c *PhoneticCalibration) count(p NamePair) {
+	d := PhoneticDistance(p.A, p.B, c.encoder)
+	for len(c.total) <= d {
+		c.same = append(c.same, 0)
+		c.total = append(c.total, 0)
+	}
+	c.total[d]++
+	if p.Same {
+		c.same[d]++
+	}
+}
+
+// refit rebuilds the probability table from the bucket counts
+func (c *PhoneticCalibration) refit() {
+	same, total := 0, 0
+	for d := range c.total {
+		same += c.same[d]
+		total += c.total[d]
+	}
+	base := float64(same) / float64(total)
+
+	// One pseudo-pair at the base rate per bucket keeps empty and tiny
+	// buckets near the corpus average
+	rates := make([]float64, len(c.total))
+	weights := make([]float64, len(c.total))
+	for d := range c.total {
+		weights[d] = float64(c.total[d]) + 1
+		rates[d] = (float64(c.same[d]) + base) / weights[d]
+	}
+	c.table = nonIncreasing(rates, weights)
+}
+
+// dmCodeLength is the number of digits in a Daitch-Mokotoff code
+const dmCodeLength = 6
+
+// dmRule codes a letter group at the start of a name, before a vowel and
+// elsewhere; each position lists alternatives, "" meaning not coded
+type dmRule struct {
+	pattern             string
+	start, vowel, other []string
+}
+
+// dmTable lists the Daitch-Mokotoff coding chart as
+// "GROUPS start vowel other", alternatives separated by | and - for not coded
+var dmTable = []string{
+	"AI,AJ,AY 0 1 -",
+	"AU 0 7 -",
+	"A 0 - -",
+	"B 7 7 7",
+	"CHS 5 54 54",
+	"CH 5|4 5|4 5|4",
+	"CK 5|45 5|45 5|45",
+	"CZ,CS,CSZ,CZS 4 4 4",
+	"C 5|4 5|4 5|4",
+	"DRZ,DRS 4 4 4",
+	"DS,DSH,DSZ 4 4 4",
+	"DZ,DZH,DZS 4 4 4",
+	"D,DT 3 3 3",
+	"EI,EJ,EY 0 1 -",
+	"EU 1 1 -",
+	"E 0 - -",
+	"FB,F 7 7 7",
+	"G 5 5 5",
+	"H 5 5 -",
+	"IA,IE,IO,IU 1 - -",
+	"I 0 - -",
+	"J 1|4 -|4 -|4",
+	"KS 5 54 54",
+	"KH,K 5 5 5",
+	"L 8 8 8",
+	"MN,NM 66 66 66",
+	"M 6 6 6",
+	"N 6 6 6",
+	"OI,OJ,OY 0 1 -",
+	"O 0 - -",
+	"P,PF,PH 7 7 7",
+	"Q 5 5 5",
+	"RZ,RS 94|4 94|4 94|4",
+	"R 9 9 9",
+	"SCHTSCH,SCHTSH,SCHTCH 2 4 4",
+	"SCH 4 4 4",
+	"SHTCH,SHCH,SHTSH 2 4 4",
+	"SHT,SCHT,SCHD 2 43 43",
+	"SH 4 4 4",
+	"STCH,STSCH,SC 2 4 4",
+	"STRZ,STRS,STSH 2 4 4",
+	"ST 2 43 43",
+	"SZCZ,SZCS 2 4 4",
+	"SZT,SHD,SZD,SD 2 43 43",
+	"SZ 4 4 4",
+	"S 4 4 4",
+	"TCH,TTCH,TTSCH 4 4 4",
+	"TH 3 3 3",
+	"TRZ,TRS 4 4 4",
+	"TSCH,TSH 4 4 4",
+	"TS,TTS,TTSZ,TC 4 4 4",
+	"TZ,TTZ,TZS,TSZ 4 4 4",
+	"T 3 3 3",
+	"UI,UJ,UY 0 1 -",
+	"U,UE 0 - -",
+	"V 7 7 7",
+	"W 7 7 7",
+	"X 5 54 54",
+	"Y 1 - -",
+	"ZDZ,ZDZH,ZHDZH 2 4 4",
+	"ZD,ZHD 2 43 43",
+	"ZH,ZS,ZSCH,ZSH 4 4 4",
+	"Z 4 4 4",
+}
+
+// dmRules indexes dmTable by first letter, longest patterns first
+var dmRules = func() map[byte][]dmRule {
+	alternatives := func(field string) []string {
+		alts := strings.Split(field, "|")
+		for i, a := range alts {
+			if a == "-" {
+				alts[i] = ""
+			}
+		}
+		return alts
+	}
+
+	rules := make(map[byte][]dmRule)
+	for _, line := range dmTable {
+		f := strings.Fields(line)
+		for _, pattern := range strings.Split(f[0], ",") {
+			rules[pattern[0]] = append(rules[pattern[0]], dmRule{
+				pattern: pattern,
+				start:   alternatives(f[1]),
+				vowel:   alternatives(f[2]),
+				other:   alternatives(f[3]),
+			})
+		}
+	}
+	for _, list := range rules {
+		sort.SliceStable(list, func(i, j int) bool { return len(list[i].pattern) > len(list[j].pattern) })
+	}
+	return rules
+}()
+
+// dmRuleAt returns the longest rule matching letters at position i
+func dmRuleAt(letters string, i int) (dmRule, bool) {
+	for _, rule := range dmRules[letters[i]] {
+		if strings.HasPrefix(letters[i:], rule.pattern) {
+			return rule, true
+		}
+	}
+	return dmRule{}, false
+}
+
+// phoneticLetters returns the ASCII letters of s, uppercased, after
+// romanizing Cyrillic, Greek and Arabic and stripping diacritics (so "Łódź"
+// becomes "LODZ" and "Шварц" becomes "SHVARTS")
+func phoneticLetters(s string) string {
+	s = Transliterate(s, defaultTransliteration...)
+	s = stripDiacritics(NormalizeString(s, NormalizeNFD))
+	var b strings.Builder
+	for _, r := range s {
+		r = unicode.ToUpper(r)
+		if r >= 'A' && r <= 'Z' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// nonIncreasing returns the weighted least-squares non-increasing fit to
+// values by pool-adjacent-violators
+func nonIncreasing(values, weights []float64) []float64 {
+	type block struct {
+		value, weight float64
+		size          int
+	}
+	blocks := make([]block, 0, len(values))
+	for i, v := range values {
+		blocks = append(blocks, block{v, weights[i], 1})
+		for len(blocks) > 1 && blocks[len(blocks)-2].value < blocks[len(blocks)-1].value {
+			last, prev := blocks[len(blocks)-1], blocks[len(blocks)-2]
+			w := prev.weight + last.weight
+			blocks = blocks[:len(blocks)-2]
+			blocks = append(blocks, block{(prev.value*prev.weight + last.value*last.weight) / w, w, prev.size + last.size})
+		}
+	}
+	fit := make([]float64, 0, len(values))
+	for _, b := range blocks {
+		for j := 0; j < b.size; j++ {
+			fit = append(fit, b.value)
+		}
+	}
+	return fit
+}
@@ -0,0 +1,152 @@
+package distance
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRefinedSoundex(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"testing", "T6036084"},
+		{"TESTING", "T6036084"},
+		{"The", "T60"},
+		{"quick", "Q503"},
+		{"brown", "B1908"},
+		{"fox", "F205"},
+		{"jumped", "J408106"},
+		{"over", "O0209"},
+		{"lazy", "L7050"},
+		{"dogs", "D6043"},
+		{"Müller", "M80709"},
+		{"O'Brien", "O01908"},
+		{"", ""},
+		{"123", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := RefinedSoundex(tt.input); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	// Soundex conflates these; Refined Soundex keeps them apart
+	if Soundex("Carl") != Soundex("Cearly") || RefinedSoundex("Carl") == RefinedSoundex("Cearly") {
+		t.Errorf("expected Refined Soundex to separate Carl/Cearly: %s vs %s", RefinedSoundex("Carl"), RefinedSoundex("Cearly"))
+	}
+}
+
+func TestDaitchMokotoffSoundex(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"Moskowitz", []string{"645740"}},
+		{"Moskovitz", []string{"645740"}},
+		{"Auerbach", []string{"097400", "097500"}},
+		{"Ohrbach", []string{"097400", "097500"}},
+		{"Lipshitz", []string{"874400"}},
+		{"Peters", []string{"734000", "739400"}},
+		{"Jackson", []string{"145460", "154600", "445460", "454600"}},
+		{"Schwarzenegger", []string{"474659", "479465"}},
+		{"Wąsowicz", []string{"747400"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := DaitchMokotoffSoundex(tt.input); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDaitchMokotoffMatch(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"Moskowitz", "Moskovitz", true},
+		{"Auerbach", "Ohrbach", true},
+		{"Lipshitz", "Lipschitz", true},
+		{"Szymański", "Shimanski", true},
+		{"Moskowitz", "Lipshitz", false},
+		{"", "Cohen", false},
+	}
+
+	for _, tt := range tests {
+		if got := DaitchMokotoffMatch(tt.a, tt.b); got != tt.expected {
+			t.Errorf("%s/%s: expected %v, got %v (%v vs %v)", tt.a, tt.b, tt.expected, got,
+				DaitchMokotoffSoundex(tt.a), DaitchMokotoffSoundex(tt.b))
+		}
+	}
+}
+
+func TestPhoneticCalibration(t *testing.T) {
+	// Upper-casing as the encoder makes the code distance a character diff count
+	pairs := []NamePair{
+		{"AB", "AB", true}, {"CD", "cd", true}, {"EF", "EF", true}, {"GH", "GH", false},
+		{"AB", "AC", false}, {"CD", "CE", false},
+		{"AB", "CD", true}, {"EF", "GH", false},
+	}
+	c, err := NewPhoneticCalibration(strings.ToUpper, pairs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Base rate 1/2; distance 1 (1/6) and 2 (1/2) violate monotonicity and pool
+	expected := []float64{3.5 / 5, 1.0 / 3, 1.0 / 3}
+	table := c.Table()
+	if len(table) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, table)
+	}
+	for d := range expected {
+		if !almostEqual(table[d], expected[d]) {
+			t.Errorf("distance %d: expected %v, got %v", d, expected[d], table[d])
+		}
+	}
+	if p := c.Probability("XY", "XY"); !almostEqual(p, 0.7) {
+		t.Errorf("expected 0.7, got %v", p)
+	}
+	if p := c.Probability("AB", "XYZ"); !almostEqual(p, 1.0/3) {
+		t.Errorf("expected unseen distance to use the last rate 1/3, got %v", p)
+	}
+
+	c.Add(NamePair{"IJ", "IJ", true})
+	if p, want := c.Probability("XY", "XY"), (4+5.0/9)/6; !almostEqual(p, want) {
+		t.Errorf("expected %v, got %v", want, p)
+	}
+
+	if _, err := NewPhoneticCalibration(Soundex, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestPhoneticCalibrationSoundex(t *testing.T) {
+	pairs := []NamePair{
+		{"Robert", "Rupert", true}, {"Ashcraft", "Ashcroft", true}, {"Tymczak", "Tymczack", true},
+		{"Smith", "Smyth", true}, {"Catherine", "Kathryn", true}, {"Jon", "John", true},
+		{"Lee", "Leigh", true}, {"Robert", "Roberts", false}, {"Ellery", "Euler", false},
+		{"Robert", "Smith", false}, {"Tymczak", "Lee", false}, {"Ashcraft", "Jon", false},
+		{"Catherine", "Rupert", false}, {"Smyth", "Kathryn", false},
+	}
+	c, err := NewPhoneticCalibration(Soundex, pairs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	table := c.Table()
+	for d := 1; d < len(table); d++ {
+		if table[d] > table[d-1] {
+			t.Errorf("expected non-increasing table, got %v", table)
+		}
+	}
+	if same, other := c.Probability("Rubin", "Rupin"), c.Probability("Rubin", "Garcia"); same <= other {
+		t.Errorf("expected matching codes to score higher, got %v vs %v", same, other)
+	}
+}
@@ -0,0 +1,194 @@
+package distance
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PhyloNode is a node of a phylogenetic tree. Leaves carry the taxon name;
+// Length is the branch length to the parent (unused on the root).
+type PhyloNode struct {
+	Name     string
+	Length   float64
+	Children []*PhyloNode
+}
+
+// IsLeaf reports whether the node has no children.
+func (n *PhyloNode) IsLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// Leaves returns the names of the leaves below n in left-to-right order.
+func (n *PhyloNode) Leaves() []string {
+	if n.IsLeaf() {
+		return []string{n.Name}
+	}
+	var names []string
+	for _, c := range n.Children {
+		names = append(names, c.Leaves()...)
+	}
+	return names
+}
+
+// Newick renders the tree in Newick format, e.g. "((A:1,B:2):0.5,C:3);".
+// Names containing Newick punctuation or whitespace are single-quoted.
+func (n *PhyloNode) Newick() string {
+	var sb strings.Builder
+	n.writeNewick(&sb, true)
+	sb.WriteByte(';')
+	return sb.String()
+}
+
+// writeNewick appends the subtree rooted at n, with its branch length unless root
+func (n *PhyloNode) writeNewick(sb *strings.Builder, root bool) {
+	if !n.IsLeaf() {
+		sb.WriteByte('(')
+		for i, c := range n.Children {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			c.writeNewick(sb, false)
+		}
+		sb.WriteByte(')')
+	}
+	sb.WriteString(newickName(n.Name))
+	if !root {
+		sb.WriteByte(':')
+		sb.WriteString(strconv.FormatFloat(n.Length, 'g', -1, 64))
+	}
+}
+
+// UPGMA builds a rooted ultrametric tree from a distance matrix by average
+// linkage, placing each internal node at half its merge distance (the
+// molecular-clock assumption). labels name the leaves; nil labels use the
+// row indices.
+// Time: O(n³), Space: O(n²)
+func UPGMA(matrix [][]float64, labels []string) (*PhyloNode, error) {
+	names, err := phyloLabels(matrix, labels)
+	if err != nil {
+		return nil, err
+	}
+	merges, err := AgglomerativeCluster(matrix, AverageLinkage)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(matrix)
+	nodes := make([]*PhyloNode, n+len(merges))
+	heights := make([]float64, n+len(merges))
+	for i, name := range names {
+		nodes[i] = &PhyloNode{Name: name}
+	}
+	for step, m := range merges {
+		id := n + step
+		heights[id] = m.Distance / 2
+		for _, c := range []int{m.A, m.B} {
+			nodes[c].Length = heights[id] - heights[c]
+		}
+		nodes[id] = &PhyloNode{Children: []*PhyloNode{nodes[m.A], nodes[m.B]}}
+	}
+	return nodes[len(nodes)-1], nil
+}
+
+// NeighborJoining builds an unrooted tree from a distance matrix with the
+// Saitou-Nei neighbor-joining algorithm, which recovers the true tree for
+// additive distances without assuming a molecular clock. The result is
+// returned rooted at the last internal node, which has three children.
+// Negative branch lengths, possible for non-additive input, are set to 0.
+// labels name the leaves; nil labels use the row indices.
+// Time: O(n³), Space: O(n²)
+func NeighborJoining(matrix [][]float64, labels []string) (*PhyloNode, error) {
+	names, err := phyloLabels(matrix, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(matrix)
+	dist := make([][]float64, n)
+	nodes := make([]*PhyloNode, n)
+	for i := range dist {
+		dist[i] = append([]float64(nil), matrix[i]...)
+		nodes[i] = &PhyloNode{Name: names[i]}
+	}
+
+	switch n {
+	case 1:
+		return nodes[0], nil
+	case 2:
+		nodes[0].Length, nodes[1].Length = dist[0][1]/2, dist[0][1]/2
+		return &PhyloNode{Children: nodes}, nil
+	}
+
+	for r := n; r > 3; r-- {
+		sums := make([]float64, r)
+		for i := range sums {
+			for j := 0; j < r; j++ {
+				sums[i] += dist[i][j]
+			}
+		}
+
+		bi, bj := 0, 1
+		best := math.Inf(1)
+		for i := 0; i < r; i++ {
+			for j := i + 1; j < r; j++ {
+				if q := float64(r-2)*dist[i][j] - sums[i] - sums[j]; q < best {
+					bi, bj, best = i, j, q
+				}
+			}
+		}
+
+		dij := dist[bi][bj]
+		li := dij/2 + (sums[bi]-sums[bj])/float64(2*(r-2))
+		nodes[bi].Length = math.Max(li, 0)
+		nodes[bj].Length = math.Max(dij-li, 0)
+		joined := &PhyloNode{Children: []*PhyloNode{nodes[bi], nodes[bj]}}
+
+		// The joined node takes slot bi; slot bj is removed
+		for k := 0; k < r; k++ {
+			if k != bi && k != bj {
+				d := (dist[bi][k] + dist[bj][k] - dij) / 2
+				dist[bi][k], dist[k][bi] = d, d
+			}
+		}
+		dist[bi][bi] = 0
+		nodes[bi] = joined
+		dist = append(dist[:bj], dist[bj+1:]...)
+		for k := range dist {
+			dist[k] = append(dist[k][:bj], dist[k][bj+1:]...)
+		}
+		nodes = append(nodes[:bj], nodes[bj+1:]...)
+	}
+
+	// Join the last three nodes at a single center
+	d01, d02, d12 := dist[0][1], dist[0][2], dist[1][2]
+	nodes[0].Length = math.Max((d01+d02-d12)/2, 0)
+	nodes[1].Length = math.Max((d01+d12-d02)/2, 0)
+	nodes[2].Length = math.Max((d02+d12-d01)/2, 0)
+	return &PhyloNode{Children: nodes}, nil
+}
+
+// phyloLabels validates the matrix and returns leaf names
+func phyloLabels(matrix [][]float64, labels []string) ([]string, error) {
+	if err := validateDistanceMatrix(matrix); err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		labels = make([]string, len(matrix))
+		for i := range labels {
+			labels[i] = strconv.Itoa(i)
+		}
+	}
+	if len(labels) != len(matrix) {
+		return nil, ErrDimensionMismatch
+	}
+	return labels, nil
+}
+
+// newickName quotes a name that contains Newick punctuation or whitespace
+func newickName(name string) string {
+	if !strings.ContainsAny(name, "()[]':;, \t\n") {
+		return name
+	}
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
@@ -0,0 +1,106 @@
+package distance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUPGMA(t *testing.T) {
+	// Wikipedia's UPGMA example (5S rRNA of five bacteria)
+	matrix := [][]float64{
+		{0, 17, 21, 31, 23},
+		{17, 0, 30, 34, 21},
+		{21, 30, 0, 28, 39},
+		{31, 34, 28, 0, 43},
+		{23, 21, 39, 43, 0},
+	}
+	tree, err := UPGMA(matrix, []string{"a", "b", "c", "d", "e"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "((e:11,(a:8.5,b:8.5):2.5):5.5,(c:14,d:14):2.5);"
+	if got := tree.Newick(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if got := tree.Leaves(); !reflect.DeepEqual(got, []string{"e", "a", "b", "c", "d"}) {
+		t.Errorf("unexpected leaves %v", got)
+	}
+}
+
+func TestNeighborJoining(t *testing.T) {
+	// Wikipedia's neighbor-joining example (additive distances)
+	matrix := [][]float64{
+		{0, 5, 9, 9, 8},
+		{5, 0, 10, 10, 9},
+		{9, 10, 0, 8, 7},
+		{9, 10, 8, 0, 3},
+		{8, 9, 7, 3, 0},
+	}
+	tree, err := NeighborJoining(matrix, []string{"a", "b", "c", "d", "e"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "(((a:2,b:3):3,c:4):2,d:2,e:1);"
+	if got := tree.Newick(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	// Path lengths through the tree reproduce the additive input
+	depth := map[string][]float64{}
+	var walk func(n *PhyloNode, path []float64)
+	walk = func(n *PhyloNode, path []float64) {
+		path = append(append([]float64(nil), path...), n.Length)
+		if n.IsLeaf() {
+			depth[n.Name] = path
+		}
+		for _, c := range n.Children {
+			walk(c, path)
+		}
+	}
+	walk(tree, nil)
+	// a and e share only the root: 2 + 3 + 2 (a→root) + 1 (e→root)
+	if got := sum(depth["a"][1:]) + sum(depth["e"][1:]); !almostEqual(got, matrix[0][4]) {
+		t.Errorf("expected path a-e %v, got %v", matrix[0][4], got)
+	}
+}
+
+func TestPhylogenySmall(t *testing.T) {
+	one := [][]float64{{0}}
+	pair := [][]float64{{0, 4}, {4, 0}}
+
+	for name, build := range map[string]func([][]float64, []string) (*PhyloNode, error){
+		"upgma": UPGMA,
+		"nj":    NeighborJoining,
+	} {
+		t.Run(name, func(t *testing.T) {
+			tree, err := build(one, nil)
+			if err != nil || tree.Newick() != "0;" {
+				t.Errorf("expected 0;, got %v (%v)", tree, err)
+			}
+			tree, err = build(pair, []string{"Homo sapiens", "Pan's"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := tree.Newick(); got != "('Homo sapiens':2,'Pan''s':2);" {
+				t.Errorf("unexpected Newick %s", got)
+			}
+			if _, err := build(pair, []string{"x"}); err != ErrDimensionMismatch {
+				t.Errorf("expected ErrDimensionMismatch, got %v", err)
+			}
+			if _, err := build(nil, nil); err != ErrEmptyInput {
+				t.Errorf("expected ErrEmptyInput, got %v", err)
+			}
+		})
+	}
+}
+
+// sum adds up values
+func sum(values []float64) float64 {
+	var s float64
+	for _, v := range values {
+		s += v
+	}
+	return s
+}
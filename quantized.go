@@ -0,0 +1,237 @@
+package distance
+
+import "math"
+
+// QuantizedVector is an asymmetric uint8 quantization of a float vector:
+// x[i] ≈ Offset + Scale*Codes[i]. Dot products and cosine distances are
+// computed from integer sums over the codes plus closed-form offset
+// corrections, and Euclidean distances from per-element differences, so the
+// fields may be set or edited directly.
+type QuantizedVector struct {
+	Codes  []uint8
+	Scale  float64
+	Offset float64
+}
+
+// QuantizedInt8Vector is a symmetric int8 quantization of a float vector:
+// x[i] ≈ Scale*Codes[i] with codes in [-127, 127].
+type QuantizedInt8Vector struct {
+	Codes []int8
+	Scale float64
+}
+
+// QuantizeUint8 maps v onto 256 levels spanning [min(v), max(v)].
+// Time: O(n), Space: O(n)
+func QuantizeUint8[T Number](v []T) (QuantizedVector, error) {
+	if len(v) == 0 {
+		return QuantizedVector{}, ErrEmptyInput
+	}
+
+	lo, hi := float64(v[0]), float64(v[0])
+	for _, x := range v {
+		lo = math.Min(lo, float64(x))
+		hi = math.Max(hi, float64(x))
+	}
+
+	q := QuantizedVector{Codes: make([]uint8, len(v)), Offset: lo, Scale: (hi - lo) / 255}
+	for i, x := range v {
+		if q.Scale > 0 {
+			q.Codes[i] = uint8(math.Round((float64(x) - lo) / q.Scale))
+		}
+	}
+	return q, nil
+}
+
+// NewQuantizedVector builds a QuantizedVector from existing codes and parameters,
+// e.g. when loading a quantized index from storage.
+func NewQuantizedVector(codes []uint8, scale, offset float64) QuantizedVector {
+	return QuantizedVector{Codes: codes, Scale: scale, Offset: offset}
+}
+
+// Dequantize reconstructs the approximate float vector.
+// Time: O(n), Space: O(n)
+func (q QuantizedVector) Dequantize() []float64 {
+	out := make([]float64, len(q.Codes))
+	for i, c := range q.Codes {
+		out[i] = q.Offset + q.Scale*float64(c)
+	}
+	return out
+}
+
+// codeSums returns Σc and Σc² over the codes
+func (q QuantizedVector) codeSums() (sum, sqSum int64) {
+	for _, c := range q.Codes {
+		sum += int64(c)
+		sqSum += int64(c) * int64(c)
+	}
+	return sum, sqSum
+}
+
+// squaredNorm returns ||x||² = n·o² + 2·o·s·Σc + s²·Σc²
+func (q QuantizedVector) squaredNorm() float64 {
+	n := float64(len(q.Codes))
+	sum, sqSum := q.codeSums()
+	return n*q.Offset*q.Offset + 2*q.Offset*q.Scale*float64(sum) +
+		q.Scale*q.Scale*float64(sqSum)
+}
+
+// QuantizedDot computes the dot product of the dequantized vectors directly
+// from codes: x·y = n·oa·ob + oa·sb·Σd + ob·sa·Σc + sa·sb·Σc·d.
+// Time: O(n) integer operations, Space: O(1)
+func QuantizedDot(a, b QuantizedVector) (float64, error) {
+	if err := Validate(a.Codes, b.Codes); err != nil {
+		return 0, err
+	}
+
+	var cross, sumA, sumB int64
+	bc := b.Codes[:len(a.Codes)]
+	for i, c := range a.Codes {
+		cross += int64(c) * int64(bc[i])
+		sumA += int64(c)
+		sumB += int64(bc[i])
+	}
+
+	n := float64(len(a.Codes))
+	return n*a.Offset*b.Offset +
+		a.Offset*b.Scale*float64(sumB) +
+		b.Offset*a.Scale*float64(sumA) +
+		a.Scale*b.Scale*float64(cross), nil
+}
+
+// QuantizedEuclidean computes Euclidean distance between dequantized vectors
+// without materializing them. Vectors sharing Scale and Offset use integer
+// arithmetic on the code differences.
+// Time: O(n), Space: O(1)
+func QuantizedEuclidean(a, b QuantizedVector) (float64, error) {
+	if err := Validate(a.Codes, b.Codes); err != nil {
+		return 0, err
+	}
+
+	bc := b.Codes[:len(a.Codes)]
+	if a.Scale == b.Scale && a.Offset == b.Offset {
+		var sq int64
+		for i, c := range a.Codes {
+			d := int64(c) - int64(bc[i])
+			sq += d * d
+		}
+		return math.Abs(a.Scale) * math.Sqrt(float64(sq)), nil
+	}
+
+	// Sum the element differences directly: expanding ||x||² + ||y||² - 2x·y
+	// cancels catastrophically when the offsets dwarf the differences
+	offset := a.Offset - b.Offset
+	var sq float64
+	for i, c := range a.Codes {
+		d := offset + a.Scale*float64(c) - b.Scale*float64(bc[i])
+		sq += d * d
+	}
+	return math.Sqrt(sq), nil
+}
+
+// QuantizedCosine computes cosine distance between dequantized vectors
+// without materializing them.
+// Time: O(n) integer operations, Space: O(1)
+func QuantizedCosine(a, b QuantizedVector) (float64, error) {
+	dot, err := QuantizedDot(a, b)
+	if err != nil {
+		return 0, err
+	}
+	na, nb := a.squaredNorm(), b.squaredNorm()
+	if na <= 0 || nb <= 0 {
+		return 0, ErrZeroVector
+	}
+	return 1 - clampUnit(dot/(math.Sqrt(na)*math.Sqrt(nb))), nil
+}
+
+// QuantizeInt8 maps v symmetrically onto [-127, 127] using its maximum magnitude.
+// Time: O(n), Space: O(n)
+func QuantizeInt8[T Number](v []T) (QuantizedInt8Vector, error) {
+	if len(v) == 0 {
+		return QuantizedInt8Vector{}, ErrEmptyInput
+	}
+
+	var maxAbs float64
+	for _, x := range v {
+		maxAbs = math.Max(maxAbs, math.Abs(float64(x)))
+	}
+
+	q := QuantizedInt8Vector{Codes: make([]int8, len(v)), Scale: maxAbs / 127}
+	for i, x := range v {
+		if q.Scale > 0 {
+			q.Codes[i] = int8(math.Round(float64(x) / q.Scale))
+		}
+	}
+	return q, nil
+}
+
+// Dequantize reconstructs the approximate float vector.
+// Time: O(n), Space: O(n)
+func (q QuantizedInt8Vector) Dequantize() []float64 {
+	out := make([]float64, len(q.Codes))
+	for i, c := range q.Codes {
+		out[i] = q.Scale * float64(c)
+	}
+	return out
+}
+
+// Int8Dot computes the dot product of dequantized int8 vectors: sa·sb·Σc·d.
+// Time: O(n) integer operations, Space: O(1)
+func Int8Dot(a, b QuantizedInt8Vector) (float64, error) {
+	if err := Validate(a.Codes, b.Codes); err != nil {
+		return 0, err
+	}
+	return a.Scale * b.Scale * float64(int8Cross(a.Codes, b.Codes)), nil
+}
+
+// Int8Euclidean computes Euclidean distance between dequantized int8 vectors.
+// Vectors sharing Scale use integer arithmetic on the code differences.
+// Time: O(n), Space: O(1)
+func Int8Euclidean(a, b QuantizedInt8Vector) (float64, error) {
+	if err := Validate(a.Codes, b.Codes); err != nil {
+		return 0, err
+	}
+
+	bc := b.Codes[:len(a.Codes)]
+	if a.Scale == b.Scale {
+		var sq int64
+		for i, c := range a.Codes {
+			d := int64(c) - int64(bc[i])
+			sq += d * d
+		}
+		return math.Abs(a.Scale) * math.Sqrt(float64(sq)), nil
+	}
+
+	var sq float64
+	for i, c := range a.Codes {
+		d := a.Scale*float64(c) - b.Scale*float64(bc[i])
+		sq += d * d
+	}
+	return math.Sqrt(sq), nil
+}
+
+// Int8Cosine computes cosine distance between dequantized int8 vectors.
+// Scales cancel, so only integer arithmetic is needed.
+// Time: O(n) integer operations, Space: O(1)
+func Int8Cosine(a, b QuantizedInt8Vector) (float64, error) {
+	if err := Validate(a.Codes, b.Codes); err != nil {
+		return 0, err
+	}
+
+	na := float64(int8Cross(a.Codes, a.Codes))
+	nb := float64(int8Cross(b.Codes, b.Codes))
+	if na == 0 || nb == 0 {
+		return 0, ErrZeroVector
+	}
+	dot := float64(int8Cross(a.Codes, b.Codes))
+	return 1 - clampUnit(dot/(math.Sqrt(na)*math.Sqrt(nb))), nil
+}
+
+// int8Cross computes Σ a[i]·b[i] in int64; callers guarantee equal lengths
+func int8Cross(a, b []int8) int64 {
+	b = b[:len(a)]
+	var sum int64
+	for i, c := range a {
+		sum += int64(c) * int64(b[i])
+	}
+	return sum
+}
@@ -0,0 +1,146 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantizeUint8RoundTrip(t *testing.T) {
+	v := []float64{-1, -0.5, 0, 0.25, 1}
+	q, err := QuantizeUint8(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	back := q.Dequantize()
+	for i := range v {
+		if math.Abs(back[i]-v[i]) > q.Scale/2+epsilon {
+			t.Errorf("index %d: expected %v within %v, got %v", i, v[i], q.Scale/2, back[i])
+		}
+	}
+
+	if _, err := QuantizeUint8([]float64{}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestQuantizedDistancesMatchDequantized(t *testing.T) {
+	a := []float64{0.1, -0.7, 2.3, 1.1, -1.9, 0.05}
+	b := []float64{1.4, 0.2, -0.6, 0.9, 0.3, -2.2}
+	qa, _ := QuantizeUint8(a)
+	qb, _ := QuantizeUint8(b)
+	da, db := qa.Dequantize(), qb.Dequantize()
+
+	tests := []struct {
+		name      string
+		quantized func(a, b QuantizedVector) (float64, error)
+		reference func(a, b []float64) (float64, error)
+	}{
+		{"dot", QuantizedDot, DotProduct[float64]},
+		{"euclidean", QuantizedEuclidean, Euclidean[float64]},
+		{"cosine", QuantizedCosine, Cosine[float64]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.quantized(qa, qb)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want, _ := tt.reference(da, db)
+			if math.Abs(got-want) > 1e-9 {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+
+			// Quantization error against the original floats stays small
+			orig, _ := tt.reference(a, b)
+			if math.Abs(got-orig) > 0.1 {
+				t.Errorf("expected close to %v, got %v", orig, got)
+			}
+		})
+	}
+
+	if _, err := QuantizedDot(qa, NewQuantizedVector([]uint8{1}, 1, 0)); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestQuantizedVectorLiteralAndEdits(t *testing.T) {
+	a := QuantizedVector{Codes: []uint8{0, 10, 255}, Scale: 0.5, Offset: -1}
+	b := QuantizedVector{Codes: []uint8{3, 3, 3}, Scale: 1, Offset: 0}
+	check := func() {
+		t.Helper()
+		got, err := QuantizedEuclidean(a, b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want, _ := Euclidean(a.Dequantize(), b.Dequantize())
+		if !almostEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+	check()
+
+	a.Codes[1] = 200
+	check()
+}
+
+func TestQuantizedEuclideanLargeOffset(t *testing.T) {
+	// One code apart on a huge offset: expanding the square would cancel to 0
+	a := QuantizedVector{Codes: make([]uint8, 512), Scale: 1, Offset: 1e7}
+	b := QuantizedVector{Codes: make([]uint8, 512), Scale: 1, Offset: 1e7}
+	b.Codes[17] = 1
+	if got, _ := QuantizedEuclidean(a, b); got != 1 {
+		t.Errorf("expected 1, got %v", got)
+	}
+	b.Offset = 1e7 + 1
+	if got, _ := QuantizedEuclidean(a, b); !almostEqual(got, math.Sqrt(515)) {
+		t.Errorf("expected %v, got %v", math.Sqrt(515), got)
+	}
+
+	c := QuantizedInt8Vector{Codes: make([]int8, 512), Scale: 1e5}
+	d := QuantizedInt8Vector{Codes: make([]int8, 512), Scale: 1e5}
+	for i := range c.Codes {
+		c.Codes[i], d.Codes[i] = 127, 127
+	}
+	d.Codes[3] = 126
+	if got, _ := Int8Euclidean(c, d); got != 1e5 {
+		t.Errorf("expected 1e5, got %v", got)
+	}
+	d.Scale = 2e5
+	want, _ := Euclidean(c.Dequantize(), d.Dequantize())
+	if got, _ := Int8Euclidean(c, d); !almostEqualTolerance(got, want, 1e-9*want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestQuantizeInt8(t *testing.T) {
+	a := []float64{0.5, -1, 0.25, 0.75}
+	b := []float32{-0.5, 0.5, 1, 0}
+	qa, _ := QuantizeInt8(a)
+	qb, _ := QuantizeInt8(b)
+	da, db := qa.Dequantize(), qb.Dequantize()
+
+	dot, _ := Int8Dot(qa, qb)
+	wantDot, _ := DotProduct(da, db)
+	if math.Abs(dot-wantDot) > 1e-9 {
+		t.Errorf("dot: expected %v, got %v", wantDot, dot)
+	}
+
+	euc, _ := Int8Euclidean(qa, qb)
+	wantEuc, _ := Euclidean(da, db)
+	if math.Abs(euc-wantEuc) > 1e-9 {
+		t.Errorf("euclidean: expected %v, got %v", wantEuc, euc)
+	}
+
+	cos, _ := Int8Cosine(qa, qb)
+	wantCos, _ := Cosine(da, db)
+	if math.Abs(cos-wantCos) > 1e-9 {
+		t.Errorf("cosine: expected %v, got %v", wantCos, cos)
+	}
+
+	zero, _ := QuantizeInt8([]float64{0, 0, 0, 0})
+	if _, err := Int8Cosine(qa, zero); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package distance
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// Rendering layout constants, in SVG user units
+const (
+	renderCell   = 20
+	renderMargin = 80
+	renderHeight = 300
+)
+
+// RenderHeatmapSVG writes matrix as an SVG heatmap, shading cells from white
+// (zero) to dark blue (the matrix maximum). labels, if given, annotate rows
+// and columns and must match the matrix size.
+// Time: O(n²), Space: O(1)
+func RenderHeatmapSVG(w io.Writer, matrix [][]float64, labels []string) error {
+	if err := validateRender(matrix, labels); err != nil {
+		return err
+	}
+
+	n := len(matrix)
+	hi := matrixMax(matrix)
+	size := renderMargin + n*renderCell
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="10">`+"\n", size, size)
+	for i, row := range matrix {
+		for j, v := range row {
+			c := heatColor(v, hi)
+			fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="#%02x%02x%02x"><title>%g</title></rect>`+"\n",
+				renderMargin+j*renderCell, renderMargin+i*renderCell, renderCell, renderCell, c.R, c.G, c.B, v)
+		}
+	}
+	for i, label := range labels {
+		label = html.EscapeString(label)
+		center := renderMargin + i*renderCell + renderCell/2
+		fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n", renderMargin-4, center, label)
+		fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="start" dominant-baseline="middle" transform="rotate(-90 %d %d)">%s</text>`+"\n",
+			center, renderMargin-4, center, renderMargin-4, label)
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+// RenderHeatmapPNG writes matrix as a PNG heatmap with cellSize×cellSize
+// pixels per entry, using the same color scale as RenderHeatmapSVG.
+// Time: O(n²·cellSize²), Space: O(n²·cellSize²)
+func RenderHeatmapPNG(w io.Writer, matrix [][]float64, cellSize int) error {
+	if err := validateRender(matrix, nil); err != nil {
+		return err
+	}
+	if cellSize <= 0 {
+		return ErrInvalidParameter
+	}
+
+	n := len(matrix)
+	hi := matrixMax(matrix)
+	img := image.NewRGBA(image.Rect(0, 0, n*cellSize, n*cellSize))
+	for y := 0; y < n*cellSize; y++ {
+		for x := 0; x < n*cellSize; x++ {
+			img.SetRGBA(x, y, heatColor(matrix[y/cellSize][x/cellSize], hi))
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// RenderDendrogramSVG clusters matrix with the given linkage and writes the
+// resulting dendrogram as SVG, leaves along the bottom and merge height
+// increasing upwards. labels, if given, name the leaves.
+// Time: O(n³), Space: O(n²)
+func RenderDendrogramSVG(w io.Writer, matrix [][]float64, labels []string, linkage Linkage) error {
+	if err := validateRender(matrix, labels); err != nil {
+		return err
+	}
+	merges, err := AgglomerativeCluster(matrix, linkage)
+	if err != nil {
+		return err
+	}
+
+	n := len(matrix)
+	top := 0.0
+	if len(merges) > 0 {
+		top = merges[len(merges)-1].Distance
+	}
+	baseline := float64(renderHeight + renderCell)
+	yOf := func(h float64) float64 {
+		if top <= 0 {
+			return baseline
+		}
+		return baseline - h/top*renderHeight
+	}
+
+	// x and height of every cluster id, leaves first
+	xs := make([]float64, 2*n-1)
+	hs := make([]float64, 2*n-1)
+	for pos, leaf := range LeafOrder(merges) {
+		xs[leaf] = float64(renderMargin + pos*renderCell + renderCell/2)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="10">`+"\n",
+		2*renderMargin+n*renderCell, int(baseline)+renderMargin)
+	fmt.Fprintln(bw, `<g stroke="black" fill="none">`)
+	for i, m := range merges {
+		id := n + i
+		xs[id] = (xs[m.A] + xs[m.B]) / 2
+		hs[id] = m.Distance
+		y := yOf(m.Distance)
+		fmt.Fprintf(bw, `<polyline points="%.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f"/>`+"\n",
+			xs[m.A], yOf(hs[m.A]), xs[m.A], y, xs[m.B], y, xs[m.B], yOf(hs[m.B]))
+	}
+	fmt.Fprintln(bw, "</g>")
+	for leaf, label := range labels {
+		fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" text-anchor="end" dominant-baseline="middle" transform="rotate(-90 %.1f %.1f)">%s</text>`+"\n",
+			xs[leaf], baseline+4, xs[leaf], baseline+4, html.EscapeString(label))
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+func validateRender(matrix [][]float64, labels []string) error {
+	if err := validateSquare(matrix); err != nil {
+		return err
+	}
+	if len(labels) > 0 && len(labels) != len(matrix) {
+		return ErrDimensionMismatch
+	}
+	return nil
+}
+
+// matrixMax returns the largest finite entry, used to scale colors
+func matrixMax(matrix [][]float64) float64 {
+	hi := 0.0
+	for _, row := range matrix {
+		for _, v := range row {
+			if !math.IsInf(v, 0) && !math.IsNaN(v) {
+				hi = math.Max(hi, v)
+			}
+		}
+	}
+	return hi
+}
+
+// heatColor maps v in [0, hi] linearly from white to dark blue (#08306b);
+// values above hi, including +Inf, saturate. With hi = 0 (an all-zero
+// matrix) zeros stay white
+func heatColor(v, hi float64) color.RGBA {
+	t := 1.0
+	switch {
+	case math.IsNaN(v):
+	case hi > 0:
+		t = math.Max(0, math.Min(1, v/hi))
+	case v <= 0:
+		t = 0
+	}
+	lerp := func(to float64) uint8 {
+		return uint8(math.Round(255 + (to-255)*t))
+	}
+	return color.RGBA{R: lerp(0x08), G: lerp(0x30), B: lerp(0x6b), A: 255}
+}
@@ -0,0 +1,92 @@
+package distance
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestRenderHeatmapSVG(t *testing.T) {
+	matrix := [][]float64{{0, 2}, {2, 0}}
+
+	var buf bytes.Buffer
+	if err := RenderHeatmapSVG(&buf, matrix, []string{"a", "<b>"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+
+	if strings.Count(svg, "<rect") != 4 {
+		t.Errorf("expected 4 cells, got %d", strings.Count(svg, "<rect"))
+	}
+	if !strings.Contains(svg, `fill="#ffffff"`) || !strings.Contains(svg, `fill="#08306b"`) {
+		t.Error("expected white and dark blue cells")
+	}
+	if !strings.Contains(svg, "&lt;b&gt;") {
+		t.Error("expected escaped label")
+	}
+
+	if err := RenderHeatmapSVG(&buf, matrix, []string{"a"}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestRenderHeatmapPNG(t *testing.T) {
+	matrix := [][]float64{{0, 1, 2}, {1, 0, 1}, {2, 1, 0}}
+
+	var buf bytes.Buffer
+	if err := RenderHeatmapPNG(&buf, matrix, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 12 || b.Dy() != 12 {
+		t.Errorf("expected 12x12 image, got %v", b)
+	}
+	if r, _, _, _ := img.At(0, 0).RGBA(); r>>8 != 255 {
+		t.Errorf("expected white diagonal, got red=%d", r>>8)
+	}
+
+	if err := RenderHeatmapPNG(&buf, matrix, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+
+	// Identical items are all near, not all far
+	buf.Reset()
+	if err := RenderHeatmapPNG(&buf, [][]float64{{0, 0}, {0, 0}}, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	img, err = png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r, _, _, _ := img.At(1, 0).RGBA(); r>>8 != 255 {
+		t.Errorf("expected white for an all-zero matrix, got red=%d", r>>8)
+	}
+}
+
+func TestRenderDendrogramSVG(t *testing.T) {
+	points := [][]float64{{0}, {1}, {5}, {6}}
+	matrix, _ := BatchCompute(points, Euclidean[float64])
+
+	var buf bytes.Buffer
+	if err := RenderDendrogramSVG(&buf, matrix, []string{"p0", "p1", "p2", "p3"}, AverageLinkage); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+
+	if strings.Count(svg, "<polyline") != 3 {
+		t.Errorf("expected 3 merges, got %d", strings.Count(svg, "<polyline"))
+	}
+	for _, label := range []string{"p0", "p1", "p2", "p3"} {
+		if !strings.Contains(svg, ">"+label+"<") {
+			t.Errorf("missing label %s", label)
+		}
+	}
+
+	if err := RenderDendrogramSVG(&buf, [][]float64{{0}}, nil, SingleLinkage); err != nil {
+		t.Errorf("unexpected error for single leaf: %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+package distance
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// ReportConfig configures CompareSamples.
+type ReportConfig struct {
+	Bins             int     // Quantile bins for PSI and JS (default 10)
+	BootstrapSamples int     // Bootstrap resamples for confidence intervals (default 1000, negative disables)
+	ConfidenceLevel  float64 // Two-sided confidence level for intervals (default 0.95)
+	Seed             uint64  // Seed for bootstrap resampling, for reproducible reports
+}
+
+// ReportMetric is a single comparison statistic with its bootstrap confidence interval.
+type ReportMetric struct {
+	Name    string  `json:"name"`
+	Value   float64 `json:"value"`
+	CILower float64 `json:"ci_lower"`
+	CIUpper float64 `json:"ci_upper"`
+}
+
+// ComparisonReport summarizes how a treatment sample differs from a control sample.
+type ComparisonReport struct {
+	ControlSize     int            `json:"control_size"`
+	TreatmentSize   int            `json:"treatment_size"`
+	ControlMean     float64        `json:"control_mean"`
+	TreatmentMean   float64        `json:"treatment_mean"`
+	ConfidenceLevel float64        `json:"confidence_level"`
+	Metrics         []ReportMetric `json:"metrics"`
+}
+
+// Metric returns the named metric from the report.
+func (r *ComparisonReport) Metric(name string) (ReportMetric, bool) {
+	for _, m := range r.Metrics {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return ReportMetric{}, false
+}
+
+// Report metric names.
+const (
+	ReportKS          = "ks"
+	ReportWasserstein = "wasserstein"
+	ReportJS          = "jensen_shannon"
+	ReportPSI         = "psi"
+	ReportMeanDiff    = "mean_difference"
+	ReportCohensD     = "cohens_d"
+	ReportCliffsDelta = "cliffs_delta"
+)
+
+// psiEpsilon smooths empty bins so PSI stays finite
+const psiEpsilon = 1e-4
+
+// CompareSamples compares two samples of a metric (e.g. an A/B experiment's
+// control and treatment) and returns a structured report containing the
+// Kolmogorov-Smirnov statistic, Wasserstein distance, Jensen-Shannon divergence,
+// population stability index, mean difference, Cohen's d and Cliff's delta,
+// each with a percentile bootstrap confidence interval. Samples containing NaN
+// or ±Inf return ErrInvalidParameter.
+// Time: O(B·(m+n) log(m+n)) where B = bootstrap samples, Space: O(m+n)
+func CompareSamples(control, treatment []float64, cfg ReportConfig) (*ComparisonReport, error) {
+	if len(control) == 0 || len(treatment) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if cfg.Bins == 0 {
+		cfg.Bins = 10
+	}
+	if cfg.BootstrapSamples == 0 {
+		cfg.BootstrapSamples = 1000
+	}
+	if cfg.ConfidenceLevel == 0 {
+		cfg.ConfidenceLevel = 0.95
+	}
+	if cfg.Bins < 0 || cfg.ConfidenceLevel <= 0 || cfg.ConfidenceLevel >= 1 ||
+		!allFinite(control) || !allFinite(treatment) {
+		return nil, ErrInvalidParameter
+	}
+
+	stats := []struct {
+		name string
+		fn   func(a, b []float64) float64
+	}{
+		{ReportKS, ksStatistic},
+		{ReportWasserstein, empiricalWasserstein},
+		{ReportJS, func(a, b []float64) float64 { return quantileBinnedJS(a, b, cfg.Bins) }},
+		{ReportPSI, func(a, b []float64) float64 { return populationStability(a, b, cfg.Bins) }},
+		{ReportMeanDiff, func(a, b []float64) float64 { return mean(b) - mean(a) }},
+		{ReportCohensD, cohensD},
+		{ReportCliffsDelta, cliffsDelta},
+	}
+
+	report := &ComparisonReport{
+		ControlSize:     len(control),
+		TreatmentSize:   len(treatment),
+		ControlMean:     mean(control),
+		TreatmentMean:   mean(treatment),
+		ConfidenceLevel: cfg.ConfidenceLevel,
+		Metrics:         make([]ReportMetric, len(stats)),
+	}
+	for i, s := range stats {
+		v := s.fn(control, treatment)
+		report.Metrics[i] = ReportMetric{Name: s.name, Value: v, CILower: v, CIUpper: v}
+	}
+	if cfg.BootstrapSamples < 0 {
+		return report, nil
+	}
+
+	//nolint:gosec // G404: bootstrap resampling does not require cryptographic randomness
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x9e3779b97f4a7c15))
+	replicates := make([][]float64, len(stats))
+	ra := make([]float64, len(control))
+	rb := make([]float64, len(treatment))
+	for b := 0; b < cfg.BootstrapSamples; b++ {
+		resample(rng, control, ra)
+		resample(rng, treatment, rb)
+		for i, s := range stats {
+			replicates[i] = append(replicates[i], s.fn(ra, rb))
+		}
+	}
+
+	alpha := (1 - cfg.ConfidenceLevel) / 2
+	for i := range stats {
+		sort.Float64s(replicates[i])
+		report.Metrics[i].CILower = percentile(replicates[i], alpha)
+		report.Metrics[i].CIUpper = percentile(replicates[i], 1-alpha)
+	}
+	return report, nil
+}
+
+// ksStatistic computes the two-sample Kolmogorov-Smirnov statistic sup|Fa - Fb|
+func ksStatistic(a, b []float64) float64 {
+	as := append([]float64{}, a...)
+	bs := append([]float64{}, b...)
+	sort.Float64s(as)
+	sort.Float64s(bs)
+
+	var d float64
+	i, j := 0, 0
+	for i < len(as) && j < len(bs) {
+		x := math.Min(as[i], bs[j])
+		for i < len(as) && as[i] == x {
+			i++
+		}
+		for j < len(bs) && bs[j] == x {
+			j++
+		}
+		d = math.Max(d, math.Abs(float64(i)/float64(len(as))-float64(j)/float64(len(bs))))
+	}
+	return d
+}
+
+// quantileBins returns interior bin edges at the quantiles of reference
+func quantileBins(reference []float64, bins int) []float64 {
+	sorted := append([]float64{}, reference...)
+	sort.Float64s(sorted)
+	edges := make([]float64, bins-1)
+	for k := 1; k < bins; k++ {
+		edges[k-1] = percentile(sorted, float64(k)/float64(bins))
+	}
+	return edges
+}
+
+// binFractions assigns values to bins delimited by edges and returns smoothed fractions
+func binFractions(values, edges []float64) []float64 {
+	h := make([]float64, len(edges)+1)
+	for _, x := range values {
+		h[sort.SearchFloat64s(edges, x)]++
+	}
+	for k := range h {
+		h[k] = math.Max(h[k]/float64(len(values)), psiEpsilon)
+	}
+	return h
+}
+
+// populationStability computes PSI = Σ (t-c)·ln(t/c) over control-quantile bins
+func populationStability(control, treatment []float64, bins int) float64 {
+	edges := quantileBins(control, bins)
+	c := binFractions(control, edges)
+	t := binFractions(treatment, edges)
+
+	var psi float64
+	for k := range c {
+		psi += (t[k] - c[k]) * math.Log(t[k]/c[k])
+	}
+	return psi
+}
+
+// quantileBinnedJS computes Jensen-Shannon divergence over control-quantile bins
+func quantileBinnedJS(control, treatment []float64, bins int) float64 {
+	edges := quantileBins(control, bins)
+	js, _ := JensenShannonDivergence(binFractions(control, edges), binFractions(treatment, edges))
+	return js
+}
+
+// cohensD computes the standardized mean difference using the pooled standard deviation.
+// Returns 0 when both samples have zero variance.
+func cohensD(a, b []float64) float64 {
+	ma, mb := mean(a), mean(b)
+	var ssa, ssb float64
+	for _, x := range a {
+		ssa += (x - ma) * (x - ma)
+	}
+	for _, x := range b {
+		ssb += (x - mb) * (x - mb)
+	}
+
+	dof := float64(len(a) + len(b) - 2)
+	if dof <= 0 || ssa+ssb == 0 {
+		return 0
+	}
+	return (mb - ma) / math.Sqrt((ssa+ssb)/dof)
+}
+
+// cliffsDelta computes P(b > a) - P(b < a) over all cross pairs
+func cliffsDelta(a, b []float64) float64 {
+	as := append([]float64{}, a...)
+	sort.Float64s(as)
+
+	var greater, less int
+	for _, y := range b {
+		less += len(as) - sort.Search(len(as), func(i int) bool { return as[i] > y })
+		greater += sort.SearchFloat64s(as, y)
+	}
+	return float64(greater-less) / float64(len(a)*len(b))
+}
+
+// mean returns the arithmetic mean of values
+func mean(values []float64) float64 {
+	var sum float64
+	for _, x := range values {
+		sum += x
+	}
+	return sum / float64(len(values))
+}
+
+// percentile returns the linearly interpolated q-th quantile of sorted values
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := min(lo+1, len(sorted)-1)
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+// resample fills dst with a bootstrap resample of src
+func resample(rng *rand.Rand, src, dst []float64) {
+	for i := range dst {
+		dst[i] = src[rng.IntN(len(src))]
+	}
+}
@@ -0,0 +1,103 @@
+package distance
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestCompareSamples(t *testing.T) {
+	control := make([]float64, 200)
+	treatment := make([]float64, 300)
+	for i := range control {
+		control[i] = float64(i % 20)
+	}
+	for i := range treatment {
+		treatment[i] = float64(i%20) + 2
+	}
+
+	report, err := CompareSamples(control, treatment, ReportConfig{BootstrapSamples: 200, Seed: 42})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.ControlSize != 200 || report.TreatmentSize != 300 {
+		t.Errorf("unexpected sizes %d/%d", report.ControlSize, report.TreatmentSize)
+	}
+	if len(report.Metrics) != 7 {
+		t.Fatalf("expected 7 metrics, got %d", len(report.Metrics))
+	}
+
+	expected := map[string]float64{
+		ReportKS:          0.1,
+		ReportWasserstein: 2,
+		ReportMeanDiff:    2,
+	}
+	for name, want := range expected {
+		m, ok := report.Metric(name)
+		if !ok {
+			t.Fatalf("missing metric %s", name)
+		}
+		if !almostEqual(m.Value, want) {
+			t.Errorf("%s: expected %v, got %v", name, want, m.Value)
+		}
+		if m.CILower > m.Value || m.CIUpper < m.Value {
+			t.Errorf("%s: interval [%v, %v] does not contain %v", name, m.CILower, m.CIUpper, m.Value)
+		}
+	}
+
+	for _, name := range []string{ReportJS, ReportPSI, ReportCohensD, ReportCliffsDelta} {
+		m, _ := report.Metric(name)
+		if m.Value <= 0 {
+			t.Errorf("%s: expected positive shift, got %v", name, m.Value)
+		}
+	}
+
+	if _, err := json.Marshal(report); err != nil {
+		t.Errorf("report should be JSON-serializable: %v", err)
+	}
+}
+
+func TestCompareSamplesIdentical(t *testing.T) {
+	sample := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	report, err := CompareSamples(sample, sample, ReportConfig{BootstrapSamples: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, m := range report.Metrics {
+		if math.Abs(m.Value) > epsilon {
+			t.Errorf("%s: expected 0 for identical samples, got %v", m.Name, m.Value)
+		}
+		if m.CILower != m.Value || m.CIUpper != m.Value {
+			t.Errorf("%s: expected degenerate interval without bootstrap", m.Name)
+		}
+	}
+}
+
+func TestCompareSamplesErrors(t *testing.T) {
+	if _, err := CompareSamples(nil, []float64{1}, ReportConfig{}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := CompareSamples([]float64{1}, []float64{1}, ReportConfig{ConfidenceLevel: 1.5}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := CompareSamples([]float64{1, math.NaN()}, []float64{1}, ReportConfig{}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter for NaN sample, got %v", err)
+	}
+	if _, err := CompareSamples([]float64{1}, []float64{math.Inf(1)}, ReportConfig{}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter for infinite sample, got %v", err)
+	}
+}
+
+func TestCliffsDelta(t *testing.T) {
+	if d := cliffsDelta([]float64{1, 2}, []float64{3, 4}); !almostEqual(d, 1) {
+		t.Errorf("expected 1, got %v", d)
+	}
+	if d := cliffsDelta([]float64{3, 4}, []float64{1, 2}); !almostEqual(d, -1) {
+		t.Errorf("expected -1, got %v", d)
+	}
+	if d := cliffsDelta([]float64{1, 2}, []float64{1, 2}); !almostEqual(d, 0) {
+		t.Errorf("expected 0, got %v", d)
+	}
+}
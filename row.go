@@ -0,0 +1,272 @@
+package distance
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnKind selects how a RowComparator compares the cells of a column.
+type ColumnKind int
+
+const (
+	// ColumnCategorical compares cells exactly: 0 if equal, 1 otherwise.
+	ColumnCategorical ColumnKind = iota
+	// ColumnNumeric parses cells as numbers and compares their difference
+	// against Tolerance and Scale.
+	ColumnNumeric
+	// ColumnString compares cells with a string metric.
+	ColumnString
+	// ColumnDate parses cells with Layout and compares their difference in
+	// days against Tolerance and Scale.
+	ColumnDate
+)
+
+// String returns the lowercase name of the kind.
+func (k ColumnKind) String() string {
+	switch k {
+	case ColumnCategorical:
+		return "categorical"
+	case ColumnNumeric:
+		return "numeric"
+	case ColumnString:
+		return "string"
+	case ColumnDate:
+		return "date"
+	}
+	return "unknown"
+}
+
+// NullPolicy decides how a RowComparator scores a column when a cell is null.
+type NullPolicy int
+
+const (
+	// NullSkip leaves the column out of the row distance when either cell is
+	// null, so missing data neither helps nor hurts.
+	NullSkip NullPolicy = iota
+	// NullMatch scores two nulls as equal (0) and a null against a value as
+	// different (1).
+	NullMatch
+	// NullMismatch scores any null as different (1), even against another null.
+	NullMismatch
+)
+
+// ColumnSpec configures one column of a RowComparator.
+type ColumnSpec struct {
+	Name   string     // Header name, used by ForHeader
+	Kind   ColumnKind // How cells are compared
+	Weight float64    // Relative weight in the row distance (default 1)
+
+	// Numeric and date columns: differences up to Tolerance count as equal;
+	// beyond it the distance is the difference divided by Scale, capped at
+	// 1, or 1 if Scale is 0. Date differences are in days.
+	Tolerance float64
+	Scale     float64
+
+	// Metric is a string distance in [0, 1] for string columns
+	// (default NormalizedLevenshtein).
+	Metric func(a, b string) (float64, error)
+	// Preprocess is applied to string and categorical cells before comparing.
+	Preprocess StringOptions
+	// Layout parses date cells (default "2006-01-02"; see time.Parse).
+	Layout string
+
+	Nulls      NullPolicy // How null cells are scored
+	NullValues []string   // Cell values treated as null after trimming space (default "")
+}
+
+// RowComparator computes schema-aware distances between table rows, such as
+// CSV records read with encoding/csv, for diffing two versions of a dataset.
+// Each column is scored in [0, 1] according to its ColumnSpec and the row
+// distance is the weighted mean over the scored columns, in the spirit of
+// Gower's distance. Numeric and date cells that fail to parse are compared
+// as categorical strings rather than failing the whole row.
+// A RowComparator is safe for concurrent use.
+type RowComparator struct {
+	columns []ColumnSpec
+	index   []int // row position of each column
+}
+
+// NewRowComparator creates a comparator for rows laid out as columns.
+// Returns ErrEmptyInput for no columns and ErrInvalidParameter for a negative
+// weight, tolerance or scale, or an unknown kind or null policy.
+// Time: O(c), Space: O(c)
+func NewRowComparator(columns []ColumnSpec) (*RowComparator, error) {
+	if len(columns) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	c := &RowComparator{columns: make([]ColumnSpec, len(columns)), index: make([]int, len(columns))}
+	for i, col := range columns {
+		if col.Weight < 0 || col.Tolerance < 0 || col.Scale < 0 ||
+			col.Kind < ColumnCategorical || col.Kind > ColumnDate ||
+			col.Nulls < NullSkip || col.Nulls > NullMismatch {
+			return nil, ErrInvalidParameter
+		}
+		if col.Weight == 0 {
+			col.Weight = 1
+		}
+		if col.Metric == nil {
+			col.Metric = NormalizedLevenshtein
+		}
+		if col.Layout == "" {
+			col.Layout = "2006-01-02"
+		}
+		if col.NullValues == nil {
+			col.NullValues = []string{""}
+		}
+		c.columns[i] = col
+		c.index[i] = i
+	}
+	return c, nil
+}
+
+// ForHeader returns a comparator for rows laid out as header, matching
+// columns by Name; header columns without a spec are ignored.
+// Returns ErrKeyNotFound if a column's Name is not in header.
+// Time: O(c + h), Space: O(c + h)
+func (c *RowComparator) ForHeader(header []string) (*RowComparator, error) {
+	position := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := position[name]; !ok {
+			position[name] = i
+		}
+	}
+
+	bound := &RowComparator{columns: c.columns, index: make([]int, len(c.columns))}
+	for i, col := range c.columns {
+		p, ok := position[col.Name]
+		if !ok {
+			return nil, ErrKeyNotFound
+		}
+		bound.index[i] = p
+	}
+	return bound, nil
+}
+
+// ColumnDistances returns the distance in [0, 1] for each column of the
+// comparator, in spec order; columns skipped under NullSkip are NaN.
+// Returns ErrDimensionMismatch if a row is too short for the layout.
+// Time: O(c·m) where m = cost of the string metric, Space: O(c)
+func (c *RowComparator) ColumnDistances(a, b []string) ([]float64, error) {
+	dists := make([]float64, len(c.columns))
+	for i := range c.columns {
+		p := c.index[i]
+		if p >= len(a) || p >= len(b) {
+			return nil, ErrDimensionMismatch
+		}
+		d, err := c.columns[i].distance(a[p], b[p])
+		if err != nil {
+			return nil, err
+		}
+		dists[i] = d
+	}
+	return dists, nil
+}
+
+// Distance returns the weighted mean of the column distances of a and b,
+// in [0, 1]. Returns 0 if every column was skipped as null.
+// Time: O(c·m), Space: O(c)
+func (c *RowComparator) Distance(a, b []string) (float64, error) {
+	dists, err := c.ColumnDistances(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	var sum, weights float64
+	for i, d := range dists {
+		if math.IsNaN(d) {
+			continue
+		}
+		sum += c.columns[i].Weight * d
+		weights += c.columns[i].Weight
+	}
+	if weights == 0 {
+		return 0, nil
+	}
+	return sum / weights, nil
+}
+
+// CompareRows returns the distance between each pair of aligned rows of a
+// and b, e.g. the same records before and after an export.
+// Returns ErrDimensionMismatch if a and b have different lengths.
+// Time: O(n·c·m), Space: O(n + c)
+func (c *RowComparator) CompareRows(a, b [][]string) ([]float64, error) {
+	if len(a) != len(b) {
+		return nil, ErrDimensionMismatch
+	}
+
+	dists := make([]float64, len(a))
+	for i := range a {
+		d, err := c.Distance(a[i], b[i])
+		if err != nil {
+			return nil, err
+		}
+		dists[i] = d
+	}
+	return dists, nil
+}
+
+// distance scores two cells of the column in [0, 1], NaN if skipped
+func (col *ColumnSpec) distance(a, b string) (float64, error) {
+	nullA, nullB := col.isNull(a), col.isNull(b)
+	if nullA || nullB {
+		switch {
+		case col.Nulls == NullSkip:
+			return math.NaN(), nil
+		case col.Nulls == NullMatch && nullA && nullB:
+			return 0, nil
+		}
+		return 1, nil
+	}
+
+	switch col.Kind {
+	case ColumnNumeric:
+		x, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+		y, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+		if errA == nil && errB == nil {
+			return col.scaled(math.Abs(x - y)), nil
+		}
+	case ColumnDate:
+		x, errA := time.Parse(col.Layout, strings.TrimSpace(a))
+		y, errB := time.Parse(col.Layout, strings.TrimSpace(b))
+		if errA == nil && errB == nil {
+			return col.scaled(math.Abs(x.Sub(y).Hours()) / 24), nil
+		}
+	case ColumnString:
+		d, err := col.Metric(col.Preprocess.Apply(a), col.Preprocess.Apply(b))
+		if err != nil {
+			return 0, err
+		}
+		return math.Min(1, math.Max(0, d)), nil
+	}
+
+	// Categorical, or a numeric/date cell that failed to parse
+	if col.Preprocess.Apply(a) == col.Preprocess.Apply(b) {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// scaled maps an absolute difference to [0, 1] using Tolerance and Scale
+func (col *ColumnSpec) scaled(diff float64) float64 {
+	switch {
+	case diff <= col.Tolerance:
+		return 0
+	case col.Scale == 0:
+		return 1
+	}
+	return math.Min(1, diff/col.Scale)
+}
+
+// isNull reports whether cell is one of the column's null values
+func (col *ColumnSpec) isNull(cell string) bool {
+	cell = strings.TrimSpace(cell)
+	for _, v := range col.NullValues {
+		if cell == strings.TrimSpace(v) {
+			return true
+		}
+	}
+	return false
+}
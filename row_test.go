@@ -0,0 +1,179 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestColumnDistances(t *testing.T) {
+	c, err := NewRowComparator([]ColumnSpec{
+		{Name: "id", Kind: ColumnCategorical},
+		{Name: "price", Kind: ColumnNumeric, Tolerance: 0.01, Scale: 10},
+		{Name: "name", Kind: ColumnString, Preprocess: StringOptions{CaseInsensitive: true}},
+		{Name: "updated", Kind: ColumnDate, Tolerance: 1, Scale: 30},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		a, b     []string
+		expected []float64
+	}{
+		{"identical", []string{"1", "9.99", "Widget", "2024-01-01"}, []string{"1", "9.99", "Widget", "2024-01-01"}, []float64{0, 0, 0, 0}},
+		{"within tolerance", []string{"1", "9.99", "Widget", "2024-01-01"}, []string{"1", "9.995", "WIDGET", "2024-01-02"}, []float64{0, 0, 0, 0}},
+		{"scaled differences", []string{"1", "10", "Widget", "2024-01-01"}, []string{"2", "15", "Widgets", "2024-01-16"}, []float64{1, 0.5, 1.0 / 7, 0.5}},
+		{"capped at one", []string{"1", "10", "abc", "2024-01-01"}, []string{"1", "100", "xyz", "2025-01-01"}, []float64{0, 1, 1, 1}},
+		{"unparseable compared as strings", []string{"1", "n/a", "a", "soon"}, []string{"1", "n/a", "a", "later"}, []float64{0, 0, 0, 1}},
+		{"nulls skipped", []string{"1", "", "a", " "}, []string{"1", "5", "a", ""}, []float64{0, math.NaN(), 0, math.NaN()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.ColumnDistances(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, want := range tt.expected {
+				if math.IsNaN(want) != math.IsNaN(result[i]) || (!math.IsNaN(want) && !almostEqual(result[i], want)) {
+					t.Errorf("column %d: expected %v, got %v", i, want, result[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRowComparatorNullPolicies(t *testing.T) {
+	tests := []struct {
+		policy   NullPolicy
+		a, b     string
+		expected float64
+	}{
+		{NullSkip, "", "x", math.NaN()},
+		{NullMatch, "", "", 0},
+		{NullMatch, "NULL", "x", 1},
+		{NullMismatch, "", "", 1},
+		{NullMismatch, "x", "x", 0},
+	}
+
+	for _, tt := range tests {
+		c, err := NewRowComparator([]ColumnSpec{{Nulls: tt.policy, NullValues: []string{"", "NULL"}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result, err := c.ColumnDistances([]string{tt.a}, []string{tt.b})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.IsNaN(tt.expected) != math.IsNaN(result[0]) || (!math.IsNaN(tt.expected) && result[0] != tt.expected) {
+			t.Errorf("policy %v on (%q, %q): expected %v, got %v", tt.policy, tt.a, tt.b, tt.expected, result[0])
+		}
+	}
+}
+
+func TestRowComparatorDistance(t *testing.T) {
+	c, err := NewRowComparator([]ColumnSpec{
+		{Kind: ColumnCategorical, Weight: 3},
+		{Kind: ColumnNumeric},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := c.Distance([]string{"a", "1"}, []string{"b", "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(d, 0.75) {
+		t.Errorf("expected 0.75, got %v", d)
+	}
+
+	// A skipped column drops out of the weighted mean
+	d, _ = c.Distance([]string{"a", ""}, []string{"a", "2"})
+	if d != 0 {
+		t.Errorf("expected 0, got %v", d)
+	}
+	d, _ = c.Distance([]string{"", ""}, []string{"a", "2"})
+	if d != 0 {
+		t.Errorf("expected 0 when every column is skipped, got %v", d)
+	}
+
+	if _, err := c.Distance([]string{"a"}, []string{"a", "1"}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestRowComparatorForHeader(t *testing.T) {
+	c, err := NewRowComparator([]ColumnSpec{
+		{Name: "price", Kind: ColumnNumeric, Scale: 10},
+		{Name: "sku", Kind: ColumnCategorical},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bound, err := c.ForHeader([]string{"sku", "note", "price"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dists, err := bound.ColumnDistances([]string{"A1", "old", "5"}, []string{"A1", "new", "10"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(dists[0], 0.5) || dists[1] != 0 {
+		t.Errorf("expected [0.5 0], got %v", dists)
+	}
+
+	if _, err := c.ForHeader([]string{"sku"}); err != ErrKeyNotFound {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestCompareRows(t *testing.T) {
+	c, err := NewRowComparator([]ColumnSpec{{Kind: ColumnCategorical}, {Kind: ColumnCategorical}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	before := [][]string{{"1", "x"}, {"2", "y"}, {"3", "z"}}
+	after := [][]string{{"1", "x"}, {"2", "q"}, {"4", "q"}}
+	dists, err := c.CompareRows(before, after)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []float64{0, 0.5, 1}
+	for i := range expected {
+		if !almostEqual(dists[i], expected[i]) {
+			t.Errorf("row %d: expected %v, got %v", i, expected[i], dists[i])
+		}
+	}
+
+	if _, err := c.CompareRows(before, after[:2]); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestNewRowComparatorErrors(t *testing.T) {
+	if _, err := NewRowComparator(nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	invalid := []ColumnSpec{
+		{Weight: -1},
+		{Tolerance: -1},
+		{Scale: -1},
+		{Kind: ColumnKind(9)},
+		{Nulls: NullPolicy(9)},
+	}
+	for _, spec := range invalid {
+		if _, err := NewRowComparator([]ColumnSpec{spec}); err != ErrInvalidParameter {
+			t.Errorf("%+v: expected ErrInvalidParameter, got %v", spec, err)
+		}
+	}
+}
+
+func TestColumnKindString(t *testing.T) {
+	if ColumnDate.String() != "date" || ColumnKind(-1).String() != "unknown" {
+		t.Errorf("unexpected names %q, %q", ColumnDate.String(), ColumnKind(-1).String())
+	}
+}
@@ -0,0 +1,130 @@
+package distance
+
+import "sort"
+
+// SearchResult is a single hit returned by every neighbor-search structure.
+// Index is the insertion order of the item; ID and Metadata are whatever the
+// caller supplied when adding it.
+type SearchResult struct {
+	ID       string         `json:"id"`
+	Index    int            `json:"index"`
+	Distance float64        `json:"distance"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// Searcher is implemented by neighbor-search indexes over queries of type Q,
+// so callers can swap index implementations without changing result handling.
+// Results are sorted by ascending distance, ties broken by lower Index.
+type Searcher[Q any] interface {
+	Search(query Q, k int) ([]SearchResult, error)
+	SearchRadius(query Q, radius float64) ([]SearchResult, error)
+}
+
+// MultiSearch runs Search for each query against s.
+// Time: O(q) searches, Space: O(qk)
+func MultiSearch[Q any](s Searcher[Q], queries []Q, k int) ([][]SearchResult, error) {
+	results := make([][]SearchResult, len(queries))
+	for i, q := range queries {
+		res, err := s.Search(q, k)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = res
+	}
+	return results, nil
+}
+
+// BruteForceIndex is an exact Searcher that scans every stored vector.
+type BruteForceIndex[T Number] struct {
+	distFn   DistanceFunc[T]
+	vectors  [][]T
+	ids      []string
+	metadata []map[string]any
+	byID     map[string]int
+}
+
+// NewBruteForceIndex creates an empty exhaustive index using distFn.
+func NewBruteForceIndex[T Number](distFn DistanceFunc[T]) *BruteForceIndex[T] {
+	return &BruteForceIndex[T]{distFn: distFn, byID: make(map[string]int)}
+}
+
+// Add stores v under id with optional metadata. IDs must be unique.
+// Time: O(1), Space: O(d)
+func (b *BruteForceIndex[T]) Add(id string, v []T, metadata map[string]any) error {
+	if len(v) == 0 {
+		return ErrEmptyInput
+	}
+	if len(b.vectors) > 0 && len(v) != len(b.vectors[0]) {
+		return ErrDimensionMismatch
+	}
+	if _, ok := b.byID[id]; ok {
+		return ErrInvalidParameter
+	}
+
+	b.byID[id] = len(b.vectors)
+	b.vectors = append(b.vectors, v)
+	b.ids = append(b.ids, id)
+	b.metadata = append(b.metadata, metadata)
+	return nil
+}
+
+// Len returns the number of stored vectors.
+func (b *BruteForceIndex[T]) Len() int {
+	return len(b.vectors)
+}
+
+// Search returns the k nearest stored vectors to query.
+// Time: O(nd + n log k), Space: O(k)
+func (b *BruteForceIndex[T]) Search(query []T, k int) ([]SearchResult, error) {
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	neighbors, err := kNearest(b.vectors, query, -1, k, b.distFn)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, len(neighbors))
+	for i, nb := range neighbors {
+		results[i] = b.result(nb.Index, nb.Distance)
+	}
+	return results, nil
+}
+
+// SearchRadius returns all stored vectors within radius of query.
+// Time: O(nd + m log m) where m = matches, Space: O(m)
+func (b *BruteForceIndex[T]) SearchRadius(query []T, radius float64) ([]SearchResult, error) {
+	if radius < 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	var results []SearchResult
+	for i, v := range b.vectors {
+		dist, err := b.distFn(query, v)
+		if err != nil {
+			return nil, err
+		}
+		if dist <= radius {
+			results = append(results, b.result(i, dist))
+		}
+	}
+	sortSearchResults(results)
+	return results, nil
+}
+
+// result wraps the stored vector at index i, with its ID and metadata, as a
+// SearchResult
+func (b *BruteForceIndex[T]) result(i int, dist float64) SearchResult {
+	return SearchResult{ID: b.ids[i], Index: i, Distance: dist, Metadata: b.metadata[i]}
+}
+
+// sortSearchResults orders results by distance, then index
+func sortSearchResults(results []SearchResult) {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Distance != results[j].Distance {
+			return results[i].Distance < results[j].Distance
+		}
+		return results[i].Index < results[j].Index
+	})
+}
@@ -0,0 +1,86 @@
+package distance
+
+import "testing"
+
+func newTestIndex(t *testing.T) *BruteForceIndex[float64] {
+	t.Helper()
+	idx := NewBruteForceIndex(Euclidean[float64])
+	items := []struct {
+		id string
+		v  []float64
+	}{
+		{"origin", []float64{0, 0}},
+		{"east", []float64{3, 0}},
+		{"north", []float64{0, 1}},
+		{"far", []float64{10, 10}},
+	}
+	for _, it := range items {
+		if err := idx.Add(it.id, it.v, map[string]any{"label": it.id}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return idx
+}
+
+func TestBruteForceIndexSearch(t *testing.T) {
+	idx := newTestIndex(t)
+
+	got, err := idx.Search([]float64{0, 0}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "origin" || got[1].ID != "north" {
+		t.Fatalf("expected [origin north], got %v", got)
+	}
+	if got[1].Index != 2 || got[1].Distance != 1 || got[1].Metadata["label"] != "north" {
+		t.Errorf("unexpected result %+v", got[1])
+	}
+
+	if _, err := idx.Search([]float64{0, 0}, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestBruteForceIndexSearchRadius(t *testing.T) {
+	idx := newTestIndex(t)
+
+	got, err := idx.SearchRadius([]float64{1, 0}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"origin", "north", "east"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("position %d: expected %s, got %s", i, id, got[i].ID)
+		}
+	}
+}
+
+func TestBruteForceIndexAdd(t *testing.T) {
+	idx := newTestIndex(t)
+
+	if err := idx.Add("origin", []float64{1, 1}, nil); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if err := idx.Add("bad", []float64{1}, nil); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if idx.Len() != 4 {
+		t.Errorf("expected 4, got %d", idx.Len())
+	}
+}
+
+func TestMultiSearch(t *testing.T) {
+	var s Searcher[[]float64] = newTestIndex(t)
+
+	got, err := MultiSearch(s, [][]float64{{0, 0}, {9, 9}}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[0][0].ID != "origin" || got[1][0].ID != "far" {
+		t.Errorf("unexpected results %v", got)
+	}
+}
@@ -0,0 +1,137 @@
+package distance
+
+import "math"
+
+// Decomposition splits a series into additive trend, seasonal and residual
+// components: series[i] = Trend[i] + Seasonal[i] + Residual[i].
+type Decomposition struct {
+	Period   int       `json:"period"`
+	Trend    []float64 `json:"trend"`
+	Seasonal []float64 `json:"seasonal"`
+	Residual []float64 `json:"residual"`
+	// Pattern is one period of the seasonal component, summing to zero;
+	// Seasonal[i] = Pattern[i%Period]
+	Pattern []float64 `json:"pattern"`
+}
+
+// Decompose performs a classical additive seasonal decomposition, a
+// lightweight alternative to STL. The trend is a centered moving average
+// over one period (a 2×period average for even periods), with the first and
+// last half period repeating the nearest centered value. The seasonal pattern
+// is the mean detrended value at each phase, centered to sum to zero.
+// Requires at least two full periods.
+// Time: O(n), Space: O(n)
+func Decompose[T Number](series []T, period int) (*Decomposition, error) {
+	if len(series) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if period < 2 || len(series) < 2*period {
+		return nil, ErrInvalidParameter
+	}
+
+	n := len(series)
+	x := make([]float64, n)
+	for i, v := range series {
+		x[i] = float64(v)
+	}
+
+	half := period / 2
+	trend := make([]float64, n)
+	// Running sum over the window x[i-half..i+half]
+	window := 0.0
+	for j := 0; j < 2*half; j++ {
+		window += x[j]
+	}
+	for i := half; i < n-half; i++ {
+		window += x[i+half]
+		sum := window
+		if period%2 == 0 {
+			sum -= (x[i-half] + x[i+half]) / 2
+		}
+		trend[i] = sum / float64(period)
+		window -= x[i-half]
+	}
+
+	pattern := make([]float64, period)
+	counts := make([]int, period)
+	for i := half; i < n-half; i++ {
+		pattern[i%period] += x[i] - trend[i]
+		counts[i%period]++
+	}
+	offset := 0.0
+	for p := range pattern {
+		pattern[p] /= float64(counts[p])
+		offset += pattern[p]
+	}
+	offset /= float64(period)
+	for p := range pattern {
+		pattern[p] -= offset
+	}
+
+	for i := 0; i < half; i++ {
+		trend[i] = trend[half]
+		trend[n-1-i] = trend[n-1-half]
+	}
+
+	d := &Decomposition{
+		Period:   period,
+		Trend:    trend,
+		Seasonal: make([]float64, n),
+		Residual: make([]float64, n),
+		Pattern:  pattern,
+	}
+	for i := range x {
+		d.Seasonal[i] = pattern[i%period]
+		d.Residual[i] = x[i] - trend[i] - d.Seasonal[i]
+	}
+	return d, nil
+}
+
+// ComponentDistances compares two series component by component, so a
+// shared seasonality does not mask a diverging trend, and vice versa.
+type ComponentDistances struct {
+	// Trend is the root mean squared difference between the trends
+	Trend float64 `json:"trend"`
+	// Seasonal is the DTW distance between the one-period seasonal
+	// patterns, tolerant of small phase shifts
+	Seasonal float64 `json:"seasonal"`
+	// Residual is the Wasserstein distance between the residual
+	// distributions, comparing noise levels regardless of timing
+	Residual float64 `json:"residual"`
+}
+
+// DecompositionDistance decomposes two equal-length series with the same
+// period and compares their components. Components keep the units of the
+// series; z-normalize KPIs of different scales first.
+// Time: O(n + period²), Space: O(n)
+func DecompositionDistance[T Number](a, b []T, period int) (ComponentDistances, error) {
+	if len(a) != len(b) {
+		return ComponentDistances{}, ErrDimensionMismatch
+	}
+	da, err := Decompose(a, period)
+	if err != nil {
+		return ComponentDistances{}, err
+	}
+	db, err := Decompose(b, period)
+	if err != nil {
+		return ComponentDistances{}, err
+	}
+
+	trend, err := Euclidean(da.Trend, db.Trend)
+	if err != nil {
+		return ComponentDistances{}, err
+	}
+	seasonal, err := DTW(da.Pattern, db.Pattern)
+	if err != nil {
+		return ComponentDistances{}, err
+	}
+	residual, err := DriftWasserstein(da.Residual, db.Residual)
+	if err != nil {
+		return ComponentDistances{}, err
+	}
+	return ComponentDistances{
+		Trend:    trend / math.Sqrt(float64(len(a))),
+		Seasonal: seasonal,
+		Residual: residual,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+// seasonalSeries builds slope·i + amplitude·sin(2πi/period + phase) + noise(i)
+func seasonalSeries(n, period int, slope, amplitude, phase float64, noise func(i int) float64) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = slope*float64(i) + amplitude*math.Sin(2*math.Pi*float64(i)/float64(period)+phase)
+		if noise != nil {
+			x[i] += noise(i)
+		}
+	}
+	return x
+}
+
+func TestDecompose(t *testing.T) {
+	for _, period := range []int{7, 12} {
+		series := seasonalSeries(10*period, period, 0.5, 3, 0, nil)
+		d, err := Decompose(series, period)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		sum := 0.0
+		for p, v := range d.Pattern {
+			sum += v
+			expected := 3 * math.Sin(2*math.Pi*float64(p)/float64(period))
+			if math.Abs(v-expected) > 0.2 {
+				t.Errorf("period %d phase %d: expected pattern %v, got %v", period, p, expected, v)
+			}
+		}
+		if !almostEqual(sum, 0) {
+			t.Errorf("expected a zero-sum pattern, got %v", sum)
+		}
+		for i := period; i < len(series)-period; i++ {
+			if math.Abs(d.Trend[i]-0.5*float64(i)) > 0.2 {
+				t.Errorf("period %d: expected trend %v at %d, got %v", period, 0.5*float64(i), i, d.Trend[i])
+			}
+		}
+		for i, v := range series {
+			if !almostEqual(d.Trend[i]+d.Seasonal[i]+d.Residual[i], v) {
+				t.Fatalf("components do not add up at %d", i)
+			}
+		}
+	}
+
+	// A centered moving average reproduces a line exactly
+	for _, period := range []int{4, 5} {
+		line := seasonalSeries(6*period, period, 2, 0, 0, nil)
+		d, _ := Decompose(line, period)
+		for i := period / 2; i < len(line)-period/2; i++ {
+			if !almostEqual(d.Trend[i], line[i]) {
+				t.Errorf("period %d: expected trend %v at %d, got %v", period, line[i], i, d.Trend[i])
+			}
+		}
+	}
+}
+
+func TestDecompositionDistance(t *testing.T) {
+	noise := func(i int) float64 { return 0.3 * math.Sin(float64(i*i)) }
+	base := seasonalSeries(120, 12, 0.1, 5, 0, noise)
+	scaledSeason := seasonalSeries(120, 12, 0.1, 10, 0, noise)
+	steeper := seasonalSeries(120, 12, 0.3, 5, 0, noise)
+	noisier := seasonalSeries(120, 12, 0.1, 5, 0, func(i int) float64 { return 3 * noise(i) })
+
+	same, err := DecompositionDistance(base, base, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if same != (ComponentDistances{}) {
+		t.Errorf("expected zero distances, got %+v", same)
+	}
+
+	season, _ := DecompositionDistance(base, scaledSeason, 12)
+	if season.Seasonal < 10 || season.Trend > 0.5 || season.Residual > 0.5 {
+		t.Errorf("expected only the seasonal distance to grow, got %+v", season)
+	}
+	trend, _ := DecompositionDistance(base, steeper, 12)
+	if trend.Trend < 5 || trend.Seasonal > 1 || trend.Residual > 0.5 {
+		t.Errorf("expected only the trend distance to grow, got %+v", trend)
+	}
+	resid, _ := DecompositionDistance(base, noisier, 12)
+	if resid.Residual < 0.2 || resid.Trend > 0.5 || resid.Seasonal > 2 {
+		t.Errorf("expected only the residual distance to grow, got %+v", resid)
+	}
+}
+
+func TestDecomposeErrors(t *testing.T) {
+	if _, err := Decompose([]float64{}, 4); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := Decompose([]float64{1, 2, 3, 4, 5}, 3); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := Decompose([]float64{1, 2, 3, 4}, 1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := DecompositionDistance([]float64{1, 2, 3, 4}, []float64{1, 2, 3}, 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
@@ -19,7 +19,7 @@ func JaccardSet[T comparable](a, b []T) (float64, error) {
 	intersection := 0
 	setB := make(map[T]bool, len(b))
 	for _, item := range b {
-		if setA[item] {
+		if setA[item] && !setB[item] {
 			intersection++
 		}
 		setB[item] = true
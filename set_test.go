@@ -46,6 +46,13 @@ func TestJaccardSet(t *testing.T) {
 			expected: 1.0,
 			wantErr:  false,
 		},
+		{
+			name:     "duplicates ignored",
+			a:        []string{"a", "b", "a"},
+			b:        []string{"b", "b", "c"},
+			expected: 1 - 1.0/3,
+			wantErr:  false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,123 @@
+package distance
+
+// Shard is a contiguous range of matrix rows [RowStart, RowEnd) forming one
+// independent unit of a pairwise computation. Each row i covers the pairs
+// (i, j) with j >= i, so shards never overlap.
+type Shard struct {
+	Index    int `json:"index"`
+	Count    int `json:"count"`
+	RowStart int `json:"row_start"`
+	RowEnd   int `json:"row_end"`
+}
+
+// ShardResult holds the upper-triangle distances computed for one shard,
+// diagonal included: Rows[r][k] is the distance between vectors RowStart+r
+// and RowStart+r+k.
+// It is exported field by field so it can be sent over the wire with
+// encoding/gob (encoding/json cannot represent +Inf).
+type ShardResult struct {
+	Shard Shard       `json:"shard"`
+	Rows  [][]float64 `json:"rows"`
+}
+
+// PartitionPairwise splits the n(n+1)/2 pairs of an n-vector distance matrix
+// into up to shards row ranges with near-equal pair counts. The assignment
+// depends only on n and shards, so every worker can compute it independently
+// and pick its own shard by index.
+// Time: O(n), Space: O(shards)
+func PartitionPairwise(n, shards int) ([]Shard, error) {
+	if n <= 0 || shards <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	total := n * (n + 1) / 2
+	out := make([]Shard, 0, shards)
+	start, covered := 0, 0
+	for s := 0; s < shards && start < n; s++ {
+		// Grow the range until it holds its share of the remaining pairs
+		target := (total - covered) / (shards - s)
+		end, pairs := start, 0
+		for end < n && (pairs < target || end == start) {
+			pairs += n - end
+			end++
+		}
+		if s == shards-1 {
+			end = n
+		}
+		out = append(out, Shard{Index: len(out), RowStart: start, RowEnd: end})
+		covered += pairs
+		start = end
+	}
+	for i := range out {
+		out[i].Count = len(out)
+	}
+	return out, nil
+}
+
+// ComputeShard computes the pairs owned by shard. The diagonal is computed
+// with distFn rather than assumed zero, matching BatchCompute.
+// Time: O(pairs·d), Space: O(pairs)
+func ComputeShard[T Number](vectors [][]T, shard Shard, distFn DistanceFunc[T]) (*ShardResult, error) {
+	n := len(vectors)
+	if shard.RowStart < 0 || shard.RowEnd > n || shard.RowStart > shard.RowEnd {
+		return nil, ErrInvalidParameter
+	}
+
+	res := &ShardResult{Shard: shard, Rows: make([][]float64, shard.RowEnd-shard.RowStart)}
+	for i := shard.RowStart; i < shard.RowEnd; i++ {
+		row := make([]float64, n-i)
+		for j := i; j < n; j++ {
+			dist, err := distFn(vectors[i], vectors[j])
+			if err != nil {
+				return nil, err
+			}
+			row[j-i] = dist
+		}
+		res.Rows[i-shard.RowStart] = row
+	}
+	return res, nil
+}
+
+// MergeShards assembles shard results into the full symmetric n×n distance
+// matrix. Every row must be covered exactly once; gaps, overlaps or malformed
+// rows return ErrDimensionMismatch, and a nil result ErrInvalidParameter.
+// Time: O(n²), Space: O(n²)
+func MergeShards(n int, results []*ShardResult) ([][]float64, error) {
+	if n <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+	}
+	covered := make([]bool, n)
+
+	for _, res := range results {
+		if res == nil {
+			return nil, ErrInvalidParameter
+		}
+		s := res.Shard
+		if s.RowStart < 0 || s.RowEnd > n || len(res.Rows) != s.RowEnd-s.RowStart {
+			return nil, ErrDimensionMismatch
+		}
+		for r, row := range res.Rows {
+			i := s.RowStart + r
+			if covered[i] || len(row) != n-i {
+				return nil, ErrDimensionMismatch
+			}
+			covered[i] = true
+			for k, d := range row {
+				j := i + k
+				matrix[i][j], matrix[j][i] = d, d
+			}
+		}
+	}
+
+	for _, ok := range covered {
+		if !ok {
+			return nil, ErrDimensionMismatch
+		}
+	}
+	return matrix, nil
+}
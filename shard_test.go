@@ -0,0 +1,123 @@
+package distance
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestPartitionPairwise(t *testing.T) {
+	shards, err := PartitionPairwise(100, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(shards) != 4 {
+		t.Fatalf("expected 4 shards, got %d", len(shards))
+	}
+
+	next := 0
+	total := 100 * 101 / 2
+	for _, s := range shards {
+		if s.RowStart != next || s.Count != 4 {
+			t.Errorf("unexpected shard %+v", s)
+		}
+		next = s.RowEnd
+
+		pairs := 0
+		for i := s.RowStart; i < s.RowEnd; i++ {
+			pairs += 100 - i
+		}
+		if pairs < total/4-100 || pairs > total/4+100 {
+			t.Errorf("shard %d is unbalanced: %d pairs", s.Index, pairs)
+		}
+	}
+	if next != 100 {
+		t.Errorf("expected rows to be fully covered, ended at %d", next)
+	}
+
+	small, _ := PartitionPairwise(2, 5)
+	if len(small) > 2 || small[len(small)-1].RowEnd != 2 {
+		t.Errorf("unexpected partition of tiny input: %+v", small)
+	}
+	if _, err := PartitionPairwise(0, 1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestComputeAndMergeShards(t *testing.T) {
+	vectors := [][]float64{{0, 0}, {1, 0}, {0, 2}, {3, 4}, {1, 1}, {2, 2}, {5, 1}}
+	want, _ := BatchCompute(vectors, Euclidean[float64])
+
+	shards, _ := PartitionPairwise(len(vectors), 3)
+	var results []*ShardResult
+	for _, s := range shards {
+		res, err := ComputeShard(vectors, s, Euclidean[float64])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Round-trip through gob as a remote worker would
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(res); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var decoded ShardResult
+		if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		results = append(results, &decoded)
+	}
+
+	got, err := MergeShards(len(vectors), results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if !almostEqual(got[i][j], want[i][j]) {
+				t.Errorf("[%d][%d]: expected %v, got %v", i, j, want[i][j], got[i][j])
+			}
+		}
+	}
+
+	if _, err := MergeShards(len(vectors), results[:len(results)-1]); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch for missing shard, got %v", err)
+	}
+	if _, err := MergeShards(len(vectors), append(results, results[0])); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch for duplicate shard, got %v", err)
+	}
+	if _, err := MergeShards(len(vectors), append(results[:1:1], nil)); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter for nil shard, got %v", err)
+	}
+}
+
+func TestComputeShardComputesDiagonal(t *testing.T) {
+	// A smoothed divergence is nonzero between a vector and itself
+	smoothed := func(a, b []float64) (float64, error) {
+		d, err := Euclidean(a, b)
+		return d + 0.5, err
+	}
+	vectors := [][]float64{{0}, {1}, {3}}
+	want, _ := BatchCompute(vectors, smoothed)
+
+	shards, _ := PartitionPairwise(len(vectors), 2)
+	var results []*ShardResult
+	for _, s := range shards {
+		res, err := ComputeShard(vectors, s, smoothed)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		results = append(results, res)
+	}
+	got, err := MergeShards(len(vectors), results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if got[i][j] != want[i][j] {
+				t.Errorf("[%d][%d]: expected %v, got %v", i, j, want[i][j], got[i][j])
+			}
+		}
+	}
+}
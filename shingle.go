@@ -0,0 +1,63 @@
+package distance
+
+import "strings"
+
+// Shingle returns the distinct k-shingles of text in order of first
+// occurrence: runs of k consecutive runes, or of k consecutive
+// whitespace-separated words joined by single spaces when word is true.
+// Text shorter than k yields itself as the only shingle, so short documents
+// still compare. The result plugs directly into JaccardSet,
+// JaccardSimilarity or HyperMinHash.AddAll; apply StringOptions first to
+// ignore case or punctuation.
+// Time: O(n·k), Space: O(n·k)
+func Shingle(text string, k int, word bool) ([]string, error) {
+	if k <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	var shingles []string
+	seen := make(map[string]bool)
+	add := func(s string) {
+		if !seen[s] {
+			seen[s] = true
+			shingles = append(shingles, s)
+		}
+	}
+
+	if word {
+		words := strings.Fields(text)
+		if len(words) > 0 && len(words) < k {
+			add(strings.Join(words, " "))
+		}
+		for i := 0; i+k <= len(words); i++ {
+			add(strings.Join(words[i:i+k], " "))
+		}
+		return shingles, nil
+	}
+
+	runes := []rune(text)
+	if len(runes) > 0 && len(runes) < k {
+		add(text)
+	}
+	for i := 0; i+k <= len(runes); i++ {
+		add(string(runes[i : i+k]))
+	}
+	return shingles, nil
+}
+
+// ShingleSimilarity returns the Jaccard similarity of the k-shingle sets
+// of two documents (see Shingle), the resemblance measure of Broder's
+// near-duplicate detection.
+// Range [0, 1] where 1=identical
+// Time: O((n+m)·k), Space: O((n+m)·k)
+func ShingleSimilarity(a, b string, k int, word bool) (float64, error) {
+	shinglesA, err := Shingle(a, k, word)
+	if err != nil {
+		return 0, err
+	}
+	shinglesB, err := Shingle(b, k, word)
+	if err != nil {
+		return 0, err
+	}
+	return JaccardSimilarity(shinglesA, shinglesB)
+}
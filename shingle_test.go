@@ -0,0 +1,69 @@
+package distance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShingle(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		k        int
+		word     bool
+		expected []string
+	}{
+		{"characters", "abcab", 2, false, []string{"ab", "bc", "ca"}},
+		{"unicode", "café", 3, false, []string{"caf", "afé"}},
+		{"words", "a rose is a rose is a rose", 3, true, []string{"a rose is", "rose is a", "is a rose"}},
+		{"extra whitespace", " the  cat\tsat ", 2, true, []string{"the cat", "cat sat"}},
+		{"short text", "hi", 4, false, []string{"hi"}},
+		{"few words", "hello world", 3, true, []string{"hello world"}},
+		{"empty", "", 2, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Shingle(tt.text, tt.k, tt.word)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := Shingle("abc", 0, false); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestShingleSimilarity(t *testing.T) {
+	a := "the quick brown fox jumps over the lazy dog"
+	b := "the quick brown fox jumped over the lazy dog"
+
+	words, err := ShingleSimilarity(a, b, 2, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 8 bigrams each, 6 shared: 6 / 10
+	if !almostEqual(words, 0.6) {
+		t.Errorf("expected 0.6, got %v", words)
+	}
+	chars, _ := ShingleSimilarity(a, b, 4, false)
+	if chars <= words || chars >= 1 {
+		t.Errorf("expected character shingles to be more forgiving, got %v", chars)
+	}
+	if same, _ := ShingleSimilarity(a, a, 3, true); !almostEqual(same, 1) {
+		t.Errorf("expected 1, got %v", same)
+	}
+
+	// The same shingles feed a HyperMinHash sketch
+	shingles, _ := Shingle(a, 2, true)
+	s, _ := NewHyperMinHash(8)
+	s.AddAll(shingles)
+	if got := s.Cardinality(); got < 7 || got > 9 {
+		t.Errorf("expected about 8 distinct shingles, got %v", got)
+	}
+}
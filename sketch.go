@@ -0,0 +1,384 @@
+package distance
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// CountMinSketch is a fixed-size frequency summary of an unbounded event stream.
+// Each of depth rows hashes items into width counters. Two sketches built with
+// the same width and depth share hash functions and can be compared directly.
+type CountMinSketch struct {
+	width  int
+	depth  int
+	counts [][]uint64
+	total  uint64
+}
+
+// NewCountMinSketch creates an empty sketch with the given width and depth.
+// Error bound is roughly e/width with probability 1 - exp(-depth).
+func NewCountMinSketch(width, depth int) (*CountMinSketch, error) {
+	if width <= 0 || depth <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	counts := make([][]uint64, depth)
+	for i := range counts {
+		counts[i] = make([]uint64, width)
+	}
+	return &CountMinSketch{width: width, depth: depth, counts: counts}, nil
+}
+
+// Add records count occurrences of item.
+// Time: O(depth), Space: O(1)
+func (s *CountMinSketch) Add(item []byte, count uint64) {
+	h1, h2 := sketchHashes(item)
+	for row := 0; row < s.depth; row++ {
+		s.counts[row][s.bucket(h1, h2, row)] += count
+	}
+	s.total += count
+}
+
+// AddString records count occurrences of a string item.
+// Time: O(depth), Space: O(1)
+func (s *CountMinSketch) AddString(item string, count uint64) {
+	s.Add([]byte(item), count)
+}
+
+// Estimate returns the estimated frequency of item (never an underestimate).
+// Time: O(depth), Space: O(1)
+func (s *CountMinSketch) Estimate(item []byte) uint64 {
+	h1, h2 := sketchHashes(item)
+	est := uint64(math.MaxUint64)
+	for row := 0; row < s.depth; row++ {
+		if c := s.counts[row][s.bucket(h1, h2, row)]; c < est {
+			est = c
+		}
+	}
+	return est
+}
+
+// EstimateString returns the estimated frequency of a string item.
+// Time: O(depth), Space: O(1)
+func (s *CountMinSketch) EstimateString(item string) uint64 {
+	return s.Estimate([]byte(item))
+}
+
+// Total returns the total count added to the sketch.
+func (s *CountMinSketch) Total() uint64 {
+	return s.total
+}
+
+// Merge adds the counts of other into s. Both sketches must have the same shape.
+// Time: O(width*depth), Space: O(1)
+func (s *CountMinSketch) Merge(other *CountMinSketch) error {
+	if err := validateSketches(s, other); err != nil {
+		return err
+	}
+	for row := range s.counts {
+		for col := range s.counts[row] {
+			s.counts[row][col] += other.counts[row][col]
+		}
+	}
+	s.total += other.total
+	return nil
+}
+
+func (s *CountMinSketch) bucket(h1, h2 uint64, row int) int {
+	// Kirsch-Mitzenmacher double hashing: g_i(x) = h1(x) + i*h2(x)
+	return int((h1 + uint64(row)*h2) % uint64(s.width))
+}
+
+// rowDistribution returns row counts normalized to a probability distribution
+func (s *CountMinSketch) rowDistribution(row int) []float64 {
+	dist := make([]float64, s.width)
+	if s.total == 0 {
+		return dist
+	}
+	for i, c := range s.counts[row] {
+		dist[i] = float64(c) / float64(s.total)
+	}
+	return dist
+}
+
+// CountMinL1 estimates the L1 distance between the normalized item
+// distributions summarized by two sketches.
+// Hash collisions can only cancel differences, so every row is a lower bound
+// and the maximum across rows is returned.
+// Time: O(width*depth), Space: O(width)
+func CountMinL1(a, b *CountMinSketch) (float64, error) {
+	if err := validateSketches(a, b); err != nil {
+		return 0, err
+	}
+
+	best := 0.0
+	for row := 0; row < a.depth; row++ {
+		d, err := Manhattan(a.rowDistribution(row), b.rowDistribution(row))
+		if err != nil {
+			return 0, err
+		}
+		best = math.Max(best, d)
+	}
+	return best, nil
+}
+
+// CountMinL2 estimates the L2 distance between the normalized item
+// distributions summarized by two sketches, using the median across rows.
+// Time: O(width*depth), Space: O(width+depth)
+func CountMinL2(a, b *CountMinSketch) (float64, error) {
+	if err := validateSketches(a, b); err != nil {
+		return 0, err
+	}
+
+	estimates := make([]float64, a.depth)
+	for row := 0; row < a.depth; row++ {
+		d, err := Euclidean(a.rowDistribution(row), b.rowDistribution(row))
+		if err != nil {
+			return 0, err
+		}
+		estimates[row] = d
+	}
+	return median(estimates), nil
+}
+
+// CountMinJS estimates the Jensen-Shannon divergence between the item
+// distributions summarized by two sketches.
+// Bucketing can only reduce divergence, so the maximum across rows is returned.
+// Time: O(width*depth), Space: O(width)
+func CountMinJS(a, b *CountMinSketch) (float64, error) {
+	if err := validateSketches(a, b); err != nil {
+		return 0, err
+	}
+
+	best := 0.0
+	for row := 0; row < a.depth; row++ {
+		d, err := JensenShannonDivergence(a.rowDistribution(row), b.rowDistribution(row))
+		if err != nil {
+			return 0, err
+		}
+		best = math.Max(best, d)
+	}
+	return best, nil
+}
+
+// TDigest is a mergeable quantile sketch (Dunning's merging t-digest).
+// It summarizes a stream of values with O(compression) centroids while keeping
+// tail quantiles accurate, so latency distributions can be compared without
+// retaining raw samples.
+type TDigest struct {
+	compression float64
+	centroids   []tdCentroid // sorted by mean after flush
+	buffer      []tdCentroid
+	count       float64
+	min, max    float64
+}
+
+type tdCentroid struct {
+	mean   float64
+	weight float64
+}
+
+// tdQuadraturePoints is the number of quantile levels sampled by TDigestWasserstein
+const tdQuadraturePoints = 1000
+
+// NewTDigest creates an empty t-digest. Typical compression is 100;
+// higher values use more memory and give more accurate quantiles.
+func NewTDigest(compression float64) (*TDigest, error) {
+	if compression <= 0 || math.IsNaN(compression) || math.IsInf(compression, 0) {
+		return nil, ErrInvalidParameter
+	}
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}, nil
+}
+
+// Add records a single value.
+// Time: amortized O(log compression), Space: O(1)
+func (d *TDigest) Add(x float64) {
+	d.AddWeighted(x, 1)
+}
+
+// AddWeighted records a value with the given weight. Non-positive weights
+// and NaN values are ignored.
+// Time: amortized O(log compression), Space: O(1)
+func (d *TDigest) AddWeighted(x, weight float64) {
+	if weight <= 0 || math.IsNaN(x) {
+		return
+	}
+	d.buffer = append(d.buffer, tdCentroid{mean: x, weight: weight})
+	d.count += weight
+	d.min = math.Min(d.min, x)
+	d.max = math.Max(d.max, x)
+	if len(d.buffer) >= int(5*d.compression)+1 {
+		d.flush()
+	}
+}
+
+// Count returns the total weight added to the digest.
+func (d *TDigest) Count() float64 {
+	return d.count
+}
+
+// Merge adds all values summarized by other into d.
+// Time: O(k log k) where k = number of centroids, Space: O(k)
+func (d *TDigest) Merge(other *TDigest) {
+	if other == nil || other.count == 0 {
+		return
+	}
+	other.flush()
+	d.buffer = append(d.buffer, other.centroids...)
+	d.count += other.count
+	d.min = math.Min(d.min, other.min)
+	d.max = math.Max(d.max, other.max)
+	d.flush()
+}
+
+// Quantile returns the estimated value at quantile q in [0, 1].
+// Time: O(k) where k = number of centroids, Space: O(1)
+func (d *TDigest) Quantile(q float64) (float64, error) {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, ErrInvalidParameter
+	}
+	if d.count == 0 {
+		return 0, ErrEmptyInput
+	}
+	d.flush()
+
+	cs := d.centroids
+	if len(cs) == 1 {
+		return cs[0].mean, nil
+	}
+
+	// Each centroid is centered at its cumulative midpoint; interpolate
+	// between neighboring centers and towards min/max at the tails.
+	index := q * d.count
+	cumulative := 0.0
+	prevCenter, prevMean := 0.0, d.min
+	for _, c := range cs {
+		center := cumulative + c.weight/2
+		if index < center {
+			return interpolate(prevCenter, prevMean, center, c.mean, index), nil
+		}
+		prevCenter, prevMean = center, c.mean
+		cumulative += c.weight
+	}
+	return interpolate(prevCenter, prevMean, d.count, d.max, index), nil
+}
+
+// flush merges buffered values into the centroid list using the k1 scale function
+func (d *TDigest) flush() {
+	if len(d.buffer) == 0 {
+		return
+	}
+
+	all := make([]tdCentroid, 0, len(d.centroids)+len(d.buffer))
+	all = append(all, d.centroids...)
+	all = append(all, d.buffer...)
+	d.buffer = d.buffer[:0]
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+
+	merged := make([]tdCentroid, 0, len(all))
+	cur := all[0]
+	weightSoFar := 0.0
+	qLimit := d.kInverse(d.k(0) + 1)
+
+	for _, c := range all[1:] {
+		if (weightSoFar+cur.weight+c.weight)/d.count <= qLimit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		weightSoFar += cur.weight
+		merged = append(merged, cur)
+		qLimit = d.kInverse(d.k(weightSoFar/d.count) + 1)
+		cur = c
+	}
+	d.centroids = append(merged, cur)
+}
+
+// k is the k1 scale function: k(q) = δ/(2π)·asin(2q-1)
+func (d *TDigest) k(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// kInverse inverts k, saturating at q=1
+func (d *TDigest) kInverse(k float64) float64 {
+	if k >= d.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/d.compression) + 1) / 2
+}
+
+// TDigestWasserstein estimates the 1D Wasserstein (Earth Mover's) distance
+// between the distributions summarized by two t-digests:
+// W1 = ∫₀¹ |Fa⁻¹(q) - Fb⁻¹(q)| dq, evaluated with the midpoint rule.
+// Time: O(k·m) where m = quadrature points, Space: O(1)
+func TDigestWasserstein(a, b *TDigest) (float64, error) {
+	if a == nil || b == nil || a.count == 0 || b.count == 0 {
+		return 0, ErrEmptyInput
+	}
+
+	var sum float64
+	for i := 0; i < tdQuadraturePoints; i++ {
+		q := (float64(i) + 0.5) / tdQuadraturePoints
+		qa, err := a.Quantile(q)
+		if err != nil {
+			return 0, err
+		}
+		qb, err := b.Quantile(q)
+		if err != nil {
+			return 0, err
+		}
+		sum += math.Abs(qa - qb)
+	}
+	return sum / tdQuadraturePoints, nil
+}
+
+// interpolate linearly between (x0, y0) and (x1, y1) at x
+func interpolate(x0, y0, x1, y1, x float64) float64 {
+	if x1 == x0 {
+		return y0
+	}
+	return y0 + (y1-y0)*(x-x0)/(x1-x0)
+}
+
+func validateSketches(a, b *CountMinSketch) error {
+	if a == nil || b == nil {
+		return ErrEmptyInput
+	}
+	if a.width != b.width || a.depth != b.depth {
+		return ErrDimensionMismatch
+	}
+	return nil
+}
+
+// sketchHashes derives two independent 64-bit hashes from one FNV-1a pass
+func sketchHashes(item []byte) (uint64, uint64) {
+	h := fnv.New64a()
+	_, _ = h.Write(item)
+	h1 := h.Sum64()
+	// SplitMix64 finalizer decorrelates the second hash from the first
+	h2 := h1 + 0x9e3779b97f4a7c15
+	h2 = (h2 ^ (h2 >> 30)) * 0xbf58476d1ce4e5b9
+	h2 = (h2 ^ (h2 >> 27)) * 0x94d049bb133111eb
+	h2 ^= h2 >> 31
+	return h1, h2 | 1
+}
+
+// median returns the median of values; values is reordered in place
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
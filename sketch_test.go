@@ -0,0 +1,192 @@
+package distance
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCountMinSketchEstimate(t *testing.T) {
+	s, err := NewCountMinSketch(256, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s.AddString("a", 10)
+	s.AddString("b", 3)
+	s.AddString("a", 5)
+
+	if got := s.EstimateString("a"); got < 15 {
+		t.Errorf("estimate must never undercount: expected >= 15, got %d", got)
+	}
+	if got := s.EstimateString("b"); got < 3 {
+		t.Errorf("estimate must never undercount: expected >= 3, got %d", got)
+	}
+	if s.Total() != 18 {
+		t.Errorf("expected total 18, got %d", s.Total())
+	}
+}
+
+func TestNewCountMinSketchInvalid(t *testing.T) {
+	if _, err := NewCountMinSketch(0, 4); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := NewCountMinSketch(4, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestCountMinDistances(t *testing.T) {
+	a, _ := NewCountMinSketch(1024, 5)
+	b, _ := NewCountMinSketch(1024, 5)
+	c, _ := NewCountMinSketch(1024, 5)
+
+	for i := 0; i < 50; i++ {
+		key := fmt.Sprintf("event-%d", i)
+		a.AddString(key, 2)
+		b.AddString(key, 1)
+		c.AddString(fmt.Sprintf("other-%d", i), 1)
+	}
+
+	// Same distribution, different volume
+	l1, err := CountMinL1(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(l1, 0) {
+		t.Errorf("expected L1 of 0 for proportional streams, got %v", l1)
+	}
+
+	// Disjoint supports
+	l1, _ = CountMinL1(a, c)
+	if l1 < 1.5 || l1 > 2+epsilon {
+		t.Errorf("expected L1 close to 2 for disjoint streams, got %v", l1)
+	}
+	l2, _ := CountMinL2(a, c)
+	if math.Abs(l2-math.Sqrt(2.0/50)) > 0.05 {
+		t.Errorf("expected L2 close to %v, got %v", math.Sqrt(2.0/50), l2)
+	}
+	js, _ := CountMinJS(a, c)
+	if js < 0.6 || js > math.Log(2)+epsilon {
+		t.Errorf("expected JS close to log(2), got %v", js)
+	}
+}
+
+func TestCountMinMismatch(t *testing.T) {
+	a, _ := NewCountMinSketch(64, 3)
+	b, _ := NewCountMinSketch(32, 3)
+
+	if _, err := CountMinL1(a, b); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if err := a.Merge(b); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestCountMinMerge(t *testing.T) {
+	a, _ := NewCountMinSketch(64, 3)
+	b, _ := NewCountMinSketch(64, 3)
+	a.AddString("x", 4)
+	b.AddString("x", 6)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := a.EstimateString("x"); got < 10 {
+		t.Errorf("expected >= 10, got %d", got)
+	}
+	if a.Total() != 10 {
+		t.Errorf("expected total 10, got %d", a.Total())
+	}
+}
+
+func TestTDigestQuantile(t *testing.T) {
+	d, err := NewTDigest(100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 1; i <= 10000; i++ {
+		d.Add(float64(i))
+	}
+
+	for _, q := range []float64{0.01, 0.25, 0.5, 0.75, 0.99} {
+		got, err := d.Quantile(q)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := q * 10000
+		if math.Abs(got-want) > 0.01*10000 {
+			t.Errorf("quantile %v: expected ~%v, got %v", q, want, got)
+		}
+	}
+
+	if got, _ := d.Quantile(0); got != 1 {
+		t.Errorf("expected min 1, got %v", got)
+	}
+	if got, _ := d.Quantile(1); got != 10000 {
+		t.Errorf("expected max 10000, got %v", got)
+	}
+	if d.Count() != 10000 {
+		t.Errorf("expected count 10000, got %v", d.Count())
+	}
+}
+
+func TestTDigestErrors(t *testing.T) {
+	if _, err := NewTDigest(0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+
+	d, _ := NewTDigest(100)
+	if _, err := d.Quantile(0.5); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	d.Add(1)
+	if _, err := d.Quantile(1.5); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := TDigestWasserstein(d, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestTDigestWasserstein(t *testing.T) {
+	a, _ := NewTDigest(100)
+	b, _ := NewTDigest(100)
+	for i := 0; i < 5000; i++ {
+		x := float64(i%1000) / 10
+		a.Add(x)
+		b.Add(x + 20) // shifted by 20ms
+	}
+
+	dist, err := TDigestWasserstein(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(dist-20) > 0.5 {
+		t.Errorf("expected shift of ~20, got %v", dist)
+	}
+
+	self, _ := TDigestWasserstein(a, a)
+	if !almostEqual(self, 0) {
+		t.Errorf("expected 0 for identical digests, got %v", self)
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	a, _ := NewTDigest(100)
+	b, _ := NewTDigest(100)
+	for i := 0; i < 1000; i++ {
+		a.Add(float64(i))
+		b.Add(float64(i + 1000))
+	}
+	a.Merge(b)
+
+	if a.Count() != 2000 {
+		t.Errorf("expected count 2000, got %v", a.Count())
+	}
+	median, _ := a.Quantile(0.5)
+	if math.Abs(median-1000) > 20 {
+		t.Errorf("expected median ~1000, got %v", median)
+	}
+}
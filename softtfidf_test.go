@@ -0,0 +1,81 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSoftTFIDF(t *testing.T) {
+	jw := func(x, y string) float64 {
+		s, _ := JaroWinkler(x, y, 0.1)
+		return s
+	}
+	jonathon, _ := JaroWinkler("jonathon", "jonathan", 0.1)
+	smyth, _ := JaroWinkler("smyth", "smith", 0.1)
+
+	tests := []struct {
+		name      string
+		a, b      string
+		threshold float64
+		expected  float64
+	}{
+		{"identical", "Acme Corp", "acme corp", 0.9, 1},
+		{"both empty", "", " ", 0.9, 1},
+		{"one empty", "acme", "", 0.9, 0},
+		// Each token carries weight 1/sqrt(2) on both sides
+		{"fuzzy tokens", "Jonathon Smyth", "Jonathan Smith", 0.85, (jonathon + smyth) / 2},
+		{"below threshold", "Jonathon Smyth", "Jonathan Smith", 0.9, jonathon / 2},
+		{"exact only", "Jonathon Smyth", "Jonathan Smith", 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SoftTFIDF(tt.a, tt.b, jw, tt.threshold)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// A nil secondary defaults to Jaro-Winkler
+	if result, _ := SoftTFIDF("Jonathon Smyth", "Jonathan Smith", nil, 0.85); !almostEqual(result, (jonathon+smyth)/2) {
+		t.Errorf("expected %v, got %v", (jonathon+smyth)/2, result)
+	}
+}
+
+func TestTFIDFCorpusSoftTFIDF(t *testing.T) {
+	corpus := NewTFIDFCorpus([]string{
+		"Acme Corporation", "Globex Corporation", "Initech Corporation",
+		"Umbrella Corporation", "Acme Inc",
+	})
+	if corpus.IDF("corporation") >= corpus.IDF("acme") || corpus.IDF("acme") >= corpus.IDF("unseen") {
+		t.Errorf("expected rarer tokens to weigh more: %v %v %v",
+			corpus.IDF("corporation"), corpus.IDF("acme"), corpus.IDF("unseen"))
+	}
+	if want := math.Log(6.0/5) + 1; !almostEqual(corpus.IDF("Corporation"), want) {
+		t.Errorf("expected %v, got %v", want, corpus.IDF("Corporation"))
+	}
+
+	// Without IDF both candidates share one of two tokens; with it the rare name wins
+	sameName, _ := corpus.SoftTFIDF("acme corporation", "acme inc", nil, 0.9)
+	sameSuffix, _ := corpus.SoftTFIDF("acme corporation", "globex corporation", nil, 0.9)
+	if sameName <= sameSuffix {
+		t.Errorf("expected the shared rare token to dominate, got %v <= %v", sameName, sameSuffix)
+	}
+	plainName, _ := SoftTFIDF("acme corporation", "acme inc", nil, 0.9)
+	plainSuffix, _ := SoftTFIDF("acme corporation", "globex corporation", nil, 0.9)
+	if !almostEqual(plainName, plainSuffix) {
+		t.Errorf("expected equal scores without a corpus, got %v and %v", plainName, plainSuffix)
+	}
+}
+
+func TestSoftTFIDFInvalidThreshold(t *testing.T) {
+	for _, threshold := range []float64{-0.1, 1.1, math.NaN()} {
+		if _, err := SoftTFIDF("a", "b", nil, threshold); err != ErrInvalidParameter {
+			t.Errorf("threshold %v: expected ErrInvalidParameter, got %v", threshold, err)
+		}
+	}
+}
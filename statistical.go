@@ -189,6 +189,71 @@ func CrossEntropy[T Float](p, q []T) (float64, error) {
 	return entropy, nil
 }
 
+// RenyiDivergence computes Rényi divergence of order alpha:
+// D_α(P||Q) = 1/(α-1) · log Σ p^α q^(1-α)
+// alpha=1 is the limit case and returns KLDivergence.
+// NOTE: Asymmetric; alpha must be non-negative
+// Time: O(n), Space: O(1)
+func RenyiDivergence[T Float](p, q []T, alpha float64) (float64, error) {
+	if alpha == 1 {
+		return KLDivergence(p, q)
+	}
+	sum, err := alphaMoment(p, q, alpha)
+	if err != nil || math.IsInf(sum, 1) {
+		return sum, err
+	}
+	if sum == 0 {
+		return math.Inf(1), nil // disjoint supports
+	}
+	return math.Log(sum) / (alpha - 1), nil
+}
+
+// TsallisDivergence computes Tsallis relative entropy of order alpha:
+// D_α(P||Q) = (Σ p^α q^(1-α) - 1) / (α-1)
+// alpha=1 is the limit case and returns KLDivergence.
+// NOTE: Asymmetric; alpha must be non-negative
+// Time: O(n), Space: O(1)
+func TsallisDivergence[T Float](p, q []T, alpha float64) (float64, error) {
+	if alpha == 1 {
+		return KLDivergence(p, q)
+	}
+	sum, err := alphaMoment(p, q, alpha)
+	if err != nil || math.IsInf(sum, 1) {
+		return sum, err
+	}
+	return (sum - 1) / (alpha - 1), nil
+}
+
+// alphaMoment computes Σ p^α q^(1-α) over the support of p,
+// returning +Inf when α > 1 and q vanishes where p does not
+func alphaMoment[T Float](p, q []T, alpha float64) (float64, error) {
+	if alpha < 0 || math.IsNaN(alpha) || math.IsInf(alpha, 0) {
+		return 0, ErrInvalidParameter
+	}
+	if err := Validate(p, q); err != nil {
+		return 0, err
+	}
+
+	var sum float64
+	for i := range p {
+		pi, qi := float64(p[i]), float64(q[i])
+		if pi < 0 || qi < 0 {
+			return 0, ErrNegativeValue
+		}
+		if pi == 0 {
+			continue
+		}
+		if qi == 0 {
+			if alpha > 1 {
+				return math.Inf(1), nil
+			}
+			continue
+		}
+		sum += math.Pow(pi, alpha) * math.Pow(qi, 1-alpha)
+	}
+	return sum, nil
+}
+
 // PearsonCorrelation computes Pearson correlation coefficient.
 // Range [-1, 1] where 1=perfect positive, -1=perfect negative, 0=no correlation
 // Time: O(n), Space: O(1)
@@ -290,6 +355,195 @@ func computeRanks[T Number](values []T) []float64 {
 	return ranks
 }
 
+// KendallTau computes the Kendall tau-b rank correlation, which adjusts for ties.
+// Discordant pairs are counted with Knight's merge-sort algorithm.
+// Range [-1, 1] where 1=identical ordering, -1=reversed ordering
+// Time: O(n log n), Space: O(n)
+func KendallTau[T Number](a, b []T) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+
+	n := len(a)
+	pairs := make([][2]float64, n)
+	for i := range a {
+		pairs[i] = [2]float64{float64(a[i]), float64(b[i])}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+
+	// Pairs tied in a (tiesA) and tied in both (tiesBoth)
+	var tiesA, tiesBoth int64
+	for i := 0; i < n; {
+		j, k := i, i
+		for j < n && pairs[j][0] == pairs[i][0] {
+			if pairs[j][1] != pairs[k][1] {
+				tiesBoth += tiePairs(j - k)
+				k = j
+			}
+			j++
+		}
+		tiesBoth += tiePairs(j - k)
+		tiesA += tiePairs(j - i)
+		i = j
+	}
+
+	ys := make([]float64, n)
+	for i, p := range pairs {
+		ys[i] = p[1]
+	}
+	swaps := mergeCountSwaps(ys, make([]float64, n))
+
+	var tiesB int64
+	for i := 0; i < n; {
+		j := i
+		for j < n && ys[j] == ys[i] {
+			j++
+		}
+		tiesB += tiePairs(j - i)
+		i = j
+	}
+
+	total := tiePairs(n)
+	denom := math.Sqrt(float64(total-tiesA) * float64(total-tiesB))
+	if denom == 0 {
+		return 0, ErrZeroVector
+	}
+	return float64(total-tiesA-tiesB+tiesBoth-2*swaps) / denom, nil
+}
+
+// KendallDistance computes (1 - τ_b)/2, which equals the fraction of
+// discordant pairs when there are no ties.
+// Range [0, 1] where 0=identical ordering
+// Time: O(n log n), Space: O(n)
+func KendallDistance[T Number](a, b []T) (float64, error) {
+	tau, err := KendallTau(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return (1 - tau) / 2, nil
+}
+
+// tiePairs returns the number of pairs in a group of size t
+func tiePairs(t int) int64 {
+	return int64(t) * int64(t-1) / 2
+}
+
+// mergeCountSwaps sorts x in place and returns the number of inversions
+func mergeCountSwaps(x, buf []float64) int64 {
+	if len(x) < 2 {
+		return 0
+	}
+	mid := len(x) / 2
+	swaps := mergeCountSwaps(x[:mid], buf[:mid]) + mergeCountSwaps(x[mid:], buf[mid:])
+
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(x) {
+		if x[j] < x[i] {
+			buf[k] = x[j]
+			swaps += int64(mid - i)
+			j++
+		} else {
+			buf[k] = x[i]
+			i++
+		}
+		k++
+	}
+	k += copy(buf[k:], x[i:mid])
+	copy(buf[k:], x[j:])
+	copy(x, buf[:len(x)])
+	return swaps
+}
+
+// DistanceCovariance computes Székely's squared distance covariance dCov²(X, Y)
+// between paired multivariate samples (row i of X pairs with row i of Y).
+// Time: O(n²(p+q)), Space: O(n²)
+func DistanceCovariance(x, y [][]float64) (float64, error) {
+	a, b, err := centeredDistances(x, y)
+	if err != nil {
+		return 0, err
+	}
+	return productMean(a, b), nil
+}
+
+// DistanceCorrelation computes Székely's distance correlation dCor(X, Y).
+// Unlike Pearson it is zero only under independence, so it detects nonlinear
+// relationships. X and Y may have different dimensions.
+// Range [0, 1] where 0=independent
+// Time: O(n²(p+q)), Space: O(n²)
+func DistanceCorrelation(x, y [][]float64) (float64, error) {
+	a, b, err := centeredDistances(x, y)
+	if err != nil {
+		return 0, err
+	}
+
+	varX, varY := productMean(a, a), productMean(b, b)
+	if varX == 0 || varY == 0 {
+		return 0, ErrZeroVector
+	}
+	dcov2 := math.Max(productMean(a, b), 0)
+	return math.Sqrt(dcov2 / math.Sqrt(varX*varY)), nil
+}
+
+// centeredDistances returns the double-centered Euclidean distance matrices of x and y
+func centeredDistances(x, y [][]float64) ([][]float64, [][]float64, error) {
+	if len(x) == 0 || len(y) == 0 {
+		return nil, nil, ErrEmptyInput
+	}
+	if len(x) != len(y) {
+		return nil, nil, ErrDimensionMismatch
+	}
+
+	a, err := BatchCompute(x, Euclidean[float64])
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := BatchCompute(y, Euclidean[float64])
+	if err != nil {
+		return nil, nil, err
+	}
+	doubleCenter(a)
+	doubleCenter(b)
+	return a, b, nil
+}
+
+// doubleCenter subtracts row and column means and adds back the grand mean
+func doubleCenter(m [][]float64) {
+	n := float64(len(m))
+	rowMeans := make([]float64, len(m))
+	var grand float64
+	for i, row := range m {
+		for _, v := range row {
+			rowMeans[i] += v
+		}
+		grand += rowMeans[i]
+		rowMeans[i] /= n
+	}
+	grand /= n * n
+
+	// Matrix is symmetric, so column means equal row means
+	for i, row := range m {
+		for j := range row {
+			row[j] += grand - rowMeans[i] - rowMeans[j]
+		}
+	}
+}
+
+// productMean returns the mean of the elementwise product of two square matrices
+func productMean(a, b [][]float64) float64 {
+	var sum float64
+	for i := range a {
+		for j := range a[i] {
+			sum += a[i][j] * b[i][j]
+		}
+	}
+	return sum / float64(len(a)*len(a))
+}
+
 // Wasserstein1D computes 1D Wasserstein (Earth Mover's) distance.
 // For 1D distributions, this equals the area between CDFs.
 // Time: O(n log n), Space: O(n)
@@ -318,6 +572,63 @@ func Wasserstein1D[T Number](a, b []T) (float64, error) {
 	return distance / float64(len(a)), nil
 }
 
+// KolmogorovSmirnov computes the two-sample Kolmogorov-Smirnov statistic:
+// the largest absolute difference between the empirical CDFs of a and b.
+// Samples may have different sizes.
+// Range [0, 1] where 0=identical empirical distributions
+// Time: O((m+n) log(m+n)), Space: O(m+n)
+func KolmogorovSmirnov(a, b []float64) (float64, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, ErrEmptyInput
+	}
+	return ksStatistic(a, b), nil
+}
+
+// AndersonDarling computes the two-sample Anderson-Darling statistic
+// (Scholz-Stephens A² with k=2). Weights ECDF differences by 1/(F(1-F)),
+// so it is more sensitive to tail differences than Kolmogorov-Smirnov.
+// Ties are handled by evaluating only at distinct pooled values.
+// Samples may have different sizes.
+// Time: O((m+n) log(m+n)), Space: O(m+n)
+func AndersonDarling(a, b []float64) (float64, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, ErrEmptyInput
+	}
+
+	type obs struct {
+		x     float64
+		fromA bool
+	}
+	pooled := make([]obs, 0, len(a)+len(b))
+	for _, x := range a {
+		pooled = append(pooled, obs{x, true})
+	}
+	for _, x := range b {
+		pooled = append(pooled, obs{x, false})
+	}
+	sort.Slice(pooled, func(i, j int) bool {
+		return pooled[i].x < pooled[j].x
+	})
+
+	m, n := float64(len(a)), float64(len(b))
+	total := m + n
+	var sum, countA float64
+	for j := 1; j < len(pooled); j++ {
+		if pooled[j-1].fromA {
+			countA++
+		}
+		if pooled[j].x == pooled[j-1].x {
+			continue
+		}
+		// countA of the first j pooled observations come from a
+		fj := float64(j)
+		diff := total*countA - fj*m
+		sum += diff * diff / (fj * (total - fj))
+	}
+
+	return sum / (m * n), nil
+}
+
 // sortFloat64Slice sorts a float64 slice using standard library
 func sortFloat64Slice(arr []float64) {
 	sort.Float64s(arr)
@@ -548,3 +548,285 @@ func BenchmarkSpearmanCorrelation(b *testing.B) {
 		_, _ = SpearmanCorrelation(a, b2)
 	}
 }
+
+func TestKolmogorovSmirnov(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []float64
+		expected float64
+	}{
+		{"identical", []float64{1, 2, 3}, []float64{3, 1, 2}, 0},
+		{"disjoint", []float64{1, 2}, []float64{5, 6, 7}, 1},
+		{"partial overlap", []float64{1, 2, 3, 4}, []float64{3, 4, 5, 6}, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := KolmogorovSmirnov(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := KolmogorovSmirnov([]float64{}, []float64{1}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestAndersonDarling(t *testing.T) {
+	// Pettitt's formula by hand: pooled 1,2,3,4 with a={1,2}, b={3,4};
+	// j=1: (4·1-1·2)²/(1·3)=4/3, j=2: (4·2-2·2)²/(2·2)=4, j=3: (4·2-3·2)²/(3·1)=4/3
+	result, err := AndersonDarling([]float64{1, 2}, []float64{3, 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := (4.0/3 + 4 + 4.0/3) / 4; !almostEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+
+	same, _ := AndersonDarling([]float64{1, 2, 3, 4}, []float64{1, 2, 3, 4})
+	shifted, _ := AndersonDarling([]float64{1, 2, 3, 4}, []float64{3, 4, 5, 6})
+	if !almostEqual(same, 0) {
+		t.Errorf("expected 0 for identical samples, got %v", same)
+	}
+	if shifted <= same {
+		t.Errorf("expected shifted samples to score higher, got %v", shifted)
+	}
+
+	if _, err := AndersonDarling([]float64{1}, []float64{}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestRenyiTsallisDivergence(t *testing.T) {
+	p := []float64{0.5, 0.3, 0.2}
+	q := []float64{0.2, 0.5, 0.3}
+	kl, _ := KLDivergence(p, q)
+
+	// alpha=1 reduces to KL, and nearby orders converge to it
+	for _, fn := range []func([]float64, []float64, float64) (float64, error){
+		RenyiDivergence[float64], TsallisDivergence[float64],
+	} {
+		exact, err := fn(p, q, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !almostEqual(exact, kl) {
+			t.Errorf("expected KL %v at alpha=1, got %v", kl, exact)
+		}
+		near, _ := fn(p, q, 1+1e-7)
+		if math.Abs(near-kl) > 1e-5 {
+			t.Errorf("expected ~%v near alpha=1, got %v", kl, near)
+		}
+		self, _ := fn(p, p, 2)
+		if !almostEqual(self, 0) {
+			t.Errorf("expected 0 for identical distributions, got %v", self)
+		}
+	}
+
+	// Rényi order 1/2 is -2·log of the Bhattacharyya coefficient
+	bc := math.Sqrt(0.5*0.2) + math.Sqrt(0.3*0.5) + math.Sqrt(0.2*0.3)
+	r, _ := RenyiDivergence(p, q, 0.5)
+	if !almostEqual(r, -2*math.Log(bc)) {
+		t.Errorf("expected %v, got %v", -2*math.Log(bc), r)
+	}
+	ts, _ := TsallisDivergence(p, q, 2)
+	want := 0.25/0.2 + 0.09/0.5 + 0.04/0.3 - 1
+	if !almostEqual(ts, want) {
+		t.Errorf("expected %v, got %v", want, ts)
+	}
+}
+
+func TestRenyiTsallisEdgeCases(t *testing.T) {
+	p := []float64{1, 0}
+	q := []float64{0, 1}
+
+	if d, _ := RenyiDivergence(p, q, 2); !math.IsInf(d, 1) {
+		t.Errorf("expected +Inf for alpha > 1 with disjoint support, got %v", d)
+	}
+	if d, _ := RenyiDivergence(p, q, 0.5); !math.IsInf(d, 1) {
+		t.Errorf("expected +Inf for disjoint supports, got %v", d)
+	}
+	if d, _ := TsallisDivergence(p, q, 0.5); !almostEqual(d, 2) {
+		t.Errorf("expected 2, got %v", d)
+	}
+
+	if _, err := RenyiDivergence(p, q, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := TsallisDivergence([]float64{-0.1, 1.1}, q, 2); err != ErrNegativeValue {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+	if _, err := RenyiDivergence(p, []float64{1}, 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestKendallTau(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []float64
+		expected float64
+	}{
+		{"identical order", []float64{1, 2, 3, 4}, []float64{10, 20, 30, 40}, 1},
+		{"reversed", []float64{1, 2, 3, 4}, []float64{4, 3, 2, 1}, -1},
+		{"one swap", []float64{1, 2, 3, 4}, []float64{1, 3, 2, 4}, 4.0 / 6},
+		// concordant 4, discordant 0, one pair tied in a and one in b
+		{"ties", []float64{1, 1, 2, 3}, []float64{1, 2, 3, 3}, 4 / math.Sqrt(5*5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := KendallTau(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := KendallTau([]float64{1, 1}, []float64{1, 2}); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+	if _, err := KendallTau([]float64{1}, []float64{1, 2}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestKendallTauMatchesBruteForce(t *testing.T) {
+	a := []int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
+	b := []int{2, 7, 1, 8, 2, 8, 1, 8, 2, 8, 4}
+
+	var conc, disc, tiesA, tiesB float64
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			da, db := a[i]-a[j], b[i]-b[j]
+			switch {
+			case da == 0 && db == 0:
+			case da == 0:
+				tiesA++
+			case db == 0:
+				tiesB++
+			case (da > 0) == (db > 0):
+				conc++
+			default:
+				disc++
+			}
+		}
+	}
+	want := (conc - disc) / math.Sqrt((conc+disc+tiesA)*(conc+disc+tiesB))
+
+	got, err := KendallTau(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	dist, _ := KendallDistance(a, b)
+	if !almostEqual(dist, (1-want)/2) {
+		t.Errorf("expected %v, got %v", (1-want)/2, dist)
+	}
+}
+
+func TestDistanceCorrelation(t *testing.T) {
+	var x, linear, quadratic [][]float64
+	for i := -10; i <= 10; i++ {
+		v := float64(i)
+		x = append(x, []float64{v})
+		linear = append(linear, []float64{3*v + 1})
+		quadratic = append(quadratic, []float64{v * v})
+	}
+
+	dcor, err := DistanceCorrelation(x, linear)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(dcor, 1) {
+		t.Errorf("expected 1 for linear relationship, got %v", dcor)
+	}
+
+	// Pearson misses the symmetric quadratic relationship; dCor does not
+	flatX := make([]float64, len(x))
+	flatQ := make([]float64, len(x))
+	for i := range x {
+		flatX[i], flatQ[i] = x[i][0], quadratic[i][0]
+	}
+	pearson, _ := PearsonCorrelation(flatX, flatQ)
+	dcor, _ = DistanceCorrelation(x, quadratic)
+	if math.Abs(pearson) > 1e-9 || dcor < 0.3 {
+		t.Errorf("expected pearson≈0 and dcor>0.3, got %v and %v", pearson, dcor)
+	}
+
+	// dCov²(X, cX) = |c|·dVar²(X)
+	cov, _ := DistanceCovariance([][]float64{{0}, {1}, {2}}, [][]float64{{0}, {2}, {4}})
+	v, _ := DistanceCovariance([][]float64{{0}, {1}, {2}}, [][]float64{{0}, {1}, {2}})
+	if !almostEqual(cov, 2*v) {
+		t.Errorf("expected dCov to scale linearly, got %v vs %v", cov, v)
+	}
+}
+
+func TestDistanceCorrelationErrors(t *testing.T) {
+	if _, err := DistanceCorrelation(nil, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := DistanceCorrelation([][]float64{{1}, {2}}, [][]float64{{1}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := DistanceCorrelation([][]float64{{1}, {1}}, [][]float64{{1}, {2}}); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+}
+
+func TestValidateDistribution(t *testing.T) {
+	tests := []struct {
+		name string
+		p    []float64
+		err  error
+	}{
+		{"valid", []float64{0.2, 0.3, 0.5}, nil},
+		{"within tolerance", []float64{0.2, 0.3, 0.5000001}, nil},
+		{"unnormalized", []float64{2, 3, 5}, ErrNotDistribution},
+		{"negative", []float64{-0.5, 1.5}, ErrNegativeValue},
+		{"empty", []float64{}, ErrEmptyInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateDistribution(tt.p, 1e-6); err != tt.err {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestStrictAndNormalizedDivergence(t *testing.T) {
+	p := []float64{2, 3, 5}
+	q := []float64{0.3, 0.3, 0.4}
+
+	strict := StrictDivergence(KLDivergence[float64], 1e-9)
+	if _, err := strict(p, q); err != ErrNotDistribution {
+		t.Errorf("expected ErrNotDistribution, got %v", err)
+	}
+
+	normalized := NormalizedDivergence(KLDivergence[float64])
+	got, err := normalized(p, q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, _ := strict([]float64{0.2, 0.3, 0.5}, q)
+	if !almostEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if _, err := normalized([]float64{0, 0, 0}, q); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package distance
+
+import (
+	"math/rand/v2"
+)
+
+// Reservoir maintains a uniform random sample of fixed capacity over an
+// unbounded stream (Vitter's Algorithm R). Item counts within the sample are
+// maintained incrementally, so distribution distances between two reservoirs
+// cost O(distinct items) rather than O(capacity).
+type Reservoir[T comparable] struct {
+	capacity int
+	items    []T
+	counts   map[T]int
+	seen     int
+}
+
+// NewReservoir creates an empty reservoir holding at most capacity items.
+func NewReservoir[T comparable](capacity int) (*Reservoir[T], error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	return &Reservoir[T]{
+		capacity: capacity,
+		items:    make([]T, 0, capacity),
+		counts:   make(map[T]int),
+	}, nil
+}
+
+// Add offers an item from the stream to the reservoir.
+// Time: O(1), Space: O(1)
+func (r *Reservoir[T]) Add(x T) {
+	r.seen++
+	if len(r.items) < r.capacity {
+		r.items = append(r.items, x)
+		r.counts[x]++
+		return
+	}
+
+	//nolint:gosec // G404: sampling does not require cryptographic randomness
+	j := rand.IntN(r.seen)
+	if j >= r.capacity {
+		return
+	}
+
+	old := r.items[j]
+	r.counts[old]--
+	if r.counts[old] == 0 {
+		delete(r.counts, old)
+	}
+	r.items[j] = x
+	r.counts[x]++
+}
+
+// Sample returns a copy of the items currently held.
+func (r *Reservoir[T]) Sample() []T {
+	out := make([]T, len(r.items))
+	copy(out, r.items)
+	return out
+}
+
+// Len returns the number of items currently held.
+func (r *Reservoir[T]) Len() int {
+	return len(r.items)
+}
+
+// Seen returns the total number of items offered to the reservoir.
+func (r *Reservoir[T]) Seen() int {
+	return r.seen
+}
+
+// ReservoirKL estimates KL(P||Q) between the empirical distributions of two reservoirs.
+// Returns +Inf when P holds an item that Q does not.
+// NOTE: Asymmetric
+// Time: O(k) where k = distinct items, Space: O(k)
+func ReservoirKL[T comparable](p, q *Reservoir[T]) (float64, error) {
+	pd, qd, err := reservoirDistributions(p, q)
+	if err != nil {
+		return 0, err
+	}
+	return KLDivergence(pd, qd)
+}
+
+// ReservoirJS estimates Jensen-Shannon divergence between two reservoirs.
+// Bounded: 0 ≤ JS ≤ log(2)
+// Time: O(k) where k = distinct items, Space: O(k)
+func ReservoirJS[T comparable](p, q *Reservoir[T]) (float64, error) {
+	pd, qd, err := reservoirDistributions(p, q)
+	if err != nil {
+		return 0, err
+	}
+	return JensenShannonDivergence(pd, qd)
+}
+
+// ReservoirTV estimates total variation distance between two reservoirs.
+// Range [0, 1]
+// Time: O(k) where k = distinct items, Space: O(k)
+func ReservoirTV[T comparable](p, q *Reservoir[T]) (float64, error) {
+	pd, qd, err := reservoirDistributions(p, q)
+	if err != nil {
+		return 0, err
+	}
+	return TotalVariation(pd, qd)
+}
+
+// reservoirDistributions aligns the item frequencies of two reservoirs
+// over the union of their supports
+func reservoirDistributions[T comparable](p, q *Reservoir[T]) ([]float64, []float64, error) {
+	if p == nil || q == nil || p.Len() == 0 || q.Len() == 0 {
+		return nil, nil, ErrEmptyInput
+	}
+
+	keys := make([]T, 0, len(p.counts)+len(q.counts))
+	for k := range p.counts {
+		keys = append(keys, k)
+	}
+	for k := range q.counts {
+		if _, ok := p.counts[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	pd := make([]float64, len(keys))
+	qd := make([]float64, len(keys))
+	pn, qn := float64(p.Len()), float64(q.Len())
+	for i, k := range keys {
+		pd[i] = float64(p.counts[k]) / pn
+		qd[i] = float64(q.counts[k]) / qn
+	}
+	return pd, qd, nil
+}
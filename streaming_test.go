@@ -0,0 +1,91 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReservoir(t *testing.T) {
+	r, err := NewReservoir[int](100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < 10000; i++ {
+		r.Add(i % 10)
+	}
+
+	if r.Len() != 100 {
+		t.Errorf("expected 100 items, got %d", r.Len())
+	}
+	if r.Seen() != 10000 {
+		t.Errorf("expected 10000 seen, got %d", r.Seen())
+	}
+
+	// Incremental counts must agree with the sample contents
+	counts := make(map[int]int)
+	for _, x := range r.Sample() {
+		counts[x]++
+	}
+	for k, c := range counts {
+		if r.counts[k] != c {
+			t.Errorf("count for %d: expected %d, got %d", k, c, r.counts[k])
+		}
+	}
+	if len(counts) != len(r.counts) {
+		t.Errorf("expected %d distinct items, got %d", len(counts), len(r.counts))
+	}
+
+	if _, err := NewReservoir[int](0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestReservoirDistances(t *testing.T) {
+	p, _ := NewReservoir[string](10)
+	q, _ := NewReservoir[string](10)
+	for _, x := range []string{"a", "a", "b", "b"} {
+		p.Add(x)
+	}
+	for _, x := range []string{"a", "b", "c", "c"} {
+		q.Add(x)
+	}
+
+	tv, err := ReservoirTV(p, q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(tv, 0.5) {
+		t.Errorf("expected TV 0.5, got %v", tv)
+	}
+
+	kl, _ := ReservoirKL(p, q)
+	if !almostEqual(kl, math.Log(2)) {
+		t.Errorf("expected KL log(2), got %v", kl)
+	}
+
+	klRev, _ := ReservoirKL(q, p)
+	if !math.IsInf(klRev, 1) {
+		t.Errorf("expected +Inf for missing support, got %v", klRev)
+	}
+
+	js, _ := ReservoirJS(p, q)
+	if js <= 0 || js > math.Log(2) {
+		t.Errorf("expected JS in (0, log 2], got %v", js)
+	}
+
+	self, _ := ReservoirJS(p, p)
+	if !almostEqual(self, 0) {
+		t.Errorf("expected 0 for identical reservoirs, got %v", self)
+	}
+}
+
+func TestReservoirDistancesEmpty(t *testing.T) {
+	p, _ := NewReservoir[int](5)
+	q, _ := NewReservoir[int](5)
+	q.Add(1)
+
+	if _, err := ReservoirTV(p, q); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
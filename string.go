@@ -298,6 +298,153 @@ func extractNGrams(s string, n int) map[string]int {
 	return ngrams
 }
 
+// LevenshteinBounded computes Levenshtein distance, aborting as soon as the
+// result is known to exceed maxDist. Returns maxDist+1 in that case.
+// Only cells within maxDist of the diagonal are evaluated (Ukkonen's band).
+// Time: O(min(m,n)*maxDist), Space: O(min(m,n))
+func LevenshteinBounded(a, b string, maxDist int) (int, error) {
+	if maxDist < 0 {
+		return 0, ErrInvalidParameter
+	}
+
+	// Ensure a is the shorter string to optimize space
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(b)-len(a) > maxDist {
+		return maxDist + 1, nil
+	}
+	if len(a) == 0 {
+		return len(b), nil
+	}
+
+	exceeded := maxDist + 1
+	prevRow := make([]int, len(a)+1)
+	currRow := make([]int, len(a)+1)
+
+	for i := range prevRow {
+		prevRow[i] = min(i, exceeded)
+	}
+
+	for j := 1; j <= len(b); j++ {
+		lo := max(1, j-maxDist)
+		hi := min(len(a), j+maxDist)
+
+		if lo == 1 {
+			currRow[0] = min(j, exceeded)
+		} else {
+			currRow[lo-1] = exceeded
+		}
+
+		rowMin := currRow[lo-1]
+		for i := lo; i <= hi; i++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			currRow[i] = min(min3(
+				prevRow[i]+1,      // deletion
+				currRow[i-1]+1,    // insertion
+				prevRow[i-1]+cost, // substitution
+			), exceeded)
+			rowMin = min(rowMin, currRow[i])
+		}
+		if hi < len(a) {
+			currRow[hi+1] = exceeded
+		}
+
+		if rowMin > maxDist {
+			return exceeded, nil
+		}
+		prevRow, currRow = currRow, prevRow
+	}
+
+	return prevRow[len(a)], nil
+}
+
+// EditKind identifies the type of an edit operation.
+type EditKind int
+
+const (
+	// EditInsert inserts B[PosB] into a.
+	EditInsert EditKind = iota
+	// EditDelete deletes A[PosA] from a.
+	EditDelete
+	// EditSubstitute replaces A[PosA] with B[PosB].
+	EditSubstitute
+)
+
+// String returns the lowercase name of the edit kind.
+func (k EditKind) String() string {
+	switch k {
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	case EditSubstitute:
+		return "substitute"
+	}
+	return "unknown"
+}
+
+// EditOp is a single edit transforming a into b. PosA and PosB are byte
+// offsets in a and b aligned at the point of the edit.
+type EditOp struct {
+	Kind EditKind
+	PosA int
+	PosB int
+}
+
+// LevenshteinOps returns a minimal edit script transforming a into b, ordered
+// by position. len(ops) equals Levenshtein(a, b). On ties, substitutions are
+// preferred over deletions, and deletions over insertions.
+// Time: O(mn), Space: O(mn)
+func LevenshteinOps(a, b string) ([]EditOp, error) {
+	m, n := len(a), len(b)
+	d := make([][]int, m+1)
+	for i := range d {
+		d[i] = make([]int, n+1)
+		d[i][0] = i
+	}
+	for j := 0; j <= n; j++ {
+		d[0][j] = j
+	}
+	for i := 1; i <= m; i++ {
+		for j := 1; j <= n; j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+		}
+	}
+
+	// Backtrack from the bottom-right corner
+	ops := make([]EditOp, 0, d[m][n])
+	i, j := m, n
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && a[i-1] == b[j-1] && d[i][j] == d[i-1][j-1]:
+			i, j = i-1, j-1
+		case i > 0 && j > 0 && d[i][j] == d[i-1][j-1]+1:
+			ops = append(ops, EditOp{Kind: EditSubstitute, PosA: i - 1, PosB: j - 1})
+			i, j = i-1, j-1
+		case i > 0 && d[i][j] == d[i-1][j]+1:
+			ops = append(ops, EditOp{Kind: EditDelete, PosA: i - 1, PosB: j})
+			i--
+		default:
+			ops = append(ops, EditOp{Kind: EditInsert, PosA: i, PosB: j - 1})
+			j--
+		}
+	}
+
+	for l, r := 0, len(ops)-1; l < r; l, r = l+1, r-1 {
+		ops[l], ops[r] = ops[r], ops[l]
+	}
+	return ops, nil
+}
+
 // Helper functions
 func minInt(a, b int) int {
 	if a < b {
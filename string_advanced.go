@@ -181,6 +181,70 @@ func EditDistance(a, b string, insertCost, deleteCost, replaceCost int) (int, er
 	return prev[len(a)], nil
 }
 
+// EditCosts holds per-character costs for WeightedEditDistance.
+// A nil function costs 1 per operation. Substitute is only consulted for
+// differing runes; keeping a rune is free.
+type EditCosts struct {
+	Insert     func(r rune) float64    // Cost of inserting r
+	Delete     func(r rune) float64    // Cost of deleting r
+	Substitute func(a, b rune) float64 // Cost of replacing a with b
+}
+
+// WeightedEditDistance computes the minimum total cost of turning a into b
+// with per-character insertion, deletion and substitution costs, e.g. making
+// 'q'→'w' (adjacent keys) cheaper than 'q'→'p'. Compares Unicode code points.
+// Returns ErrNegativeValue if a cost function returns a negative cost.
+// Time: O(mn), Space: O(n)
+func WeightedEditDistance(a, b string, costs EditCosts) (float64, error) {
+	ra, rb := []rune(a), []rune(b)
+	insert, del, sub := costs.Insert, costs.Delete, costs.Substitute
+	if insert == nil {
+		insert = func(rune) float64 { return 1 }
+	}
+	if del == nil {
+		del = func(rune) float64 { return 1 }
+	}
+	if sub == nil {
+		sub = func(_, _ rune) float64 { return 1 }
+	}
+
+	insertCosts := make([]float64, len(rb))
+	for j, r := range rb {
+		if insertCosts[j] = insert(r); insertCosts[j] < 0 {
+			return 0, ErrNegativeValue
+		}
+	}
+
+	prev := make([]float64, len(rb)+1)
+	curr := make([]float64, len(rb)+1)
+	for j := 1; j <= len(rb); j++ {
+		prev[j] = prev[j-1] + insertCosts[j-1]
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		deleteCost := del(ra[i-1])
+		if deleteCost < 0 {
+			return 0, ErrNegativeValue
+		}
+		curr[0] = prev[0] + deleteCost
+		for j := 1; j <= len(rb); j++ {
+			replaceCost := 0.0
+			if ra[i-1] != rb[j-1] {
+				if replaceCost = sub(ra[i-1], rb[j-1]); replaceCost < 0 {
+					return 0, ErrNegativeValue
+				}
+			}
+			curr[j] = math.Min(
+				math.Min(prev[j]+deleteCost, curr[j-1]+insertCosts[j-1]),
+				prev[j-1]+replaceCost,
+			)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)], nil
+}
+
 // SmithWatermanString computes Smith-Waterman local alignment for strings
 // Returns alignment score
 // Time: O(mn), Space: O(mn)
@@ -688,3 +752,111 @@ func TokenSetRatio(a, b string) (float64, error) {
 
 	return 1.0 - float64(dist)/float64(maxLen), nil
 }
+
+// PartialRatio computes the similarity of the shorter string to its
+// best-matching substring of the longer one, like fuzzywuzzy's partial_ratio:
+// "yankees" and "new york yankees" score 1. The edit distance to the best
+// substring is found in one pass with leading and trailing characters of
+// the longer string free (Sellers' algorithm), over Unicode code points.
+// Range [0, 1] where 1=identical
+// Time: O(mn), Space: O(min(m,n))
+func PartialRatio(a, b string) (float64, error) {
+	short, long := []rune(a), []rune(b)
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	if len(short) == 0 {
+		if len(long) == 0 {
+			return 1.0, nil
+		}
+		return 0.0, nil
+	}
+
+	// col[i] is the distance from short[:i] to the best substring ending at the current position of long
+	col := make([]int, len(short)+1)
+	for i := range col {
+		col[i] = i
+	}
+	best := col[len(short)]
+	for _, r := range long {
+		diag := col[0]
+		col[0] = 0
+		for i := 1; i <= len(short); i++ {
+			cost := 1
+			if short[i-1] == r {
+				cost = 0
+			}
+			diag, col[i] = col[i], min3(col[i]+1, col[i-1]+1, diag+cost)
+		}
+		best = min(best, col[len(short)])
+	}
+
+	return 1.0 - float64(best)/float64(len(short)), nil
+}
+
+// WRatio computes fuzzywuzzy's weighted ratio, the best of several
+// heuristics after lowercasing and replacing punctuation with spaces: the
+// plain ratio and 0.95×TokenSortRatio and TokenSetRatio for strings of
+// similar length, or PartialRatio and 0.95×PartialRatio of the sorted
+// tokens once one string is at least 1.5 times longer, scaled by 0.9
+// (0.6 beyond 8 times longer). A good default scorer for a Matcher.
+// Range [0, 1] where 1=identical
+// Time: O(mn), Space: O(m+n)
+func WRatio(a, b string) (float64, error) {
+	a, b = wratioProcess(a), wratioProcess(b)
+	if a == "" || b == "" {
+		return 0.0, nil
+	}
+
+	dist, err := LevenshteinRunes(a, b)
+	if err != nil {
+		return 0, err
+	}
+	lenA, lenB := float64(len([]rune(a))), float64(len([]rune(b)))
+	base := 1.0 - float64(dist)/math.Max(lenA, lenB)
+	lengthRatio := math.Max(lenA, lenB) / math.Min(lenA, lenB)
+
+	if lengthRatio < 1.5 {
+		sorted, err := TokenSortRatio(a, b)
+		if err != nil {
+			return 0, err
+		}
+		set, err := TokenSetRatio(a, b)
+		if err != nil {
+			return 0, err
+		}
+		return math.Max(base, 0.95*math.Max(sorted, set)), nil
+	}
+
+	scale := 0.9
+	if lengthRatio >= 8 {
+		scale = 0.6
+	}
+	partial, err := PartialRatio(a, b)
+	if err != nil {
+		return 0, err
+	}
+	partialSorted, err := PartialRatio(sortedTokens(a), sortedTokens(b))
+	if err != nil {
+		return 0, err
+	}
+	return math.Max(base, scale*math.Max(partial, 0.95*partialSorted)), nil
+}
+
+// wratioProcess lowercases s and collapses runs of non-alphanumerics into single spaces
+func wratioProcess(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// sortedTokens returns the whitespace tokens of s sorted and rejoined
+func sortedTokens(s string) string {
+	tokens := strings.Fields(s)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
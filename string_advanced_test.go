@@ -1,6 +1,7 @@
 package distance
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -46,6 +47,69 @@ func TestEditDistance(t *testing.T) {
 	}
 }
 
+func TestWeightedEditDistance(t *testing.T) {
+	// Adjacent keys on the top QWERTY row cost half a substitution
+	row := "qwertyuiop"
+	keyboard := EditCosts{
+		Substitute: func(a, b rune) float64 {
+			i, j := strings.IndexRune(row, a), strings.IndexRune(row, b)
+			if i >= 0 && j >= 0 && (i-j == 1 || j-i == 1) {
+				return 0.5
+			}
+			return 1
+		},
+	}
+	vowelsCheap := EditCosts{
+		Insert: func(r rune) float64 {
+			if strings.ContainsRune("aeiou", r) {
+				return 0.25
+			}
+			return 1
+		},
+		Delete: func(rune) float64 { return 2 },
+	}
+
+	tests := []struct {
+		name     string
+		a, b     string
+		costs    EditCosts
+		expected float64
+	}{
+		{"unit costs", "kitten", "sitting", EditCosts{}, 3},
+		{"adjacent key", "qey", "wey", keyboard, 0.5},
+		{"distant key", "qey", "pey", keyboard, 1},
+		{"two adjacent", "type", "rupe", keyboard, 1},
+		{"cheap vowel insert", "brd", "bird", vowelsCheap, 0.25},
+		{"costly delete", "bird", "brd", vowelsCheap, 2},
+		{"substitute beats delete+insert", "cat", "cut", vowelsCheap, 1},
+		{"runes", "café", "cafe", EditCosts{}, 1},
+		{"empty", "", "ab", vowelsCheap, 1.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := WeightedEditDistance(tt.a, tt.b, tt.costs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// Unit costs agree with EditDistance
+	want, _ := EditDistance("sunday", "saturday", 1, 1, 1)
+	if got, _ := WeightedEditDistance("sunday", "saturday", EditCosts{}); !almostEqual(got, float64(want)) {
+		t.Errorf("expected %d, got %v", want, got)
+	}
+
+	negative := EditCosts{Substitute: func(_, _ rune) float64 { return -1 }}
+	if _, err := WeightedEditDistance("a", "b", negative); err != ErrNegativeValue {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
+
 func TestSoundex(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -109,6 +173,62 @@ func TestTokenSetRatio(t *testing.T) {
 	}
 }
 
+func TestPartialRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected float64
+	}{
+		{"substring", "yankees", "new york yankees", 1},
+		{"symmetric", "new york yankees", "yankees", 1},
+		{"one typo", "yankess", "new york yankees", 1 - 1.0/7},
+		{"identical", "abc", "abc", 1},
+		{"disjoint", "abc", "xyzxyz", 0},
+		{"both empty", "", "", 1},
+		{"one empty", "", "abc", 0},
+		{"unicode", "café", "un café noir", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PartialRatio(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWRatio(t *testing.T) {
+	sorted, _ := TokenSortRatio("new york mets", "mets new york")
+	tests := []struct {
+		name     string
+		a, b     string
+		expected float64
+	}{
+		{"identical after processing", "New York Mets!", "new york mets", 1},
+		{"reordered tokens", "new york mets", "mets new york", 0.95 * sorted},
+		{"partial", "yankees", "new york yankees", 0.9},
+		{"very different lengths", "mets", "the new york mets baseball club of queens", 0.6},
+		{"empty after processing", "!!!", "mets", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := WRatio(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestQGramDistance(t *testing.T) {
 	result, err := QGramDistance("hello", "hallo", 2)
 	if err != nil {
@@ -0,0 +1,181 @@
+package distance
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalization selects a Unicode normalization form. The forms are partial:
+// decomposition data covers only Latin, Greek and common compatibility
+// characters, and other runes (including Hangul syllables) pass through
+// unchanged, so results differ from full Unicode normalization outside
+// those ranges.
+type Normalization int
+
+const (
+	// NormalizeNone leaves the text unchanged.
+	NormalizeNone Normalization = iota
+	// NormalizeNFC composes characters canonically (é as one rune).
+	NormalizeNFC
+	// NormalizeNFD decomposes characters canonically (é as e + U+0301).
+	NormalizeNFD
+	// NormalizeNFKC applies compatibility decomposition, then canonical composition.
+	NormalizeNFKC
+	// NormalizeNFKD applies compatibility decomposition (ﬁ → fi, ² → 2, Ａ → A).
+	NormalizeNFKD
+)
+
+// StringOptions configures the preprocessing applied by WithStringOptions,
+// so record-linkage pipelines can compare names without hand-written cleanup.
+// The zero value leaves strings unchanged.
+type StringOptions struct {
+	CaseInsensitive bool          // Fold case before comparing
+	Normalization   Normalization // Unicode normalization form
+	StripDiacritics bool          // Remove accents and other combining marks (é → e, ø → o)
+	TrimSpace       bool          // Trim and collapse runs of whitespace to one space
+
+	// Transliterate romanizes other scripts before any other step, so
+	// "Дмитрий" and "Dmitriy" compare equal (see TransliterationForLocale)
+	Transliterate []TransliterationTable
+}
+
+// Apply returns s preprocessed according to the options.
+// Time: O(n), Space: O(n)
+func (o StringOptions) Apply(s string) string {
+	if len(o.Transliterate) > 0 {
+		s = Transliterate(s, o.Transliterate...)
+	}
+	if o.StripDiacritics {
+		s = stripDiacritics(NormalizeString(s, NormalizeNFD))
+	}
+	if o.Normalization != NormalizeNone {
+		s = NormalizeString(s, o.Normalization)
+	}
+	if o.CaseInsensitive {
+		s = strings.Map(foldCase, s)
+	}
+	if o.TrimSpace {
+		s = strings.Join(strings.Fields(s), " ")
+	}
+	return s
+}
+
+// WithStringOptions wraps a string metric such as Levenshtein, Jaro,
+// TokenSortRatio or LevenshteinRunes so both inputs are preprocessed by opts.
+func WithStringOptions[R any](fn func(a, b string) (R, error), opts StringOptions) func(a, b string) (R, error) {
+	return func(a, b string) (R, error) {
+		return fn(opts.Apply(a), opts.Apply(b))
+	}
+}
+
+// NormalizeString converts s to the given Unicode normalization form,
+// within the coverage described on Normalization (ligatures,
+// super/subscripts, fractions and fullwidth ASCII are included).
+// Time: O(n), Space: O(n)
+func NormalizeString(s string, form Normalization) string {
+	switch form {
+	case NormalizeNFC:
+		return string(composeCanonical(decompose(s, false)))
+	case NormalizeNFD:
+		return string(decompose(s, false))
+	case NormalizeNFKC:
+		return string(composeCanonical(decompose(s, true)))
+	case NormalizeNFKD:
+		return string(decompose(s, true))
+	default:
+		return s
+	}
+}
+
+// decompose fully decomposes s and puts combining marks in canonical order
+func decompose(s string, compat bool) []rune {
+	out := make([]rune, 0, len(s))
+	var expand func(r rune)
+	expand = func(r rune) {
+		d, ok := canonicalDecomposition[r]
+		if !ok && compat {
+			d, ok = compatibilityDecomposition[r]
+		}
+		if !ok {
+			out = append(out, r)
+			return
+		}
+		for _, c := range d {
+			expand(c)
+		}
+	}
+	for _, r := range s {
+		expand(r)
+	}
+
+	// Canonical ordering: stable sort each run of marks by combining class
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0; j-- {
+			cj, cp := combiningClass[out[j]], combiningClass[out[j-1]]
+			if cj == 0 || cp <= cj {
+				break
+			}
+			out[j], out[j-1] = out[j-1], out[j]
+		}
+	}
+	return out
+}
+
+// composeCanonical applies canonical composition to decomposed runes
+func composeCanonical(runes []rune) []rune {
+	out := make([]rune, 0, len(runes))
+	starter, lastClass := -1, uint8(0)
+	for _, r := range runes {
+		class := combiningClass[r]
+		if starter >= 0 {
+			adjacent := starter == len(out)-1
+			if c, ok := canonicalComposition[[2]rune{out[starter], r}]; ok && (adjacent || (lastClass != 0 && lastClass < class)) {
+				out[starter] = c
+				continue
+			}
+		}
+		if class == 0 {
+			starter = len(out)
+		}
+		lastClass = class
+		out = append(out, r)
+	}
+	return out
+}
+
+// canonicalComposition maps a (starter, mark) pair to its primary composite
+var canonicalComposition = func() map[[2]rune]rune {
+	m := make(map[[2]rune]rune)
+	for r, d := range canonicalDecomposition {
+		pair := []rune(d)
+		if len(pair) == 2 {
+			m[[2]rune{pair[0], pair[1]}] = r
+		}
+	}
+	return m
+}()
+
+// diacriticFree maps letters whose diacritics are not combining marks to a base letter
+var diacriticFree = map[rune]rune{
+	'ø': 'o', 'Ø': 'O', 'đ': 'd', 'Đ': 'D', 'ł': 'l', 'Ł': 'L',
+	'ħ': 'h', 'Ħ': 'H', 'ı': 'i', 'ŧ': 't', 'Ŧ': 'T', 'ƀ': 'b',
+}
+
+// stripDiacritics removes nonspacing marks from decomposed text
+func stripDiacritics(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		if base, ok := diacriticFree[r]; ok {
+			return base
+		}
+		return r
+	}, s)
+}
+
+// foldCase maps r to a case-folded form, so that e.g. 'K', 'k' and the
+// Kelvin sign compare equal
+func foldCase(r rune) rune {
+	return unicode.ToLower(unicode.ToUpper(r))
+}
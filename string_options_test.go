@@ -0,0 +1,92 @@
+package distance
+
+import "testing"
+
+func TestNormalizeString(t *testing.T) {
+	tests := []struct {
+		input                string
+		nfc, nfd, nfkc, nfkd string
+	}{
+		{"Crème Brûlée", "Crème Brûlée", "Cre\u0300me Bru\u0302le\u0301e", "Crème Brûlée", "Cre\u0300me Bru\u0302le\u0301e"},
+		{"\u1ec7", "\u1ec7", "e\u0323\u0302", "\u1ec7", "e\u0323\u0302"},
+		{"a\u0302\u0323", "\u1ead", "a\u0323\u0302", "\u1ead", "a\u0323\u0302"}, // marks out of canonical order
+		{"ǖ", "ǖ", "u\u0308\u0304", "ǖ", "u\u0308\u0304"},
+		{"\ufb01nancial", "\ufb01nancial", "\ufb01nancial", "financial", "financial"},
+		{"x\u00b2", "x\u00b2", "x\u00b2", "x2", "x2"},
+		{"\uff21\uff42\uff43", "\uff21\uff42\uff43", "\uff21\uff42\uff43", "Abc", "Abc"},
+		{"\u00bd", "\u00bd", "\u00bd", "1\u20442", "1\u20442"},
+		{"\u038f", "\u038f", "\u03a9\u0301", "\u038f", "\u03a9\u0301"},
+		{"plain 日本", "plain 日本", "plain 日本", "plain 日本", "plain 日本"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			forms := []struct {
+				form Normalization
+				want string
+			}{
+				{NormalizeNFC, tt.nfc}, {NormalizeNFD, tt.nfd},
+				{NormalizeNFKC, tt.nfkc}, {NormalizeNFKD, tt.nfkd},
+				{NormalizeNone, tt.input},
+			}
+			for _, f := range forms {
+				if got := NormalizeString(tt.input, f.form); got != f.want {
+					t.Errorf("form %d: expected %+q, got %+q", f.form, f.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestStringOptionsApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     StringOptions
+		input    string
+		expected string
+	}{
+		{"zero value", StringOptions{}, "  Crème  ", "  Crème  "},
+		{"case", StringOptions{CaseInsensitive: true}, "ÉCOLE Straße", "école straße"},
+		{"kelvin sign", StringOptions{CaseInsensitive: true}, "K", "k"},
+		{"strip diacritics", StringOptions{StripDiacritics: true}, "Crème Brûlée, Łódź, Øre", "Creme Brulee, Lodz, Ore"},
+		{"trim", StringOptions{TrimSpace: true}, "  John \t  Smith\n", "John Smith"},
+		{"nfkc", StringOptions{Normalization: NormalizeNFKC}, "ﬁle", "file"},
+		{"all", StringOptions{CaseInsensitive: true, StripDiacritics: true, TrimSpace: true, Normalization: NormalizeNFKC}, " JOSÉ  Ｇarcía ", "jose garcia"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.Apply(tt.input); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithStringOptions(t *testing.T) {
+	opts := StringOptions{CaseInsensitive: true, StripDiacritics: true, TrimSpace: true}
+
+	lev := WithStringOptions(Levenshtein, opts)
+	if d, err := lev("  José Müller", "jose   MULLER "); err != nil || d != 0 {
+		t.Errorf("expected 0, got %d (%v)", d, err)
+	}
+
+	jaro := WithStringOptions(Jaro, opts)
+	if s, err := jaro("Renée", "RENEE"); err != nil || !almostEqual(s, 1) {
+		t.Errorf("expected 1, got %v (%v)", s, err)
+	}
+
+	// Precomposed and decomposed forms match once normalized
+	nfc := WithStringOptions(LevenshteinRunes, StringOptions{Normalization: NormalizeNFC})
+	if d, _ := nfc("caf\u00e9", "cafe\u0301"); d != 0 {
+		t.Errorf("expected 0, got %d", d)
+	}
+	if d, _ := LevenshteinRunes("caf\u00e9", "cafe\u0301"); d != 2 {
+		t.Errorf("expected 2 without normalization, got %d", d)
+	}
+
+	tokens := WithStringOptions(TokenSortRatio, opts)
+	if r, err := tokens("Gómez  María", "maria gomez"); err != nil || !almostEqual(r, 1) {
+		t.Errorf("expected 1, got %v (%v)", r, err)
+	}
+}
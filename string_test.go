@@ -173,6 +173,57 @@ func TestNGramDistance(t *testing.T) {
 }
 
 // Benchmarks
+func TestLevenshteinBounded(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		maxDist  int
+		expected int
+	}{
+		{"within bound", "kitten", "sitting", 3, 3},
+		{"generous bound", "kitten", "sitting", 10, 3},
+		{"exceeds bound", "kitten", "sitting", 2, 3},
+		{"length difference prunes", "a", "abcdef", 2, 3},
+		{"identical zero bound", "hello", "hello", 0, 0},
+		{"one empty", "", "abc", 3, 3},
+		{"complete difference", "abc", "xyz", 1, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := LevenshteinBounded(tt.a, tt.b, tt.maxDist)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := LevenshteinBounded("a", "b", -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestLevenshteinBoundedMatchesLevenshtein(t *testing.T) {
+	words := []string{"", "a", "ab", "abc", "flaw", "lawn", "saturday", "sunday", "rosettacode", "raisethysword"}
+	for _, a := range words {
+		for _, b := range words {
+			want, _ := Levenshtein(a, b)
+			for bound := 0; bound <= 12; bound++ {
+				got, _ := LevenshteinBounded(a, b, bound)
+				if want <= bound && got != want {
+					t.Errorf("LevenshteinBounded(%q, %q, %d) = %d, want %d", a, b, bound, got, want)
+				}
+				if want > bound && got <= bound {
+					t.Errorf("LevenshteinBounded(%q, %q, %d) = %d, want > %d", a, b, bound, got, bound)
+				}
+			}
+		}
+	}
+}
+
 func BenchmarkLevenshtein(b *testing.B) {
 	s1 := "kitten"
 	s2 := "sitting"
@@ -199,3 +250,72 @@ func BenchmarkNGramDistance(b *testing.B) {
 		_, _ = NGramDistance(s1, s2, 2)
 	}
 }
+
+// applyEditOps replays an edit script on a, reading inserted bytes from b
+func applyEditOps(a, b string, ops []EditOp) string {
+	var out []byte
+	i := 0
+	for _, op := range ops {
+		out = append(out, a[i:op.PosA]...)
+		i = op.PosA
+		switch op.Kind {
+		case EditInsert:
+			out = append(out, b[op.PosB])
+		case EditDelete:
+			i++
+		case EditSubstitute:
+			out = append(out, b[op.PosB])
+			i++
+		}
+	}
+	return string(append(out, a[i:]...))
+}
+
+func TestLevenshteinOps(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"kitten", "sitting"},
+		{"", "abc"},
+		{"abc", ""},
+		{"flaw", "lawn"},
+		{"same", "same"},
+		{"intention", "execution"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"->"+tt.b, func(t *testing.T) {
+			ops, err := LevenshteinOps(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			dist, _ := Levenshtein(tt.a, tt.b)
+			if len(ops) != dist {
+				t.Errorf("expected %d ops, got %d: %v", dist, len(ops), ops)
+			}
+			if got := applyEditOps(tt.a, tt.b, ops); got != tt.b {
+				t.Errorf("replaying %v on %q: expected %q, got %q", ops, tt.a, tt.b, got)
+			}
+		})
+	}
+}
+
+func TestLevenshteinOpsScript(t *testing.T) {
+	ops, _ := LevenshteinOps("kitten", "sitting")
+	want := []EditOp{
+		{EditSubstitute, 0, 0},
+		{EditSubstitute, 4, 4},
+		{EditInsert, 6, 6},
+	}
+	if len(ops) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ops)
+	}
+	for i := range want {
+		if ops[i] != want[i] {
+			t.Errorf("op %d: expected %v, got %v", i, want[i], ops[i])
+		}
+	}
+	if EditDelete.String() != "delete" {
+		t.Errorf("expected delete, got %s", EditDelete)
+	}
+}
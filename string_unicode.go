@@ -0,0 +1,265 @@
+package distance
+
+import "unicode"
+
+// The byte-oriented string distances (Levenshtein, Jaro, ...) count a
+// multibyte character as several edits. The variants below compare Unicode
+// code points (Runes) or user-perceived characters (Graphemes) instead, so
+// "café" vs "cafe" is one edit however é is encoded. Both share one generic
+// implementation per metric over []E; the byte-oriented versions keep their
+// own loops so they can index the strings without copying them.
+
+// LevenshteinRunes computes Levenshtein distance over Unicode code points.
+// Time: O(mn), Space: O(min(m,n))
+func LevenshteinRunes(a, b string) (int, error) {
+	return levenshteinSeq([]rune(a), []rune(b)), nil
+}
+
+// DamerauLevenshteinRunes computes Damerau-Levenshtein distance over Unicode code points.
+// Time: O(mn), Space: O(mn)
+func DamerauLevenshteinRunes(a, b string) (int, error) {
+	return damerauLevenshteinSeq([]rune(a), []rune(b)), nil
+}
+
+// JaroRunes computes Jaro similarity over Unicode code points.
+// Time: O(mn), Space: O(max(m,n))
+func JaroRunes(a, b string) (float64, error) {
+	return jaroSeq([]rune(a), []rune(b)), nil
+}
+
+// JaroWinklerRunes computes Jaro-Winkler similarity over Unicode code points.
+// Time: O(mn), Space: O(max(m,n))
+func JaroWinklerRunes(a, b string, prefixScale float64) (float64, error) {
+	return jaroWinklerSeq([]rune(a), []rune(b), prefixScale), nil
+}
+
+// HammingRunes computes Hamming distance over Unicode code points.
+// The strings must contain the same number of runes.
+// Time: O(n), Space: O(n)
+func HammingRunes(a, b string) (int, error) {
+	return hammingSeq([]rune(a), []rune(b))
+}
+
+// LevenshteinGraphemes computes Levenshtein distance over grapheme clusters,
+// so an emoji sequence or a letter with combining accents is one unit.
+// Time: O(mn), Space: O(min(m,n))
+func LevenshteinGraphemes(a, b string) (int, error) {
+	return levenshteinSeq(Graphemes(a), Graphemes(b)), nil
+}
+
+// DamerauLevenshteinGraphemes computes Damerau-Levenshtein distance over grapheme clusters.
+// Time: O(mn), Space: O(mn)
+func DamerauLevenshteinGraphemes(a, b string) (int, error) {
+	return damerauLevenshteinSeq(Graphemes(a), Graphemes(b)), nil
+}
+
+// JaroGraphemes computes Jaro similarity over grapheme clusters.
+// Time: O(mn), Space: O(max(m,n))
+func JaroGraphemes(a, b string) (float64, error) {
+	return jaroSeq(Graphemes(a), Graphemes(b)), nil
+}
+
+// JaroWinklerGraphemes computes Jaro-Winkler similarity over grapheme clusters.
+// Time: O(mn), Space: O(max(m,n))
+func JaroWinklerGraphemes(a, b string, prefixScale float64) (float64, error) {
+	return jaroWinklerSeq(Graphemes(a), Graphemes(b), prefixScale), nil
+}
+
+// HammingGraphemes computes Hamming distance over grapheme clusters.
+// The strings must contain the same number of clusters.
+// Time: O(n), Space: O(n)
+func HammingGraphemes(a, b string) (int, error) {
+	return hammingSeq(Graphemes(a), Graphemes(b))
+}
+
+// Graphemes splits s into user-perceived characters, approximating the
+// extended grapheme clusters of Unicode UAX #29: combining marks, variation
+// selectors, emoji skin-tone modifiers and tag characters attach to the
+// preceding character, ZWJ joins emoji sequences, regional indicators pair
+// into flags and CRLF stays together. Hangul jamo are not composed.
+// Time: O(n), Space: O(n)
+func Graphemes(s string) []string {
+	var clusters []string
+	start, regional := 0, 0
+	var prev rune
+	for i, r := range s {
+		if i > 0 && !continuesCluster(prev, r, regional) {
+			clusters = append(clusters, s[start:i])
+			start = i
+		}
+		if isRegionalIndicator(r) {
+			regional++
+		} else {
+			regional = 0
+		}
+		prev = r
+	}
+	if len(s) > 0 {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
+
+// zeroWidthJoiner glues emoji into a single sequence (e.g. family emoji)
+const zeroWidthJoiner = '\u200d'
+
+// continuesCluster reports whether r belongs to the same grapheme cluster as
+// prev; regional is the number of consecutive regional indicators ending at prev
+func continuesCluster(prev, r rune, regional int) bool {
+	switch {
+	case prev == '\r':
+		return r == '\n'
+	case unicode.IsControl(prev) || unicode.IsControl(r):
+		return false
+	case isGraphemeExtend(r):
+		return true
+	case prev == zeroWidthJoiner:
+		return true
+	case isRegionalIndicator(prev) && isRegionalIndicator(r):
+		return regional%2 == 1
+	}
+	return false
+}
+
+// isGraphemeExtend reports whether r never starts a cluster of its own
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == zeroWidthJoiner ||
+		(r >= 0xFE00 && r <= 0xFE0F) || // variation selectors
+		(r >= 0xE0100 && r <= 0xE01EF) || // variation selectors supplement
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // emoji skin-tone modifiers
+		(r >= 0xE0020 && r <= 0xE007F) // tag characters (subdivision flags)
+}
+
+// isRegionalIndicator reports whether r is one of the letters that pair into flag emoji
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// levenshteinSeq computes Levenshtein distance between two sequences
+func levenshteinSeq[E comparable](a, b []E) int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	prevRow := make([]int, len(a)+1)
+	currRow := make([]int, len(a)+1)
+	for i := range prevRow {
+		prevRow[i] = i
+	}
+
+	for j := 1; j <= len(b); j++ {
+		currRow[0] = j
+		for i := 1; i <= len(a); i++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			currRow[i] = min3(prevRow[i]+1, currRow[i-1]+1, prevRow[i-1]+cost)
+		}
+		prevRow, currRow = currRow, prevRow
+	}
+	return prevRow[len(a)]
+}
+
+// damerauLevenshteinSeq computes Damerau-Levenshtein distance (optimal string
+// alignment, as in DamerauLevenshtein) between two sequences
+func damerauLevenshteinSeq[E comparable](a, b []E) int {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) + len(b)
+	}
+	h := make([][]int, len(a)+1)
+	for i := range h {
+		h[i] = make([]int, len(b)+1)
+		h[i][0] = i
+	}
+	for j := range h[0] {
+		h[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			h[i][j] = min3(h[i-1][j]+1, h[i][j-1]+1, h[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				h[i][j] = min(h[i][j], h[i-2][j-2]+1)
+			}
+		}
+	}
+	return h[len(a)][len(b)]
+}
+
+// jaroSeq computes Jaro similarity between two sequences
+func jaroSeq[E comparable](a, b []E) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	matchWindow := max(max(len(a), len(b))/2-1, 0)
+	aMatches := make([]bool, len(a))
+	bMatches := make([]bool, len(b))
+
+	matches := 0
+	for i := range a {
+		start := max(0, i-matchWindow)
+		end := min(i+matchWindow+1, len(b))
+		for j := start; j < end; j++ {
+			if bMatches[j] || a[i] != b[j] {
+				continue
+			}
+			aMatches[i], bMatches[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, k := 0, 0
+	for i := range a {
+		if !aMatches[i] {
+			continue
+		}
+		for !bMatches[k] {
+			k++
+		}
+		if a[i] != b[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	t := float64(transpositions) / 2
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-t)/m) / 3
+}
+
+// jaroWinklerSeq computes Jaro-Winkler similarity between two sequences
+func jaroWinklerSeq[E comparable](a, b []E, prefixScale float64) float64 {
+	sim := jaroSeq(a, b)
+	prefixLen := 0
+	for i := 0; i < min(len(a), len(b), 4) && a[i] == b[i]; i++ {
+		prefixLen++
+	}
+	return sim + float64(prefixLen)*prefixScale*(1-sim)
+}
+
+// hammingSeq counts positions at which two equal-length sequences differ
+func hammingSeq[E comparable](a, b []E) (int, error) {
+	if len(a) != len(b) {
+		return 0, ErrDimensionMismatch
+	}
+	count := 0
+	for i := range a {
+		if a[i] != b[i] {
+			count++
+		}
+	}
+	return count, nil
+}
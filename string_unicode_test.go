@@ -0,0 +1,123 @@
+package distance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"ascii", "abc", []string{"a", "b", "c"}},
+		{"combining accent", "cafe\u0301", []string{"c", "a", "f", "e\u0301"}},
+		{"skin tone", "\U0001F44D\U0001F3FD!", []string{"\U0001F44D\U0001F3FD", "!"}},
+		{"zwj family", "\U0001F468\u200d\U0001F469\u200d\U0001F467", []string{"\U0001F468\u200d\U0001F469\u200d\U0001F467"}},
+		{"flags", "\U0001F1FA\U0001F1F8\U0001F1EB\U0001F1F7", []string{"\U0001F1FA\U0001F1F8", "\U0001F1EB\U0001F1F7"}},
+		{"variation selector", "❤\ufe0fx", []string{"❤\ufe0f", "x"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"mark after newline", "\n\u0301", []string{"\n", "\u0301"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Graphemes(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestUnicodeLevenshtein(t *testing.T) {
+	tests := []struct {
+		name            string
+		a, b            string
+		bytes, runes, g int
+	}{
+		{"precomposed é", "caf\u00e9", "cafe", 2, 1, 1},
+		{"decomposed é", "cafe\u0301", "cafe", 2, 1, 1},
+		{"precomposed vs decomposed", "caf\u00e9", "cafe\u0301", 3, 2, 1},
+		{"emoji skin tone", "\U0001F44D\U0001F3FD", "\U0001F44D", 4, 1, 1},
+		{"cjk", "日本語", "日本", 3, 1, 1},
+		{"ascii", "kitten", "sitting", 3, 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d, _ := Levenshtein(tt.a, tt.b); d != tt.bytes {
+				t.Errorf("bytes: expected %d, got %d", tt.bytes, d)
+			}
+			if d, _ := LevenshteinRunes(tt.a, tt.b); d != tt.runes {
+				t.Errorf("runes: expected %d, got %d", tt.runes, d)
+			}
+			if d, _ := LevenshteinGraphemes(tt.a, tt.b); d != tt.g {
+				t.Errorf("graphemes: expected %d, got %d", tt.g, d)
+			}
+		})
+	}
+}
+
+func TestUnicodeDamerauLevenshtein(t *testing.T) {
+	if d, _ := DamerauLevenshteinRunes("ñú", "úñ"); d != 1 {
+		t.Errorf("expected 1 transposition, got %d", d)
+	}
+	if d, _ := DamerauLevenshtein("ñú", "úñ"); d == 1 {
+		t.Errorf("byte version unexpectedly sees a single transposition")
+	}
+	if d, _ := DamerauLevenshteinGraphemes("e\u0301a", "ae\u0301"); d != 1 {
+		t.Errorf("expected 1 transposition of clusters, got %d", d)
+	}
+
+	// ASCII input must agree with the byte implementation
+	for _, p := range [][2]string{{"ca", "abc"}, {"abcdef", "abdcef"}, {"", "abc"}} {
+		want, _ := DamerauLevenshtein(p[0], p[1])
+		if got, _ := DamerauLevenshteinRunes(p[0], p[1]); got != want {
+			t.Errorf("%q/%q: expected %d, got %d", p[0], p[1], want, got)
+		}
+	}
+}
+
+func TestUnicodeJaro(t *testing.T) {
+	// ASCII input must agree with the byte implementations
+	for _, p := range [][2]string{{"MARTHA", "MARHTA"}, {"DIXON", "DICKSONX"}, {"", ""}, {"abc", ""}} {
+		want, _ := Jaro(p[0], p[1])
+		if got, _ := JaroRunes(p[0], p[1]); !almostEqual(got, want) {
+			t.Errorf("Jaro %q/%q: expected %v, got %v", p[0], p[1], want, got)
+		}
+		want, _ = JaroWinkler(p[0], p[1], 0.1)
+		if got, _ := JaroWinklerGraphemes(p[0], p[1], 0.1); !almostEqual(got, want) {
+			t.Errorf("JaroWinkler %q/%q: expected %v, got %v", p[0], p[1], want, got)
+		}
+	}
+
+	// "Zoë" vs "Zoe": 2 of 3 runes match, giving (2/3 + 2/3 + 1) / 3
+	if got, _ := JaroRunes("Zoë", "Zoe"); !almostEqual(got, 7.0/9) {
+		t.Errorf("expected %v, got %v", 7.0/9, got)
+	}
+	// Clusters are compared as written: canonically equivalent forms still differ
+	if got, _ := JaroGraphemes("Zoe\u0308", "Zoë"); !almostEqual(got, 7.0/9) {
+		t.Errorf("expected %v, got %v", 7.0/9, got)
+	}
+	if got, _ := JaroWinklerRunes("Zoë", "Zoë", 0.1); !almostEqual(got, 1) {
+		t.Errorf("expected 1, got %v", got)
+	}
+}
+
+func TestUnicodeHamming(t *testing.T) {
+	if d, err := HammingRunes("naïve", "naive"); err != nil || d != 1 {
+		t.Errorf("expected 1, got %d (%v)", d, err)
+	}
+	if _, err := HammingString("naïve", "naive"); err != ErrDimensionMismatch {
+		t.Errorf("byte version: expected ErrDimensionMismatch, got %v", err)
+	}
+	if d, err := HammingGraphemes("nai\u0308ve", "naive"); err != nil || d != 1 {
+		t.Errorf("expected 1, got %d (%v)", d, err)
+	}
+	if _, err := HammingRunes("ab", "abc"); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,286 @@
+package distance
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DriftSeverity grades how far a column's distribution has shifted.
+type DriftSeverity int
+
+const (
+	// DriftNone means the column is stable (PSI below ModerateThreshold).
+	DriftNone DriftSeverity = iota
+	// DriftModerate means the shift warrants a look.
+	DriftModerate
+	// DriftMajor means the distribution has changed materially, or the
+	// column has values in only one of the tables.
+	DriftMajor
+)
+
+// String returns the lowercase name of the severity.
+func (s DriftSeverity) String() string {
+	switch s {
+	case DriftNone:
+		return "none"
+	case DriftModerate:
+		return "moderate"
+	case DriftMajor:
+		return "major"
+	}
+	return "unknown"
+}
+
+// TableDriftConfig configures CompareTables.
+type TableDriftConfig struct {
+	// Columns selects and types the columns to compare, matched to the header
+	// by Name; only Name, Kind, Preprocess, Layout and NullValues are used.
+	// Nil compares every header column, treating a column as numeric when all
+	// its non-empty reference cells parse as numbers and as categorical
+	// otherwise.
+	Columns []ColumnSpec
+
+	Bins              int     // Quantile bins for numeric PSI and JS (default 10)
+	ModerateThreshold float64 // PSI at which drift is moderate (default 0.1)
+	MajorThreshold    float64 // PSI at which drift is major (default 0.25)
+}
+
+// ColumnDrift describes the drift of one column. Numeric and date columns
+// are compared with the Kolmogorov-Smirnov statistic and Wasserstein
+// distance (in the column's units, days for dates), plus PSI and
+// Jensen-Shannon divergence over reference-quantile bins; string and
+// categorical columns with PSI and Jensen-Shannon divergence over their
+// category frequencies, KS and Wasserstein being 0. Null and unparseable
+// cells are left out of the distributions and reported as null rates.
+type ColumnDrift struct {
+	Name              string        `json:"name"`
+	Kind              ColumnKind    `json:"kind"`
+	Severity          DriftSeverity `json:"severity"`
+	PSI               float64       `json:"psi"`
+	JensenShannon     float64       `json:"jensen_shannon"`
+	KS                float64       `json:"ks"`
+	Wasserstein       float64       `json:"wasserstein"`
+	ReferenceNullRate float64       `json:"reference_null_rate"`
+	CurrentNullRate   float64       `json:"current_null_rate"`
+}
+
+// TableDriftReport summarizes the drift between two versions of a table.
+type TableDriftReport struct {
+	ReferenceRows int `json:"reference_rows"`
+	CurrentRows   int `json:"current_rows"`
+	// Columns is ranked from most to least drifted: by severity, then PSI.
+	Columns []ColumnDrift `json:"columns"`
+}
+
+// Drifted returns the columns whose severity is at least atLeast, most
+// drifted first.
+func (r *TableDriftReport) Drifted(atLeast DriftSeverity) []ColumnDrift {
+	var drifted []ColumnDrift
+	for _, c := range r.Columns {
+		if c.Severity >= atLeast {
+			drifted = append(drifted, c)
+		}
+	}
+	return drifted
+}
+
+// CompareTables compares each column of current against reference, two
+// tables of string cells (e.g. CSV records) laid out as header, choosing a
+// distance suited to the column's type, and ranks the columns by severity.
+// Severity follows the population stability index convention: below 0.1 is
+// stable, 0.1 to 0.25 moderate, above 0.25 major.
+// Returns ErrEmptyInput if either table has no rows, ErrKeyNotFound if a
+// configured column is not in header, ErrDimensionMismatch if a row is
+// shorter than header, and ErrInvalidParameter for invalid thresholds.
+// Time: O(c·(m+n) log(m+n)) for c columns, Space: O(m+n)
+func CompareTables(header []string, reference, current [][]string, cfg TableDriftConfig) (*TableDriftReport, error) {
+	if len(reference) == 0 || len(current) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if cfg.Bins == 0 {
+		cfg.Bins = 10
+	}
+	if cfg.ModerateThreshold == 0 {
+		cfg.ModerateThreshold = 0.1
+	}
+	if cfg.MajorThreshold == 0 {
+		cfg.MajorThreshold = 0.25
+	}
+	if cfg.Bins < 0 || cfg.ModerateThreshold < 0 || cfg.MajorThreshold < cfg.ModerateThreshold {
+		return nil, ErrInvalidParameter
+	}
+	for _, rows := range [][][]string{reference, current} {
+		for _, row := range rows {
+			if len(row) < len(header) {
+				return nil, ErrDimensionMismatch
+			}
+		}
+	}
+
+	position := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := position[name]; !ok {
+			position[name] = i
+		}
+	}
+	columns := cfg.Columns
+	if columns == nil {
+		for _, name := range header {
+			columns = append(columns, ColumnSpec{Name: name, Kind: inferColumnKind(reference, position[name])})
+		}
+	}
+
+	report := &TableDriftReport{
+		ReferenceRows: len(reference),
+		CurrentRows:   len(current),
+		Columns:       make([]ColumnDrift, 0, len(columns)),
+	}
+	for _, col := range columns {
+		p, ok := position[col.Name]
+		if !ok {
+			return nil, ErrKeyNotFound
+		}
+		if col.Layout == "" {
+			col.Layout = "2006-01-02"
+		}
+		if col.NullValues == nil {
+			col.NullValues = []string{""}
+		}
+		report.Columns = append(report.Columns, columnDrift(&col, p, reference, current, cfg))
+	}
+
+	sort.SliceStable(report.Columns, func(i, j int) bool {
+		a, b := report.Columns[i], report.Columns[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		return a.PSI > b.PSI
+	})
+	return report, nil
+}
+
+// columnDrift compares column p of two tables
+func columnDrift(col *ColumnSpec, p int, reference, current [][]string, cfg TableDriftConfig) ColumnDrift {
+	d := ColumnDrift{Name: col.Name, Kind: col.Kind}
+	numeric := col.Kind == ColumnNumeric || col.Kind == ColumnDate
+
+	var refValues, curValues []float64
+	var refCats, curCats []string
+	for _, side := range []struct {
+		rows   [][]string
+		values *[]float64
+		cats   *[]string
+		nulls  *float64
+	}{
+		{reference, &refValues, &refCats, &d.ReferenceNullRate},
+		{current, &curValues, &curCats, &d.CurrentNullRate},
+	} {
+		for _, row := range side.rows {
+			cell := row[p]
+			switch {
+			case col.isNull(cell):
+			case numeric:
+				if x, ok := col.parseNumber(cell); ok {
+					*side.values = append(*side.values, x)
+				}
+			default:
+				*side.cats = append(*side.cats, col.Preprocess.Apply(cell))
+			}
+		}
+		present := len(*side.values) + len(*side.cats)
+		*side.nulls = 1 - float64(present)/float64(len(side.rows))
+	}
+
+	refPresent, curPresent := len(refValues)+len(refCats) > 0, len(curValues)+len(curCats) > 0
+	switch {
+	case refPresent != curPresent:
+		d.Severity = DriftMajor
+		return d
+	case !refPresent:
+		return d
+	case numeric:
+		d.PSI = populationStability(refValues, curValues, cfg.Bins)
+		d.JensenShannon = quantileBinnedJS(refValues, curValues, cfg.Bins)
+		d.KS = ksStatistic(refValues, curValues)
+		d.Wasserstein = empiricalWasserstein(refValues, curValues)
+	default:
+		ref, cur := categoryFractions(refCats, curCats)
+		for k := range ref {
+			d.PSI += (cur[k] - ref[k]) * math.Log(cur[k]/ref[k])
+		}
+		d.JensenShannon, _ = JensenShannonDivergence(ref, cur)
+	}
+
+	switch {
+	case d.PSI >= cfg.MajorThreshold:
+		d.Severity = DriftMajor
+	case d.PSI >= cfg.ModerateThreshold:
+		d.Severity = DriftModerate
+	}
+	return d
+}
+
+// parseNumber parses a numeric cell, or a date cell as days since the epoch
+func (col *ColumnSpec) parseNumber(cell string) (float64, bool) {
+	cell = strings.TrimSpace(cell)
+	if col.Kind == ColumnDate {
+		t, err := time.Parse(col.Layout, cell)
+		if err != nil {
+			return 0, false
+		}
+		return float64(t.Unix()) / 86400, true
+	}
+	x, err := strconv.ParseFloat(cell, 64)
+	if err != nil || math.IsNaN(x) || math.IsInf(x, 0) {
+		return 0, false
+	}
+	return x, true
+}
+
+// categoryFractions returns smoothed category frequencies of a and b over
+// their combined categories
+func categoryFractions(a, b []string) ([]float64, []float64) {
+	index := make(map[string]int)
+	for _, s := range [][]string{a, b} {
+		for _, c := range s {
+			if _, ok := index[c]; !ok {
+				index[c] = len(index)
+			}
+		}
+	}
+
+	fractions := func(cats []string) []float64 {
+		h := make([]float64, len(index))
+		for _, c := range cats {
+			h[index[c]]++
+		}
+		for k := range h {
+			h[k] = math.Max(h[k]/float64(len(cats)), psiEpsilon)
+		}
+		return h
+	}
+	return fractions(a), fractions(b)
+}
+
+// inferColumnKind reports numeric if every non-empty cell of column p
+// parses as a number
+func inferColumnKind(rows [][]string, p int) ColumnKind {
+	seen := false
+	for _, row := range rows {
+		cell := strings.TrimSpace(row[p])
+		if cell == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(cell, 64); err != nil {
+			return ColumnCategorical
+		}
+		seen = true
+	}
+	if !seen {
+		return ColumnCategorical
+	}
+	return ColumnNumeric
+}
@@ -0,0 +1,162 @@
+package distance
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+// driftTables builds a reference and current table whose "shifted" column
+// moves by shift and whose "region" column changes mix
+func driftTables(seed uint64, shift float64, regionMix float64) (header []string, reference, current [][]string) {
+	rng := testRNG(seed)
+	header = []string{"id", "stable", "shifted", "region"}
+	row := func(i int, shift, mix float64) []string {
+		region := "north"
+		if rng.Float64() < mix {
+			region = "south"
+		}
+		return []string{
+			"r" + strconv.Itoa(i),
+			strconv.FormatFloat(rng.NormFloat64(), 'f', 4, 64),
+			strconv.FormatFloat(rng.NormFloat64()+shift, 'f', 4, 64),
+			region,
+		}
+	}
+	for i := 0; i < 2000; i++ {
+		reference = append(reference, row(i, 0, 0.5))
+		current = append(current, row(i, shift, regionMix))
+	}
+	return header, reference, current
+}
+
+func TestCompareTables(t *testing.T) {
+	header, reference, current := driftTables(1, 1, 0.5)
+	report, err := CompareTables(header, reference, current, TableDriftConfig{
+		Columns: []ColumnSpec{
+			{Name: "stable", Kind: ColumnNumeric},
+			{Name: "shifted", Kind: ColumnNumeric},
+			{Name: "region", Kind: ColumnCategorical},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.ReferenceRows != 2000 || report.CurrentRows != 2000 || len(report.Columns) != 3 {
+		t.Fatalf("unexpected report shape: %+v", report)
+	}
+
+	top := report.Columns[0]
+	if top.Name != "shifted" || top.Severity != DriftMajor {
+		t.Errorf("expected shifted column ranked first with major drift, got %+v", top)
+	}
+	if !almostEqualTolerance(top.Wasserstein, 1, 0.1) || top.KS < 0.3 {
+		t.Errorf("expected Wasserstein ≈ 1 and a large KS, got %+v", top)
+	}
+	for _, c := range report.Columns[1:] {
+		if c.Severity != DriftNone {
+			t.Errorf("expected no drift in %q, got %+v", c.Name, c)
+		}
+	}
+	if drifted := report.Drifted(DriftModerate); len(drifted) != 1 || drifted[0].Name != "shifted" {
+		t.Errorf("expected only shifted to have drifted, got %+v", drifted)
+	}
+
+	if _, err := json.Marshal(report); err != nil {
+		t.Errorf("report should encode as JSON: %v", err)
+	}
+}
+
+func TestCompareTablesCategorical(t *testing.T) {
+	header, reference, current := driftTables(2, 0, 0.8)
+	report, err := CompareTables(header, reference, current, TableDriftConfig{
+		Columns: []ColumnSpec{{Name: "region", Kind: ColumnCategorical}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 50/50 → 20/80 has PSI = 0.3·ln(0.8/0.5) + 0.3·ln(0.5/0.2) ≈ 0.42
+	c := report.Columns[0]
+	if c.Severity != DriftMajor || !almostEqualTolerance(c.PSI, 0.416, 0.05) {
+		t.Errorf("expected major drift with PSI ≈ 0.42, got %+v", c)
+	}
+	if c.KS != 0 || c.Wasserstein != 0 || c.JensenShannon <= 0 {
+		t.Errorf("expected only PSI and JS for a categorical column, got %+v", c)
+	}
+}
+
+func TestCompareTablesInference(t *testing.T) {
+	header, reference, current := driftTables(3, 0, 0.5)
+	report, err := CompareTables(header, reference, current, TableDriftConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	kinds := make(map[string]ColumnKind)
+	for _, c := range report.Columns {
+		kinds[c.Name] = c.Kind
+	}
+	if kinds["id"] != ColumnCategorical || kinds["stable"] != ColumnNumeric || kinds["region"] != ColumnCategorical {
+		t.Errorf("unexpected inferred kinds: %v", kinds)
+	}
+}
+
+func TestCompareTablesNullsAndDates(t *testing.T) {
+	header := []string{"when", "note"}
+	reference := [][]string{{"2024-01-01", "a"}, {"2024-01-02", "b"}, {"", "c"}, {"2024-01-04", "d"}}
+	current := [][]string{{"2024-01-11", ""}, {"2024-01-12", ""}, {"bad", ""}, {"2024-01-14", "NA"}}
+	report, err := CompareTables(header, reference, current, TableDriftConfig{
+		Columns: []ColumnSpec{
+			{Name: "when", Kind: ColumnDate},
+			{Name: "note", Kind: ColumnString, NullValues: []string{"", "NA"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byName := make(map[string]ColumnDrift)
+	for _, c := range report.Columns {
+		byName[c.Name] = c
+	}
+	when := byName["when"]
+	if !almostEqual(when.Wasserstein, 10) || when.ReferenceNullRate != 0.25 || when.CurrentNullRate != 0.25 {
+		t.Errorf("expected a 10-day shift and 25%% nulls, got %+v", when)
+	}
+	note := byName["note"]
+	if note.Severity != DriftMajor || note.CurrentNullRate != 1 {
+		t.Errorf("expected an emptied column to be major drift, got %+v", note)
+	}
+}
+
+func TestCompareTablesErrors(t *testing.T) {
+	header := []string{"a"}
+	rows := [][]string{{"1"}}
+	tests := []struct {
+		name     string
+		ref, cur [][]string
+		cfg      TableDriftConfig
+		expected error
+	}{
+		{"empty reference", nil, rows, TableDriftConfig{}, ErrEmptyInput},
+		{"short row", [][]string{{}}, rows, TableDriftConfig{}, ErrDimensionMismatch},
+		{"unknown column", rows, rows, TableDriftConfig{Columns: []ColumnSpec{{Name: "b"}}}, ErrKeyNotFound},
+		{"inverted thresholds", rows, rows, TableDriftConfig{ModerateThreshold: 0.5, MajorThreshold: 0.2}, ErrInvalidParameter},
+		{"negative bins", rows, rows, TableDriftConfig{Bins: -1}, ErrInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CompareTables(header, tt.ref, tt.cur, tt.cfg); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestDriftSeverityString(t *testing.T) {
+	if DriftMajor.String() != "major" || DriftSeverity(7).String() != "unknown" {
+		t.Errorf("unexpected names %q, %q", DriftMajor.String(), DriftSeverity(7).String())
+	}
+}
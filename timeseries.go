@@ -278,6 +278,77 @@ func NeedlemanWunsch[T comparable](a, b []T, match, mismatch, gap int) (int, err
 	return F[m][n], nil
 }
 
+// NeedlemanWunschAffine computes global sequence alignment score with
+// Gotoh's affine gap model: a gap of length k scores gapOpen + (k-1)*gapExtend,
+// so one long gap is preferred over several short ones when gapOpen < gapExtend.
+// Gap scores must be non-positive; gapOpen == gapExtend matches NeedlemanWunsch.
+// Time: O(mn), Space: O(n)
+func NeedlemanWunschAffine[T comparable](a, b []T, match, mismatch, gapOpen, gapExtend int) (int, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, ErrEmptyInput
+	}
+	if gapOpen > 0 || gapExtend > 0 {
+		return 0, ErrInvalidParameter
+	}
+
+	n := len(b)
+	// H is the best score ending at (i, j); X ends with a[i-1] against a gap,
+	// Y ends with b[j-1] against a gap
+	H, X, Y := make([]int, n+1), make([]int, n+1), make([]int, n+1)
+	for j := 1; j <= n; j++ {
+		H[j] = gapOpen + (j-1)*gapExtend
+		X[j] = negInfScore
+		Y[j] = H[j]
+	}
+	X[0], Y[0] = negInfScore, negInfScore
+
+	prevH := make([]int, n+1)
+	for i := 1; i <= len(a); i++ {
+		copy(prevH, H)
+		H[0] = gapOpen + (i-1)*gapExtend
+		X[0], Y[0] = H[0], negInfScore
+		for j := 1; j <= n; j++ {
+			X[j] = max(prevH[j]+gapOpen, X[j]+gapExtend)
+			Y[j] = max(H[j-1]+gapOpen, Y[j-1]+gapExtend)
+			H[j] = max(prevH[j-1]+alignScore(a[i-1], b[j-1], match, mismatch), X[j], Y[j])
+		}
+	}
+
+	return H[n], nil
+}
+
+// SmithWatermanAffine computes local sequence alignment score with Gotoh's
+// affine gap model (see NeedlemanWunschAffine).
+// Time: O(mn), Space: O(n)
+func SmithWatermanAffine[T comparable](a, b []T, match, mismatch, gapOpen, gapExtend int) (int, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, ErrEmptyInput
+	}
+	if gapOpen > 0 || gapExtend > 0 {
+		return 0, ErrInvalidParameter
+	}
+
+	n := len(b)
+	H, X, Y := make([]int, n+1), make([]int, n+1), make([]int, n+1)
+	for j := range X {
+		X[j], Y[j] = negInfScore, negInfScore
+	}
+
+	maxScore := 0
+	prevH := make([]int, n+1)
+	for i := 1; i <= len(a); i++ {
+		copy(prevH, H)
+		for j := 1; j <= n; j++ {
+			X[j] = max(prevH[j]+gapOpen, X[j]+gapExtend)
+			Y[j] = max(H[j-1]+gapOpen, Y[j-1]+gapExtend)
+			H[j] = max(0, prevH[j-1]+alignScore(a[i-1], b[j-1], match, mismatch), X[j], Y[j])
+			maxScore = max(maxScore, H[j])
+		}
+	}
+
+	return maxScore, nil
+}
+
 // SoftDTW computes differentiable DTW using soft-min.
 // Useful for machine learning applications.
 // gamma controls smoothness (smaller = closer to DTW).
@@ -358,3 +429,14 @@ func Autocorrelation[T Number](data []T, lag int) (float64, error) {
 
 	return ck / c0, nil
 }
+
+// negInfScore is an unreachable alignment score that cannot overflow when penalties are added
+const negInfScore = math.MinInt / 4
+
+// alignScore returns the substitution score for aligning x with y
+func alignScore[T comparable](x, y T, match, mismatch int) int {
+	if x == y {
+		return match
+	}
+	return mismatch
+}
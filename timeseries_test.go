@@ -109,6 +109,93 @@ func TestNeedlemanWunsch(t *testing.T) {
 	}
 }
 
+func TestNeedlemanWunschAffine(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected int
+	}{
+		{"identical", "ACGT", "ACGT", 4},
+		{"one long gap", "ACGT", "AT", -2},        // A--T: 2 matches, gap of 2 = -3-1
+		{"gap at start", "GGACGT", "ACGT", 0},     // --ACGT: 4 matches, gap of 2 = -3-1
+		{"single gap", "ACGT", "AGT", 0},          // A-GT: 3 matches, gap of 1 = -3
+		{"mismatch beats gap", "ACGT", "AGGT", 2}, // 3 matches, 1 mismatch
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, err := NeedlemanWunschAffine([]byte(tt.a), []byte(tt.b), 1, -1, -3, -1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if score != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, score)
+			}
+		})
+	}
+}
+
+func TestSmithWatermanAffine(t *testing.T) {
+	a, b := []byte("AAAGGGTTT"), []byte("AAATTT")
+
+	// Expensive gap opening: the best local alignment is AAA alone
+	score, err := SmithWatermanAffine(a, b, 2, -3, -5, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if score != 6 {
+		t.Errorf("expected 6, got %d", score)
+	}
+
+	// Cheap gap opening: AAA---TTT bridges the gap, 12 - 2 - 1 - 1
+	score, err = SmithWatermanAffine(a, b, 2, -3, -2, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if score != 8 {
+		t.Errorf("expected 8, got %d", score)
+	}
+}
+
+func TestAffineMatchesLinearGaps(t *testing.T) {
+	pairs := [][2]string{
+		{"GATTACA", "GCATGCU"},
+		{"ACGT", "AT"},
+		{"AAAGGGTTT", "AAATTT"},
+		{"A", "CCCC"},
+	}
+
+	for _, p := range pairs {
+		a, b := []byte(p[0]), []byte(p[1])
+		nw, _ := NeedlemanWunsch(a, b, 1, -1, -2)
+		nwAffine, err := NeedlemanWunschAffine(a, b, 1, -1, -2, -2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if nw != nwAffine {
+			t.Errorf("%s/%s: NeedlemanWunsch %d, affine %d", p[0], p[1], nw, nwAffine)
+		}
+
+		sw, _ := SmithWaterman(a, b, 2, -1, -1)
+		swAffine, err := SmithWatermanAffine(a, b, 2, -1, -1, -1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sw != swAffine {
+			t.Errorf("%s/%s: SmithWaterman %d, affine %d", p[0], p[1], sw, swAffine)
+		}
+	}
+}
+
+func TestAffineAlignmentErrors(t *testing.T) {
+	if _, err := NeedlemanWunschAffine([]byte{}, []byte("A"), 1, -1, -3, -1); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := SmithWatermanAffine([]byte("A"), []byte("A"), 1, -1, 3, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
 func TestAutocorrelation(t *testing.T) {
 	data := []float64{1, 2, 3, 4, 5}
 
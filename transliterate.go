@@ -0,0 +1,158 @@
+package distance
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TransliterationTable maps lowercase letter sequences of one script to
+// their Latin spelling. Keys may span several runes (e.g. Greek "ου" → "ou");
+// Transliterate always applies the longest matching key. Uppercase input is
+// looked up in lowercase and the output capitalized to match, so tables list
+// lowercase forms only. Tables are plain maps: copy and edit one of the
+// built-in tables, or build one from scratch, to follow a different
+// romanization standard.
+type TransliterationTable map[string]string
+
+// Transliterate rewrites s into Latin script using tables, tried in order at
+// each position; runes no table covers (including Latin text) pass through
+// unchanged. A rune that begins an all-caps word is uppercased in full
+// ("ЩУКА" → "SHCHUKA"), otherwise only its first letter is ("Щука" →
+// "Shchuka"). Combine with StringOptions or PhoneticDistance to match names
+// across scripts.
+// Time: O(n·k) where k = longest key, Space: O(n)
+func Transliterate(s string, tables ...TransliterationTable) string {
+	longest := make([]int, len(tables))
+	for t, table := range tables {
+		for key := range table {
+			longest[t] = max(longest[t], utf8.RuneCountInString(key))
+		}
+	}
+
+	runes := []rune(s)
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		latin, n := transliterateAt(runes, i, tables, longest)
+		if n == 0 {
+			b.WriteRune(runes[i])
+			i++
+			continue
+		}
+		if unicode.IsUpper(runes[i]) {
+			allCaps := (i+n < len(runes) && unicode.IsUpper(runes[i+n])) ||
+				(i > 0 && unicode.IsUpper(runes[i-1]))
+			if allCaps {
+				latin = strings.ToUpper(latin)
+			} else if r, size := utf8.DecodeRuneInString(latin); size > 0 {
+				latin = string(unicode.ToUpper(r)) + latin[size:]
+			}
+		}
+		b.WriteString(latin)
+		i += n
+	}
+	return b.String()
+}
+
+// TransliterationForLocale returns a fresh copy of the built-in table for a
+// language code: "ru" (Russian), "uk" (Ukrainian), "bg" (Bulgarian), "el"
+// (Greek) or "ar" (Arabic). Cyrillic tables follow simplified BGN/PCGN
+// spellings, the ones common in passports and news ("Дмитрий" → "Dmitriy");
+// Greek follows ELOT 743 and Arabic a simplified consonantal scheme.
+// Returns ErrKeyNotFound for other locales.
+func TransliterationForLocale(locale string) (TransliterationTable, error) {
+	var base, overrides TransliterationTable
+	switch strings.ToLower(locale) {
+	case "ru":
+		base = cyrillicLatin
+	case "uk":
+		base, overrides = cyrillicLatin, ukrainianLatin
+	case "bg":
+		base, overrides = cyrillicLatin, bulgarianLatin
+	case "el":
+		base = greekLatin
+	case "ar":
+		base = arabicLatin
+	default:
+		return nil, ErrKeyNotFound
+	}
+
+	table := make(TransliterationTable, len(base)+len(overrides))
+	for k, v := range base {
+		table[k] = v
+	}
+	for k, v := range overrides {
+		table[k] = v
+	}
+	return table, nil
+}
+
+// defaultTransliteration romanizes every script with a built-in table
+var defaultTransliteration = []TransliterationTable{cyrillicLatin, greekLatin, arabicLatin}
+
+// transliterateAt finds the longest key matching runes[i:] in the first table
+// that has one, returning its Latin spelling and length in runes (0 if none)
+func transliterateAt(runes []rune, i int, tables []TransliterationTable, longest []int) (string, int) {
+	for t, table := range tables {
+		for n := min(longest[t], len(runes)-i); n > 0; n-- {
+			key := make([]rune, n)
+			for j := range key {
+				key[j] = unicode.ToLower(runes[i+j])
+			}
+			if latin, ok := table[string(key)]; ok {
+				return latin, n
+			}
+		}
+	}
+	return "", 0
+}
+
+// cyrillicLatin is the Russian alphabet in simplified BGN/PCGN romanization,
+// plus the Ukrainian letters so any Cyrillic name yields Latin letters
+var cyrillicLatin = TransliterationTable{
+	"а": "a", "б": "b", "в": "v", "г": "g", "д": "d", "е": "e", "ё": "yo",
+	"ж": "zh", "з": "z", "и": "i", "й": "y", "к": "k", "л": "l", "м": "m",
+	"н": "n", "о": "o", "п": "p", "р": "r", "с": "s", "т": "t", "у": "u",
+	"ф": "f", "х": "kh", "ц": "ts", "ч": "ch", "ш": "sh", "щ": "shch", "ъ": "",
+	"ы": "y", "ь": "", "э": "e", "ю": "yu", "я": "ya",
+	"і": "i", "ї": "yi", "є": "ye", "ґ": "g",
+	"ый": "y",
+}
+
+// ukrainianLatin overrides cyrillicLatin with the Ukrainian national system
+// (word-initial forms such as "ye" for є are not distinguished)
+var ukrainianLatin = TransliterationTable{
+	"г": "h", "ґ": "g", "є": "ie", "и": "y", "і": "i", "ї": "i", "й": "i",
+	"ю": "iu", "я": "ia", "'": "", "’": "", "зг": "zgh",
+}
+
+// bulgarianLatin overrides cyrillicLatin with the Bulgarian streamlined system
+var bulgarianLatin = TransliterationTable{
+	"х": "h", "щ": "sht", "ъ": "a", "ь": "y",
+}
+
+// greekLatin is the Greek alphabet in ELOT 743 romanization, with tonos and
+// dialytika forms listed so accented text needs no normalization first
+var greekLatin = TransliterationTable{
+	"α": "a", "β": "v", "γ": "g", "δ": "d", "ε": "e", "ζ": "z", "η": "i",
+	"θ": "th", "ι": "i", "κ": "k", "λ": "l", "μ": "m", "ν": "n", "ξ": "x",
+	"ο": "o", "π": "p", "ρ": "r", "σ": "s", "ς": "s", "τ": "t", "υ": "y",
+	"φ": "f", "χ": "ch", "ψ": "ps", "ω": "o",
+	"ά": "a", "έ": "e", "ή": "i", "ί": "i", "ό": "o", "ύ": "y", "ώ": "o",
+	"ϊ": "i", "ϋ": "y", "ΐ": "i", "ΰ": "y",
+	"ου": "ou", "ού": "ou", "αυ": "av", "αύ": "av", "ευ": "ev", "εύ": "ev",
+	"ηυ": "iv", "γγ": "ng", "γξ": "nx", "γχ": "nch",
+}
+
+// arabicLatin romanizes Arabic letters; short-vowel marks are usually
+// omitted in writing, so unvowelled names yield consonant skeletons
+// ("محمد" → "mhmd") best compared with a phonetic code or a vowel-blind metric
+var arabicLatin = TransliterationTable{
+	"ا": "a", "أ": "a", "إ": "i", "آ": "a", "ء": "", "ؤ": "", "ئ": "",
+	"ب": "b", "ت": "t", "ث": "th", "ج": "j", "ح": "h", "خ": "kh", "د": "d",
+	"ذ": "dh", "ر": "r", "ز": "z", "س": "s", "ش": "sh", "ص": "s", "ض": "d",
+	"ط": "t", "ظ": "z", "ع": "", "غ": "gh", "ف": "f", "ق": "q", "ك": "k",
+	"ل": "l", "م": "m", "ن": "n", "ه": "h", "و": "w", "ي": "y", "ى": "a",
+	"ة": "a", "َ": "a", "ِ": "i", "ُ": "u", "ْ": "",
+	"ّ": "", "ً": "an", "ٍ": "in", "ٌ": "un", "ـ": "",
+}
@@ -0,0 +1,82 @@
+package distance
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	ru, _ := TransliterationForLocale("ru")
+	uk, _ := TransliterationForLocale("uk")
+	bg, _ := TransliterationForLocale("bg")
+	el, _ := TransliterationForLocale("el")
+	ar, _ := TransliterationForLocale("AR")
+
+	tests := []struct {
+		name     string
+		input    string
+		table    TransliterationTable
+		expected string
+	}{
+		{"russian", "Дмитрий", ru, "Dmitriy"},
+		{"russian digraphs", "Щукин Хрущёв", ru, "Shchukin Khrushchyov"},
+		{"russian adjective ending", "Белый", ru, "Bely"},
+		{"all caps", "ЩУКА", ru, "SHCHUKA"},
+		{"mixed scripts", "Иван Smith", ru, "Ivan Smith"},
+		{"ukrainian", "Григорій Згурський", uk, "Hryhorii Zghurskyi"},
+		{"bulgarian", "Христо Ъгълов", bg, "Hristo Agalov"},
+		{"greek", "Γεώργιος Παπαδόπουλος", el, "Georgios Papadopoulos"},
+		{"greek clusters", "Ευάγγελος", el, "Evangelos"},
+		{"arabic", "محمد", ar, "mhmd"},
+		{"arabic vowelled", "مُحَمَّد", ar, "muhamad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Transliterate(tt.input, tt.table); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	// Earlier tables take precedence; uncovered runes pass through
+	custom := TransliterationTable{"д": "dd"}
+	if got := Transliterate("Дима ☃", custom, ru); got != "Ddima ☃" {
+		t.Errorf("expected %q, got %q", "Ddima ☃", got)
+	}
+	if got := Transliterate("Дима"); got != "Дима" {
+		t.Errorf("expected unchanged text without tables, got %q", got)
+	}
+}
+
+func TestTransliterationForLocale(t *testing.T) {
+	ru, err := TransliterationForLocale("ru")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ru["д"] = "x"
+	if again, _ := TransliterationForLocale("ru"); again["д"] != "d" {
+		t.Errorf("expected a fresh copy, got %q", again["д"])
+	}
+	if _, err := TransliterationForLocale("xx"); err != ErrKeyNotFound {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestTransliterateCrossScriptMatching(t *testing.T) {
+	ru, _ := TransliterationForLocale("ru")
+	opts := StringOptions{Transliterate: []TransliterationTable{ru}, CaseInsensitive: true}
+	lev := WithStringOptions(Levenshtein, opts)
+	if d, err := lev("Дмитрий", "dmitriy"); err != nil || d != 0 {
+		t.Errorf("expected 0, got %d (%v)", d, err)
+	}
+	jw := WithStringOptions(func(a, b string) (float64, error) { return JaroWinkler(a, b, 0.1) }, opts)
+	if s, _ := jw("Дмитрий", "Dmitry"); s < 0.9 {
+		t.Errorf("expected similarity above 0.9, got %v", s)
+	}
+
+	// Phonetic codes romanize automatically
+	if RefinedSoundex("Шварц") != RefinedSoundex("Shvarts") {
+		t.Errorf("expected equal codes, got %q and %q", RefinedSoundex("Шварц"), RefinedSoundex("Shvarts"))
+	}
+	if !DaitchMokotoffMatch("Шварц", "Schwarz") {
+		t.Errorf("expected a match, got %v and %v", DaitchMokotoffSoundex("Шварц"), DaitchMokotoffSoundex("Schwarz"))
+	}
+}
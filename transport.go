@@ -0,0 +1,253 @@
+package distance
+
+import "math"
+
+// transportTolerance is the mass below which supply or demand counts as exhausted
+const transportTolerance = 1e-12
+
+// EMD computes the Earth Mover's Distance between histograms p and q under an
+// arbitrary ground cost, where costMatrix[i][j] is the cost of moving unit mass
+// from bin i of p to bin j of q. Histograms may have different lengths and are
+// normalized to unit mass, so the result is the minimum average transport cost.
+// Solved exactly with successive shortest paths on the transportation network.
+// Time: O((m+n)·mn) per augmentation, Space: O(mn)
+func EMD(p, q []float64, costMatrix [][]float64) (float64, error) {
+	plan, err := transportPlan(p, q, costMatrix)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for i, row := range plan {
+		for j, f := range row {
+			total += f * costMatrix[i][j]
+		}
+	}
+	return total, nil
+}
+
+// validateTransport checks histograms and cost matrix, returning normalized copies
+func validateTransport(p, q []float64, costMatrix [][]float64) ([]float64, []float64, error) {
+	if len(p) == 0 || len(q) == 0 {
+		return nil, nil, ErrEmptyInput
+	}
+	if len(costMatrix) != len(p) {
+		return nil, nil, ErrDimensionMismatch
+	}
+	for _, row := range costMatrix {
+		if len(row) != len(q) {
+			return nil, nil, ErrDimensionMismatch
+		}
+		for _, c := range row {
+			if c < 0 {
+				return nil, nil, ErrNegativeValue
+			}
+			if math.IsNaN(c) || math.IsInf(c, 0) {
+				return nil, nil, ErrInvalidParameter
+			}
+		}
+	}
+
+	supply, err := NormalizeToDistribution(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	demand, err := NormalizeToDistribution(q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return supply, demand, nil
+}
+
+// transportPlan solves the transportation problem, returning the optimal flow
+// matrix. Residual arcs are i→j with cost c[i][j] and, where flow exists,
+// j→i with cost -c[i][j]; Bellman-Ford finds the cheapest augmenting path
+// from any source with supply left to any sink with demand left.
+func transportPlan(p, q []float64, costMatrix [][]float64) ([][]float64, error) {
+	supply, demand, err := validateTransport(p, q, costMatrix)
+	if err != nil {
+		return nil, err
+	}
+
+	m, n := len(supply), len(demand)
+	flow := make([][]float64, m)
+	for i := range flow {
+		flow[i] = make([]float64, n)
+	}
+
+	// Nodes 0..m-1 are sources, m..m+n-1 are sinks
+	dist := make([]float64, m+n)
+	pred := make([]int, m+n)
+	remaining := 1.0
+
+	for remaining > transportTolerance {
+		for v := range dist {
+			dist[v] = math.Inf(1)
+			pred[v] = -1
+		}
+		for i, s := range supply {
+			if s > transportTolerance {
+				dist[i] = 0
+			}
+		}
+
+		for iter := 0; iter < m+n; iter++ {
+			changed := false
+			for i := 0; i < m; i++ {
+				for j := 0; j < n; j++ {
+					if d := dist[i] + costMatrix[i][j]; d < dist[m+j] {
+						dist[m+j], pred[m+j] = d, i
+						changed = true
+					}
+					if flow[i][j] > transportTolerance {
+						if d := dist[m+j] - costMatrix[i][j]; d < dist[i] {
+							dist[i], pred[i] = d, m+j
+							changed = true
+						}
+					}
+				}
+			}
+			if !changed {
+				break
+			}
+		}
+
+		sink := -1
+		for j, d := range demand {
+			if d > transportTolerance && (sink < 0 || dist[m+j] < dist[m+sink]) {
+				sink = j
+			}
+		}
+		if sink < 0 || math.IsInf(dist[m+sink], 1) {
+			break
+		}
+
+		// Find the bottleneck along the path back to its source
+		amount := demand[sink]
+		v := m + sink
+		for pred[v] >= 0 {
+			u := pred[v]
+			if v < m { // backward arc sink u → source v
+				amount = math.Min(amount, flow[v][u-m])
+			}
+			v = u
+		}
+		amount = math.Min(amount, supply[v])
+
+		supply[v] -= amount
+		demand[sink] -= amount
+		remaining -= amount
+		v = m + sink
+		for pred[v] >= 0 {
+			u := pred[v]
+			if v >= m {
+				flow[u][v-m] += amount
+			} else {
+				flow[v][u-m] -= amount
+			}
+			v = u
+		}
+	}
+
+	return flow, nil
+}
+
+// GroundDistanceMatrix builds an EMD cost matrix from bin positions using distFn,
+// e.g. pixel coordinates or word embeddings.
+// Time: O(mn·d), Space: O(mn)
+func GroundDistanceMatrix[T Number](from, to [][]T, distFn DistanceFunc[T]) ([][]float64, error) {
+	if len(from) == 0 || len(to) == 0 {
+		return nil, ErrEmptyInput
+	}
+	return CrossCompute(from, to, distFn)
+}
+
+// sinkhornTolerance stops Sinkhorn early once dual potentials stop moving
+const sinkhornTolerance = 1e-9
+
+// Sinkhorn approximates optimal transport between histograms p and q with
+// entropic regularization strength epsilon, returning the transport cost
+// <P, C> of the regularized plan. Smaller epsilon is closer to EMD but needs
+// more iterations; updates run in the log domain so small epsilon stays stable.
+// Stops after iters iterations or once the potentials converge.
+// Time: O(iters·mn), Space: O(m+n)
+func Sinkhorn(p, q []float64, cost [][]float64, epsilon float64, iters int) (float64, error) {
+	if epsilon <= 0 || math.IsNaN(epsilon) || iters <= 0 {
+		return 0, ErrInvalidParameter
+	}
+	a, b, err := validateTransport(p, q, cost)
+	if err != nil {
+		return 0, err
+	}
+
+	logA, logB := logMass(a), logMass(b)
+	f, g := sinkhornPotentials(logA, logB, cost, epsilon, iters)
+
+	var total float64
+	for i := range f {
+		for j := range g {
+			total += math.Exp(logA[i]+logB[j]+(f[i]+g[j]-cost[i][j])/epsilon) * cost[i][j]
+		}
+	}
+	return total, nil
+}
+
+// sinkhornPotentials runs log-domain Sinkhorn iterations for marginals with
+// logs logA and logB, returning the dual potentials f and g. The regularized
+// plan is exp(logA[i] + logB[j] + (f[i] + g[j] - cost[i][j]) / epsilon).
+// cost need not be non-negative.
+func sinkhornPotentials(logA, logB []float64, cost [][]float64, epsilon float64, iters int) ([]float64, []float64) {
+	f := make([]float64, len(logA))
+	g := make([]float64, len(logB))
+	terms := make([]float64, max(len(logA), len(logB)))
+
+	for iter := 0; iter < iters; iter++ {
+		delta := 0.0
+		for i := range f {
+			for j := range g {
+				terms[j] = logB[j] + (g[j]-cost[i][j])/epsilon
+			}
+			next := -epsilon * logSumExp(terms[:len(g)])
+			delta = math.Max(delta, math.Abs(next-f[i]))
+			f[i] = next
+		}
+		for j := range g {
+			for i := range f {
+				terms[i] = logA[i] + (f[i]-cost[i][j])/epsilon
+			}
+			next := -epsilon * logSumExp(terms[:len(f)])
+			delta = math.Max(delta, math.Abs(next-g[j]))
+			g[j] = next
+		}
+		if delta < sinkhornTolerance {
+			break
+		}
+	}
+	return f, g
+}
+
+// logMass returns elementwise logs, with -Inf for empty bins
+func logMass(h []float64) []float64 {
+	out := make([]float64, len(h))
+	for i, v := range h {
+		out[i] = math.Log(v)
+	}
+	return out
+}
+
+// logSumExp computes log Σ exp(x) without overflow, ignoring -Inf terms
+func logSumExp(x []float64) float64 {
+	m := math.Inf(-1)
+	for _, v := range x {
+		m = math.Max(m, v)
+	}
+	if math.IsInf(m, -1) {
+		return m
+	}
+
+	var sum float64
+	for _, v := range x {
+		sum += math.Exp(v - m)
+	}
+	return m + math.Log(sum)
+}
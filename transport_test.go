@@ -0,0 +1,134 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEMDMatchesWasserstein1D(t *testing.T) {
+	// Bins at positions 0..4, unit spacing
+	positions := [][]float64{{0}, {1}, {2}, {3}, {4}}
+	cost, err := GroundDistanceMatrix(positions, positions, Euclidean[float64])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		p, q     []float64
+		expected float64
+	}{
+		{"identical", []float64{1, 2, 3, 2, 1}, []float64{1, 2, 3, 2, 1}, 0},
+		{"shift by one", []float64{1, 0, 0, 0, 0}, []float64{0, 1, 0, 0, 0}, 1},
+		{"opposite ends", []float64{1, 0, 0, 0, 0}, []float64{0, 0, 0, 0, 1}, 4},
+		{"split", []float64{0, 0, 2, 0, 0}, []float64{1, 0, 0, 0, 1}, 2},
+		{"unnormalized", []float64{5, 0, 0, 0, 5}, []float64{0, 0, 1, 0, 0}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EMD(tt.p, tt.q, cost)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEMDRectangular(t *testing.T) {
+	// Two source bins, three target bins on a 2D plane
+	from := [][]float64{{0, 0}, {4, 0}}
+	to := [][]float64{{0, 1}, {4, 1}, {2, 0}}
+	cost, _ := GroundDistanceMatrix(from, to, Euclidean[float64])
+
+	got, err := EMD([]float64{0.5, 0.5}, []float64{0.25, 0.25, 0.5}, cost)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Each source sends 0.25 straight up (cost 1) and 0.25 to the middle (cost 2)
+	if !almostEqual(got, 0.25*1*2+0.25*2*2) {
+		t.Errorf("expected %v, got %v", 1.5, got)
+	}
+}
+
+func TestEMDNeedsRerouting(t *testing.T) {
+	// Greedy assignment is suboptimal here; the solver must use a backward arc
+	cost := [][]float64{
+		{1, 2},
+		{1, 10},
+	}
+	got, err := EMD([]float64{1, 1}, []float64{1, 1}, cost)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(got, (2+1)/2.0) {
+		t.Errorf("expected 1.5, got %v", got)
+	}
+}
+
+func TestEMDErrors(t *testing.T) {
+	cost := [][]float64{{0, 1}, {1, 0}}
+
+	tests := []struct {
+		name string
+		p, q []float64
+		cost [][]float64
+		err  error
+	}{
+		{"empty", []float64{}, []float64{1, 1}, cost, ErrEmptyInput},
+		{"rows", []float64{1, 1, 1}, []float64{1, 1}, cost, ErrDimensionMismatch},
+		{"cols", []float64{1, 1}, []float64{1}, cost, ErrDimensionMismatch},
+		{"negative mass", []float64{1, -1}, []float64{1, 1}, cost, ErrNegativeValue},
+		{"zero mass", []float64{0, 0}, []float64{1, 1}, cost, ErrZeroVector},
+		{"negative cost", []float64{1, 1}, []float64{1, 1}, [][]float64{{0, -1}, {1, 0}}, ErrNegativeValue},
+		{"nan cost", []float64{1, 1}, []float64{1, 1}, [][]float64{{0, math.NaN()}, {1, 0}}, ErrInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := EMD(tt.p, tt.q, tt.cost); err != tt.err {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSinkhornApproachesEMD(t *testing.T) {
+	positions := [][]float64{{0}, {1}, {2}, {3}, {4}}
+	cost, _ := GroundDistanceMatrix(positions, positions, Euclidean[float64])
+	p := []float64{0.4, 0.3, 0.2, 0.1, 0}
+	q := []float64{0, 0.1, 0.2, 0.3, 0.4}
+
+	exact, _ := EMD(p, q, cost)
+	got, err := Sinkhorn(p, q, cost, 0.01, 5000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(got-exact) > 0.02 {
+		t.Errorf("expected close to %v, got %v", exact, got)
+	}
+
+	// Heavier regularization spreads mass and costs more
+	blurred, _ := Sinkhorn(p, q, cost, 1, 5000)
+	if blurred <= got {
+		t.Errorf("expected larger cost with epsilon=1, got %v <= %v", blurred, got)
+	}
+}
+
+func TestSinkhornErrors(t *testing.T) {
+	cost := [][]float64{{0, 1}, {1, 0}}
+	p := []float64{1, 1}
+
+	if _, err := Sinkhorn(p, p, cost, 0, 10); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := Sinkhorn(p, p, cost, 0.1, 0); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := Sinkhorn(p, []float64{1}, cost, 0.1, 10); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
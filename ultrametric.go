@@ -0,0 +1,101 @@
+package distance
+
+import "math"
+
+// CopheneticMatrix returns the cophenetic distances of a dendrogram: the
+// height of the merge at which each pair of leaves first joins a common
+// cluster. For monotone linkages (single, complete, average) the result is
+// an ultrametric.
+// Time: O(n²), Space: O(n²)
+func CopheneticMatrix(merges []Merge) [][]float64 {
+	n := len(merges) + 1
+	result := make([][]float64, n)
+	for i := range result {
+		result[i] = make([]float64, n)
+	}
+
+	// leaves[c] lists the leaves of cluster c
+	leaves := make([][]int, n+len(merges))
+	for i := 0; i < n; i++ {
+		leaves[i] = []int{i}
+	}
+	for step, m := range merges {
+		for _, x := range leaves[m.A] {
+			for _, y := range leaves[m.B] {
+				result[x][y], result[y][x] = m.Distance, m.Distance
+			}
+		}
+		leaves[n+step] = append(leaves[m.A], leaves[m.B]...)
+		leaves[m.A], leaves[m.B] = nil, nil
+	}
+	return result
+}
+
+// FitUltrametric fits an ultrametric tree to a distance matrix by average
+// linkage agglomeration (UPGMA) and returns its cophenetic distances together
+// with the merges. Each fitted distance is the average of the original
+// distances between the two clusters it joins, the classical approximation to
+// the least-squares ultrametric for molecular-clock phylogenies built from
+// sequence distances.
+// Time: O(n³), Space: O(n²)
+func FitUltrametric(matrix [][]float64) ([][]float64, []Merge, error) {
+	merges, err := AgglomerativeCluster(matrix, AverageLinkage)
+	if err != nil {
+		return nil, nil, err
+	}
+	return CopheneticMatrix(merges), merges, nil
+}
+
+// CopheneticCorrelation measures how faithfully a dendrogram preserves the
+// original distances: the Pearson correlation between the matrix's
+// off-diagonal entries and the corresponding cophenetic distances.
+// Range [-1, 1] where 1=perfect fit
+// Time: O(n²), Space: O(n²)
+func CopheneticCorrelation(matrix [][]float64, merges []Merge) (float64, error) {
+	if err := validateSquare(matrix); err != nil {
+		return 0, err
+	}
+	if len(matrix) != len(merges)+1 {
+		return 0, ErrDimensionMismatch
+	}
+	if len(matrix) < 3 {
+		return 0, ErrInvalidParameter
+	}
+
+	cophenetic := CopheneticMatrix(merges)
+	var original, fitted []float64
+	for i := range matrix {
+		for j := i + 1; j < len(matrix); j++ {
+			original = append(original, matrix[i][j])
+			fitted = append(fitted, cophenetic[i][j])
+		}
+	}
+	return PearsonCorrelation(original, fitted)
+}
+
+// IsUltrametric reports whether matrix is an ultrametric within tolerance:
+// symmetric with zero diagonal and d(i,j) ≤ max(d(i,k), d(j,k)) for all
+// triples, i.e. every triangle is isosceles with a short base.
+// Time: O(n³), Space: O(1)
+func IsUltrametric(matrix [][]float64, tolerance float64) bool {
+	if validateSquare(matrix) != nil {
+		return false
+	}
+	n := len(matrix)
+	for i := 0; i < n; i++ {
+		if math.Abs(matrix[i][i]) > tolerance {
+			return false
+		}
+		for j := i + 1; j < n; j++ {
+			if math.Abs(matrix[i][j]-matrix[j][i]) > tolerance || matrix[i][j] < -tolerance {
+				return false
+			}
+			for k := 0; k < n; k++ {
+				if matrix[i][j] > math.Max(matrix[i][k], matrix[j][k])+tolerance {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
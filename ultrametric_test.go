@@ -0,0 +1,123 @@
+package distance
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCopheneticMatrix(t *testing.T) {
+	// ((0,1):1, (2,3):2):5
+	merges := []Merge{
+		{A: 0, B: 1, Distance: 1, Size: 2},
+		{A: 2, B: 3, Distance: 2, Size: 2},
+		{A: 4, B: 5, Distance: 5, Size: 4},
+	}
+	expected := [][]float64{
+		{0, 1, 5, 5},
+		{1, 0, 5, 5},
+		{5, 5, 0, 2},
+		{5, 5, 2, 0},
+	}
+	if got := CopheneticMatrix(merges); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := CopheneticMatrix(nil); !reflect.DeepEqual(got, [][]float64{{0}}) {
+		t.Errorf("expected [[0]], got %v", got)
+	}
+}
+
+func TestFitUltrametric(t *testing.T) {
+	// Classic UPGMA example (Wikipedia, 5S rRNA of five bacteria)
+	matrix := [][]float64{
+		{0, 17, 21, 31, 23},
+		{17, 0, 30, 34, 21},
+		{21, 30, 0, 28, 39},
+		{31, 34, 28, 0, 43},
+		{23, 21, 39, 43, 0},
+	}
+	fitted, merges, err := FitUltrametric(matrix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	heights := make([]float64, len(merges))
+	for i, m := range merges {
+		heights[i] = m.Distance
+	}
+	if !reflect.DeepEqual(heights, []float64{17, 22, 28, 33}) {
+		t.Errorf("expected merge heights [17 22 28 33], got %v", heights)
+	}
+	if !IsUltrametric(fitted, 1e-9) {
+		t.Errorf("expected an ultrametric, got %v", fitted)
+	}
+	if fitted[0][4] != 22 || fitted[2][3] != 28 || fitted[0][3] != 33 {
+		t.Errorf("unexpected fitted distances %v", fitted)
+	}
+
+	// Fitting an ultrametric reproduces it exactly
+	again, _, err := FitUltrametric(fitted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(again, fitted) {
+		t.Errorf("expected %v, got %v", fitted, again)
+	}
+}
+
+func TestCopheneticCorrelation(t *testing.T) {
+	ultra := [][]float64{
+		{0, 1, 5, 5},
+		{1, 0, 5, 5},
+		{5, 5, 0, 2},
+		{5, 5, 2, 0},
+	}
+	_, merges, _ := FitUltrametric(ultra)
+	c, err := CopheneticCorrelation(ultra, merges)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(c, 1) {
+		t.Errorf("expected 1 for an ultrametric, got %v", c)
+	}
+
+	// Points on a line are far from ultrametric
+	line := layoutMatrix([][]float64{{0}, {1}, {2}, {3}, {4}})
+	_, merges, _ = FitUltrametric(line)
+	c, err = CopheneticCorrelation(line, merges)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c >= 1 || c <= 0 || math.IsNaN(c) {
+		t.Errorf("expected correlation in (0, 1), got %v", c)
+	}
+
+	if _, err := CopheneticCorrelation(line, merges[:2]); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := CopheneticCorrelation([][]float64{{0, 1}, {1, 0}}, []Merge{{A: 0, B: 1, Distance: 1, Size: 2}}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestIsUltrametric(t *testing.T) {
+	tests := []struct {
+		name     string
+		matrix   [][]float64
+		expected bool
+	}{
+		{"ultrametric", [][]float64{{0, 1, 5}, {1, 0, 5}, {5, 5, 0}}, true},
+		{"line", [][]float64{{0, 1, 2}, {1, 0, 1}, {2, 1, 0}}, false},
+		{"asymmetric", [][]float64{{0, 1}, {2, 0}}, false},
+		{"nonzero diagonal", [][]float64{{1, 1}, {1, 0}}, false},
+		{"empty", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUltrametric(tt.matrix, 1e-9); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToUTM(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        Coord
+		expected UTM
+	}{
+		{"zone origin", Coord{Lat: 0, Lon: 3}, UTM{Zone: 31, North: true, Easting: 500000, Northing: 0}},
+		// Northing on the central meridian is 0.9996 times the meridian arc
+		{"central meridian 45N", Coord{Lat: 45, Lon: 3}, UTM{Zone: 31, North: true, Easting: 500000, Northing: 4982950.400}},
+		{"New York", Coord{Lat: 40.7128, Lon: -74.0060}, UTM{Zone: 18, North: true, Easting: 583959.372, Northing: 4507350.998}},
+		{"Sydney", Coord{Lat: -33.8688, Lon: 151.2093}, UTM{Zone: 56, North: false, Easting: 334368.634, Northing: 6250948.345}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := ToUTM(tt.c)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if u.Zone != tt.expected.Zone || u.North != tt.expected.North ||
+				math.Abs(u.Easting-tt.expected.Easting) > 1e-3 || math.Abs(u.Northing-tt.expected.Northing) > 1e-3 {
+				t.Errorf("expected %+v, got %+v", tt.expected, u)
+			}
+		})
+	}
+}
+
+func TestUTMZones(t *testing.T) {
+	tests := []struct {
+		c    Coord
+		zone int
+	}{
+		{Coord{Lat: 0, Lon: -180}, 1},
+		{Coord{Lat: 0, Lon: 180}, 1},
+		{Coord{Lat: 0, Lon: 179.9}, 60},
+		{Coord{Lat: 60, Lon: 5}, 32},  // Norway
+		{Coord{Lat: 60, Lon: 2}, 31},  // West of the Norway exception
+		{Coord{Lat: 78, Lon: 8}, 31},  // Svalbard
+		{Coord{Lat: 78, Lon: 15}, 33}, // Svalbard
+		{Coord{Lat: 78, Lon: 40}, 37}, // Svalbard
+		{Coord{Lat: 78, Lon: 45}, 38},
+	}
+
+	for _, tt := range tests {
+		u, err := ToUTM(tt.c)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if u.Zone != tt.zone {
+			t.Errorf("%v: expected zone %d, got %d", tt.c, tt.zone, u.Zone)
+		}
+	}
+}
+
+func TestUTMRoundTrip(t *testing.T) {
+	rng := testRNG(9)
+	for i := 0; i < 2000; i++ {
+		c := Coord{Lat: rng.Float64()*164 - 80, Lon: rng.Float64()*360 - 180}
+		u, err := ToUTM(c)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		back, err := FromUTM(u)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if d := Haversine(c, back) * 1e6; d > 1 {
+			t.Errorf("round trip of %v moved %v mm to %v", c, d, back)
+		}
+	}
+}
+
+func TestUTMGridDistance(t *testing.T) {
+	// Grid distance approximates the geodesic within a zone
+	a, b := Coord{Lat: 48.85, Lon: 2.35}, Coord{Lat: 48.5, Lon: 2.9}
+	ua, _ := ToUTMZone(a, 31)
+	ub, _ := ToUTMZone(b, 31)
+	grid := math.Hypot(ua.Easting-ub.Easting, ua.Northing-ub.Northing)
+	geodesic, err := Karney(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(grid-geodesic)/geodesic > 1e-3 {
+		t.Errorf("grid distance %v m too far from geodesic %v m", grid, geodesic)
+	}
+}
+
+func TestUTMErrors(t *testing.T) {
+	if _, err := ToUTM(Coord{Lat: 85, Lon: 0}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := ToUTM(Coord{Lat: -80.5, Lon: 0}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := ToUTMZone(Coord{Lat: 0, Lon: 0}, 61); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := FromUTM(UTM{Zone: 0}); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
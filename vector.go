@@ -242,6 +242,71 @@ func WeightedEuclidean[T Number](a, b []T, weights []float64) (float64, error) {
 	return math.Sqrt(sum), nil
 }
 
+// WeightedEuclideanMasked computes weighted Euclidean distance over the
+// dimensions where present[i] is true, renormalized so records with different
+// available fields stay comparable: the weighted sum over present dimensions
+// is scaled by (total weight) / (present weight) before the square root.
+// With every dimension present it equals WeightedEuclidean. Dimensions with
+// zero weight carry no information and do not count as present.
+// Returns ErrEmptyInput if no weighted dimension is present.
+// Time: O(n), Space: O(1)
+func WeightedEuclideanMasked[T Number](a, b []T, weights []float64, present []bool) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+	if err := ValidateWeights(a, weights); err != nil {
+		return 0, err
+	}
+	if len(present) != len(a) {
+		return 0, ErrDimensionMismatch
+	}
+
+	return maskedWeightedEuclidean(len(a), weights, func(i int) (float64, bool) {
+		return float64(a[i]) - float64(b[i]), present[i]
+	})
+}
+
+// WeightedEuclideanMissing is WeightedEuclideanMasked with missing values
+// encoded as NaN: a dimension counts as present only if it is a number in
+// both a and b.
+// Time: O(n), Space: O(1)
+func WeightedEuclideanMissing[T Float](a, b []T, weights []float64) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+	if err := ValidateWeights(a, weights); err != nil {
+		return 0, err
+	}
+
+	return maskedWeightedEuclidean(len(a), weights, func(i int) (float64, bool) {
+		diff := float64(a[i]) - float64(b[i])
+		return diff, !math.IsNaN(diff)
+	})
+}
+
+// maskedWeightedEuclidean sums w·diff² over present dimensions and rescales
+// by total/present weight; diff reports each dimension's difference and presence
+func maskedWeightedEuclidean(n int, weights []float64, diff func(i int) (float64, bool)) (float64, error) {
+	var sum, total, available float64
+	for i := 0; i < n; i++ {
+		w := 1.0
+		if len(weights) > 0 {
+			w = weights[i]
+		}
+		total += w
+		d, ok := diff(i)
+		if !ok || w == 0 {
+			continue
+		}
+		available += w
+		sum += w * d * d
+	}
+	if available == 0 {
+		return 0, ErrEmptyInput
+	}
+	return math.Sqrt(sum * total / available), nil
+}
+
 // DotProduct computes the dot product (inner product) of two vectors.
 // Time: O(n), Space: O(1)
 func DotProduct[T Number](a, b []T) (float64, error) {
@@ -285,3 +350,27 @@ func Norm[T Number](v []T, p float64) (float64, error) {
 	}
 	return math.Pow(sum, 1/p), nil
 }
+
+// EuclideanBounded computes Euclidean distance, aborting as soon as the
+// partial result exceeds maxDist. Returns +Inf when the distance is known to
+// exceed maxDist, so pruned pairs compare greater than any real distance.
+// Time: O(n) worst case, Space: O(1)
+func EuclideanBounded[T Number](a, b []T, maxDist float64) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+	if maxDist < 0 {
+		return 0, ErrInvalidParameter
+	}
+
+	limit := maxDist * maxDist
+	var sum float64
+	for i := range a {
+		diff := float64(a[i]) - float64(b[i])
+		sum += diff * diff
+		if sum > limit {
+			return math.Inf(1), nil
+		}
+	}
+	return math.Sqrt(sum), nil
+}
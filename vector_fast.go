@@ -0,0 +1,245 @@
+package distance
+
+import "math"
+
+// Specialized non-generic fast paths for []float32 and []float64.
+// The generic Number functions convert every element to float64, which shows up
+// in hot loops. These variants operate on the native element type and unroll
+// the inner loop so the compiler can keep several independent accumulators.
+// Float32 variants accumulate in float32 and therefore trade a little precision
+// for throughput; use the generic functions when exact float64 summation matters.
+
+// EuclideanF64 computes Euclidean distance for float64 slices without conversion.
+// Time: O(n), Space: O(1)
+func EuclideanF64(a, b []float64) (float64, error) {
+	sum, err := EuclideanSquaredF64(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(sum), nil
+}
+
+// EuclideanSquaredF64 computes squared Euclidean distance for float64 slices.
+// Time: O(n), Space: O(1)
+func EuclideanSquaredF64(a, b []float64) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float64
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		d0 := a[i] - b[i]
+		d1 := a[i+1] - b[i+1]
+		d2 := a[i+2] - b[i+2]
+		d3 := a[i+3] - b[i+3]
+		s0 += d0 * d0
+		s1 += d1 * d1
+		s2 += d2 * d2
+		s3 += d3 * d3
+	}
+	for ; i < len(a); i++ {
+		d := a[i] - b[i]
+		s0 += d * d
+	}
+	return s0 + s1 + s2 + s3, nil
+}
+
+// ManhattanF64 computes Manhattan distance for float64 slices without conversion.
+// Time: O(n), Space: O(1)
+func ManhattanF64(a, b []float64) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+
+	b = b[:len(a)]
+	var sum float64
+	for i := range a {
+		sum += math.Abs(a[i] - b[i])
+	}
+	return sum, nil
+}
+
+// DotProductF64 computes the dot product of two float64 slices.
+// Time: O(n), Space: O(1)
+func DotProductF64(a, b []float64) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+	return dotF64(a, b[:len(a)]), nil
+}
+
+// CosineF64 computes cosine distance (1 - cosine similarity) for float64 slices.
+// Range [0, 2] where 0=identical direction, 2=opposite
+// Time: O(n), Space: O(1)
+func CosineF64(a, b []float64) (float64, error) {
+	sim, err := CosineSimilarityF64(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return 1 - sim, nil
+}
+
+// CosineSimilarityF64 computes cosine similarity for float64 slices.
+// Range [-1, 1] where 1=identical, 0=orthogonal, -1=opposite
+// Time: O(n), Space: O(1)
+func CosineSimilarityF64(a, b []float64) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+
+	b = b[:len(a)]
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0, ErrZeroVector
+	}
+	return clampUnit(dot / (math.Sqrt(normA) * math.Sqrt(normB))), nil
+}
+
+// EuclideanF32 computes Euclidean distance for float32 slices without conversion.
+// Time: O(n), Space: O(1)
+func EuclideanF32(a, b []float32) (float64, error) {
+	sum, err := EuclideanSquaredF32(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(sum), nil
+}
+
+// EuclideanSquaredF32 computes squared Euclidean distance for float32 slices.
+// Time: O(n), Space: O(1)
+func EuclideanSquaredF32(a, b []float32) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		d0 := a[i] - b[i]
+		d1 := a[i+1] - b[i+1]
+		d2 := a[i+2] - b[i+2]
+		d3 := a[i+3] - b[i+3]
+		s0 += d0 * d0
+		s1 += d1 * d1
+		s2 += d2 * d2
+		s3 += d3 * d3
+	}
+	for ; i < len(a); i++ {
+		d := a[i] - b[i]
+		s0 += d * d
+	}
+	return float64(s0 + s1 + s2 + s3), nil
+}
+
+// ManhattanF32 computes Manhattan distance for float32 slices without conversion.
+// Time: O(n), Space: O(1)
+func ManhattanF32(a, b []float32) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+
+	b = b[:len(a)]
+	var sum float32
+	for i := range a {
+		d := a[i] - b[i]
+		if d < 0 {
+			d = -d
+		}
+		sum += d
+	}
+	return float64(sum), nil
+}
+
+// DotProductF32 computes the dot product of two float32 slices.
+// Time: O(n), Space: O(1)
+func DotProductF32(a, b []float32) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+	return float64(dotF32(a, b[:len(a)])), nil
+}
+
+// CosineF32 computes cosine distance (1 - cosine similarity) for float32 slices.
+// Range [0, 2] where 0=identical direction, 2=opposite
+// Time: O(n), Space: O(1)
+func CosineF32(a, b []float32) (float64, error) {
+	sim, err := CosineSimilarityF32(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return 1 - sim, nil
+}
+
+// CosineSimilarityF32 computes cosine similarity for float32 slices.
+// Range [-1, 1] where 1=identical, 0=orthogonal, -1=opposite
+// Time: O(n), Space: O(1)
+func CosineSimilarityF32(a, b []float32) (float64, error) {
+	if err := Validate(a, b); err != nil {
+		return 0, err
+	}
+
+	b = b[:len(a)]
+	var dot, normA, normB float32
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0, ErrZeroVector
+	}
+	sim := float64(dot) / (math.Sqrt(float64(normA)) * math.Sqrt(float64(normB)))
+	return clampUnit(sim), nil
+}
+
+// dotF64 computes a 4-way unrolled dot product; callers guarantee equal lengths.
+func dotF64(a, b []float64) float64 {
+	var s0, s1, s2, s3 float64
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < len(a); i++ {
+		s0 += a[i] * b[i]
+	}
+	return s0 + s1 + s2 + s3
+}
+
+// dotF32 computes a 4-way unrolled dot product; callers guarantee equal lengths.
+func dotF32(a, b []float32) float32 {
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < len(a); i++ {
+		s0 += a[i] * b[i]
+	}
+	return s0 + s1 + s2 + s3
+}
+
+// clampUnit clamps a similarity to [-1, 1] to absorb floating point error
+func clampUnit(x float64) float64 {
+	if x > 1 {
+		return 1
+	} else if x < -1 {
+		return -1
+	}
+	return x
+}
@@ -0,0 +1,109 @@
+package distance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat64FastPathsMatchGeneric(t *testing.T) {
+	a := []float64{1, -2, 3.5, 4, 0.25, -6, 7}
+	b := []float64{-1, 2, 0.5, 4, 1.25, 3, -2}
+
+	tests := []struct {
+		name    string
+		fast    func(a, b []float64) (float64, error)
+		generic func(a, b []float64) (float64, error)
+	}{
+		{"Euclidean", EuclideanF64, Euclidean[float64]},
+		{"EuclideanSquared", EuclideanSquaredF64, EuclideanSquared[float64]},
+		{"Manhattan", ManhattanF64, Manhattan[float64]},
+		{"DotProduct", DotProductF64, DotProduct[float64]},
+		{"Cosine", CosineF64, Cosine[float64]},
+		{"CosineSimilarity", CosineSimilarityF64, CosineSimilarity[float64]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, _ := tt.generic(a, b)
+			got, err := tt.fast(a, b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(got, want) {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestFloat32FastPathsMatchGeneric(t *testing.T) {
+	a := []float32{1, -2, 3.5, 4, 0.25, -6, 7}
+	b := []float32{-1, 2, 0.5, 4, 1.25, 3, -2}
+
+	tests := []struct {
+		name    string
+		fast    func(a, b []float32) (float64, error)
+		generic func(a, b []float32) (float64, error)
+	}{
+		{"Euclidean", EuclideanF32, Euclidean[float32]},
+		{"EuclideanSquared", EuclideanSquaredF32, EuclideanSquared[float32]},
+		{"Manhattan", ManhattanF32, Manhattan[float32]},
+		{"DotProduct", DotProductF32, DotProduct[float32]},
+		{"Cosine", CosineF32, Cosine[float32]},
+		{"CosineSimilarity", CosineSimilarityF32, CosineSimilarity[float32]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, _ := tt.generic(a, b)
+			got, err := tt.fast(a, b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(got-want) > 1e-5 {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestFastPathErrors(t *testing.T) {
+	if _, err := EuclideanF64([]float64{1}, []float64{1, 2}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := EuclideanF32(nil, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := CosineF64([]float64{0, 0}, []float64{1, 1}); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+	if _, err := CosineF32([]float32{1, 1}, []float32{0, 0}); err != ErrZeroVector {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+}
+
+func BenchmarkEuclideanGeneric(b *testing.B) {
+	x, y := benchVectors(768)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Euclidean(x, y)
+	}
+}
+
+func BenchmarkEuclideanF64(b *testing.B) {
+	x, y := benchVectors(768)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = EuclideanF64(x, y)
+	}
+}
+
+func benchVectors(n int) ([]float64, []float64) {
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range x {
+		x[i] = float64(i)
+		y[i] = float64(i * 2)
+	}
+	return x, y
+}
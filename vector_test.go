@@ -2,6 +2,7 @@ package distance
 
 import (
 	"math"
+	"math/rand/v2"
 	"testing"
 )
 
@@ -11,6 +12,12 @@ func almostEqual(a, b float64) bool {
 	return math.Abs(a-b) < epsilon
 }
 
+// testRNG returns a reproducible random source for generating test data
+func testRNG(seed uint64) *rand.Rand {
+	//nolint:gosec // G404: test data does not require cryptographic randomness
+	return rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
+
 func TestEuclidean(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -208,6 +215,73 @@ func TestWeightedEuclidean(t *testing.T) {
 	}
 }
 
+func TestWeightedEuclideanMasked(t *testing.T) {
+	a := []float64{1, 2, 3, 4}
+	b := []float64{4, 5, 6, 100}
+	weights := []float64{1, 2, 3, 4}
+
+	tests := []struct {
+		name     string
+		weights  []float64
+		present  []bool
+		expected float64
+	}{
+		// sqrt((9 + 18 + 27) · 10/6)
+		{"masked", weights, []bool{true, true, true, false}, math.Sqrt(90)},
+		// sqrt(9 · 4/1)
+		{"unweighted", nil, []bool{true, false, false, false}, 6},
+		// Weight-zero dimensions are neither counted nor renormalized away
+		{"zero weight", []float64{1, 0, 1, 0}, []bool{true, true, false, false}, math.Sqrt(18)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := WeightedEuclideanMasked(a, b, tt.weights, tt.present)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// All present matches WeightedEuclidean
+	full, _ := WeightedEuclidean(a, b, weights)
+	if result, _ := WeightedEuclideanMasked(a, b, weights, []bool{true, true, true, true}); !almostEqual(result, full) {
+		t.Errorf("expected %v, got %v", full, result)
+	}
+
+	if _, err := WeightedEuclideanMasked(a, b, weights, []bool{true}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := WeightedEuclideanMasked(a, b, weights, make([]bool, 4)); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestWeightedEuclideanMissing(t *testing.T) {
+	nan := math.NaN()
+	a := []float64{1, nan, 3, 4}
+	b := []float64{4, 5, 6, nan}
+
+	// Dimensions 0 and 2 are present in both: sqrt((9 + 9) · 4/2)
+	result, err := WeightedEuclideanMissing(a, b, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(result, 6) {
+		t.Errorf("expected 6, got %v", result)
+	}
+
+	if _, err := WeightedEuclideanMissing([]float32{float32(nan)}, []float32{1}, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := WeightedEuclideanMissing(a, b, []float64{1, -1, 1, 1}); err != ErrNegativeValue {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
+
 func TestNorm(t *testing.T) {
 	v := []float64{3, 4}
 
@@ -231,6 +305,36 @@ func TestNorm(t *testing.T) {
 }
 
 // Benchmarks
+func TestEuclideanBounded(t *testing.T) {
+	a := []float64{0, 0, 0}
+	b := []float64{3, 4, 12}
+
+	result, err := EuclideanBounded(a, b, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(result, 13) {
+		t.Errorf("expected 13, got %v", result)
+	}
+
+	result, _ = EuclideanBounded(a, b, 13)
+	if !almostEqual(result, 13) {
+		t.Errorf("expected 13 at exact bound, got %v", result)
+	}
+
+	result, _ = EuclideanBounded(a, b, 4)
+	if !math.IsInf(result, 1) {
+		t.Errorf("expected +Inf when bound exceeded, got %v", result)
+	}
+
+	if _, err := EuclideanBounded(a, b, -1); err != ErrInvalidParameter {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+	if _, err := EuclideanBounded(a, []float64{1}, 1); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
 func BenchmarkEuclidean(b *testing.B) {
 	v1 := make([]float64, 1000)
 	v2 := make([]float64, 1000)